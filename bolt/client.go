@@ -37,12 +37,15 @@ type Client struct {
 	SourcesStore            *SourcesStore
 	ServersStore            *ServersStore
 	LayoutsStore            *LayoutsStore
+	ProtoboardsStore        *ProtoboardsStore
 	DashboardsStore         *DashboardsStore
 	UsersStore              *UsersStore
 	OrganizationsStore      *OrganizationsStore
 	ConfigStore             *ConfigStore
 	MappingsStore           *MappingsStore
 	OrganizationConfigStore *OrganizationConfigStore
+	SourceCredentialsStore  *SourceCredentialsStore
+	DashboardSharesStore    *DashboardSharesStore
 }
 
 // NewClient initializes all stores
@@ -55,6 +58,10 @@ func NewClient() *Client {
 		client: c,
 		IDs:    &id.UUID{},
 	}
+	c.ProtoboardsStore = &ProtoboardsStore{
+		client: c,
+		IDs:    &id.UUID{},
+	}
 	c.DashboardsStore = &DashboardsStore{
 		client: c,
 		IDs:    &id.UUID{},
@@ -64,6 +71,11 @@ func NewClient() *Client {
 	c.ConfigStore = &ConfigStore{client: c}
 	c.MappingsStore = &MappingsStore{client: c}
 	c.OrganizationConfigStore = &OrganizationConfigStore{client: c}
+	c.SourceCredentialsStore = &SourceCredentialsStore{client: c}
+	c.DashboardSharesStore = &DashboardSharesStore{
+		client: c,
+		IDs:    &id.UUID{},
+	}
 	return c
 }
 
@@ -143,6 +155,10 @@ func (c *Client) initialize(ctx context.Context) error {
 		if _, err := tx.CreateBucketIfNotExists(LayoutsBucket); err != nil {
 			return err
 		}
+		// Always create Protoboards bucket.
+		if _, err := tx.CreateBucketIfNotExists(ProtoboardsBucket); err != nil {
+			return err
+		}
 		// Always create Dashboards bucket.
 		if _, err := tx.CreateBucketIfNotExists(DashboardsBucket); err != nil {
 			return err
@@ -167,10 +183,22 @@ func (c *Client) initialize(ctx context.Context) error {
 		if _, err := tx.CreateBucketIfNotExists(OrganizationConfigBucket); err != nil {
 			return err
 		}
+		// Always create SourceCredentials bucket.
+		if _, err := tx.CreateBucketIfNotExists(SourceCredentialsBucket); err != nil {
+			return err
+		}
+		// Always create DashboardShares bucket.
+		if _, err := tx.CreateBucketIfNotExists(DashboardSharesBucket); err != nil {
+			return err
+		}
 		// Always create Cells bucket.
 		if err := c.initializeCells(ctx, tx); err != nil {
 			return err
 		}
+		// Always create v2 Dashboards bucket.
+		if err := c.initializeDashboards(ctx, tx); err != nil {
+			return err
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -0,0 +1,116 @@
+package bolt
+
+import (
+	"context"
+
+	"github.com/boltdb/bolt"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/bolt/internal"
+)
+
+// Ensure DashboardSharesStore implements chronograf.DashboardSharesStore.
+var _ chronograf.DashboardSharesStore = &DashboardSharesStore{}
+
+var (
+	// DashboardSharesBucket is the bucket where the read-only shares of
+	// dashboards are stored.
+	DashboardSharesBucket = []byte("dashboardsharesv1")
+)
+
+// DashboardSharesStore uses bolt to store the read-only shares of dashboards
+type DashboardSharesStore struct {
+	client *Client
+	IDs    chronograf.ID
+}
+
+// All lists the shares of a dashboard
+func (s *DashboardSharesStore) All(ctx context.Context, id chronograf.DashboardID) ([]chronograf.DashboardShare, error) {
+	shares := []chronograf.DashboardShare{}
+	err := s.client.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(DashboardSharesBucket).ForEach(func(k, v []byte) error {
+			var share chronograf.DashboardShare
+			if err := internal.UnmarshalDashboardShare(v, &share); err != nil {
+				return err
+			}
+			if share.DashboardID == id {
+				shares = append(shares, share)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// Add creates a new share with a generated ID
+func (s *DashboardSharesStore) Add(ctx context.Context, share chronograf.DashboardShare) (chronograf.DashboardShare, error) {
+	id, err := s.IDs.Generate()
+	if err != nil {
+		return chronograf.DashboardShare{}, err
+	}
+	share.ID = id
+	v, err := internal.MarshalDashboardShare(share)
+	if err != nil {
+		return chronograf.DashboardShare{}, err
+	}
+	if err := s.client.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(DashboardSharesBucket).Put([]byte(share.ID), v)
+	}); err != nil {
+		return chronograf.DashboardShare{}, err
+	}
+	return share, nil
+}
+
+// Get retrieves a share by its ID
+func (s *DashboardSharesStore) Get(ctx context.Context, id string) (chronograf.DashboardShare, error) {
+	var share chronograf.DashboardShare
+	err := s.client.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(DashboardSharesBucket).Get([]byte(id))
+		if v == nil {
+			return chronograf.ErrDashboardShareNotFound
+		}
+		return internal.UnmarshalDashboardShare(v, &share)
+	})
+	if err != nil {
+		return chronograf.DashboardShare{}, err
+	}
+	return share, nil
+}
+
+// Delete removes the share, revoking its tokens
+func (s *DashboardSharesStore) Delete(ctx context.Context, share chronograf.DashboardShare) error {
+	return s.client.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(DashboardSharesBucket)
+		if v := b.Get([]byte(share.ID)); v == nil {
+			return chronograf.ErrDashboardShareNotFound
+		}
+		return b.Delete([]byte(share.ID))
+	})
+}
+
+// deleteDashboardShares removes all shares of a dashboard, revoking their
+// tokens along with the dashboard
+func deleteDashboardShares(tx *bolt.Tx, id chronograf.DashboardID) error {
+	b := tx.Bucket(DashboardSharesBucket)
+	var keys [][]byte
+	if err := b.ForEach(func(k, v []byte) error {
+		var share chronograf.DashboardShare
+		if err := internal.UnmarshalDashboardShare(v, &share); err != nil {
+			return err
+		}
+		if share.DashboardID == id {
+			keys = append(keys, k)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
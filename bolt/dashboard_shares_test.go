@@ -0,0 +1,100 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/chronograf"
+)
+
+func TestDashboardSharesStore(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	s := client.DashboardSharesStore
+
+	lobby, err := s.Add(ctx, chronograf.DashboardShare{
+		DashboardID:  1,
+		Organization: "default",
+		Name:         "Lobby TV",
+		CreatedBy:    "doc",
+		CreatedAt:    time.Date(1985, 10, 26, 1, 20, 0, 0, time.UTC),
+		ExpiresAt:    time.Date(1985, 10, 27, 1, 20, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lobby.ID == "" {
+		t.Fatal("Add() did not generate an ID")
+	}
+	if _, err := s.Add(ctx, chronograf.DashboardShare{DashboardID: 2, Name: "Vendor"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get(ctx, lobby.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, lobby); diff != "" {
+		t.Errorf("Get() -got/+want diff:\n%s", diff)
+	}
+
+	all, err := s.All(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(all, []chronograf.DashboardShare{lobby}); diff != "" {
+		t.Errorf("All() -got/+want diff:\n%s", diff)
+	}
+
+	if err := s.Delete(ctx, lobby); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, lobby.ID); err != chronograf.ErrDashboardShareNotFound {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, chronograf.ErrDashboardShareNotFound)
+	}
+	if err := s.Delete(ctx, lobby); err != chronograf.ErrDashboardShareNotFound {
+		t.Errorf("Delete() twice error = %v, want %v", err, chronograf.ErrDashboardShareNotFound)
+	}
+}
+
+func TestDashboardsStore_DeleteRevokesShares(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	dash, err := client.DashboardsStore.Add(ctx, chronograf.Dashboard{Name: "Clock Tower"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := client.DashboardsStore.Add(ctx, chronograf.Dashboard{Name: "Twin Pines Mall"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := client.DashboardSharesStore
+	for _, id := range []chronograf.DashboardID{dash.ID, dash.ID, other.ID} {
+		if _, err := s.Add(ctx, chronograf.DashboardShare{DashboardID: id, Name: "Lobby TV"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := client.DashboardsStore.Delete(ctx, dash); err != nil {
+		t.Fatal(err)
+	}
+	if shares, err := s.All(ctx, dash.ID); err != nil || len(shares) != 0 {
+		t.Errorf("All() after deleting the dashboard = %v, %v, want no shares", shares, err)
+	}
+	if shares, err := s.All(ctx, other.ID); err != nil || len(shares) != 1 {
+		t.Errorf("All() of another dashboard = %v, %v, want its share", shares, err)
+	}
+}
@@ -1,7 +1,9 @@
 package bolt
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"strconv"
 
 	"github.com/boltdb/bolt"
@@ -15,6 +17,12 @@ var _ chronograf.DashboardsStore = &DashboardsStore{}
 // DashboardsBucket is the bolt bucket dashboards are stored in
 var DashboardsBucket = []byte("Dashoard")
 
+// DashboardsIndexBucket is the bolt bucket of the index used to search
+// dashboards without decoding their cells. Entries are keyed by the
+// organization of the dashboard followed by its ID so that the dashboards
+// of an organization are found by seeking to its prefix.
+var DashboardsIndexBucket = []byte("dashboardsindexv1")
+
 // DashboardsStore is the bolt implementation of storing dashboards
 type DashboardsStore struct {
 	client *Client
@@ -47,8 +55,32 @@ func (d *DashboardsStore) AddIDs(ctx context.Context, boards []chronograf.Dashbo
 	return nil
 }
 
+// Reindex rebuilds the search index from the stored dashboards
+func (d *DashboardsStore) Reindex(ctx context.Context) error {
+	return d.client.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(DashboardsIndexBucket); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		if _, err := tx.CreateBucket(DashboardsIndexBucket); err != nil {
+			return err
+		}
+		return tx.Bucket(DashboardsBucket).ForEach(func(k, v []byte) error {
+			var board chronograf.Dashboard
+			if err := internal.UnmarshalDashboard(v, &board); err != nil {
+				return err
+			}
+			return indexDashboard(tx, board)
+		})
+	})
+}
+
 // Migrate updates the dashboards at runtime
 func (d *DashboardsStore) Migrate(ctx context.Context) error {
+	// 0. Index dashboards stored before the index or by older versions
+	if err := d.Reindex(ctx); err != nil {
+		return err
+	}
+
 	// 1. Add UUIDs to cells without one
 	boards, err := d.All(ctx)
 	if err != nil {
@@ -118,7 +150,10 @@ func (d *DashboardsStore) Add(ctx context.Context, src chronograf.Dashboard) (ch
 		if err != nil {
 			return err
 		}
-		return b.Put([]byte(strID), v)
+		if err := b.Put([]byte(strID), v); err != nil {
+			return err
+		}
+		return indexDashboard(tx, src)
 	}); err != nil {
 		return chronograf.Dashboard{}, err
 	}
@@ -147,11 +182,22 @@ func (d *DashboardsStore) Get(ctx context.Context, id chronograf.DashboardID) (c
 // Delete the dashboard from DashboardsStore
 func (d *DashboardsStore) Delete(ctx context.Context, dash chronograf.Dashboard) error {
 	if err := d.client.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(DashboardsBucket)
 		strID := strconv.Itoa(int(dash.ID))
-		if err := tx.Bucket(DashboardsBucket).Delete([]byte(strID)); err != nil {
+		// The index entry is keyed by the stored organization of the dashboard
+		stored := dash
+		if v := b.Get([]byte(strID)); v != nil {
+			if err := internal.UnmarshalDashboard(v, &stored); err != nil {
+				return err
+			}
+		}
+		if err := b.Delete([]byte(strID)); err != nil {
 			return err
 		}
-		return nil
+		if err := unindexDashboard(tx, stored); err != nil {
+			return err
+		}
+		return deleteDashboardShares(tx, dash.ID)
 	}); err != nil {
 		return err
 	}
@@ -165,9 +211,20 @@ func (d *DashboardsStore) Update(ctx context.Context, dash chronograf.Dashboard)
 		// Get an existing dashboard with the same ID.
 		b := tx.Bucket(DashboardsBucket)
 		strID := strconv.Itoa(int(dash.ID))
-		if v := b.Get([]byte(strID)); v == nil {
+		v := b.Get([]byte(strID))
+		if v == nil {
 			return chronograf.ErrDashboardNotFound
 		}
+		var stored chronograf.Dashboard
+		if err := internal.UnmarshalDashboard(v, &stored); err != nil {
+			return err
+		}
+		// Dashboards moved to another organization move in the index too
+		if stored.Organization != dash.Organization {
+			if err := unindexDashboard(tx, stored); err != nil {
+				return err
+			}
+		}
 
 		for i, cell := range dash.Cells {
 			if cell.ID != "" {
@@ -185,10 +242,89 @@ func (d *DashboardsStore) Update(ctx context.Context, dash chronograf.Dashboard)
 		} else if err := b.Put([]byte(strID), v); err != nil {
 			return err
 		}
-		return nil
+		return indexDashboard(tx, dash)
 	}); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Search filters, sorts and pages the entries of the index and only decodes
+// the dashboards of the requested page. Searches of an organization only
+// read the entries of the organization.
+func (d *DashboardsStore) Search(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+	var boards []chronograf.Dashboard
+	var total int
+	if err := d.client.db.View(func(tx *bolt.Tx) error {
+		var prefix []byte
+		if q.Organization != "" {
+			prefix = dashboardIndexPrefix(q.Organization)
+		}
+
+		var entries []chronograf.Dashboard
+		c := tx.Bucket(DashboardsIndexBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var entry chronograf.Dashboard
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+
+		var page []chronograf.Dashboard
+		page, total = q.Apply(entries)
+
+		b := tx.Bucket(DashboardsBucket)
+		boards = make([]chronograf.Dashboard, len(page))
+		for i, entry := range page {
+			strID := strconv.Itoa(int(entry.ID))
+			v := b.Get([]byte(strID))
+			if v == nil {
+				return chronograf.ErrDashboardNotFound
+			}
+			if err := internal.UnmarshalDashboard(v, &boards[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	return boards, total, nil
+}
+
+// dashboardIndexPrefix is the prefix of the index keys of the dashboards of
+// the organization
+func dashboardIndexPrefix(org string) []byte {
+	return []byte(org + "/")
+}
+
+// dashboardIndexKey is the index key of the dashboard
+func dashboardIndexKey(board chronograf.Dashboard) []byte {
+	return append(dashboardIndexPrefix(board.Organization), strconv.Itoa(int(board.ID))...)
+}
+
+// indexDashboard stores the fields of the dashboard that can be searched
+func indexDashboard(tx *bolt.Tx, board chronograf.Dashboard) error {
+	v, err := json.Marshal(chronograf.Dashboard{
+		ID:           board.ID,
+		Name:         board.Name,
+		Organization: board.Organization,
+		Tags:         board.Tags,
+		Folder:       board.Folder,
+		Owner:        board.Owner,
+		OwnerID:      board.OwnerID,
+		Permissions:  board.Permissions,
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(DashboardsIndexBucket).Put(dashboardIndexKey(board), v)
+}
+
+// unindexDashboard removes the index entry of the dashboard
+func unindexDashboard(tx *bolt.Tx, board chronograf.Dashboard) error {
+	return tx.Bucket(DashboardsIndexBucket).Delete(dashboardIndexKey(board))
+}
@@ -105,3 +105,156 @@ func TestDashboardsStore_Add(t *testing.T) {
 		}
 	}
 }
+
+func TestDashboardsStore_Search(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	s := client.DashboardsStore
+	boards := []chronograf.Dashboard{
+		{Name: "Clock Tower", Organization: "1337", Folder: "hill-valley/1955", Tags: []string{"clock", "society"}},
+		{Name: "Cafe 80s", Organization: "1337", Folder: "hill-valley/2015", Tags: []string{"society"}},
+		{Name: "Biff Co", Organization: "1337", Folder: "hill-valley-casino", Tags: []string{"society"}},
+		{Name: "clockwork", Organization: "1338", Folder: "hill-valley/1955", Tags: []string{"clock"}},
+	}
+	for i, b := range boards {
+		if boards[i], err = s.Add(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(ds []chronograf.Dashboard) []string {
+		res := []string{}
+		for _, d := range ds {
+			res = append(res, d.Name)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name      string
+		q         chronograf.DashboardsQuery
+		want      []string
+		wantTotal int
+	}{
+		{
+			name:      "all dashboards by name",
+			q:         chronograf.DashboardsQuery{},
+			want:      []string{"Biff Co", "Cafe 80s", "Clock Tower", "clockwork"},
+			wantTotal: 4,
+		},
+		{
+			name:      "name substring ignores case",
+			q:         chronograf.DashboardsQuery{Name: "CLOCK"},
+			want:      []string{"Clock Tower", "clockwork"},
+			wantTotal: 2,
+		},
+		{
+			name:      "folder and subfolders of an organization",
+			q:         chronograf.DashboardsQuery{Folder: "hill-valley", Organization: "1337"},
+			want:      []string{"Cafe 80s", "Clock Tower"},
+			wantTotal: 2,
+		},
+		{
+			name:      "all tags",
+			q:         chronograf.DashboardsQuery{Tags: []string{"clock", "society"}},
+			want:      []string{"Clock Tower"},
+			wantTotal: 1,
+		},
+		{
+			name:      "page of descending folders",
+			q:         chronograf.DashboardsQuery{SortBy: chronograf.DashboardsByFolder, Descending: true, Offset: 1, Limit: 2},
+			want:      []string{"clockwork", "Clock Tower"},
+			wantTotal: 4,
+		},
+		{
+			name:      "offset past the end",
+			q:         chronograf.DashboardsQuery{Offset: 10},
+			want:      []string{},
+			wantTotal: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := s.Search(ctx, tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(names(got), tt.want); diff != "" {
+				t.Errorf("DashboardsStore.Search() -got/+want diff:\n%s", diff)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("DashboardsStore.Search() total = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+
+	// The index follows updates and deletes
+	boards[3].Name = "Doc's Garage"
+	if err := s.Update(ctx, boards[3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, boards[0]); err != nil {
+		t.Fatal(err)
+	}
+	got, total, err := s.Search(ctx, chronograf.DashboardsQuery{Name: "clock"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 || total != 0 {
+		t.Errorf("DashboardsStore.Search() after update and delete = %v, want none", names(got))
+	}
+
+	// Reindex rebuilds the index from the dashboards
+	if err := s.Reindex(ctx); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err = s.Search(ctx, chronograf.DashboardsQuery{Folder: "hill-valley/1955"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(names(got), []string{"Doc's Garage"}); diff != "" {
+		t.Errorf("DashboardsStore.Search() after Reindex -got/+want diff:\n%s", diff)
+	}
+
+	// Dashboards with permissions are only found by the users they allow
+	boards[1].Permissions = []chronograf.DashboardPermission{
+		{UserID: 2, User: "doc", Permission: chronograf.DashboardViewPermission},
+	}
+	if err := s.Update(ctx, boards[1]); err != nil {
+		t.Fatal(err)
+	}
+	q := chronograf.DashboardsQuery{Organization: "1337", UserID: 3, Role: "viewer"}
+	if got, _, err = s.Search(ctx, q); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(names(got), []string{"Biff Co"}); diff != "" {
+		t.Errorf("DashboardsStore.Search() of biff -got/+want diff:\n%s", diff)
+	}
+	q.UserID = 2
+	if got, _, err = s.Search(ctx, q); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(names(got), []string{"Biff Co", "Cafe 80s"}); diff != "" {
+		t.Errorf("DashboardsStore.Search() of doc -got/+want diff:\n%s", diff)
+	}
+
+	// Dashboards moved to another organization are only found in it
+	boards[2].Organization = "1338"
+	if err := s.Update(ctx, boards[2]); err != nil {
+		t.Fatal(err)
+	}
+	for org, want := range map[string][]string{"1337": {"Cafe 80s"}, "1338": {"Biff Co", "Doc's Garage"}} {
+		got, _, err := s.Search(ctx, chronograf.DashboardsQuery{Organization: org})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(names(got), want); diff != "" {
+			t.Errorf("DashboardsStore.Search() of organization %s -got/+want diff:\n%s", org, diff)
+		}
+	}
+}
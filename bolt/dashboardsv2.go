@@ -219,6 +219,18 @@ func (c *Client) updateDashboard(ctx context.Context, tx *bolt.Tx, id platform.I
 		d.Cells = upd.Cells
 	}
 
+	if upd.Description != nil {
+		d.Description = *upd.Description
+	}
+
+	if upd.Templates != nil {
+		d.Templates = upd.Templates
+	}
+
+	if upd.TimeRange != nil {
+		d.TimeRange = *upd.TimeRange
+	}
+
 	if err := c.putDashboard(ctx, tx, d); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,87 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	platform "github.com/influxdata/chronograf/v2"
+)
+
+func TestClient_DashboardsV2(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	cell := &platform.Cell{
+		CellContents: platform.CellContents{Name: "CPU"},
+		Visualization: platform.GaugeVisualization{
+			Queries: []platform.DashboardQuery{{Command: `SELECT last("usage_user") FROM "cpu"`}},
+			Suffix:  "%",
+			Colors: []platform.CellColor{
+				{Type: "min", Hex: "#00C9FF", Value: "0"},
+				{Type: "max", Hex: "#9394FF", Value: "100"},
+			},
+		},
+	}
+	if err := client.CreateCell(ctx, cell); err != nil {
+		t.Fatal(err)
+	}
+	gotCell, err := client.FindCellByID(ctx, cell.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(gotCell, cell); diff != "" {
+		t.Errorf("FindCellByID() = %s", diff)
+	}
+
+	d := &platform.Dashboard{
+		Name:  "Hosts",
+		Cells: []platform.DashboardCell{{W: 4, H: 4, Ref: "/chronograf/v2/cells/" + string(cell.ID)}},
+	}
+	if err := client.CreateDashboard(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+
+	description := "CPU of all hosts"
+	templates := []platform.Template{
+		{
+			TemplateVar: platform.TemplateVar{
+				Var:    ":host:",
+				Values: []platform.TemplateValue{{Value: "db01", Type: "tagValue", Selected: true}},
+			},
+			Type:  "tagValues",
+			Query: &platform.TemplateQuery{Command: "SHOW TAG VALUES WITH KEY = host", DB: "telegraf"},
+		},
+	}
+	updated, err := client.UpdateDashboard(ctx, d.ID, platform.DashboardUpdate{
+		Description: &description,
+		Templates:   templates,
+		TimeRange:   &platform.TimeRange{Lower: "now() - 6h"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.FindDashboardByID(ctx, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &platform.Dashboard{
+		ID:          d.ID,
+		Name:        "Hosts",
+		Description: description,
+		Cells:       d.Cells,
+		Templates:   templates,
+		TimeRange:   platform.TimeRange{Lower: "now() - 6h"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("FindDashboardByID() = %s", diff)
+	}
+	if diff := cmp.Diff(updated, want); diff != "" {
+		t.Errorf("UpdateDashboard() = %s", diff)
+	}
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/influxdata/chronograf"
@@ -48,6 +49,10 @@ func MarshalSource(s chronograf.Source) ([]byte, error) {
 		Role:               s.Role,
 		DefaultRP:          s.DefaultRP,
 		Version:            s.Version,
+		UserAuth:           s.UserAuth,
+		Org:                s.Org,
+		Token:              s.Token,
+		Backends:           s.Backends,
 	})
 }
 
@@ -73,6 +78,10 @@ func UnmarshalSource(data []byte, s *chronograf.Source) error {
 	s.Role = pb.Role
 	s.DefaultRP = pb.DefaultRP
 	s.Version = pb.Version
+	s.UserAuth = pb.UserAuth
+	s.Org = pb.Org
+	s.Token = pb.Token
+	s.Backends = pb.Backends
 	return nil
 }
 
@@ -372,12 +381,28 @@ func MarshalDashboard(d chronograf.Dashboard) ([]byte, error) {
 		}
 		templates[i] = template
 	}
+	permissions := make([]*DashboardPermission, len(d.Permissions))
+	for i, p := range d.Permissions {
+		permissions[i] = &DashboardPermission{
+			UserID:     p.UserID,
+			User:       p.User,
+			Role:       p.Role,
+			Permission: p.Permission,
+		}
+	}
 	return proto.Marshal(&Dashboard{
 		ID:           int64(d.ID),
 		Cells:        cells,
 		Templates:    templates,
 		Name:         d.Name,
 		Organization: d.Organization,
+		Description:  d.Description,
+		Tags:         d.Tags,
+		Folder:       d.Folder,
+		Owner:        d.Owner,
+		OwnerID:      d.OwnerID,
+		Permissions:  permissions,
+		Locked:       d.Locked,
 	})
 }
 
@@ -566,6 +591,23 @@ func UnmarshalDashboard(data []byte, d *chronograf.Dashboard) error {
 	d.Templates = templates
 	d.Name = pb.Name
 	d.Organization = pb.Organization
+	d.Description = pb.Description
+	d.Tags = pb.Tags
+	d.Folder = pb.Folder
+	d.Owner = pb.Owner
+	d.OwnerID = pb.OwnerID
+	d.Locked = pb.Locked
+
+	var permissions []chronograf.DashboardPermission
+	for _, p := range pb.Permissions {
+		permissions = append(permissions, chronograf.DashboardPermission{
+			UserID:     p.UserID,
+			User:       p.User,
+			Role:       p.Role,
+			Permission: p.Permission,
+		})
+	}
+	d.Permissions = permissions
 	return nil
 }
 
@@ -902,3 +944,77 @@ func UnmarshalMapping(data []byte, m *chronograf.Mapping) error {
 func UnmarshalMappingPB(data []byte, m *Mapping) error {
 	return proto.Unmarshal(data, m)
 }
+
+// MarshalSourceCredentials encodes source credentials to binary protobuf format.
+func MarshalSourceCredentials(c chronograf.SourceCredentials) ([]byte, error) {
+	return proto.Marshal(&SourceCredentials{
+		SourceID: int64(c.SourceID),
+		UserID:   c.UserID,
+		Username: c.Username,
+		Password: c.Password,
+	})
+}
+
+// UnmarshalSourceCredentials decodes source credentials from binary protobuf data.
+func UnmarshalSourceCredentials(data []byte, c *chronograf.SourceCredentials) error {
+	var pb SourceCredentials
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+
+	c.SourceID = int(pb.SourceID)
+	c.UserID = pb.UserID
+	c.Username = pb.Username
+	c.Password = pb.Password
+
+	return nil
+}
+
+// MarshalDashboardShare encodes a dashboard share to binary protobuf format.
+func MarshalDashboardShare(s chronograf.DashboardShare) ([]byte, error) {
+	return proto.Marshal(&DashboardShare{
+		ID:           s.ID,
+		DashboardID:  int64(s.DashboardID),
+		Organization: s.Organization,
+		Name:         s.Name,
+		CreatedBy:    s.CreatedBy,
+		CreatedByID:  s.CreatedByID,
+		CreatedAt:    marshalTime(s.CreatedAt),
+		ExpiresAt:    marshalTime(s.ExpiresAt),
+	})
+}
+
+// UnmarshalDashboardShare decodes a dashboard share from binary protobuf data.
+func UnmarshalDashboardShare(data []byte, s *chronograf.DashboardShare) error {
+	var pb DashboardShare
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+
+	s.ID = pb.ID
+	s.DashboardID = chronograf.DashboardID(pb.DashboardID)
+	s.Organization = pb.Organization
+	s.Name = pb.Name
+	s.CreatedBy = pb.CreatedBy
+	s.CreatedByID = pb.CreatedByID
+	s.CreatedAt = unmarshalTime(pb.CreatedAt)
+	s.ExpiresAt = unmarshalTime(pb.ExpiresAt)
+
+	return nil
+}
+
+// marshalTime encodes a time as nanoseconds since the epoch; the zero time
+// is encoded as 0 as it is out of the range of nanoseconds
+func marshalTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func unmarshalTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns).UTC()
+}
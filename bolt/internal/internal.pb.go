@@ -38,6 +38,8 @@ It has these top-level messages:
 	LogViewerColumn
 	ColumnEncoding
 	BuildInfo
+	SourceCredentials
+	DashboardShare
 */
 package internal
 
@@ -57,21 +59,25 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type Source struct {
-	ID                 int64  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Name               string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Type               string `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
-	Username           string `protobuf:"bytes,4,opt,name=Username,proto3" json:"Username,omitempty"`
-	Password           string `protobuf:"bytes,5,opt,name=Password,proto3" json:"Password,omitempty"`
-	URL                string `protobuf:"bytes,6,opt,name=URL,proto3" json:"URL,omitempty"`
-	Default            bool   `protobuf:"varint,7,opt,name=Default,proto3" json:"Default,omitempty"`
-	Telegraf           string `protobuf:"bytes,8,opt,name=Telegraf,proto3" json:"Telegraf,omitempty"`
-	InsecureSkipVerify bool   `protobuf:"varint,9,opt,name=InsecureSkipVerify,proto3" json:"InsecureSkipVerify,omitempty"`
-	MetaURL            string `protobuf:"bytes,10,opt,name=MetaURL,proto3" json:"MetaURL,omitempty"`
-	SharedSecret       string `protobuf:"bytes,11,opt,name=SharedSecret,proto3" json:"SharedSecret,omitempty"`
-	Organization       string `protobuf:"bytes,12,opt,name=Organization,proto3" json:"Organization,omitempty"`
-	Role               string `protobuf:"bytes,13,opt,name=Role,proto3" json:"Role,omitempty"`
-	DefaultRP          string `protobuf:"bytes,14,opt,name=DefaultRP,proto3" json:"DefaultRP,omitempty"`
-	Version            string `protobuf:"bytes,15,opt,name=Version,proto3" json:"Version,omitempty"`
+	ID                 int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name               string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Type               string   `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
+	Username           string   `protobuf:"bytes,4,opt,name=Username,proto3" json:"Username,omitempty"`
+	Password           string   `protobuf:"bytes,5,opt,name=Password,proto3" json:"Password,omitempty"`
+	URL                string   `protobuf:"bytes,6,opt,name=URL,proto3" json:"URL,omitempty"`
+	Default            bool     `protobuf:"varint,7,opt,name=Default,proto3" json:"Default,omitempty"`
+	Telegraf           string   `protobuf:"bytes,8,opt,name=Telegraf,proto3" json:"Telegraf,omitempty"`
+	InsecureSkipVerify bool     `protobuf:"varint,9,opt,name=InsecureSkipVerify,proto3" json:"InsecureSkipVerify,omitempty"`
+	MetaURL            string   `protobuf:"bytes,10,opt,name=MetaURL,proto3" json:"MetaURL,omitempty"`
+	SharedSecret       string   `protobuf:"bytes,11,opt,name=SharedSecret,proto3" json:"SharedSecret,omitempty"`
+	Organization       string   `protobuf:"bytes,12,opt,name=Organization,proto3" json:"Organization,omitempty"`
+	Role               string   `protobuf:"bytes,13,opt,name=Role,proto3" json:"Role,omitempty"`
+	DefaultRP          string   `protobuf:"bytes,14,opt,name=DefaultRP,proto3" json:"DefaultRP,omitempty"`
+	Version            string   `protobuf:"bytes,15,opt,name=Version,proto3" json:"Version,omitempty"`
+	UserAuth           string   `protobuf:"bytes,16,opt,name=UserAuth,proto3" json:"UserAuth,omitempty"`
+	Org                string   `protobuf:"bytes,17,opt,name=Org,proto3" json:"Org,omitempty"`
+	Token              string   `protobuf:"bytes,18,opt,name=Token,proto3" json:"Token,omitempty"`
+	Backends           []string `protobuf:"bytes,19,rep,name=Backends" json:"Backends,omitempty"`
 }
 
 func (m *Source) Reset()                    { *m = Source{} }
@@ -184,12 +190,47 @@ func (m *Source) GetVersion() string {
 	return ""
 }
 
+func (m *Source) GetUserAuth() string {
+	if m != nil {
+		return m.UserAuth
+	}
+	return ""
+}
+
+func (m *Source) GetOrg() string {
+	if m != nil {
+		return m.Org
+	}
+	return ""
+}
+
+func (m *Source) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Source) GetBackends() []string {
+	if m != nil {
+		return m.Backends
+	}
+	return nil
+}
+
 type Dashboard struct {
-	ID           int64            `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Name         string           `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Cells        []*DashboardCell `protobuf:"bytes,3,rep,name=cells" json:"cells,omitempty"`
-	Templates    []*Template      `protobuf:"bytes,4,rep,name=templates" json:"templates,omitempty"`
-	Organization string           `protobuf:"bytes,5,opt,name=Organization,proto3" json:"Organization,omitempty"`
+	ID           int64                  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Cells        []*DashboardCell       `protobuf:"bytes,3,rep,name=cells" json:"cells,omitempty"`
+	Templates    []*Template            `protobuf:"bytes,4,rep,name=templates" json:"templates,omitempty"`
+	Organization string                 `protobuf:"bytes,5,opt,name=Organization,proto3" json:"Organization,omitempty"`
+	Description  string                 `protobuf:"bytes,6,opt,name=Description,proto3" json:"Description,omitempty"`
+	Tags         []string               `protobuf:"bytes,7,rep,name=Tags" json:"Tags,omitempty"`
+	Folder       string                 `protobuf:"bytes,8,opt,name=Folder,proto3" json:"Folder,omitempty"`
+	Owner        string                 `protobuf:"bytes,9,opt,name=Owner,proto3" json:"Owner,omitempty"`
+	Permissions  []*DashboardPermission `protobuf:"bytes,10,rep,name=Permissions" json:"Permissions,omitempty"`
+	Locked       bool                   `protobuf:"varint,11,opt,name=Locked,proto3" json:"Locked,omitempty"`
+	OwnerID      uint64                 `protobuf:"varint,12,opt,name=OwnerID,proto3" json:"OwnerID,omitempty"`
 }
 
 func (m *Dashboard) Reset()                    { *m = Dashboard{} }
@@ -232,6 +273,94 @@ func (m *Dashboard) GetOrganization() string {
 	return ""
 }
 
+func (m *Dashboard) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Dashboard) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Dashboard) GetFolder() string {
+	if m != nil {
+		return m.Folder
+	}
+	return ""
+}
+
+func (m *Dashboard) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Dashboard) GetPermissions() []*DashboardPermission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *Dashboard) GetLocked() bool {
+	if m != nil {
+		return m.Locked
+	}
+	return false
+}
+
+func (m *Dashboard) GetOwnerID() uint64 {
+	if m != nil {
+		return m.OwnerID
+	}
+	return 0
+}
+
+type DashboardPermission struct {
+	User       string `protobuf:"bytes,1,opt,name=User,proto3" json:"User,omitempty"`
+	Role       string `protobuf:"bytes,2,opt,name=Role,proto3" json:"Role,omitempty"`
+	Permission string `protobuf:"bytes,3,opt,name=Permission,proto3" json:"Permission,omitempty"`
+	UserID     uint64 `protobuf:"varint,4,opt,name=UserID,proto3" json:"UserID,omitempty"`
+}
+
+func (m *DashboardPermission) Reset()         { *m = DashboardPermission{} }
+func (m *DashboardPermission) String() string { return proto.CompactTextString(m) }
+func (*DashboardPermission) ProtoMessage()    {}
+
+func (m *DashboardPermission) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *DashboardPermission) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *DashboardPermission) GetPermission() string {
+	if m != nil {
+		return m.Permission
+	}
+	return ""
+}
+
+func (m *DashboardPermission) GetUserID() uint64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
 type DashboardCell struct {
 	X              int32             `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
 	Y              int32             `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
@@ -1504,9 +1633,120 @@ func (m *BuildInfo) GetCommit() string {
 	return ""
 }
 
+type SourceCredentials struct {
+	SourceID int64  `protobuf:"varint,1,opt,name=SourceID,proto3" json:"SourceID,omitempty"`
+	UserID   uint64 `protobuf:"varint,2,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=Username,proto3" json:"Username,omitempty"`
+	Password string `protobuf:"bytes,4,opt,name=Password,proto3" json:"Password,omitempty"`
+}
+
+func (m *SourceCredentials) Reset()         { *m = SourceCredentials{} }
+func (m *SourceCredentials) String() string { return proto.CompactTextString(m) }
+func (*SourceCredentials) ProtoMessage()    {}
+
+func (m *SourceCredentials) GetSourceID() int64 {
+	if m != nil {
+		return m.SourceID
+	}
+	return 0
+}
+
+func (m *SourceCredentials) GetUserID() uint64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *SourceCredentials) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *SourceCredentials) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type DashboardShare struct {
+	ID           string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	DashboardID  int64  `protobuf:"varint,2,opt,name=DashboardID,proto3" json:"DashboardID,omitempty"`
+	Organization string `protobuf:"bytes,3,opt,name=Organization,proto3" json:"Organization,omitempty"`
+	Name         string `protobuf:"bytes,4,opt,name=Name,proto3" json:"Name,omitempty"`
+	CreatedBy    string `protobuf:"bytes,5,opt,name=CreatedBy,proto3" json:"CreatedBy,omitempty"`
+	CreatedByID  uint64 `protobuf:"varint,6,opt,name=CreatedByID,proto3" json:"CreatedByID,omitempty"`
+	CreatedAt    int64  `protobuf:"varint,7,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	ExpiresAt    int64  `protobuf:"varint,8,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+}
+
+func (m *DashboardShare) Reset()         { *m = DashboardShare{} }
+func (m *DashboardShare) String() string { return proto.CompactTextString(m) }
+func (*DashboardShare) ProtoMessage()    {}
+
+func (m *DashboardShare) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *DashboardShare) GetDashboardID() int64 {
+	if m != nil {
+		return m.DashboardID
+	}
+	return 0
+}
+
+func (m *DashboardShare) GetOrganization() string {
+	if m != nil {
+		return m.Organization
+	}
+	return ""
+}
+
+func (m *DashboardShare) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DashboardShare) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
+	}
+	return ""
+}
+
+func (m *DashboardShare) GetCreatedByID() uint64 {
+	if m != nil {
+		return m.CreatedByID
+	}
+	return 0
+}
+
+func (m *DashboardShare) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *DashboardShare) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Source)(nil), "internal.Source")
 	proto.RegisterType((*Dashboard)(nil), "internal.Dashboard")
+	proto.RegisterType((*DashboardPermission)(nil), "internal.DashboardPermission")
 	proto.RegisterType((*DashboardCell)(nil), "internal.DashboardCell")
 	proto.RegisterType((*DecimalPlaces)(nil), "internal.DecimalPlaces")
 	proto.RegisterType((*TableOptions)(nil), "internal.TableOptions")
@@ -1535,126 +1775,128 @@ func init() {
 	proto.RegisterType((*LogViewerColumn)(nil), "internal.LogViewerColumn")
 	proto.RegisterType((*ColumnEncoding)(nil), "internal.ColumnEncoding")
 	proto.RegisterType((*BuildInfo)(nil), "internal.BuildInfo")
+	proto.RegisterType((*SourceCredentials)(nil), "internal.SourceCredentials")
+	proto.RegisterType((*DashboardShare)(nil), "internal.DashboardShare")
 }
 
 func init() { proto.RegisterFile("internal.proto", fileDescriptorInternal) }
 
 var fileDescriptorInternal = []byte{
-	// 1843 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdd, 0x6f, 0xdb, 0xc8,
-	0x11, 0x07, 0x25, 0x51, 0x16, 0x47, 0xb6, 0xe3, 0x6e, 0x83, 0x3b, 0xde, 0xb5, 0x28, 0x54, 0xa2,
-	0xbd, 0xba, 0x1f, 0x97, 0x1e, 0x1c, 0xf4, 0x03, 0x87, 0xbb, 0x03, 0xfc, 0x91, 0xa4, 0x4e, 0x9c,
-	0xc4, 0x59, 0x3b, 0xee, 0x53, 0x71, 0x58, 0x91, 0x2b, 0x69, 0x71, 0x14, 0xc9, 0x2e, 0x49, 0xdb,
-	0xec, 0xbf, 0xd0, 0xb7, 0xfe, 0x01, 0x01, 0x0a, 0xb4, 0xef, 0x45, 0xd1, 0xc7, 0x02, 0x7d, 0xef,
-	0x5f, 0x55, 0xcc, 0x7e, 0x50, 0x4b, 0x59, 0x09, 0x52, 0xa0, 0xe8, 0x93, 0xf6, 0x37, 0x33, 0x9c,
-	0x9d, 0x9d, 0xaf, 0x9d, 0x15, 0xec, 0x8a, 0xac, 0xe2, 0x32, 0x63, 0xe9, 0x83, 0x42, 0xe6, 0x55,
-	0x4e, 0x46, 0x16, 0x47, 0x6f, 0xfa, 0x30, 0xbc, 0xc8, 0x6b, 0x19, 0x73, 0xb2, 0x0b, 0xbd, 0xd3,
-	0x93, 0xd0, 0x9b, 0x78, 0xfb, 0x7d, 0xda, 0x3b, 0x3d, 0x21, 0x04, 0x06, 0x2f, 0xd8, 0x92, 0x87,
-	0xbd, 0x89, 0xb7, 0x1f, 0x50, 0xb5, 0x46, 0xda, 0x65, 0x53, 0xf0, 0xb0, 0xaf, 0x69, 0xb8, 0x26,
-	0x1f, 0xc3, 0xe8, 0x75, 0x89, 0xda, 0x96, 0x3c, 0x1c, 0x28, 0x7a, 0x8b, 0x91, 0x77, 0xce, 0xca,
-	0xf2, 0x26, 0x97, 0x49, 0xe8, 0x6b, 0x9e, 0xc5, 0x64, 0x0f, 0xfa, 0xaf, 0xe9, 0x59, 0x38, 0x54,
-	0x64, 0x5c, 0x92, 0x10, 0xb6, 0x4e, 0xf8, 0x8c, 0xd5, 0x69, 0x15, 0x6e, 0x4d, 0xbc, 0xfd, 0x11,
-	0xb5, 0x10, 0xf5, 0x5c, 0xf2, 0x94, 0xcf, 0x25, 0x9b, 0x85, 0x23, 0xad, 0xc7, 0x62, 0xf2, 0x00,
-	0xc8, 0x69, 0x56, 0xf2, 0xb8, 0x96, 0xfc, 0xe2, 0x1b, 0x51, 0x5c, 0x71, 0x29, 0x66, 0x4d, 0x18,
-	0x28, 0x05, 0x1b, 0x38, 0xb8, 0xcb, 0x73, 0x5e, 0x31, 0xdc, 0x1b, 0x94, 0x2a, 0x0b, 0x49, 0x04,
-	0xdb, 0x17, 0x0b, 0x26, 0x79, 0x72, 0xc1, 0x63, 0xc9, 0xab, 0x70, 0xac, 0xd8, 0x1d, 0x1a, 0xca,
-	0xbc, 0x94, 0x73, 0x96, 0x89, 0x3f, 0xb0, 0x4a, 0xe4, 0x59, 0xb8, 0xad, 0x65, 0x5c, 0x1a, 0x7a,
-	0x89, 0xe6, 0x29, 0x0f, 0x77, 0xb4, 0x97, 0x70, 0x4d, 0xbe, 0x0b, 0x81, 0x39, 0x0c, 0x3d, 0x0f,
-	0x77, 0x15, 0x63, 0x45, 0x40, 0x9b, 0xae, 0xb8, 0x2c, 0x51, 0xe1, 0x3d, 0x6d, 0x93, 0x81, 0xd1,
-	0x3f, 0x3c, 0x08, 0x4e, 0x58, 0xb9, 0x98, 0xe6, 0x4c, 0x26, 0xef, 0x15, 0xa3, 0x4f, 0xc1, 0x8f,
-	0x79, 0x9a, 0x96, 0x61, 0x7f, 0xd2, 0xdf, 0x1f, 0x1f, 0x7c, 0xf8, 0xa0, 0x0d, 0x7e, 0xab, 0xe7,
-	0x98, 0xa7, 0x29, 0xd5, 0x52, 0xe4, 0x33, 0x08, 0x2a, 0xbe, 0x2c, 0x52, 0x56, 0xf1, 0x32, 0x1c,
-	0xa8, 0x4f, 0xc8, 0xea, 0x93, 0x4b, 0xc3, 0xa2, 0x2b, 0xa1, 0x3b, 0x2e, 0xf0, 0xef, 0xba, 0x20,
-	0xfa, 0x93, 0x0f, 0x3b, 0x9d, 0xed, 0xc8, 0x36, 0x78, 0xb7, 0xca, 0x72, 0x9f, 0x7a, 0xb7, 0x88,
-	0x1a, 0x65, 0xb5, 0x4f, 0xbd, 0x06, 0xd1, 0x8d, 0xca, 0x29, 0x9f, 0x7a, 0x37, 0x88, 0x16, 0x2a,
-	0x93, 0x7c, 0xea, 0x2d, 0xc8, 0x8f, 0x61, 0xeb, 0xf7, 0x35, 0x97, 0x82, 0x97, 0xa1, 0xaf, 0xac,
-	0xbb, 0xb7, 0xb2, 0xee, 0x55, 0xcd, 0x65, 0x43, 0x2d, 0x1f, 0xbd, 0xa1, 0xb2, 0x50, 0xa7, 0x94,
-	0x5a, 0x23, 0xad, 0xc2, 0x8c, 0xdd, 0xd2, 0x34, 0x5c, 0x1b, 0x2f, 0xea, 0x3c, 0x42, 0x2f, 0xfe,
-	0x02, 0x06, 0xec, 0x96, 0x97, 0x61, 0xa0, 0xf4, 0x7f, 0xff, 0x2d, 0x0e, 0x7b, 0x70, 0x78, 0xcb,
-	0xcb, 0x47, 0x59, 0x25, 0x1b, 0xaa, 0xc4, 0xc9, 0x8f, 0x60, 0x18, 0xe7, 0x69, 0x2e, 0xcb, 0x10,
-	0xd6, 0x0d, 0x3b, 0x46, 0x3a, 0x35, 0x6c, 0xb2, 0x0f, 0xc3, 0x94, 0xcf, 0x79, 0x96, 0xa8, 0x8c,
-	0x1a, 0x1f, 0xec, 0xad, 0x04, 0xcf, 0x14, 0x9d, 0x1a, 0x3e, 0xf9, 0x1c, 0xb6, 0x2b, 0x36, 0x4d,
-	0xf9, 0xcb, 0x02, 0xbd, 0x58, 0xaa, 0xec, 0x1a, 0x1f, 0x7c, 0xe0, 0xc4, 0xc3, 0xe1, 0xd2, 0x8e,
-	0x2c, 0xf9, 0x02, 0xb6, 0x67, 0x82, 0xa7, 0x89, 0xfd, 0x76, 0x47, 0x19, 0x15, 0xae, 0xbe, 0xa5,
-	0x3c, 0x63, 0x4b, 0xfc, 0xe2, 0x31, 0x8a, 0xd1, 0x8e, 0x34, 0xf9, 0x1e, 0x40, 0x25, 0x96, 0xfc,
-	0x71, 0x2e, 0x97, 0xac, 0x32, 0x09, 0xea, 0x50, 0xc8, 0x97, 0xb0, 0x93, 0xf0, 0x58, 0x2c, 0x59,
-	0x7a, 0x9e, 0xb2, 0x98, 0x97, 0x2a, 0x4f, 0xbb, 0xd9, 0xe5, 0xb2, 0x69, 0x57, 0x5a, 0x85, 0x26,
-	0xaf, 0x78, 0xb8, 0x67, 0x42, 0x93, 0x57, 0x9c, 0x7c, 0x02, 0xbb, 0xf8, 0x7b, 0x25, 0x4a, 0x31,
-	0x15, 0xa9, 0xa8, 0x9a, 0xf0, 0x5b, 0x8a, 0xbb, 0x46, 0xfd, 0xf8, 0x09, 0x04, 0xad, 0xeb, 0xb1,
-	0x6b, 0x7c, 0xc3, 0x1b, 0x95, 0x48, 0x01, 0xc5, 0x25, 0xf9, 0x01, 0xf8, 0xd7, 0x2c, 0xad, 0x75,
-	0x11, 0x8c, 0x0f, 0x76, 0x57, 0x16, 0x1d, 0xde, 0x8a, 0x92, 0x6a, 0xe6, 0xe7, 0xbd, 0x5f, 0x7b,
-	0xd1, 0x13, 0xd8, 0xe9, 0x18, 0x89, 0x87, 0x16, 0xe5, 0xa3, 0x6c, 0x96, 0xcb, 0x98, 0x27, 0x4a,
-	0xe7, 0x88, 0x3a, 0x14, 0xf2, 0x01, 0x0c, 0x13, 0x31, 0x17, 0x55, 0x69, 0x52, 0xd5, 0xa0, 0xe8,
-	0x9f, 0x1e, 0x6c, 0xbb, 0x91, 0x20, 0x3f, 0x81, 0xbd, 0x6b, 0x2e, 0x2b, 0x11, 0xb3, 0xf4, 0x52,
-	0x2c, 0x39, 0x6e, 0xac, 0x3e, 0x19, 0xd1, 0x3b, 0x74, 0xf2, 0x19, 0x0c, 0xcb, 0x5c, 0x56, 0x47,
-	0x8d, 0xca, 0xf8, 0x77, 0x45, 0xc8, 0xc8, 0x61, 0xf7, 0xbb, 0x91, 0xac, 0x28, 0x44, 0x36, 0xb7,
-	0x1d, 0xd6, 0x62, 0x74, 0xe2, 0x4c, 0xdc, 0x3e, 0x16, 0xb2, 0xac, 0x8e, 0xf3, 0xb4, 0x5e, 0x66,
-	0x2a, 0xfb, 0x47, 0x74, 0x8d, 0xfa, 0x74, 0x30, 0xf2, 0xf6, 0x7a, 0x4f, 0x07, 0x23, 0x7f, 0x6f,
-	0x18, 0x15, 0xb0, 0xdb, 0xdd, 0x09, 0x4b, 0xda, 0x1a, 0xa1, 0xfa, 0x89, 0x76, 0x6f, 0x87, 0x46,
-	0x26, 0x30, 0x4e, 0x44, 0x59, 0xa4, 0xac, 0x71, 0x5a, 0x8e, 0x4b, 0xc2, 0x2e, 0x76, 0x8d, 0x61,
-	0x4b, 0xf5, 0x05, 0x31, 0xa2, 0x16, 0x46, 0x73, 0xf0, 0x55, 0x49, 0x38, 0x0d, 0x2c, 0xb0, 0x0d,
-	0x4c, 0x5d, 0x28, 0x3d, 0xe7, 0x42, 0xd9, 0x83, 0xfe, 0x6f, 0xf8, 0xad, 0xb9, 0x63, 0x70, 0xd9,
-	0xb6, 0xb9, 0x81, 0xd3, 0xe6, 0xee, 0x83, 0x7f, 0xa5, 0xc2, 0xae, 0xdb, 0x8f, 0x06, 0xd1, 0x57,
-	0x30, 0xd4, 0x25, 0xd5, 0x6a, 0xf6, 0x1c, 0xcd, 0x13, 0x18, 0xbf, 0x94, 0x82, 0x67, 0x95, 0x6e,
-	0x5c, 0xe6, 0x08, 0x0e, 0x29, 0xfa, 0xbb, 0x07, 0x03, 0x15, 0xa5, 0x08, 0xb6, 0x53, 0x3e, 0x67,
-	0x71, 0x73, 0x94, 0xd7, 0x59, 0x52, 0x86, 0xde, 0xa4, 0xbf, 0xdf, 0xa7, 0x1d, 0x1a, 0xa6, 0xc7,
-	0x54, 0x73, 0x7b, 0x93, 0xfe, 0x7e, 0x40, 0x0d, 0x42, 0xd3, 0x52, 0x36, 0xe5, 0xa9, 0x39, 0x82,
-	0x06, 0x28, 0x5d, 0x48, 0x3e, 0x13, 0xb7, 0xe6, 0x18, 0x06, 0x21, 0xbd, 0xac, 0x67, 0x48, 0xd7,
-	0x27, 0x31, 0x08, 0x0f, 0x30, 0x65, 0x65, 0xdb, 0xcd, 0x70, 0x8d, 0x9a, 0xcb, 0x98, 0xa5, 0xb6,
-	0x9d, 0x69, 0x10, 0xfd, 0xcb, 0xc3, 0xeb, 0x51, 0xb7, 0xe7, 0x3b, 0x1e, 0xfe, 0x08, 0x46, 0xd8,
-	0xba, 0xbf, 0xbe, 0x66, 0xd2, 0x1c, 0x78, 0x0b, 0xf1, 0x15, 0x93, 0xe4, 0xe7, 0x30, 0x54, 0xc5,
-	0xb1, 0xe1, 0xaa, 0xb0, 0xea, 0x94, 0x57, 0xa9, 0x11, 0x6b, 0x9b, 0xe9, 0xc0, 0x69, 0xa6, 0xed,
-	0x61, 0x7d, 0xf7, 0xb0, 0x9f, 0x82, 0x8f, 0x5d, 0xb9, 0x51, 0xd6, 0x6f, 0xd4, 0xac, 0x7b, 0xb7,
-	0x96, 0x8a, 0xe6, 0xb0, 0xd3, 0xd9, 0xb1, 0xdd, 0xc9, 0xeb, 0xee, 0xb4, 0x2a, 0xf4, 0xc0, 0x14,
-	0x36, 0x16, 0x47, 0xc9, 0x53, 0x1e, 0x57, 0x3c, 0x31, 0x59, 0xd7, 0x62, 0xdb, 0x2c, 0x06, 0x6d,
-	0xb3, 0x88, 0xfe, 0xec, 0xad, 0x76, 0x52, 0x16, 0x60, 0xd2, 0xc6, 0xf9, 0x72, 0xc9, 0xb2, 0xc4,
-	0x6c, 0x66, 0x21, 0x7a, 0x32, 0x99, 0x9a, 0xcd, 0x7a, 0xc9, 0x14, 0xb1, 0x2c, 0x4c, 0x4c, 0x7b,
-	0xb2, 0xc0, 0x6c, 0x5a, 0x72, 0x56, 0xd6, 0x92, 0x2f, 0x79, 0x56, 0x99, 0x5d, 0x5c, 0x12, 0xf9,
-	0x10, 0xb6, 0x2a, 0x36, 0xff, 0x1a, 0x6d, 0x30, 0xb1, 0xad, 0xd8, 0xfc, 0x19, 0x6f, 0xc8, 0x77,
-	0x20, 0x50, 0xdd, 0x57, 0xb1, 0x74, 0x80, 0x47, 0x8a, 0xf0, 0x8c, 0x37, 0xd1, 0xdf, 0x7a, 0x30,
-	0xbc, 0xe0, 0xf2, 0x9a, 0xcb, 0xf7, 0xba, 0xef, 0xdd, 0xf9, 0xab, 0xff, 0x8e, 0xf9, 0x6b, 0xb0,
-	0x79, 0xfe, 0xf2, 0x57, 0xf3, 0xd7, 0x7d, 0xf0, 0x2f, 0x64, 0x7c, 0x7a, 0xa2, 0x2c, 0xea, 0x53,
-	0x0d, 0x30, 0x3f, 0x0f, 0xe3, 0x4a, 0x5c, 0x73, 0x33, 0x94, 0x19, 0x74, 0x67, 0x0c, 0x18, 0x6d,
-	0x98, 0x84, 0xfe, 0xdb, 0xd9, 0xcc, 0x16, 0x2d, 0x38, 0x45, 0x1b, 0xc1, 0x36, 0x0e, 0x68, 0x09,
-	0xab, 0xd8, 0xd3, 0x8b, 0x97, 0x2f, 0xec, 0x54, 0xe6, 0xd2, 0xa2, 0x37, 0x1e, 0x0c, 0xcf, 0x58,
-	0x93, 0xd7, 0xd5, 0x9d, 0xfc, 0x9f, 0xc0, 0xf8, 0xb0, 0x28, 0x52, 0x11, 0x77, 0x6a, 0xde, 0x21,
-	0xa1, 0xc4, 0x73, 0x27, 0x8e, 0xda, 0x87, 0x2e, 0x09, 0xaf, 0x98, 0x63, 0x35, 0x52, 0xe9, 0xf9,
-	0xc8, 0xb9, 0x62, 0xf4, 0x24, 0xa5, 0x98, 0xe8, 0xec, 0xc3, 0xba, 0xca, 0x67, 0x69, 0x7e, 0xa3,
-	0xbc, 0x3a, 0xa2, 0x2d, 0x8e, 0xfe, 0xdd, 0x83, 0xc1, 0xff, 0x6b, 0x0c, 0xda, 0x06, 0x4f, 0x98,
-	0xa4, 0xf2, 0x44, 0x3b, 0x14, 0x6d, 0x39, 0x43, 0x51, 0x08, 0x5b, 0x8d, 0x64, 0xd9, 0x9c, 0x97,
-	0xe1, 0x48, 0xf5, 0x35, 0x0b, 0x15, 0x47, 0x55, 0xb0, 0x9e, 0x86, 0x02, 0x6a, 0x61, 0x5b, 0x91,
-	0xe0, 0x54, 0xe4, 0xcf, 0xcc, 0xe0, 0x34, 0x5e, 0x1f, 0x35, 0x36, 0xcd, 0x4b, 0xff, 0xbb, 0x7b,
-	0xfc, 0x8f, 0x3d, 0xf0, 0xdb, 0xe2, 0x3d, 0xee, 0x16, 0xef, 0xf1, 0xaa, 0x78, 0x4f, 0x8e, 0x6c,
-	0xf1, 0x9e, 0x1c, 0x21, 0xa6, 0xe7, 0xb6, 0x78, 0xe9, 0x39, 0x06, 0xeb, 0x89, 0xcc, 0xeb, 0xe2,
-	0xa8, 0xd1, 0x51, 0x0d, 0x68, 0x8b, 0x31, 0xe3, 0x7f, 0xbb, 0xe0, 0xd2, 0xb8, 0x3a, 0xa0, 0x06,
-	0x61, 0x7d, 0x9c, 0xa9, 0x56, 0xa7, 0x9d, 0xab, 0x01, 0xf9, 0x21, 0xf8, 0x14, 0x9d, 0xa7, 0x3c,
-	0xdc, 0x89, 0x8b, 0x22, 0x53, 0xcd, 0x45, 0xa5, 0xfa, 0xa1, 0x65, 0x0a, 0xc5, 0x3e, 0xbb, 0x7e,
-	0x0a, 0xc3, 0x8b, 0x85, 0x98, 0x55, 0x76, 0xfc, 0xfc, 0xb6, 0xd3, 0x2a, 0xc5, 0x92, 0x2b, 0x1e,
-	0x35, 0x22, 0x9b, 0xea, 0x23, 0x7a, 0x05, 0x41, 0x2b, 0xb8, 0x32, 0xd1, 0x73, 0x4d, 0x24, 0x30,
-	0x78, 0x9d, 0x89, 0xca, 0xb6, 0x0d, 0x5c, 0xa3, 0x03, 0x5e, 0xd5, 0x2c, 0xab, 0x70, 0xee, 0x32,
-	0x6d, 0xc3, 0xe2, 0xe8, 0xa1, 0x39, 0x12, 0xaa, 0x7b, 0x5d, 0x14, 0x5c, 0x9a, 0x16, 0xa4, 0x81,
-	0xda, 0x24, 0xbf, 0xe1, 0xfa, 0x3e, 0xe9, 0x53, 0x0d, 0xa2, 0xdf, 0x41, 0x70, 0x98, 0x72, 0x59,
-	0xd1, 0x3a, 0xe5, 0x9b, 0xee, 0x79, 0x55, 0xbc, 0xc6, 0x02, 0x5c, 0xaf, 0xda, 0x4d, 0x7f, 0xad,
-	0xdd, 0x3c, 0x63, 0x05, 0x3b, 0x3d, 0x51, 0xb9, 0xdf, 0xa7, 0x06, 0x45, 0x7f, 0xf1, 0x60, 0x80,
-	0x7d, 0xcd, 0x51, 0x3d, 0x78, 0x57, 0x4f, 0x3c, 0x97, 0xf9, 0xb5, 0x48, 0xb8, 0xb4, 0x87, 0xb3,
-	0x58, 0x05, 0x22, 0x5e, 0xf0, 0x76, 0x9c, 0x30, 0x08, 0xf3, 0x0f, 0x5f, 0x6a, 0xb6, 0xbe, 0x9c,
-	0xfc, 0x43, 0x32, 0xd5, 0x4c, 0x1c, 0x19, 0x2f, 0xea, 0x82, 0xcb, 0xc3, 0x64, 0x29, 0xec, 0xac,
-	0xe5, 0x50, 0xa2, 0xaf, 0xf4, 0xdb, 0xef, 0x4e, 0x77, 0xf4, 0x36, 0xbf, 0x13, 0xd7, 0x2d, 0x8f,
-	0xfe, 0xea, 0xc1, 0xd6, 0x73, 0x33, 0xdb, 0xb9, 0xa7, 0xf0, 0xde, 0x7a, 0x8a, 0x5e, 0xe7, 0x14,
-	0x07, 0x70, 0xdf, 0xca, 0x74, 0xf6, 0xd7, 0x5e, 0xd8, 0xc8, 0x33, 0x1e, 0x1d, 0xb4, 0xc1, 0x7a,
-	0x9f, 0x07, 0xde, 0x65, 0x57, 0x66, 0x53, 0xc0, 0xef, 0x44, 0x65, 0x02, 0x63, 0xfb, 0xe4, 0xcd,
-	0x53, 0x7b, 0x59, 0xb9, 0xa4, 0xe8, 0x00, 0x86, 0xc7, 0x79, 0x36, 0x13, 0x73, 0xb2, 0x0f, 0x83,
-	0xc3, 0xba, 0x5a, 0x28, 0x8d, 0xe3, 0x83, 0xfb, 0x4e, 0x33, 0xa8, 0xab, 0x85, 0x96, 0xa1, 0x4a,
-	0x22, 0xfa, 0x02, 0x60, 0x45, 0xc3, 0x1b, 0x67, 0x15, 0x8d, 0x17, 0xfc, 0x06, 0x53, 0xa6, 0x34,
-	0xa3, 0xfd, 0x06, 0x4e, 0x54, 0x03, 0x71, 0xcf, 0x61, 0xb4, 0x7c, 0x02, 0xbb, 0x2e, 0xb5, 0x3d,
-	0xd9, 0x1a, 0x95, 0xfc, 0x0a, 0x82, 0xb3, 0x7c, 0x7e, 0x25, 0xb8, 0xad, 0x86, 0xf1, 0xc1, 0x47,
-	0xce, 0xe3, 0xce, 0xb2, 0x8c, 0xbd, 0x2b, 0xd9, 0xe8, 0x31, 0xdc, 0x5b, 0xe3, 0x92, 0x87, 0xd8,
-	0xcb, 0x70, 0x56, 0xd7, 0xc3, 0xe6, 0xdb, 0x34, 0xa1, 0x04, 0xb5, 0x92, 0x51, 0xd3, 0xd1, 0x83,
-	0xb4, 0xd6, 0xf3, 0xde, 0x5a, 0x3d, 0xe4, 0xa5, 0x68, 0x6f, 0x40, 0x9f, 0xb6, 0x98, 0xfc, 0x12,
-	0x82, 0x47, 0x59, 0x9c, 0x27, 0x22, 0x9b, 0xdb, 0x41, 0x30, 0xec, 0xbc, 0x64, 0xeb, 0x65, 0x66,
-	0x05, 0xe8, 0x4a, 0x34, 0x7a, 0x01, 0xbb, 0x5d, 0xe6, 0xc6, 0x91, 0xbb, 0x1d, 0xd3, 0x7b, 0xce,
-	0x98, 0xde, 0xda, 0xd8, 0x77, 0x32, 0xff, 0x4b, 0x08, 0x8e, 0x6a, 0x91, 0x26, 0xa7, 0xd9, 0x2c,
-	0x77, 0xff, 0x10, 0xf1, 0x3a, 0x7f, 0x88, 0x60, 0xe2, 0x63, 0x8f, 0x6f, 0xbb, 0x99, 0x41, 0xd3,
-	0xa1, 0xfa, 0x6b, 0xeb, 0xe1, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x08, 0x42, 0x4c, 0x95, 0xec,
-	0x12, 0x00, 0x00,
+	// 1847 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x5b, 0x6f, 0x1c, 0x49,
+	0x15, 0x56, 0xcf, 0x4c, 0xcf, 0x4c, 0x9f, 0x19, 0x3b, 0xa6, 0x88, 0xb2, 0xbd, 0x0b, 0x42, 0x43,
+	0x0b, 0x16, 0x73, 0xd9, 0xb0, 0x72, 0xc4, 0x45, 0xab, 0xdd, 0x95, 0x7c, 0x49, 0x82, 0x13, 0x27,
+	0x71, 0xca, 0x8e, 0x79, 0x42, 0xab, 0xf2, 0x74, 0xcd, 0xb8, 0xb4, 0x7d, 0xa3, 0xba, 0xdb, 0x76,
+	0xf3, 0x17, 0x78, 0xe3, 0x07, 0x20, 0x21, 0xc1, 0x3b, 0x42, 0x3c, 0x21, 0x24, 0xde, 0xf9, 0x55,
+	0xe8, 0xd4, 0xa5, 0xa7, 0x7a, 0x3c, 0x89, 0x82, 0x84, 0x78, 0x9a, 0xfa, 0xce, 0x39, 0x7d, 0xea,
+	0xd4, 0xb9, 0xd5, 0xa9, 0x81, 0x6d, 0x91, 0x55, 0x5c, 0x66, 0x2c, 0x79, 0x58, 0xc8, 0xbc, 0xca,
+	0xc9, 0xd8, 0xe2, 0xe8, 0x1f, 0x7d, 0x18, 0x9e, 0xe5, 0xb5, 0x9c, 0x73, 0xb2, 0x0d, 0xbd, 0xe3,
+	0xa3, 0xd0, 0x9b, 0x79, 0xbb, 0x7d, 0xda, 0x3b, 0x3e, 0x22, 0x04, 0x06, 0x2f, 0x59, 0xca, 0xc3,
+	0xde, 0xcc, 0xdb, 0x0d, 0xa8, 0x5a, 0x23, 0xed, 0xbc, 0x29, 0x78, 0xd8, 0xd7, 0x34, 0x5c, 0x93,
+	0x8f, 0x60, 0xfc, 0xa6, 0x44, 0x6d, 0x29, 0x0f, 0x07, 0x8a, 0xde, 0x62, 0xe4, 0x9d, 0xb2, 0xb2,
+	0xbc, 0xc9, 0x65, 0x1c, 0xfa, 0x9a, 0x67, 0x31, 0xd9, 0x81, 0xfe, 0x1b, 0x7a, 0x12, 0x0e, 0x15,
+	0x19, 0x97, 0x24, 0x84, 0xd1, 0x11, 0x5f, 0xb0, 0x3a, 0xa9, 0xc2, 0xd1, 0xcc, 0xdb, 0x1d, 0x53,
+	0x0b, 0x51, 0xcf, 0x39, 0x4f, 0xf8, 0x52, 0xb2, 0x45, 0x38, 0xd6, 0x7a, 0x2c, 0x26, 0x0f, 0x81,
+	0x1c, 0x67, 0x25, 0x9f, 0xd7, 0x92, 0x9f, 0x7d, 0x2d, 0x8a, 0x0b, 0x2e, 0xc5, 0xa2, 0x09, 0x03,
+	0xa5, 0x60, 0x03, 0x07, 0x77, 0x79, 0xc1, 0x2b, 0x86, 0x7b, 0x83, 0x52, 0x65, 0x21, 0x89, 0x60,
+	0x7a, 0x76, 0xc5, 0x24, 0x8f, 0xcf, 0xf8, 0x5c, 0xf2, 0x2a, 0x9c, 0x28, 0x76, 0x87, 0x86, 0x32,
+	0xaf, 0xe4, 0x92, 0x65, 0xe2, 0x77, 0xac, 0x12, 0x79, 0x16, 0x4e, 0xb5, 0x8c, 0x4b, 0x43, 0x2f,
+	0xd1, 0x3c, 0xe1, 0xe1, 0x96, 0xf6, 0x12, 0xae, 0xc9, 0xb7, 0x21, 0x30, 0x87, 0xa1, 0xa7, 0xe1,
+	0xb6, 0x62, 0xac, 0x08, 0x68, 0xd3, 0x05, 0x97, 0x25, 0x2a, 0xbc, 0xa7, 0x6d, 0x32, 0xd0, 0x7a,
+	0x77, 0xbf, 0xae, 0xae, 0xc2, 0x9d, 0x95, 0x77, 0x11, 0x47, 0x7f, 0xf7, 0x20, 0x38, 0x62, 0xe5,
+	0xd5, 0x65, 0xce, 0x64, 0xfc, 0x5e, 0xf1, 0xfb, 0x04, 0xfc, 0x39, 0x4f, 0x92, 0x32, 0xec, 0xcf,
+	0xfa, 0xbb, 0x93, 0xbd, 0x0f, 0x1e, 0xb6, 0x89, 0xd1, 0xea, 0x39, 0xe4, 0x49, 0x42, 0xb5, 0x14,
+	0xf9, 0x14, 0x82, 0x8a, 0xa7, 0x45, 0xc2, 0x2a, 0x5e, 0x86, 0x03, 0xf5, 0x09, 0x59, 0x7d, 0x72,
+	0x6e, 0x58, 0x74, 0x25, 0x74, 0xc7, 0x3d, 0xfe, 0x5d, 0xf7, 0x44, 0x7f, 0xf0, 0x61, 0xab, 0xb3,
+	0x1d, 0x99, 0x82, 0x77, 0xab, 0x2c, 0xf7, 0xa9, 0x77, 0x8b, 0xa8, 0x51, 0x56, 0xfb, 0xd4, 0x6b,
+	0x10, 0xdd, 0xa8, 0x7c, 0xf3, 0xa9, 0x77, 0x83, 0xe8, 0x4a, 0x65, 0x99, 0x4f, 0xbd, 0x2b, 0xf2,
+	0x43, 0x18, 0xfd, 0xb6, 0xe6, 0x52, 0xf0, 0x32, 0xf4, 0x95, 0x75, 0xf7, 0x56, 0xd6, 0xbd, 0xae,
+	0xb9, 0x6c, 0xa8, 0xe5, 0xa3, 0x37, 0x54, 0x86, 0xea, 0x74, 0x53, 0x6b, 0xa4, 0x55, 0x98, 0xcd,
+	0x23, 0x4d, 0xc3, 0xb5, 0xf1, 0xa2, 0xce, 0x31, 0xf4, 0xe2, 0xcf, 0x60, 0xc0, 0x6e, 0x79, 0x19,
+	0x06, 0x4a, 0xff, 0x77, 0xdf, 0xe2, 0xb0, 0x87, 0xfb, 0xb7, 0xbc, 0x7c, 0x9c, 0x55, 0xb2, 0xa1,
+	0x4a, 0x9c, 0xfc, 0x00, 0x86, 0xf3, 0x3c, 0xc9, 0x65, 0x19, 0xc2, 0xba, 0x61, 0x87, 0x48, 0xa7,
+	0x86, 0x4d, 0x76, 0x61, 0x98, 0xf0, 0x25, 0xcf, 0x62, 0x95, 0x6d, 0x93, 0xbd, 0x9d, 0x95, 0xe0,
+	0x89, 0xa2, 0x53, 0xc3, 0x27, 0x9f, 0xc1, 0xb4, 0x62, 0x97, 0x09, 0x7f, 0x55, 0xa0, 0x17, 0x4b,
+	0x95, 0x79, 0x93, 0xbd, 0x07, 0x4e, 0x3c, 0x1c, 0x2e, 0xed, 0xc8, 0x92, 0xcf, 0x61, 0xba, 0x10,
+	0x3c, 0x89, 0xed, 0xb7, 0x5b, 0xca, 0xa8, 0x70, 0xf5, 0x2d, 0xe5, 0x19, 0x4b, 0xf1, 0x8b, 0x27,
+	0x28, 0x46, 0x3b, 0xd2, 0xe4, 0x3b, 0x00, 0x95, 0x48, 0xf9, 0x93, 0x5c, 0xa6, 0xac, 0x32, 0xc9,
+	0xeb, 0x50, 0xc8, 0x17, 0xb0, 0x15, 0xf3, 0xb9, 0x48, 0x59, 0x72, 0x9a, 0xb0, 0x39, 0x2f, 0x55,
+	0x0e, 0x77, 0xb3, 0xcb, 0x65, 0xd3, 0xae, 0xb4, 0x0a, 0x4d, 0x5e, 0x71, 0x93, 0xde, 0x6a, 0x4d,
+	0x3e, 0x86, 0x6d, 0xfc, 0xbd, 0x10, 0xa5, 0xb8, 0x14, 0x89, 0xa8, 0x9a, 0xf0, 0x1b, 0x8a, 0xbb,
+	0x46, 0xfd, 0xe8, 0x29, 0x04, 0xad, 0xeb, 0xb1, 0xa3, 0x7c, 0xcd, 0x1b, 0x95, 0x48, 0x01, 0xc5,
+	0x25, 0xf9, 0x1e, 0xf8, 0xd7, 0x2c, 0xa9, 0x75, 0x11, 0x4c, 0xf6, 0xb6, 0x57, 0x16, 0xed, 0xdf,
+	0x8a, 0x92, 0x6a, 0xe6, 0x67, 0xbd, 0x5f, 0x7a, 0xd1, 0x53, 0xd8, 0xea, 0x18, 0x89, 0x87, 0x16,
+	0xe5, 0xe3, 0x6c, 0x91, 0xcb, 0x39, 0x8f, 0x95, 0xce, 0x31, 0x75, 0x28, 0xe4, 0x01, 0x0c, 0x63,
+	0xb1, 0x14, 0x55, 0x69, 0x52, 0xd5, 0xa0, 0xe8, 0x9f, 0x1e, 0x4c, 0xdd, 0x48, 0x90, 0x1f, 0xc1,
+	0xce, 0x35, 0x97, 0x95, 0x98, 0xb3, 0xe4, 0x5c, 0xa4, 0x1c, 0x37, 0x56, 0x9f, 0x8c, 0xe9, 0x1d,
+	0x3a, 0xf9, 0x14, 0x86, 0x65, 0x2e, 0xab, 0x83, 0x46, 0x65, 0xfc, 0xbb, 0x22, 0x64, 0xe4, 0xb0,
+	0x3f, 0xdc, 0x48, 0x56, 0x14, 0x22, 0x5b, 0xda, 0xee, 0x6b, 0x31, 0x3a, 0x71, 0x21, 0x6e, 0x9f,
+	0x08, 0x59, 0x56, 0x87, 0x79, 0x52, 0xa7, 0x99, 0xca, 0xfe, 0x31, 0x5d, 0xa3, 0x3e, 0x1b, 0x8c,
+	0xbd, 0x9d, 0xde, 0xb3, 0xc1, 0xd8, 0xdf, 0x19, 0x46, 0x05, 0x6c, 0x77, 0x77, 0xc2, 0x92, 0xb6,
+	0x46, 0xa8, 0x7e, 0xa2, 0xdd, 0xdb, 0xa1, 0x91, 0x19, 0x4c, 0x62, 0x51, 0x16, 0x09, 0x6b, 0x9c,
+	0x96, 0xe3, 0x92, 0xb0, 0xc3, 0x5d, 0x63, 0xd8, 0x12, 0x7d, 0x79, 0x8c, 0xa9, 0x85, 0xd1, 0x12,
+	0x7c, 0x55, 0x12, 0x4e, 0x03, 0x0b, 0x6c, 0x03, 0x53, 0x97, 0x4d, 0xcf, 0xb9, 0x6c, 0x76, 0xa0,
+	0xff, 0x2b, 0x7e, 0x6b, 0xee, 0x1f, 0x5c, 0xb6, 0x6d, 0x6e, 0xe0, 0xb4, 0xb9, 0xfb, 0xe0, 0x5f,
+	0xa8, 0xb0, 0xeb, 0xf6, 0xa3, 0x41, 0xf4, 0x25, 0x0c, 0x75, 0x49, 0xb5, 0x9a, 0x3d, 0x47, 0xf3,
+	0x0c, 0x26, 0xaf, 0xa4, 0xe0, 0x59, 0xa5, 0x1b, 0x97, 0x39, 0x82, 0x43, 0x8a, 0xfe, 0xe6, 0xc1,
+	0x40, 0x45, 0x29, 0x82, 0x69, 0xc2, 0x97, 0x6c, 0xde, 0x1c, 0xe4, 0x75, 0x16, 0x97, 0xa1, 0x37,
+	0xeb, 0xef, 0xf6, 0x69, 0x87, 0x86, 0xe9, 0x71, 0xa9, 0xb9, 0xbd, 0x59, 0x7f, 0x37, 0xa0, 0x06,
+	0xa1, 0x69, 0x09, 0xbb, 0xe4, 0x89, 0x39, 0x82, 0x06, 0x28, 0x5d, 0x48, 0xbe, 0x10, 0xb7, 0xe6,
+	0x18, 0x06, 0x21, 0xbd, 0xac, 0x17, 0x48, 0xd7, 0x27, 0x31, 0x08, 0x0f, 0x70, 0xc9, 0xca, 0xb6,
+	0x9b, 0xe1, 0x1a, 0x35, 0x97, 0x73, 0x96, 0xd8, 0x76, 0xa6, 0x41, 0xf4, 0x2f, 0x0f, 0xaf, 0x4e,
+	0xdd, 0x9e, 0xef, 0x78, 0xf8, 0x43, 0x18, 0x63, 0xeb, 0xfe, 0xea, 0x9a, 0x49, 0x73, 0xe0, 0x11,
+	0xe2, 0x0b, 0x26, 0xc9, 0x4f, 0x61, 0xa8, 0x8a, 0x63, 0xc3, 0x55, 0x61, 0xd5, 0x29, 0xaf, 0x52,
+	0x23, 0xd6, 0x36, 0xd3, 0x81, 0xd3, 0x4c, 0xdb, 0xc3, 0xfa, 0xee, 0x61, 0x3f, 0x01, 0x1f, 0xbb,
+	0x72, 0xa3, 0xac, 0xdf, 0xa8, 0x59, 0xf7, 0x6e, 0x2d, 0x15, 0x2d, 0x61, 0xab, 0xb3, 0x63, 0xbb,
+	0x93, 0xd7, 0xdd, 0x69, 0x55, 0xe8, 0x81, 0x29, 0x6c, 0x2c, 0x8e, 0x92, 0x27, 0x7c, 0x5e, 0xf1,
+	0xd8, 0x64, 0x5d, 0x8b, 0x6d, 0xb3, 0x18, 0xb4, 0xcd, 0x22, 0xfa, 0x93, 0x07, 0x5b, 0x1d, 0x0b,
+	0x30, 0x69, 0xe7, 0x79, 0x9a, 0xb2, 0x2c, 0x36, 0x9b, 0x59, 0x88, 0x9e, 0x8c, 0x2f, 0xcd, 0x66,
+	0xbd, 0xf8, 0x12, 0xb1, 0x2c, 0x4c, 0x4c, 0x7b, 0xb2, 0xc0, 0x6c, 0x4a, 0x39, 0x2b, 0x6b, 0xc9,
+	0x53, 0x9e, 0x55, 0x66, 0x17, 0x97, 0x44, 0x3e, 0x80, 0x51, 0xc5, 0x96, 0x5f, 0xa1, 0x0d, 0x26,
+	0xb6, 0x15, 0x5b, 0x3e, 0xe7, 0x0d, 0xf9, 0x16, 0x04, 0xaa, 0xfb, 0x2a, 0x96, 0x0e, 0xf0, 0x58,
+	0x11, 0x9e, 0xf3, 0x26, 0xfa, 0x6b, 0x0f, 0x86, 0x67, 0x5c, 0x5e, 0x73, 0xf9, 0x5e, 0xf7, 0xbd,
+	0x3b, 0x9b, 0xf5, 0xdf, 0x31, 0x9b, 0x0d, 0x36, 0xcf, 0x66, 0xfe, 0x6a, 0x36, 0xbb, 0x0f, 0xfe,
+	0x99, 0x9c, 0x1f, 0x1f, 0x29, 0x8b, 0xfa, 0x54, 0x03, 0xcc, 0xcf, 0xfd, 0x79, 0x25, 0xae, 0xb9,
+	0x19, 0xd8, 0x0c, 0xba, 0x33, 0x06, 0x8c, 0x37, 0x4c, 0x49, 0xff, 0xed, 0xdc, 0x66, 0x8b, 0x16,
+	0x9c, 0xa2, 0x8d, 0x60, 0x8a, 0xc3, 0x5b, 0xcc, 0x2a, 0xf6, 0xec, 0xec, 0xd5, 0x4b, 0x3b, 0xb1,
+	0xb9, 0xb4, 0xe8, 0x8f, 0x1e, 0x0c, 0x4f, 0x58, 0x93, 0xd7, 0xd5, 0x9d, 0xfc, 0x9f, 0xc1, 0x64,
+	0xbf, 0x28, 0x12, 0x31, 0xef, 0xd4, 0xbc, 0x43, 0x42, 0x89, 0x17, 0x4e, 0x1c, 0xb5, 0x0f, 0x5d,
+	0x12, 0x5e, 0x31, 0x87, 0x6a, 0xa4, 0xd2, 0xf3, 0x91, 0x73, 0xc5, 0xe8, 0x49, 0x4a, 0x31, 0xd1,
+	0xd9, 0xfb, 0x75, 0x95, 0x2f, 0x92, 0xfc, 0x46, 0x79, 0x75, 0x4c, 0x5b, 0x1c, 0xfd, 0xbb, 0x07,
+	0x83, 0xff, 0xd7, 0x18, 0x34, 0x05, 0x4f, 0x98, 0xa4, 0xf2, 0x44, 0x3b, 0x14, 0x8d, 0x9c, 0xa1,
+	0x28, 0x84, 0x51, 0x23, 0x59, 0xb6, 0xe4, 0x65, 0x38, 0x56, 0x7d, 0xcd, 0x42, 0xc5, 0x51, 0x15,
+	0xac, 0xa7, 0xa1, 0x80, 0x5a, 0xd8, 0x56, 0x24, 0x38, 0x15, 0xf9, 0x13, 0x33, 0x38, 0x4d, 0xd6,
+	0x47, 0x8d, 0x4d, 0xf3, 0xd2, 0xff, 0xee, 0x1e, 0xff, 0x7d, 0x0f, 0xfc, 0xb6, 0x78, 0x0f, 0xbb,
+	0xc5, 0x7b, 0xb8, 0x2a, 0xde, 0xa3, 0x03, 0x5b, 0xbc, 0x47, 0x07, 0x88, 0xe9, 0xa9, 0x2d, 0x5e,
+	0x7a, 0x8a, 0xc1, 0x7a, 0x2a, 0xf3, 0xba, 0x38, 0x68, 0x74, 0x54, 0x03, 0xda, 0x62, 0xcc, 0xf8,
+	0x5f, 0x5f, 0x71, 0x69, 0x5c, 0x1d, 0x50, 0x83, 0xb0, 0x3e, 0x4e, 0x54, 0xab, 0xd3, 0xce, 0xd5,
+	0x80, 0x7c, 0x1f, 0x7c, 0x8a, 0xce, 0x53, 0x1e, 0xee, 0xc4, 0x45, 0x91, 0xa9, 0xe6, 0x92, 0x07,
+	0xf6, 0x11, 0x66, 0x0a, 0xc5, 0x20, 0xf2, 0x63, 0x18, 0x9e, 0x5d, 0x89, 0x45, 0x65, 0xc7, 0xcf,
+	0x6f, 0x3a, 0xad, 0x52, 0xa4, 0x5c, 0xf1, 0xa8, 0x11, 0xd9, 0x54, 0x1f, 0xd1, 0x6b, 0x08, 0x5a,
+	0xc1, 0x95, 0x89, 0x9e, 0x6b, 0x22, 0x81, 0xc1, 0x9b, 0x4c, 0x54, 0xb6, 0x6d, 0xe0, 0x1a, 0x1d,
+	0xf0, 0xba, 0x66, 0x59, 0x85, 0x73, 0x97, 0x69, 0x1b, 0x16, 0x47, 0x8f, 0xcc, 0x91, 0x50, 0xdd,
+	0x9b, 0xa2, 0xe0, 0xd2, 0xb4, 0x20, 0x0d, 0xd4, 0x26, 0xf9, 0x0d, 0xd7, 0xf7, 0x49, 0x9f, 0x6a,
+	0x10, 0xfd, 0x06, 0x82, 0xfd, 0x84, 0xcb, 0x8a, 0xd6, 0x09, 0xdf, 0x74, 0xcf, 0xab, 0xe2, 0x35,
+	0x16, 0xe0, 0x7a, 0xd5, 0x6e, 0xfa, 0x6b, 0xed, 0xe6, 0x39, 0x2b, 0xd8, 0xf1, 0x91, 0xca, 0xfd,
+	0x3e, 0x35, 0x28, 0xfa, 0xb3, 0x07, 0x03, 0xec, 0x6b, 0x8e, 0xea, 0xc1, 0xbb, 0x7a, 0xe2, 0xa9,
+	0xcc, 0xaf, 0x45, 0xcc, 0xa5, 0x3d, 0x9c, 0xc5, 0x2a, 0x10, 0xf3, 0x2b, 0xde, 0x8e, 0x13, 0x06,
+	0x61, 0xfe, 0xe1, 0x2b, 0xce, 0xd6, 0x97, 0x93, 0x7f, 0x48, 0xa6, 0x9a, 0x89, 0x23, 0xe3, 0x59,
+	0x5d, 0x70, 0xb9, 0x1f, 0xa7, 0xc2, 0xce, 0x5a, 0x0e, 0x25, 0xfa, 0x52, 0xbf, 0x0b, 0xef, 0x74,
+	0x47, 0x6f, 0xf3, 0x1b, 0x72, 0xdd, 0xf2, 0xe8, 0x2f, 0x1e, 0x8c, 0x5e, 0x98, 0xd9, 0xce, 0x3d,
+	0x85, 0xf7, 0xd6, 0x53, 0xf4, 0x3a, 0xa7, 0xd8, 0x83, 0xfb, 0x56, 0xa6, 0xb3, 0xbf, 0xf6, 0xc2,
+	0x46, 0x9e, 0xf1, 0xe8, 0xa0, 0x0d, 0xd6, 0xfb, 0x3c, 0xf0, 0xce, 0x61, 0xba, 0x41, 0x47, 0x27,
+	0xe0, 0x77, 0xa2, 0x32, 0x83, 0x89, 0x7d, 0x0e, 0xe7, 0x89, 0xbd, 0xac, 0x5c, 0x52, 0xb4, 0x07,
+	0xc3, 0xc3, 0x3c, 0x5b, 0x88, 0x25, 0xd9, 0x85, 0x81, 0x7a, 0x0f, 0x7b, 0xaa, 0xa8, 0xee, 0x3b,
+	0xcd, 0xa0, 0xae, 0xae, 0xb4, 0x0c, 0x55, 0x12, 0xd1, 0xe7, 0x00, 0x2b, 0x1a, 0xde, 0x38, 0xab,
+	0x68, 0xbc, 0xe4, 0x37, 0x98, 0x32, 0xa5, 0x19, 0xed, 0x37, 0x70, 0xa2, 0x1a, 0x88, 0x7b, 0x0e,
+	0xa3, 0xe5, 0x63, 0xd8, 0x76, 0xa9, 0xed, 0xc9, 0xd6, 0xa8, 0xe4, 0x17, 0x10, 0x9c, 0xe4, 0xcb,
+	0x0b, 0xc1, 0x6d, 0x35, 0x4c, 0xf6, 0x3e, 0x74, 0x1e, 0x77, 0x96, 0x65, 0xec, 0x5d, 0xc9, 0x46,
+	0x4f, 0xe0, 0xde, 0x1a, 0x97, 0x3c, 0x82, 0x91, 0x9e, 0xd5, 0xf5, 0xb0, 0xf9, 0x36, 0x4d, 0x28,
+	0x41, 0xad, 0x64, 0xd4, 0x74, 0xf4, 0x20, 0xad, 0xf5, 0xbc, 0xb7, 0x56, 0x0f, 0x79, 0x29, 0xda,
+	0x1b, 0xd0, 0xa7, 0x2d, 0x26, 0x3f, 0x87, 0xe0, 0x71, 0x36, 0xcf, 0x63, 0x91, 0x2d, 0xed, 0x20,
+	0x18, 0x76, 0x5e, 0xb2, 0x75, 0x9a, 0x59, 0x01, 0xba, 0x12, 0x8d, 0x5e, 0xc2, 0x76, 0x97, 0xb9,
+	0x71, 0xe4, 0x6e, 0xc7, 0xf4, 0x9e, 0x33, 0xa6, 0xb7, 0x36, 0xf6, 0x9d, 0xcc, 0xff, 0x02, 0x82,
+	0x83, 0x5a, 0x24, 0xf1, 0x71, 0xb6, 0xc8, 0xdd, 0x3f, 0x4b, 0xbc, 0xee, 0x9f, 0x25, 0x0f, 0x30,
+	0x45, 0xd2, 0xb4, 0xed, 0x66, 0x06, 0x5d, 0x0e, 0xd5, 0xdf, 0x5e, 0x8f, 0xfe, 0x33, 0x00, 0xbc,
+	0xc7, 0x5a, 0x2e, 0x08, 0x13, 0x00, 0x00,
 }
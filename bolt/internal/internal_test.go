@@ -3,6 +3,7 @@ package internal_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/chronograf"
@@ -40,6 +41,17 @@ func TestMarshalSource(t *testing.T) {
 	} else if !reflect.DeepEqual(v, vv) {
 		t.Fatalf("source protobuf copy error: got %#v, expected %#v", vv, v)
 	}
+
+	// Test the backends of relays
+	v.Type = "influx-relay"
+	v.Backends = []string{"http://twin-pines.mall.io:8087", "http://lone-pine.mall.io:8086"}
+	if buf, err := internal.MarshalSource(v); err != nil {
+		t.Fatal(err)
+	} else if err := internal.UnmarshalSource(buf, &vv); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(v, vv) {
+		t.Fatalf("source protobuf copy error: got %#v, expected %#v", vv, v)
+	}
 }
 func TestMarshalSourceWithSecret(t *testing.T) {
 	v := chronograf.Source{
@@ -95,6 +107,53 @@ func TestMarshalServer(t *testing.T) {
 	}
 }
 
+func TestMarshalSourceCredentials(t *testing.T) {
+	v := chronograf.SourceCredentials{
+		SourceID: 2,
+		UserID:   12,
+		Username: "docbrown",
+		Password: "ZW5jcnlwdGVk",
+	}
+
+	var vv chronograf.SourceCredentials
+	if buf, err := internal.MarshalSourceCredentials(v); err != nil {
+		t.Fatal(err)
+	} else if err := internal.UnmarshalSourceCredentials(buf, &vv); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(v, vv) {
+		t.Fatalf("source credentials protobuf copy error: got %#v, expected %#v", vv, v)
+	}
+}
+
+func TestMarshalDashboardShare(t *testing.T) {
+	tests := []chronograf.DashboardShare{
+		{
+			ID:           "1",
+			DashboardID:  4,
+			Organization: "default",
+			Name:         "Lobby TV",
+			CreatedBy:    "docbrown",
+			CreatedByID:  12,
+			CreatedAt:    time.Date(1985, 10, 26, 1, 20, 0, 0, time.UTC),
+			ExpiresAt:    time.Date(1985, 10, 27, 1, 20, 0, 0, time.UTC),
+		},
+		{
+			ID:          "2",
+			DashboardID: 4,
+		},
+	}
+	for _, v := range tests {
+		var vv chronograf.DashboardShare
+		if buf, err := internal.MarshalDashboardShare(v); err != nil {
+			t.Fatal(err)
+		} else if err := internal.UnmarshalDashboardShare(buf, &vv); err != nil {
+			t.Fatal(err)
+		} else if !cmp.Equal(v, vv) {
+			t.Fatalf("dashboard share protobuf copy error: diff follows:\n%s", cmp.Diff(v, vv))
+		}
+	}
+}
+
 func TestMarshalLayout(t *testing.T) {
 	layout := chronograf.Layout{
 		ID:          "id",
@@ -200,8 +259,25 @@ func Test_MarshalDashboard(t *testing.T) {
 				TimeFormat:   "",
 			},
 		},
-		Templates: []chronograf.Template{},
-		Name:      "Dashboard",
+		Templates:   []chronograf.Template{},
+		Name:        "Dashboard",
+		Description: "Production hosts",
+		Tags:        []string{"hosts", "production"},
+		Folder:      "infra/hosts",
+		Owner:       "marty",
+		OwnerID:     1,
+		Permissions: []chronograf.DashboardPermission{
+			{
+				UserID:     2,
+				User:       "doc",
+				Permission: chronograf.DashboardEditPermission,
+			},
+			{
+				Role:       "viewer",
+				Permission: chronograf.DashboardViewPermission,
+			},
+		},
+		Locked: true,
 	}
 
 	var actual chronograf.Dashboard
@@ -0,0 +1,109 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/boltdb/bolt"
+	"github.com/influxdata/chronograf"
+)
+
+// Ensure ProtoboardsStore implements chronograf.ProtoboardsStore.
+var _ chronograf.ProtoboardsStore = &ProtoboardsStore{}
+
+// ProtoboardsBucket is the bolt bucket protoboards defined by users are
+// stored in. Protoboards are stored as JSON like the protoboard files.
+var ProtoboardsBucket = []byte("protoboardsv1")
+
+// ProtoboardsStore is the bolt implementation to store protoboards defined
+// by users
+type ProtoboardsStore struct {
+	client *Client
+	IDs    chronograf.ID
+}
+
+// All returns all known protoboards
+func (s *ProtoboardsStore) All(ctx context.Context) ([]chronograf.Protoboard, error) {
+	pbs := []chronograf.Protoboard{}
+	if err := s.client.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(ProtoboardsBucket).ForEach(func(k, v []byte) error {
+			var pb chronograf.Protoboard
+			if err := json.Unmarshal(v, &pb); err != nil {
+				return err
+			}
+			pbs = append(pbs, pb)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return pbs, nil
+}
+
+// Add creates a new Protoboard in the ProtoboardsStore.
+func (s *ProtoboardsStore) Add(ctx context.Context, pb chronograf.Protoboard) (chronograf.Protoboard, error) {
+	if err := s.client.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(ProtoboardsBucket)
+		id, err := s.IDs.Generate()
+		if err != nil {
+			return err
+		}
+
+		pb.ID = id
+		if v, err := json.Marshal(pb); err != nil {
+			return err
+		} else if err := b.Put([]byte(pb.ID), v); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return chronograf.Protoboard{}, err
+	}
+
+	return pb, nil
+}
+
+// Delete removes the Protoboard from the ProtoboardsStore
+func (s *ProtoboardsStore) Delete(ctx context.Context, pb chronograf.Protoboard) error {
+	_, err := s.Get(ctx, pb.ID)
+	if err != nil {
+		return err
+	}
+	return s.client.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(ProtoboardsBucket).Delete([]byte(pb.ID))
+	})
+}
+
+// Get returns a Protoboard if the id exists.
+func (s *ProtoboardsStore) Get(ctx context.Context, id string) (chronograf.Protoboard, error) {
+	var pb chronograf.Protoboard
+	if err := s.client.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(ProtoboardsBucket).Get([]byte(id)); v == nil {
+			return chronograf.ErrProtoboardNotFound
+		} else if err := json.Unmarshal(v, &pb); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return chronograf.Protoboard{}, err
+	}
+
+	return pb, nil
+}
+
+// Update a Protoboard
+func (s *ProtoboardsStore) Update(ctx context.Context, pb chronograf.Protoboard) error {
+	return s.client.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(ProtoboardsBucket)
+		if v := b.Get([]byte(pb.ID)); v == nil {
+			return chronograf.ErrProtoboardNotFound
+		}
+
+		v, err := json.Marshal(pb)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(pb.ID), v)
+	})
+}
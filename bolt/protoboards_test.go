@@ -0,0 +1,62 @@
+package bolt_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+)
+
+func TestProtoboardsStore(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	s := client.ProtoboardsStore
+
+	pb, err := s.Add(ctx, chronograf.Protoboard{
+		Meta:         chronograf.ProtoboardMeta{Name: "System", Measurements: []string{"cpu"}},
+		Organization: "1337",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pb.ID == "" {
+		t.Fatalf("Add() did not set an ID")
+	}
+
+	got, err := s.Get(ctx, pb.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, pb) {
+		t.Errorf("Get() = %v, want %v", got, pb)
+	}
+
+	pb.Meta.Name = "Hosts"
+	if err := s.Update(ctx, pb); err != nil {
+		t.Fatal(err)
+	}
+	all, err := s.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].Meta.Name != "Hosts" {
+		t.Errorf("All() = %v, want the updated protoboard", all)
+	}
+
+	if err := s.Update(ctx, chronograf.Protoboard{ID: "missing"}); err != chronograf.ErrProtoboardNotFound {
+		t.Errorf("Update() error = %v, want %v", err, chronograf.ErrProtoboardNotFound)
+	}
+
+	if err := s.Delete(ctx, pb); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, pb.ID); err != chronograf.ErrProtoboardNotFound {
+		t.Errorf("Get() after Delete error = %v, want %v", err, chronograf.ErrProtoboardNotFound)
+	}
+}
@@ -0,0 +1,162 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/bolt/internal"
+)
+
+// Ensure SourceCredentialsStore implements chronograf.SourceCredentialsStore.
+var _ chronograf.SourceCredentialsStore = &SourceCredentialsStore{}
+
+var (
+	// SourceCredentialsBucket is the bucket where the InfluxDB credentials
+	// of users are stored.
+	SourceCredentialsBucket = []byte("sourcecredentialsv1")
+)
+
+// ErrNoCredentialsSecret is returned when storing credentials without a
+// secret to encrypt them with
+const ErrNoCredentialsSecret = chronograf.Error("a credentials secret is required to store source credentials")
+
+// SourceCredentialsStore uses bolt to store the InfluxDB credentials of
+// users. Passwords are encrypted with AES-GCM using a key derived from Secret.
+type SourceCredentialsStore struct {
+	client *Client
+	Secret string
+}
+
+// sourceCredentialsKey keys credentials by source so that every credential
+// of a source shares a common prefix
+func sourceCredentialsKey(srcID int, userID uint64) []byte {
+	return []byte(fmt.Sprintf("%s%d", sourceCredentialsPrefix(srcID), userID))
+}
+
+func sourceCredentialsPrefix(srcID int) []byte {
+	return []byte(fmt.Sprintf("%d/", srcID))
+}
+
+// Get retrieves the credentials of a user for a source
+func (s *SourceCredentialsStore) Get(ctx context.Context, srcID int, userID uint64) (chronograf.SourceCredentials, error) {
+	var creds chronograf.SourceCredentials
+	err := s.client.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(SourceCredentialsBucket).Get(sourceCredentialsKey(srcID, userID))
+		if v == nil {
+			return chronograf.ErrSourceCredentialsNotFound
+		}
+		if err := internal.UnmarshalSourceCredentials(v, &creds); err != nil {
+			return err
+		}
+		password, err := s.decrypt(creds.Password)
+		if err != nil {
+			return err
+		}
+		creds.Password = password
+		return nil
+	})
+	if err != nil {
+		return chronograf.SourceCredentials{}, err
+	}
+	return creds, nil
+}
+
+// Put creates or replaces the credentials of a user for a source
+func (s *SourceCredentialsStore) Put(ctx context.Context, creds chronograf.SourceCredentials) error {
+	password, err := s.encrypt(creds.Password)
+	if err != nil {
+		return err
+	}
+	creds.Password = password
+	v, err := internal.MarshalSourceCredentials(creds)
+	if err != nil {
+		return err
+	}
+	return s.client.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(SourceCredentialsBucket).Put(sourceCredentialsKey(creds.SourceID, creds.UserID), v)
+	})
+}
+
+// Delete removes the credentials of a user for a source
+func (s *SourceCredentialsStore) Delete(ctx context.Context, srcID int, userID uint64) error {
+	return s.client.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(SourceCredentialsBucket)
+		key := sourceCredentialsKey(srcID, userID)
+		if v := b.Get(key); v == nil {
+			return chronograf.ErrSourceCredentialsNotFound
+		}
+		return b.Delete(key)
+	})
+}
+
+// DeleteSource removes the credentials of every user for a source
+func (s *SourceCredentialsStore) DeleteSource(ctx context.Context, srcID int) error {
+	return s.client.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(SourceCredentialsBucket)
+		prefix := sourceCredentialsPrefix(srcID)
+		// Keys are collected first as deleting moves the cursor
+		keys := [][]byte{}
+		c := b.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, append([]byte{}, k...))
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SourceCredentialsStore) aead() (cipher.AEAD, error) {
+	if s.Secret == "" {
+		return nil, ErrNoCredentialsSecret
+	}
+	key := sha256.Sum256([]byte(s.Secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt seals the plaintext with a random nonce prepended to the
+// ciphertext and encodes both as base64
+func (s *SourceCredentialsStore) encrypt(plaintext string) (string, error) {
+	gcm, err := s.aead()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+func (s *SourceCredentialsStore) decrypt(encoded string) (string, error) {
+	gcm, err := s.aead()
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(ciphertext) < gcm.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted password")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt password: %v", err)
+	}
+	return string(plaintext), nil
+}
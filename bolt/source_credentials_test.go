@@ -0,0 +1,75 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/bolt"
+)
+
+func TestSourceCredentialsStore(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	s := client.SourceCredentialsStore
+
+	creds := chronograf.SourceCredentials{
+		SourceID: 1,
+		UserID:   2,
+		Username: "marty",
+		Password: "I❤️ the DeLorean",
+	}
+	if err := s.Put(ctx, creds); err != bolt.ErrNoCredentialsSecret {
+		t.Fatalf("Put() without secret error = %v, want %v", err, bolt.ErrNoCredentialsSecret)
+	}
+
+	s.Secret = "flux capacitor"
+	if err := s.Put(ctx, creds); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, chronograf.SourceCredentials{SourceID: 1, UserID: 3, Username: "doc", Password: "1.21"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, chronograf.SourceCredentials{SourceID: 11, UserID: 2, Username: "marty", Password: "88"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != creds {
+		t.Errorf("Get() = %v, want %v", got, creds)
+	}
+
+	// Passwords are only readable with the secret they were encrypted with
+	s.Secret = "biff"
+	if _, err := s.Get(ctx, 1, 2); err == nil {
+		t.Errorf("Get() with another secret expected error")
+	}
+	s.Secret = "flux capacitor"
+
+	if err := s.DeleteSource(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	for _, userID := range []uint64{2, 3} {
+		if _, err := s.Get(ctx, 1, userID); err != chronograf.ErrSourceCredentialsNotFound {
+			t.Errorf("Get() after DeleteSource error = %v, want %v", err, chronograf.ErrSourceCredentialsNotFound)
+		}
+	}
+	if _, err := s.Get(ctx, 11, 2); err != nil {
+		t.Errorf("Get() credentials of another source error = %v", err)
+	}
+
+	if err := s.Delete(ctx, 11, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, 11, 2); err != chronograf.ErrSourceCredentialsNotFound {
+		t.Errorf("Delete() error = %v, want %v", err, chronograf.ErrSourceCredentialsNotFound)
+	}
+}
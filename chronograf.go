@@ -18,8 +18,12 @@ const (
 	ErrDashboardNotFound               = Error("dashboard not found")
 	ErrUserNotFound                    = Error("user not found")
 	ErrLayoutInvalid                   = Error("layout is invalid")
+	ErrLayoutReadOnly                  = Error("canned layouts cannot be changed")
 	ErrProtoboardInvalid               = Error("protoboard is invalid")
+	ErrProtoboardReadOnly              = Error("built-in protoboards cannot be changed")
 	ErrDashboardInvalid                = Error("dashboard is invalid")
+	ErrDashboardLocked                 = Error("dashboard is locked")
+	ErrDashboardShareNotFound          = Error("dashboard share not found")
 	ErrSourceInvalid                   = Error("source is invalid")
 	ErrServerInvalid                   = Error("server is invalid")
 	ErrAlertNotFound                   = Error("alert not found")
@@ -38,11 +42,12 @@ const (
 	ErrCannotDeleteDefaultOrganization = Error("cannot delete default organization")
 	ErrConfigNotFound                  = Error("cannot find configuration")
 	ErrAnnotationNotFound              = Error("annotation not found")
+	ErrSourceCredentialsNotFound       = Error("source credentials not found")
 	ErrInvalidCellOptionsText          = Error("invalid text wrapping option. Valid wrappings are 'truncate', 'wrap', and 'single line'")
 	ErrInvalidCellOptionsSort          = Error("cell options sortby cannot be empty'")
 	ErrInvalidCellOptionsColumns       = Error("cell options columns cannot be empty'")
 	ErrOrganizationConfigNotFound      = Error("could not find organization config")
-	ErrInvalidCellQueryType            = Error("invalid cell query type: must be 'flux' or 'influxql'")
+	ErrInvalidCellQueryType            = Error("invalid cell query type: must be 'flux', 'influxql' or 'promql'")
 )
 
 // Error is a domain error encountered while processing chronograf requests
@@ -93,6 +98,11 @@ const (
 	InfluxEnterprise = "influx-enterprise"
 	// InfluxRelay is the basic HA layer over InfluxDB
 	InfluxRelay = "influx-relay"
+	// InfluxDBv2 is InfluxDB 2.x, queried within the Org of the source with
+	// its API Token
+	InfluxDBv2 = "influx-v2"
+	// Prometheus is queried with PromQL over the Prometheus HTTP API
+	Prometheus = "prometheus"
 )
 
 // TSDBStatus represents the current status of a time series database
@@ -133,6 +143,25 @@ type TimeSeries interface {
 	Roles(context.Context) (RolesStore, error)
 }
 
+// RunningQuery is a query currently executing within a time series database
+type RunningQuery struct {
+	ID       uint64 `json:"id"`             // ID is the query ID assigned by the database
+	Query    string `json:"query"`          // Query is the text of the running query
+	Database string `json:"database"`       // Database is the database the query runs against
+	Duration string `json:"duration"`       // Duration is how long the query has been running
+	Status   string `json:"status"`         // Status is the execution status of the query, e.g. running or killed
+	Host     string `json:"host,omitempty"` // Host is the address of the data node executing the query
+}
+
+// QueryManager inspects and terminates queries running within a time series database
+type QueryManager interface {
+	// RunningQueries lists the queries currently executing
+	RunningQueries(context.Context) ([]RunningQuery, error)
+	// KillQuery terminates a running query. Host is the data node executing
+	// the query and may be empty for single node databases.
+	KillQuery(ctx context.Context, id uint64, host string) error
+}
+
 // Role is a restricted set of permissions assigned to a set of users.
 type Role struct {
 	Name         string      `json:"name"`
@@ -198,6 +227,9 @@ type Query struct {
 	Label    string   `json:"label,omitempty"`    // Label is the Y-Axis label for the data
 	Range    *Range   `json:"range,omitempty"`    // Range is the default Y-Axis range for the data
 	UUID     string   `json:"uuid,omitempty"`     // Indentifier from client to be added to the result
+	Start    string   `json:"start,omitempty"`    // Start of the time range of PromQL queries as RFC3339 or unix seconds
+	End      string   `json:"end,omitempty"`      // End of the time range of PromQL queries as RFC3339 or unix seconds
+	Step     string   `json:"step,omitempty"`     // Step is the resolution of PromQL queries as a duration or seconds
 }
 
 // DashboardQuery includes state for the query builder.  This is a transition
@@ -209,7 +241,7 @@ type DashboardQuery struct {
 	QueryConfig QueryConfig `json:"queryConfig,omitempty"` // QueryConfig represents the query state that is understood by the data explorer
 	Source      string      `json:"source"`                // Source is the optional URI to the data source for this queryConfig
 	Shifts      []TimeShift `json:"-"`                     // Shifts represents shifts to apply to an influxql query's time range.  Clients expect the shift to be in the generated QueryConfig
-	Type        string      `json:"type"`                  // Type represents the language the query is in (flux or influxql)
+	Type        string      `json:"type"`                  // Type represents the language the query is in (flux, influxql or promql)
 }
 
 // TemplateQuery is used to retrieve choices for template replacement
@@ -229,21 +261,55 @@ type Response interface {
 
 // Source is connection information to a time-series data store.
 type Source struct {
-	ID                 int    `json:"id,string"`                    // ID is the unique ID of the source
-	Name               string `json:"name"`                         // Name is the user-defined name for the source
-	Type               string `json:"type,omitempty"`               // Type specifies which kinds of source (enterprise vs oss)
-	Username           string `json:"username,omitempty"`           // Username is the username to connect to the source
-	Password           string `json:"password,omitempty"`           // Password is in CLEARTEXT
-	SharedSecret       string `json:"sharedSecret,omitempty"`       // ShareSecret is the optional signing secret for Influx JWT authorization
-	URL                string `json:"url"`                          // URL are the connections to the source
-	MetaURL            string `json:"metaUrl,omitempty"`            // MetaURL is the url for the meta node
-	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"` // InsecureSkipVerify as true means any certificate presented by the source is accepted.
-	Default            bool   `json:"default"`                      // Default specifies the default source for the application
-	Telegraf           string `json:"telegraf"`                     // Telegraf is the db telegraf is written to.  By default it is "telegraf"
-	Organization       string `json:"organization"`                 // Organization is the organization ID that resource belongs to
-	Role               string `json:"role,omitempty"`               // Not Currently Used. Role is the name of the minimum role that a user must possess to access the resource.
-	DefaultRP          string `json:"defaultRP"`                    // DefaultRP is the default retention policy used in database queries to this source
-	Version            string `json:"version,omitempty"`            // Version of influxdb
+	ID                 int      `json:"id,string"`                    // ID is the unique ID of the source
+	Name               string   `json:"name"`                         // Name is the user-defined name for the source
+	Type               string   `json:"type,omitempty"`               // Type specifies which kinds of source (enterprise vs oss)
+	Username           string   `json:"username,omitempty"`           // Username is the username to connect to the source
+	Password           string   `json:"password,omitempty"`           // Password is in CLEARTEXT
+	SharedSecret       string   `json:"sharedSecret,omitempty"`       // ShareSecret is the optional signing secret for Influx JWT authorization
+	URL                string   `json:"url"`                          // URL are the connections to the source
+	MetaURL            string   `json:"metaUrl,omitempty"`            // MetaURL is the url for the meta node
+	InsecureSkipVerify bool     `json:"insecureSkipVerify,omitempty"` // InsecureSkipVerify as true means any certificate presented by the source is accepted.
+	Default            bool     `json:"default"`                      // Default specifies the default source for the application
+	Telegraf           string   `json:"telegraf"`                     // Telegraf is the db telegraf is written to.  By default it is "telegraf"
+	Organization       string   `json:"organization"`                 // Organization is the organization ID that resource belongs to
+	Role               string   `json:"role,omitempty"`               // Not Currently Used. Role is the name of the minimum role that a user must possess to access the resource.
+	DefaultRP          string   `json:"defaultRP"`                    // DefaultRP is the default retention policy used in database queries to this source
+	Version            string   `json:"version,omitempty"`            // Version of influxdb
+	UserAuth           string   `json:"userAuth,omitempty"`           // UserAuth is how each Chronograf user is authenticated with the source; the shared credentials of the source are used if empty
+	Org                string   `json:"org,omitempty"`                // Org is the InfluxDB 2.x organization the source is queried within
+	Token              string   `json:"token,omitempty"`              // Token is the InfluxDB 2.x API token in CLEARTEXT
+	Backends           []string `json:"backends,omitempty"`           // Backends are the urls of the InfluxDBs behind a relay
+}
+
+const (
+	// UserAuthCredentials authenticates Chronograf users with the InfluxDB
+	// credentials each of them stored for the source
+	UserAuthCredentials = "credentials"
+	// UserAuthJWT authenticates Chronograf users with a JWT of their name
+	// signed by the shared secret of the source
+	UserAuthJWT = "jwt"
+)
+
+// SourceCredentials are the InfluxDB credentials a Chronograf user stored
+// for a source
+type SourceCredentials struct {
+	SourceID int    `json:"sourceID,string"` // SourceID is the ID of the source the credentials are used for
+	UserID   uint64 `json:"userID,string"`   // UserID is the ID of the Chronograf user
+	Username string `json:"username"`        // Username of the InfluxDB user
+	Password string `json:"password"`        // Password of the InfluxDB user; it is encrypted at rest
+}
+
+// SourceCredentialsStore stores the InfluxDB credentials of Chronograf users
+type SourceCredentialsStore interface {
+	// Get retrieves the credentials of a user for a source
+	Get(ctx context.Context, srcID int, userID uint64) (SourceCredentials, error)
+	// Put creates or replaces the credentials of a user for a source
+	Put(ctx context.Context, creds SourceCredentials) error
+	// Delete removes the credentials of a user for a source
+	Delete(ctx context.Context, srcID int, userID uint64) error
+	// DeleteSource removes the credentials of every user for a source
+	DeleteSource(ctx context.Context, srcID int) error
 }
 
 // SourcesStore stores connection information for a `TimeSeries`
@@ -490,6 +556,39 @@ type Measurement struct {
 	Name string `json:"name"` // a unique string identifier for the measurement
 }
 
+// ContinuousQuery represents a continuous query in a time series source
+type ContinuousQuery struct {
+	Name          string `json:"name"`                    // a unique string identifier for the continuous query within its database
+	Database      string `json:"db"`                      // the database the continuous query is defined on
+	ResampleEvery string `json:"resampleEvery,omitempty"` // how often the continuous query is executed
+	ResampleFor   string `json:"resampleFor,omitempty"`   // how far back in time each execution resamples
+	Source        string `json:"source,omitempty"`        // the measurement(s) the continuous query reads from
+	Target        string `json:"target,omitempty"`        // the measurement the continuous query writes into
+	Query         string `json:"query"`                   // the InfluxQL SELECT ... INTO statement executed by the continuous query
+}
+
+// Shard represents a shard of a retention policy in a time series source
+type Shard struct {
+	ID              uint64   `json:"id,string"`         // a unique identifier for the shard within the source
+	Database        string   `json:"db"`                // the database of the shard
+	RetentionPolicy string   `json:"rp"`                // the retention policy of the shard
+	ShardGroup      uint64   `json:"shardGroup,string"` // the shard group the shard belongs to
+	StartTime       string   `json:"startTime"`         // the start of the time range of the shard
+	EndTime         string   `json:"endTime"`           // the end of the time range of the shard
+	ExpiryTime      string   `json:"expiryTime"`        // when the shard is expired by its retention policy
+	Owners          []string `json:"owners"`            // the data nodes owning the shard in a cluster
+	Size            int64    `json:"size"`              // the size of the shard on disk in bytes
+}
+
+// Subscription represents a subscription of a retention policy in a time series source
+type Subscription struct {
+	Name            string   `json:"name"`         // a unique string identifier for the subscription within its retention policy
+	Database        string   `json:"db"`           // the database the subscription is defined on
+	RetentionPolicy string   `json:"rp"`           // the retention policy the subscription is defined on
+	Mode            string   `json:"mode"`         // ALL sends writes to every destination and ANY to one of them
+	Destinations    []string `json:"destinations"` // the URLs writes are sent to
+}
+
 // Databases represents a databases in a time series source
 type Databases interface {
 	// AllDB lists all databases in the current data source
@@ -512,6 +611,29 @@ type Databases interface {
 
 	// GetMeasurements lists measurements in the current data source
 	GetMeasurements(ctx context.Context, db string, limit, offset int) ([]Measurement, error)
+
+	// AllCQ lists all continuous queries of a database in the current data source
+	AllCQ(context.Context, string) ([]ContinuousQuery, error)
+	// CreateCQ creates a continuous query in a database of the current data source
+	CreateCQ(context.Context, string, *ContinuousQuery) (*ContinuousQuery, error)
+	// UpdateCQ replaces a continuous query in a database of the current data source
+	UpdateCQ(context.Context, string, string, *ContinuousQuery) (*ContinuousQuery, error)
+	// DropCQ drops a continuous query from a database of the current data source
+	DropCQ(context.Context, string, string) error
+
+	// AllShards lists all shards of a database in the current data source
+	AllShards(context.Context, string) ([]Shard, error)
+	// DropShard drops a shard from the current data source
+	DropShard(context.Context, uint64) error
+
+	// AllSubscriptions lists all subscriptions of a database in the current data source
+	AllSubscriptions(context.Context, string) ([]Subscription, error)
+	// CreateSubscription creates a subscription in a database of the current data source
+	CreateSubscription(context.Context, string, *Subscription) (*Subscription, error)
+	// UpdateSubscription replaces a subscription of a retention policy in a database of the current data source
+	UpdateSubscription(ctx context.Context, db, rp, name string, sub *Subscription) (*Subscription, error)
+	// DropSubscription drops a subscription of a retention policy from a database of the current data source
+	DropSubscription(ctx context.Context, db, rp, name string) error
 }
 
 // AnnotationTags describes a set of user-defined tags associated with an Annotation
@@ -584,11 +706,71 @@ type DashboardID int
 
 // Dashboard represents all visual and query data for a dashboard
 type Dashboard struct {
-	ID           DashboardID     `json:"id"`
-	Cells        []DashboardCell `json:"cells"`
-	Templates    []Template      `json:"templates"`
-	Name         string          `json:"name"`
-	Organization string          `json:"organization"` // Organization is the organization ID that resource belongs to
+	ID           DashboardID           `json:"id"`
+	Cells        []DashboardCell       `json:"cells"`
+	Templates    []Template            `json:"templates"`
+	Name         string                `json:"name"`
+	Organization string                `json:"organization"`          // Organization is the organization ID that resource belongs to
+	Description  string                `json:"description,omitempty"` // Description is the user-defined description of the dashboard
+	Tags         []string              `json:"tags,omitempty"`        // Tags are the user-defined labels used to search dashboards
+	Folder       string                `json:"folder,omitempty"`      // Folder is the slash-separated path of the folder of the dashboard, e.g. "infra/hosts"
+	Owner        string                `json:"owner,omitempty"`          // Owner is the name of the user that created the dashboard
+	OwnerID      uint64                `json:"ownerID,string,omitempty"` // OwnerID is the ID of the user that created the dashboard
+	Permissions  []DashboardPermission `json:"permissions,omitempty"`    // Permissions restrict who may view and edit the dashboard; none means the organization role decides
+	Locked       bool                  `json:"locked,omitempty"`         // Locked dashboards cannot be edited until they are unlocked
+}
+
+// Permissions on a dashboard. Edit implies view.
+const (
+	DashboardViewPermission = "view"
+	DashboardEditPermission = "edit"
+)
+
+// DashboardPermission grants view or edit of a dashboard to a user or to all
+// users having a role in the organization of the dashboard
+type DashboardPermission struct {
+	UserID     uint64 `json:"userID,string,omitempty"` // UserID is the ID of the user granted the permission
+	User       string `json:"user,omitempty"`          // User is the name of the user granted the permission
+	Role       string `json:"role,omitempty"`          // Role is the organization role granted the permission, e.g. editor
+	Permission string `json:"permission"`              // Permission is either view or edit
+}
+
+// dashboardRoleRanks orders the organization roles so that a permission
+// granted to a role is granted to every higher role as well
+var dashboardRoleRanks = map[string]int{
+	"member": 1,
+	"viewer": 2,
+	"editor": 3,
+	"admin":  4,
+}
+
+// grantedRole reports whether the role is the granted role or ranks above it
+func grantedRole(role, granted string) bool {
+	rank, ok := dashboardRoleRanks[granted]
+	if !ok {
+		return role == granted
+	}
+	return dashboardRoleRanks[role] >= rank
+}
+
+// Allows returns true if the dashboard grants the permission to the user
+// with the ID having the role. Permissions granted to a role apply to every
+// higher role too. Permissions restrict, but never extend, the organization
+// role of the user. Dashboards without permissions and their owner allow
+// everything.
+func (d Dashboard) Allows(userID uint64, role, permission string) bool {
+	if len(d.Permissions) == 0 || (userID != 0 && d.OwnerID == userID) {
+		return true
+	}
+	for _, p := range d.Permissions {
+		if (p.UserID == 0 || p.UserID != userID) && (p.Role == "" || !grantedRole(role, p.Role)) {
+			continue
+		}
+		if p.Permission == DashboardEditPermission || p.Permission == permission {
+			return true
+		}
+	}
+	return false
 }
 
 // Axis represents the visible extents of a visualization
@@ -671,6 +853,56 @@ type DashboardsStore interface {
 	Get(ctx context.Context, id DashboardID) (Dashboard, error)
 	// Update replaces the dashboard information
 	Update(context.Context, Dashboard) error
+	// Search returns the page of dashboards matching the query and the
+	// total number of matching dashboards
+	Search(context.Context, DashboardsQuery) ([]Dashboard, int, error)
+}
+
+// DashboardShare grants read-only access to a dashboard and to the queries of
+// its cells to anyone holding a token of the share until it expires. Deleting
+// the share revokes its tokens.
+type DashboardShare struct {
+	ID           string      `json:"id"`
+	DashboardID  DashboardID `json:"dashboardID"`
+	Organization string      `json:"organization"`
+	Name         string      `json:"name"`                         // Name describes where the share is used, e.g. "Lobby TV"
+	CreatedBy    string      `json:"createdBy"`                    // CreatedBy is the name of the user who shared the dashboard
+	CreatedByID  uint64      `json:"createdByID,string,omitempty"` // CreatedByID is the ID of the user who shared the dashboard; sources authenticating each user are queried as this user
+	CreatedAt    time.Time   `json:"createdAt"`
+	ExpiresAt    time.Time   `json:"expiresAt"`
+}
+
+// DashboardSharesStore is the storage and retrieval of dashboard shares
+type DashboardSharesStore interface {
+	// All lists the shares of a dashboard
+	All(ctx context.Context, id DashboardID) ([]DashboardShare, error)
+	// Add creates a new share and returns it with its ID
+	Add(context.Context, DashboardShare) (DashboardShare, error)
+	// Get retrieves a share if `ID` exists
+	Get(ctx context.Context, id string) (DashboardShare, error)
+	// Delete removes the share, revoking its tokens
+	Delete(context.Context, DashboardShare) error
+}
+
+// Fields dashboards can be sorted by
+const (
+	DashboardsByName   = "name"
+	DashboardsByFolder = "folder"
+	DashboardsByID     = "id"
+)
+
+// DashboardsQuery filters, sorts and pages the dashboards of a DashboardsStore
+type DashboardsQuery struct {
+	Name         string   // Name matches dashboards whose name contains it, ignoring case
+	Tags         []string // Tags matches dashboards having all of the tags
+	Folder       string   // Folder matches dashboards of the folder and of its subfolders
+	Organization string   // Organization matches dashboards of the organization
+	UserID       uint64   // UserID matches dashboards the user may view; any dashboard when zero
+	Role         string   // Role is the organization role of the user
+	SortBy       string   // SortBy is either name, folder or id; defaults to name
+	Descending   bool     // Descending reverses the order of the dashboards
+	Offset       int      // Offset is the number of matching dashboards to skip
+	Limit        int      // Limit is the maximum number of dashboards to return; zero is unlimited
 }
 
 // Cell is a rectangle and multiple time series queries to visualize.
@@ -751,17 +983,24 @@ type ProtoboardData struct {
 
 // Protoboard is a prototype of a dashboard that can be instantiated
 type Protoboard struct {
-	ID   string         `json:"id"`
-	Meta ProtoboardMeta `json:"meta"`
-	Data ProtoboardData `json:"data"`
+	ID           string         `json:"id"`
+	Meta         ProtoboardMeta `json:"meta"`
+	Data         ProtoboardData `json:"data"`
+	Organization string         `json:"organization,omitempty"` // Organization is the organization ID of protoboards defined by users; built-in protoboards have none
 }
 
 // ProtoboardsStore stores protoboards that can be instantiated into dashboards
 type ProtoboardsStore interface {
 	// All returns all protoboards in the store
 	All(context.Context) ([]Protoboard, error)
+	// Add creates a new protoboard in the ProtoboardsStore
+	Add(context.Context, Protoboard) (Protoboard, error)
+	// Delete the protoboard from the store
+	Delete(context.Context, Protoboard) error
 	// Get returns the specified protoboard from the store
 	Get(ctx context.Context, ID string) (Protoboard, error)
+	// Update the protoboard in the store.
+	Update(context.Context, Protoboard) error
 }
 
 // MappingWildcard is the wildcard value for mappings
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/chronograf/v2/migrate"
+)
+
+type MigrateDashboardsCommand struct {
+	BoltPath string `short:"b" long:"bolt-path" description:"Full path to boltDB file (e.g. './chronograf-v1.db')" env:"BOLT_PATH" default:"chronograf-v1.db"`
+}
+
+var migrateDashboardsCommand MigrateDashboardsCommand
+
+func (m *MigrateDashboardsCommand) Execute(args []string) error {
+	c, err := NewBoltClient(m.BoltPath)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	report, err := migrate.Dashboards(ctx, c.DashboardsStore, c, c)
+	if err != nil {
+		return err
+	}
+
+	w := NewTabWriter()
+	fmt.Fprintln(w, "Status\tV1 ID\tV2 ID\tName\tCells\tError")
+	for _, res := range report.Migrated {
+		WriteMigration(w, "migrated", res)
+	}
+	for _, res := range report.Skipped {
+		WriteMigration(w, "skipped", res)
+	}
+	for _, res := range report.Failed {
+		WriteMigration(w, "failed", res)
+	}
+	w.Flush()
+
+	fmt.Printf("%d migrated, %d skipped, %d failed\n", len(report.Migrated), len(report.Skipped), len(report.Failed))
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%d dashboards could not be migrated", len(report.Failed))
+	}
+	return nil
+}
+
+func init() {
+	parser.AddCommand("migrate-dashboards",
+		"Migrates v1 dashboards to v2",
+		"The migrate-dashboards command converts every v1 dashboard into v2 cells and a v2 dashboard. Dashboards migrated by an earlier run are skipped.",
+		&migrateDashboardsCommand)
+}
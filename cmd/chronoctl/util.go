@@ -11,6 +11,7 @@ import (
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/bolt"
 	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/v2/migrate"
 )
 
 func NewBoltClient(path string) (*bolt.Client, error) {
@@ -42,3 +43,7 @@ func WriteUser(w io.Writer, user *chronograf.User) {
 	}
 	fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%t\t%s\n", user.ID, user.Name, user.Provider, user.Scheme, user.SuperAdmin, strings.Join(orgs, ","))
 }
+
+func WriteMigration(w io.Writer, status string, res migrate.Result) {
+	fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\t%s\n", status, res.V1ID, res.ID, res.Name, res.Cells, res.Error)
+}
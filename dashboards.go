@@ -0,0 +1,79 @@
+package chronograf
+
+import (
+	"sort"
+	"strings"
+)
+
+// Matches returns true if the dashboard satisfies all of the filters of the query
+func (q DashboardsQuery) Matches(d Dashboard) bool {
+	if q.Organization != "" && d.Organization != q.Organization {
+		return false
+	}
+	if q.UserID != 0 && !d.Allows(q.UserID, q.Role, DashboardViewPermission) {
+		return false
+	}
+	if q.Name != "" && !strings.Contains(strings.ToLower(d.Name), strings.ToLower(q.Name)) {
+		return false
+	}
+	if q.Folder != "" && d.Folder != q.Folder && !strings.HasPrefix(d.Folder, q.Folder+"/") {
+		return false
+	}
+	for _, tag := range q.Tags {
+		found := false
+		for _, t := range d.Tags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply filters, sorts and pages dashboards. It returns the page and the
+// total number of matching dashboards. DashboardsStores searching all of
+// their dashboards use it so that every store searches alike.
+func (q DashboardsQuery) Apply(ds []Dashboard) ([]Dashboard, int) {
+	matches := []Dashboard{}
+	for _, d := range ds {
+		if q.Matches(d) {
+			matches = append(matches, d)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if q.Descending {
+			return q.less(matches[j], matches[i])
+		}
+		return q.less(matches[i], matches[j])
+	})
+
+	total := len(matches)
+	if q.Offset >= total {
+		return []Dashboard{}, total
+	}
+	matches = matches[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(matches) {
+		matches = matches[:q.Limit]
+	}
+	return matches, total
+}
+
+// less orders dashboards by the sort field of the query, breaking ties by
+// name and then ID
+func (q DashboardsQuery) less(a, b Dashboard) bool {
+	if q.SortBy == DashboardsByID {
+		return a.ID < b.ID
+	}
+	if q.SortBy == DashboardsByFolder && a.Folder != b.Folder {
+		return a.Folder < b.Folder
+	}
+	if an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name); an != bn {
+		return an < bn
+	}
+	return a.ID < b.ID
+}
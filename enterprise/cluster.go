@@ -0,0 +1,289 @@
+package enterprise
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+// probeTimeout bounds how long each node of the cluster is waited for
+const probeTimeout = 5 * time.Second
+
+// ClusterOverview is the state of an Influx Enterprise cluster
+type ClusterOverview struct {
+	MetaNodes []MetaNodeOverview `json:"metaNodes"`
+	DataNodes []DataNodeOverview `json:"dataNodes"`
+	Databases []DatabaseShards   `json:"databases"`
+}
+
+// MetaNodeOverview is a meta node and whether it can be reached
+type MetaNodeOverview struct {
+	Node
+	Reachable bool   `json:"reachable"`
+	Version   string `json:"version,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DataNodeOverview is a data node, whether it can be reached and its stats
+type DataNodeOverview struct {
+	DataNode
+	Reachable bool       `json:"reachable"`
+	Version   string     `json:"version,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Stats     *NodeStats `json:"stats,omitempty"`
+}
+
+// NodeStats are the disk and write statistics of a data node as reported
+// by SHOW STATS
+type NodeStats struct {
+	DiskBytes    int64 `json:"diskBytes"`    // DiskBytes is the size of all shards on the node
+	PointReq     int64 `json:"pointReq"`     // PointReq is the number of points the node was asked to write
+	WriteOK      int64 `json:"writeOk"`      // WriteOK is the number of successful writes
+	WriteError   int64 `json:"writeError"`   // WriteError is the number of failed writes
+	WriteDrop    int64 `json:"writeDrop"`    // WriteDrop is the number of writes dropped
+	WriteTimeout int64 `json:"writeTimeout"` // WriteTimeout is the number of writes timing out
+}
+
+// DatabaseShards are the shard groups of a retention policy
+type DatabaseShards struct {
+	Database        string       `json:"database"`
+	RetentionPolicy string       `json:"retentionPolicy"`
+	ShardGroups     []ShardGroup `json:"shardGroups"`
+}
+
+// ShardGroup is a set of shards covering the same time range
+type ShardGroup struct {
+	ID        string  `json:"id"`
+	StartTime string  `json:"startTime"`
+	EndTime   string  `json:"endTime"`
+	Shards    []Shard `json:"shards"`
+}
+
+// Overview reports the meta and data nodes of the cluster, whether they
+// can be reached, their versions and statistics, and the shards of every
+// database and retention policy. Nodes that cannot be reached are reported
+// with the error rather than failing the whole overview.
+func (c *Client) Overview(ctx context.Context) (*ClusterOverview, error) {
+	cluster, err := c.Ctrl.ShowCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shards, err := c.Ctrl.ShowShards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &ClusterOverview{
+		MetaNodes: []MetaNodeOverview{},
+		DataNodes: []DataNodeOverview{},
+		Databases: groupShards(shards),
+	}
+
+	for _, n := range cluster.MetaNodes {
+		meta := MetaNodeOverview{
+			Node: n,
+		}
+		scheme := n.HTTPScheme
+		if scheme == "" {
+			scheme = "http"
+		}
+		cl, err := c.nodeClient(ctx, scheme+"://"+n.Addr)
+		if err == nil {
+			meta.Version, err = probe(ctx, cl)
+		}
+		if err != nil {
+			meta.Error = err.Error()
+		} else {
+			meta.Reachable = true
+		}
+		overview.MetaNodes = append(overview.MetaNodes, meta)
+	}
+
+	for _, n := range cluster.DataNodes {
+		data := DataNodeOverview{
+			DataNode: n,
+		}
+		cl, err := c.nodeClient(ctx, dataNodeURL(n))
+		if err == nil {
+			data.Version, err = probe(ctx, cl)
+		}
+		if err != nil {
+			data.Error = err.Error()
+			overview.DataNodes = append(overview.DataNodes, data)
+			continue
+		}
+		data.Reachable = true
+
+		if data.Stats, err = nodeStats(ctx, cl); err != nil {
+			data.Error = err.Error()
+		}
+		overview.DataNodes = append(overview.DataNodes, data)
+	}
+
+	return overview, nil
+}
+
+// nodeClient connects to a node of the cluster with the credentials of
+// the source
+func (c *Client) nodeClient(ctx context.Context, u string) (*influx.Client, error) {
+	cl := &influx.Client{
+		Logger: c.Logger,
+	}
+	src := c.src
+	src.URL = u
+	if err := cl.Connect(ctx, &src); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+// probe pings the node and returns its version
+func probe(ctx context.Context, cl *influx.Client) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	return cl.Version(ctx)
+}
+
+// nodeStats sums the shard and write statistics of SHOW STATS
+func nodeStats(ctx context.Context, cl *influx.Client) (*NodeStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	res, err := cl.Query(ctx, chronograf.Query{
+		Command: "SHOW STATS",
+	})
+	if err != nil {
+		return nil, err
+	}
+	octets, err := res.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []struct {
+		Series []struct {
+			Name    string          `json:"name"`
+			Columns []string        `json:"columns"`
+			Values  [][]interface{} `json:"values"`
+		} `json:"series"`
+	}{}
+	d := json.NewDecoder(bytes.NewReader(octets))
+	d.UseNumber()
+	if err := d.Decode(&results); err != nil {
+		return nil, err
+	}
+
+	stats := &NodeStats{}
+	for _, r := range results {
+		for _, s := range r.Series {
+			for _, v := range s.Values {
+				for i, col := range s.Columns {
+					if i >= len(v) {
+						break
+					}
+					n, ok := statValue(v[i])
+					if !ok {
+						continue
+					}
+					switch s.Name + "." + col {
+					case "shard.diskBytes":
+						stats.DiskBytes += n
+					case "write.pointReq":
+						stats.PointReq += n
+					case "write.writeOk":
+						stats.WriteOK += n
+					case "write.writeError":
+						stats.WriteError += n
+					case "write.writeDrop":
+						stats.WriteDrop += n
+					case "write.writeTimeout":
+						stats.WriteTimeout += n
+					}
+				}
+			}
+		}
+	}
+	return stats, nil
+}
+
+func statValue(v interface{}) (int64, bool) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	if n, err := num.Int64(); err == nil {
+		return n, true
+	}
+	f, err := num.Float64()
+	if err != nil {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// groupShards groups the shards by database, retention policy and shard
+// group. Shard groups are ordered by start time.
+func groupShards(shards []Shard) []DatabaseShards {
+	type rpKey struct{ db, rp string }
+	groups := map[rpKey]map[string]*ShardGroup{}
+	keys := []rpKey{}
+	for _, s := range shards {
+		k := rpKey{s.Database, s.RetentionPolicy}
+		if _, ok := groups[k]; !ok {
+			groups[k] = map[string]*ShardGroup{}
+			keys = append(keys, k)
+		}
+		g, ok := groups[k][s.ShardGroupID]
+		if !ok {
+			g = &ShardGroup{
+				ID:        s.ShardGroupID,
+				StartTime: s.StartTime,
+				EndTime:   s.EndTime,
+				Shards:    []Shard{},
+			}
+			groups[k][s.ShardGroupID] = g
+		}
+		if s.Owners == nil {
+			s.Owners = []ShardOwner{}
+		}
+		g.Shards = append(g.Shards, s)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].db != keys[j].db {
+			return keys[i].db < keys[j].db
+		}
+		return keys[i].rp < keys[j].rp
+	})
+
+	dbs := []DatabaseShards{}
+	for _, k := range keys {
+		sgs := []ShardGroup{}
+		for _, g := range groups[k] {
+			sgs = append(sgs, *g)
+		}
+		sort.Slice(sgs, func(i, j int) bool {
+			if sgs[i].StartTime != sgs[j].StartTime {
+				return sgs[i].StartTime < sgs[j].StartTime
+			}
+			return shardGroupID(sgs[i].ID) < shardGroupID(sgs[j].ID)
+		})
+		dbs = append(dbs, DatabaseShards{
+			Database:        k.db,
+			RetentionPolicy: k.rp,
+			ShardGroups:     sgs,
+		})
+	}
+	return dbs
+}
+
+func shardGroupID(id string) uint64 {
+	n, _ := strconv.ParseUint(id, 10, 64)
+	return n
+}
@@ -0,0 +1,128 @@
+package enterprise_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/log"
+)
+
+func TestClient_Overview(t *testing.T) {
+	t.Parallel()
+
+	node := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			rw.Header().Set("X-Influxdb-Version", "1.6.2-c1.6.2")
+			rw.WriteHeader(http.StatusNoContent)
+		case "/query":
+			if q := r.URL.Query().Get("q"); q != "SHOW STATS" {
+				t.Errorf("Expected SHOW STATS but was %s", q)
+			}
+			rw.Write([]byte(`{"results":[{"statement_id":0,"series":[` +
+				`{"name":"shard","tags":{"id":"1"},"columns":["diskBytes","writePointsOk"],"values":[[1024,10]]},` +
+				`{"name":"shard","tags":{"id":"2"},"columns":["diskBytes","writePointsOk"],"values":[[2048,20]]},` +
+				`{"name":"write","columns":["pointReq","writeOk","writeError","writeDrop","writeTimeout"],"values":[[300,25,2,1,0]]}]}]}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer node.Close()
+
+	down := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	downURL := down.URL
+	down.Close()
+
+	nodeURL, _ := url.Parse(node.URL)
+	ctrl := &ControlClient{
+		Cluster: &enterprise.Cluster{
+			MetaNodes: []enterprise.Node{
+				{ID: 1, Addr: nodeURL.Host},
+			},
+			DataNodes: []enterprise.DataNode{
+				{ID: 2, HTTPAddr: nodeURL.Host},
+				{ID: 3, HTTPAddr: downURL},
+			},
+		},
+		Shards: []enterprise.Shard{
+			{
+				ID:              "2",
+				Database:        "telegraf",
+				RetentionPolicy: "autogen",
+				ShardGroupID:    "2",
+				StartTime:       "2018-09-03T00:00:00Z",
+				EndTime:         "2018-09-10T00:00:00Z",
+				Owners:          []enterprise.ShardOwner{{ID: "2", TCPAddr: "data-2:8088"}},
+			},
+			{
+				ID:              "1",
+				Database:        "telegraf",
+				RetentionPolicy: "autogen",
+				ShardGroupID:    "1",
+				StartTime:       "2018-08-27T00:00:00Z",
+				EndTime:         "2018-09-03T00:00:00Z",
+				Owners:          []enterprise.ShardOwner{{ID: "3", TCPAddr: "data-3:8088"}},
+			},
+			{
+				ID:              "3",
+				Database:        "_internal",
+				RetentionPolicy: "monitor",
+				ShardGroupID:    "3",
+				StartTime:       "2018-09-03T00:00:00Z",
+				EndTime:         "2018-09-04T00:00:00Z",
+			},
+		},
+	}
+	cl := &enterprise.Client{
+		Ctrl:   ctrl,
+		Logger: log.New(log.DebugLevel),
+		Nodes:  enterprise.NewClusterState(),
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error initializing client: err:", err)
+	}
+
+	got, err := cl.Overview(context.Background())
+	if err != nil {
+		t.Fatal("Unexpected error describing cluster: err:", err)
+	}
+
+	if len(got.MetaNodes) != 1 || !got.MetaNodes[0].Reachable || got.MetaNodes[0].Version != "1.6.2-c1.6.2" {
+		t.Errorf("Expected the meta node to be reachable but got %#v", got.MetaNodes)
+	}
+	if len(got.DataNodes) != 2 {
+		t.Fatalf("Expected two data nodes but got %#v", got.DataNodes)
+	}
+	wantStats := &enterprise.NodeStats{
+		DiskBytes:  3072,
+		PointReq:   300,
+		WriteOK:    25,
+		WriteError: 2,
+		WriteDrop:  1,
+	}
+	if dn := got.DataNodes[0]; !dn.Reachable || !reflect.DeepEqual(dn.Stats, wantStats) {
+		t.Errorf("Expected data node stats %#v but got %#v", wantStats, dn)
+	}
+	if dn := got.DataNodes[1]; dn.Reachable || dn.Error == "" || dn.Stats != nil {
+		t.Errorf("Expected the closed data node to be unreachable but got %#v", dn)
+	}
+
+	dbs := []string{}
+	for _, db := range got.Databases {
+		groups := []string{}
+		for _, sg := range db.ShardGroups {
+			groups = append(groups, sg.ID)
+		}
+		dbs = append(dbs, db.Database+"."+db.RetentionPolicy+":"+strings.Join(groups, ","))
+	}
+	if want := []string{"_internal.monitor:3", "telegraf.autogen:1,2"}; !reflect.DeepEqual(dbs, want) {
+		t.Errorf("Expected shard groups %v but got %v", want, dbs)
+	}
+}
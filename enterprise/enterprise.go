@@ -1,9 +1,11 @@
 package enterprise
 
 import (
-	"container/ring"
+	"errors"
+	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 
 	"context"
 
@@ -12,10 +14,12 @@ import (
 )
 
 var _ chronograf.TimeSeries = &Client{}
+var _ chronograf.QueryManager = &Client{}
 
 // Ctrl represents administrative controls over an Influx Enterprise cluster
 type Ctrl interface {
 	ShowCluster(ctx context.Context) (*Cluster, error)
+	ShowShards(ctx context.Context) ([]Shard, error)
 
 	Users(ctx context.Context, name *string) (*Users, error)
 	User(ctx context.Context, name string) (*User, error)
@@ -39,15 +43,22 @@ type Ctrl interface {
 // Client is a device for retrieving time series data from an Influx Enterprise
 // cluster. It is configured using the addresses of one or more meta node URLs.
 // Data node URLs are retrieved automatically from the meta nodes and queries
-// are appropriately load balanced across the cluster.
+// are appropriately load balanced across the healthy nodes of the cluster.
 type Client struct {
 	Ctrl
 	UsersStore chronograf.UsersStore
 	RolesStore chronograf.RolesStore
 	Logger     chronograf.Logger
 
-	dataNodes *ring.Ring
-	opened    bool
+	// Nodes is the state of the data nodes shared by the clients of the
+	// source; every client has its own when nil
+	Nodes *ClusterState
+
+	mu         sync.Mutex
+	dataNodes  []dataNode
+	src        chronograf.Source
+	discovered bool // discovered is set when data nodes come from ShowCluster
+	opened     bool
 }
 
 // NewClientWithTimeSeries initializes a Client with a known set of TimeSeries.
@@ -68,13 +79,14 @@ func NewClientWithTimeSeries(lg chronograf.Logger, mu string, authorizer influx.
 			Ctrl:   ctrl,
 			Logger: lg,
 		},
+		Logger: lg,
 	}
 
-	c.dataNodes = ring.New(len(series))
-
 	for _, s := range series {
-		c.dataNodes.Value = s
-		c.dataNodes = c.dataNodes.Next()
+		c.dataNodes = append(c.dataNodes, dataNode{
+			addr:       nodeAddr(s),
+			TimeSeries: s,
+		})
 	}
 
 	return c, nil
@@ -106,42 +118,138 @@ func NewClientWithURL(mu string, authorizer influx.Authorizer, tls bool, insecur
 	}, nil
 }
 
-// Connect prepares a Client to process queries. It must be called prior to calling Query
+// Connect prepares a Client to process queries. It must be called prior to
+// calling Query. Clients created without data nodes discover them from the
+// meta nodes and refresh them every RefreshInterval of their ClusterState.
 func (c *Client) Connect(ctx context.Context, src *chronograf.Source) error {
 	c.opened = true
+	c.src = *src
 	// return early if we already have dataNodes
-	if c.dataNodes != nil {
+	if len(c.dataNodes) > 0 || c.discovered {
 		return nil
 	}
-	cluster, err := c.Ctrl.ShowCluster(ctx)
+	c.discovered = true
+	return c.discover(ctx)
+}
+
+// discover connects to the data nodes last discovered from the meta nodes,
+// discovering them again if they are stale. The URL of the source is queried
+// when no data node was ever discovered.
+func (c *Client) discover(ctx context.Context) error {
+	urls, err := c.state().dataNodeURLs(ctx, c.Ctrl)
+	if len(urls) == 0 && c.src.URL != "" {
+		urls = []string{c.src.URL}
+	}
 	if err != nil {
-		return err
+		if len(urls) == 0 {
+			return err
+		}
+		if c.Logger != nil {
+			c.Logger.Error("Unable to discover data nodes from meta nodes: ", err)
+		}
 	}
 
-	c.dataNodes = ring.New(len(cluster.DataNodes))
-	for _, dn := range cluster.DataNodes {
+	nodes := []dataNode{}
+	for _, u := range urls {
 		cl := &influx.Client{
 			Logger: c.Logger,
 		}
-		dataSrc := &chronograf.Source{}
-		*dataSrc = *src
-		dataSrc.URL = dn.HTTPAddr
-		if err := cl.Connect(ctx, dataSrc); err != nil {
+		dataSrc := c.src
+		dataSrc.URL = u
+		if err := cl.Connect(ctx, &dataSrc); err != nil {
 			continue
 		}
-		c.dataNodes.Value = cl
-		c.dataNodes = c.dataNodes.Next()
+		nodes = append(nodes, dataNode{
+			addr:       nodeAddr(cl),
+			TimeSeries: cl,
+		})
 	}
+
+	c.mu.Lock()
+	c.dataNodes = nodes
+	c.mu.Unlock()
 	return nil
 }
 
+// dataNodeURL adds the scheme of the data node to its address if missing
+func dataNodeURL(dn DataNode) string {
+	if strings.Contains(dn.HTTPAddr, "://") {
+		return dn.HTTPAddr
+	}
+	scheme := dn.HTTPScheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	return scheme + "://" + dn.HTTPAddr
+}
+
+// refresh reconnects to the data nodes, which are discovered again from the
+// meta nodes once the RefreshInterval of the cluster has elapsed
+func (c *Client) refresh(ctx context.Context) {
+	if !c.discovered {
+		return
+	}
+	if err := c.discover(ctx); err != nil && c.Logger != nil {
+		c.Logger.Error("Unable to refresh data nodes from meta nodes: ", err)
+	}
+}
+
+// state returns the state of the data nodes of the client
+func (c *Client) state() *ClusterState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Nodes == nil {
+		c.Nodes = NewClusterState()
+	}
+	return c.Nodes
+}
+
+// health returns the tracker of the data node health of the client
+func (c *Client) health() *Health {
+	return c.state().health()
+}
+
 // Query retrieves timeseries information pertaining to a specified query. It
-// can be cancelled by using a provided context.
+// can be cancelled by using a provided context. Reads failing because of
+// their data node are retried on the other data nodes.
 func (c *Client) Query(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
 	if !c.opened {
 		return nil, chronograf.ErrUninitialized
 	}
-	return c.nextDataNode().Query(ctx, q)
+	c.refresh(ctx)
+
+	attempts := 1
+	if influx.IsReadOnly(q.Command) {
+		attempts = c.numDataNodes()
+	}
+
+	tried := map[string]bool{}
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		node, err := c.nextDataNode(ctx, tried)
+		if err != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+		tried[node.addr] = true
+
+		res, err := node.Query(ctx, q)
+		if err == nil {
+			c.health().Success(node.addr)
+			return res, nil
+		}
+		if !influx.IsServerFailure(err) {
+			return nil, err
+		}
+		c.nodeFailed(node.addr, err)
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
 }
 
 // Write records points into a time series
@@ -149,7 +257,57 @@ func (c *Client) Write(ctx context.Context, points []chronograf.Point) error {
 	if !c.opened {
 		return chronograf.ErrUninitialized
 	}
-	return c.nextDataNode().Write(ctx, points)
+	c.refresh(ctx)
+
+	node, err := c.nextDataNode(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := node.Write(ctx, points); err != nil {
+		if influx.IsServerFailure(err) {
+			c.nodeFailed(node.addr, err)
+		}
+		return err
+	}
+	c.health().Success(node.addr)
+	return nil
+}
+
+// WriteLines writes line protocol to the next healthy data node. It returns
+// the number of lines written before any error.
+func (c *Client) WriteLines(ctx context.Context, db, rp, precision string, lines []string) (int, error) {
+	if !c.opened {
+		return 0, chronograf.ErrUninitialized
+	}
+	c.refresh(ctx)
+
+	node, err := c.nextDataNode(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	cl, ok := node.TimeSeries.(*influx.Client)
+	if !ok {
+		return 0, fmt.Errorf("data node %s does not accept line protocol", node.addr)
+	}
+	n, err := cl.WriteLines(ctx, db, rp, precision, lines)
+	if err != nil {
+		if influx.IsServerFailure(err) {
+			c.nodeFailed(node.addr, err)
+		}
+		return n, err
+	}
+	c.health().Success(node.addr)
+	return n, nil
+}
+
+func (c *Client) nodeFailed(addr string, err error) {
+	c.health().Failure(addr, err)
+	if c.Logger != nil {
+		c.Logger.
+			WithField("component", "enterprise").
+			WithField("node", addr).
+			Error("Ejecting data node: ", err)
+	}
 }
 
 // Users is the interface to the users within Influx Enterprise
@@ -198,10 +356,149 @@ func (c *Client) Permissions(context.Context) chronograf.Permissions {
 	}
 }
 
-// nextDataNode retrieves the next available data node
-func (c *Client) nextDataNode() chronograf.TimeSeries {
-	c.dataNodes = c.dataNodes.Next()
-	return c.dataNodes.Value.(chronograf.TimeSeries)
+// DataNodeError is the failure of an operation on a single data node
+type DataNodeError struct {
+	Host string // Host is the address of the data node
+	Err  error
+}
+
+// DataNodeErrors are the failures of an operation on several data nodes
+type DataNodeErrors []DataNodeError
+
+func (e DataNodeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ne := range e {
+		msgs[i] = fmt.Sprintf("%s: %v", ne.Host, ne.Err)
+	}
+	return "data nodes failed: " + strings.Join(msgs, "; ")
+}
+
+// RunningQueries lists the queries executing on every data node of the
+// cluster. When only some of the data nodes fail, the queries of the others
+// are returned along with the DataNodeErrors of the failed ones.
+func (c *Client) RunningQueries(ctx context.Context) ([]chronograf.RunningQuery, error) {
+	if !c.opened {
+		return nil, chronograf.ErrUninitialized
+	}
+
+	queries := []chronograf.RunningQuery{}
+	var errs DataNodeErrors
+	nodes := 0
+	for _, node := range c.nodes() {
+		qm, ok := node.TimeSeries.(chronograf.QueryManager)
+		if !ok {
+			continue
+		}
+		nodes++
+		qs, err := qm.RunningQueries(ctx)
+		if err != nil {
+			errs = append(errs, DataNodeError{Host: node.addr, Err: err})
+			continue
+		}
+		queries = append(queries, qs...)
+	}
+	if len(errs) == 0 {
+		return queries, nil
+	}
+	if len(errs) == nodes {
+		return nil, errs
+	}
+	return queries, errs
+}
+
+// ErrQueryHostRequired is returned when a query is killed without the host
+// of the data node running it. Query IDs are only unique per data node.
+var ErrQueryHostRequired = errors.New("host of the data node running the query is required")
+
+// KillQuery terminates a query running on the data node identified by host
+func (c *Client) KillQuery(ctx context.Context, id uint64, host string) error {
+	if !c.opened {
+		return chronograf.ErrUninitialized
+	}
+	if host == "" {
+		return ErrQueryHostRequired
+	}
+
+	for _, qm := range c.queryManagers() {
+		if cl, ok := qm.(*influx.Client); ok && cl.URL.Host == host {
+			return qm.KillQuery(ctx, id, host)
+		}
+	}
+	return fmt.Errorf("no data node found for host %s", host)
+}
+
+// queryManagers returns the data nodes that are able to manage queries
+func (c *Client) queryManagers() []chronograf.QueryManager {
+	qms := []chronograf.QueryManager{}
+	for _, node := range c.nodes() {
+		if qm, ok := node.TimeSeries.(chronograf.QueryManager); ok {
+			qms = append(qms, qm)
+		}
+	}
+	return qms
+}
+
+func (c *Client) nodes() []dataNode {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]dataNode(nil), c.dataNodes...)
+}
+
+func (c *Client) numDataNodes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.dataNodes)
+}
+
+// nextDataNode retrieves the next healthy data node that has not been tried.
+// Nodes re-admitted after an ejection are pinged before being used. When no
+// node is healthy the next untried node is used anyway.
+func (c *Client) nextDataNode(ctx context.Context, tried map[string]bool) (dataNode, error) {
+	nodes := c.nodes()
+	if len(nodes) == 0 {
+		return dataNode{}, fmt.Errorf("no data nodes available")
+	}
+
+	state := c.state()
+	state.mu.Lock()
+	start := state.next % len(nodes)
+	state.next = (start + 1) % len(nodes)
+	state.mu.Unlock()
+
+	var fallback *dataNode
+	for i := 0; i < len(nodes); i++ {
+		node := nodes[(start+i)%len(nodes)]
+		if tried[node.addr] {
+			continue
+		}
+		if fallback == nil {
+			fallback = &node
+		}
+
+		health := c.health()
+		if !health.Healthy(node.addr) {
+			continue
+		}
+		if health.OnProbation(node.addr) {
+			if p, ok := node.TimeSeries.(pinger); ok {
+				if err := p.Ping(ctx); err != nil {
+					c.nodeFailed(node.addr, err)
+					continue
+				}
+				health.Success(node.addr)
+			}
+		}
+
+		state.mu.Lock()
+		state.next = (start + i + 1) % len(nodes)
+		state.mu.Unlock()
+		return node, nil
+	}
+
+	if fallback == nil {
+		return dataNode{}, fmt.Errorf("no data nodes available")
+	}
+	return *fallback, nil
 }
 
 // parseMetaURL constructs a url from either a host:port combination or a
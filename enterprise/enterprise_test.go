@@ -268,3 +268,116 @@ func TestClient_Permissions(t *testing.T) {
 		}
 	}
 }
+
+func Test_Enterprise_RunningQueries(t *testing.T) {
+	t.Parallel()
+
+	killed := map[string]string{}
+	newDataNode := func() *httptest.Server {
+		var ts *httptest.Server
+		ts = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			if q == "SHOW QUERIES" {
+				rw.Write([]byte(`{"results":[{"series":[{"columns":["qid","query","database","duration","status"],"values":[[1,"SELECT * FROM cpu","telegraf","10s","running"]]}]}]}`))
+				return
+			}
+			killed[r.Host] = q
+			rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		}))
+		return ts
+	}
+	node1, node2 := newDataNode(), newDataNode()
+	defer node1.Close()
+	defer node2.Close()
+
+	series := []chronograf.TimeSeries{}
+	for _, node := range []*httptest.Server{node1, node2} {
+		cl := &influx.Client{
+			Logger: log.New(log.DebugLevel),
+		}
+		if err := cl.Connect(context.Background(), &chronograf.Source{URL: node.URL}); err != nil {
+			t.Fatal("Unexpected error initializing data node client: err:", err)
+		}
+		series = append(series, cl)
+	}
+
+	cl, err := enterprise.NewClientWithTimeSeries(log.New(log.DebugLevel), "http://meta.example.com:8091", nil, false, false, series...)
+	if err != nil {
+		t.Fatal("Unexpected error while initializing client: err:", err)
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error while initializing client: err:", err)
+	}
+
+	queries, err := cl.RunningQueries(context.Background())
+	if err != nil {
+		t.Fatal("Unexpected error listing running queries: err:", err)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("Expected a running query per data node but got %d", len(queries))
+	}
+	hosts := map[string]bool{}
+	for _, q := range queries {
+		hosts[q.Host] = true
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("Expected running queries to be attributed to both data nodes but got %v", hosts)
+	}
+
+	host := queries[1].Host
+	if err := cl.KillQuery(context.Background(), queries[1].ID, host); err != nil {
+		t.Fatal("Unexpected error killing query: err:", err)
+	}
+	if len(killed) != 1 || killed[host] != "KILL QUERY 1" {
+		t.Fatalf("Expected KILL QUERY 1 to be sent to %s only but got %v", host, killed)
+	}
+
+	// Query IDs are only unique per data node
+	if err := cl.KillQuery(context.Background(), queries[0].ID, ""); err != enterprise.ErrQueryHostRequired {
+		t.Fatalf("Expected killing a query without a host to fail but got %v", err)
+	}
+	if len(killed) != 1 {
+		t.Fatalf("Expected no query to be killed without a host but got %v", killed)
+	}
+}
+
+func Test_Enterprise_RunningQueriesOfRespondingDataNodes(t *testing.T) {
+	t.Parallel()
+
+	up := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte(`{"results":[{"series":[{"columns":["qid","query","database","duration","status"],"values":[[1,"SELECT * FROM cpu","telegraf","10s","running"]]}]}]}`))
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	series := []chronograf.TimeSeries{}
+	for _, node := range []*httptest.Server{up, down} {
+		cl := &influx.Client{
+			Logger: log.New(log.DebugLevel),
+		}
+		if err := cl.Connect(context.Background(), &chronograf.Source{URL: node.URL}); err != nil {
+			t.Fatal("Unexpected error initializing data node client: err:", err)
+		}
+		series = append(series, cl)
+	}
+
+	cl, err := enterprise.NewClientWithTimeSeries(log.New(log.DebugLevel), "http://meta.example.com:8091", nil, false, false, series...)
+	if err != nil {
+		t.Fatal("Unexpected error while initializing client: err:", err)
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error while initializing client: err:", err)
+	}
+
+	queries, err := cl.RunningQueries(context.Background())
+	errs, ok := err.(enterprise.DataNodeErrors)
+	if !ok || len(errs) != 1 || errs[0].Host != series[1].(*influx.Client).URL.Host {
+		t.Fatalf("Expected the failing data node to be reported but got %v", err)
+	}
+	if len(queries) != 1 || queries[0].Host != series[0].(*influx.Client).URL.Host {
+		t.Errorf("Expected the running query of the responding data node but got %v", queries)
+	}
+}
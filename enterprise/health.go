@@ -0,0 +1,254 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+const (
+	// DefaultBaseBackoff is how long a data node is first ejected for
+	DefaultBaseBackoff = 5 * time.Second
+	// DefaultMaxBackoff caps the ejection of a data node failing repeatedly
+	DefaultMaxBackoff = 5 * time.Minute
+	// DefaultRefreshInterval is how often the data nodes of a cluster are
+	// refreshed from the meta nodes
+	DefaultRefreshInterval = time.Minute
+)
+
+// Health tracks the health of data nodes by address. A failing node is
+// ejected for a backoff that doubles with each consecutive failure, from
+// BaseBackoff up to MaxBackoff. Once its backoff has elapsed the node is
+// re-admitted on probation and a single further failure ejects it again.
+type Health struct {
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Now         func() time.Time
+
+	mu    sync.Mutex
+	nodes map[string]*nodeHealth
+}
+
+type nodeHealth struct {
+	failures     int       // failures is the number of consecutive failures
+	ejectedUntil time.Time // ejectedUntil is when the node may be tried again
+	lastErr      error
+}
+
+// NodeStatus is the health of a single data node
+type NodeStatus struct {
+	Addr         string    `json:"addr"`
+	Healthy      bool      `json:"healthy"`
+	Failures     int       `json:"failures"`
+	EjectedUntil time.Time `json:"ejectedUntil,omitempty"`
+	LastError    string    `json:"lastError,omitempty"`
+}
+
+// NewHealth creates a Health with the default backoffs
+func NewHealth() *Health {
+	return &Health{
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		Now:         time.Now,
+		nodes:       map[string]*nodeHealth{},
+	}
+}
+
+func (h *Health) now() time.Time {
+	if h.Now == nil {
+		return time.Now()
+	}
+	return h.Now()
+}
+
+func (h *Health) node(addr string) *nodeHealth {
+	if h.nodes == nil {
+		h.nodes = map[string]*nodeHealth{}
+	}
+	n, ok := h.nodes[addr]
+	if !ok {
+		n = &nodeHealth{}
+		h.nodes[addr] = n
+	}
+	return n
+}
+
+// Healthy reports whether queries may be sent to the node. Nodes whose
+// ejection has elapsed are healthy again, although on probation.
+func (h *Health) Healthy(addr string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return !h.now().Before(h.node(addr).ejectedUntil)
+}
+
+// OnProbation reports whether the node has been re-admitted after an
+// ejection without having succeeded since
+func (h *Health) OnProbation(addr string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := h.node(addr)
+	return n.failures > 0 && !h.now().Before(n.ejectedUntil)
+}
+
+// Success records that the node answered and clears its failures
+func (h *Health) Success(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := h.node(addr)
+	n.failures = 0
+	n.ejectedUntil = time.Time{}
+	n.lastErr = nil
+}
+
+// Failure records that the node failed and ejects it
+func (h *Health) Failure(addr string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := h.node(addr)
+	n.failures++
+	n.lastErr = err
+
+	backoff := h.BaseBackoff
+	for i := 1; i < n.failures && backoff < h.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if h.MaxBackoff > 0 && backoff > h.MaxBackoff {
+		backoff = h.MaxBackoff
+	}
+	n.ejectedUntil = h.now().Add(backoff)
+}
+
+// Status returns the health of the node
+func (h *Health) Status(addr string) NodeStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := h.node(addr)
+	status := NodeStatus{
+		Addr:     addr,
+		Healthy:  !h.now().Before(n.ejectedUntil),
+		Failures: n.failures,
+	}
+	if !status.Healthy {
+		status.EjectedUntil = n.ejectedUntil
+	}
+	if n.lastErr != nil {
+		status.LastError = n.lastErr.Error()
+	}
+	return status
+}
+
+// ClusterState is the state of the data nodes of a cluster shared by every
+// client of its source: the data nodes discovered from the meta nodes, their
+// health and the data node queried next. Clients are created for every
+// request with the credentials of its user while the state outlives them.
+type ClusterState struct {
+	// Health tracks the health of the data nodes; one is created when nil
+	Health *Health
+	// RefreshInterval is how often data nodes discovered from the meta nodes
+	// are refreshed; DefaultRefreshInterval is used if zero
+	RefreshInterval time.Duration
+
+	mu        sync.Mutex
+	urls      []string  // urls of the data nodes last discovered
+	refreshed time.Time // refreshed is when the data nodes were last discovered
+	next      int       // next is the index of the data node queried next
+}
+
+// NewClusterState creates a ClusterState with the default backoffs
+func NewClusterState() *ClusterState {
+	return &ClusterState{
+		Health: NewHealth(),
+	}
+}
+
+func (s *ClusterState) health() *Health {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Health == nil {
+		s.Health = NewHealth()
+	}
+	return s.Health
+}
+
+// dataNodeURLs returns the URLs of the data nodes, discovering them from the
+// meta nodes first when they were never discovered or RefreshInterval has
+// elapsed. Failed discoveries keep the previous URLs until the next refresh
+// so that an unreachable meta node is not asked on every query.
+func (s *ClusterState) dataNodeURLs(ctx context.Context, ctrl Ctrl) ([]string, error) {
+	interval := s.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	s.mu.Lock()
+	stale := s.refreshed.IsZero() || time.Since(s.refreshed) >= interval
+	urls := s.urls
+	s.mu.Unlock()
+	if !stale {
+		return urls, nil
+	}
+
+	cluster, err := ctrl.ShowCluster(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshed = time.Now()
+	if err != nil {
+		return s.urls, err
+	}
+	urls = make([]string, len(cluster.DataNodes))
+	for i, dn := range cluster.DataNodes {
+		urls[i] = dataNodeURL(dn)
+	}
+	s.urls = urls
+	return urls, nil
+}
+
+// CheckHealth pings the data nodes last discovered, or the source itself
+// when none were, and records their health. Checking periodically keeps the
+// health of the data nodes current between queries.
+func (s *ClusterState) CheckHealth(ctx context.Context, src *chronograf.Source) {
+	s.mu.Lock()
+	urls := append([]string(nil), s.urls...)
+	s.mu.Unlock()
+	if len(urls) == 0 && src.URL != "" {
+		urls = []string{src.URL}
+	}
+
+	for _, u := range urls {
+		cl := &influx.Client{}
+		dataSrc := *src
+		dataSrc.URL = u
+		if err := cl.Connect(ctx, &dataSrc); err != nil {
+			continue
+		}
+		if err := cl.Ping(ctx); err != nil {
+			s.health().Failure(nodeAddr(cl), err)
+			continue
+		}
+		s.health().Success(nodeAddr(cl))
+	}
+}
+
+// dataNode is a data node client along with the address its health is
+// tracked by
+type dataNode struct {
+	addr string
+	chronograf.TimeSeries
+}
+
+// pinger is implemented by data node clients able to check their health
+type pinger interface {
+	Ping(context.Context) error
+}
+
+// nodeAddr identifies the data node of a TimeSeries
+func nodeAddr(ts chronograf.TimeSeries) string {
+	if cl, ok := ts.(*influx.Client); ok && cl.URL != nil {
+		return cl.URL.Host
+	}
+	return fmt.Sprintf("%p", ts)
+}
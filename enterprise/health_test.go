@@ -0,0 +1,285 @@
+package enterprise_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/log"
+)
+
+func TestHealth_Backoff(t *testing.T) {
+	now := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	h := enterprise.NewHealth()
+	h.BaseBackoff = time.Second
+	h.MaxBackoff = 3 * time.Second
+	h.Now = func() time.Time { return now }
+
+	addr := "data-1:8086"
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		h.Failure(addr, fmt.Errorf("connection refused"))
+		if h.Healthy(addr) {
+			t.Fatalf("failure %d: expected node to be ejected", i+1)
+		}
+		now = now.Add(want - time.Millisecond)
+		if h.Healthy(addr) {
+			t.Fatalf("failure %d: expected node to be ejected for %s", i+1, want)
+		}
+		now = now.Add(time.Millisecond)
+		if !h.Healthy(addr) || !h.OnProbation(addr) {
+			t.Fatalf("failure %d: expected node to be re-admitted on probation after %s", i+1, want)
+		}
+	}
+
+	h.Success(addr)
+	if status := h.Status(addr); !status.Healthy || status.Failures != 0 || h.OnProbation(addr) {
+		t.Errorf("expected success to clear failures but got %#v", status)
+	}
+}
+
+func Test_Enterprise_FailsOverToHealthyDataNode(t *testing.T) {
+	t.Parallel()
+
+	queries := map[string]int{}
+	newDataNode := func(status int) *httptest.Server {
+		var ts *httptest.Server
+		ts = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			queries[ts.URL]++
+			rw.WriteHeader(status)
+			rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		}))
+		return ts
+	}
+	down, up := newDataNode(http.StatusServiceUnavailable), newDataNode(http.StatusOK)
+	defer down.Close()
+	defer up.Close()
+
+	ctrl := &ControlClient{
+		Cluster: &enterprise.Cluster{
+			DataNodes: []enterprise.DataNode{
+				{HTTPAddr: down.URL},
+				{HTTPAddr: up.URL},
+			},
+		},
+	}
+	cl := &enterprise.Client{
+		Ctrl:   ctrl,
+		Logger: log.New(log.DebugLevel),
+		Nodes:  enterprise.NewClusterState(),
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error initializing client: err:", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := cl.Query(context.Background(), chronograf.Query{Command: "SELECT * FROM cpu", DB: "telegraf"}); err != nil {
+			t.Fatalf("query %d: unexpected error: %v", i, err)
+		}
+	}
+	if queries[down.URL] != 1 || queries[up.URL] != 3 {
+		t.Errorf("Expected the failing node to be tried once and then ejected but got %v", queries)
+	}
+
+	if cl.Nodes.Health.Healthy(hostOf(down.URL)) || !cl.Nodes.Health.Healthy(hostOf(up.URL)) {
+		t.Errorf("Expected only the failing node to be unhealthy")
+	}
+}
+
+func Test_Enterprise_DoesNotRetryWrites(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	newDataNode := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			calls++
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+	}
+	node1, node2 := newDataNode(), newDataNode()
+	defer node1.Close()
+	defer node2.Close()
+
+	ctrl := &ControlClient{
+		Cluster: &enterprise.Cluster{
+			DataNodes: []enterprise.DataNode{
+				{HTTPAddr: node1.URL},
+				{HTTPAddr: node2.URL},
+			},
+		},
+	}
+	cl := &enterprise.Client{
+		Ctrl:   ctrl,
+		Logger: log.New(log.DebugLevel),
+		Nodes:  enterprise.NewClusterState(),
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error initializing client: err:", err)
+	}
+
+	if _, err := cl.Query(context.Background(), chronograf.Query{Command: `DROP DATABASE "telegraf"`}); err == nil {
+		t.Fatal("Expected error from failing data node")
+	}
+	if calls != 1 {
+		t.Errorf("Expected DROP DATABASE to be sent once but was sent %d times", calls)
+	}
+}
+
+func Test_Enterprise_RefreshesDataNodes(t *testing.T) {
+	t.Parallel()
+
+	hits := map[string]int{}
+	newDataNode := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			hits[name]++
+			rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		}))
+	}
+	old, added := newDataNode("old"), newDataNode("added")
+	defer old.Close()
+	defer added.Close()
+
+	ctrl := &ControlClient{
+		Cluster: &enterprise.Cluster{
+			DataNodes: []enterprise.DataNode{
+				{HTTPAddr: old.URL},
+			},
+		},
+	}
+	cl := &enterprise.Client{
+		Ctrl:   ctrl,
+		Logger: log.New(log.DebugLevel),
+		Nodes: &enterprise.ClusterState{
+			RefreshInterval: time.Nanosecond,
+		},
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error initializing client: err:", err)
+	}
+
+	ctrl.Cluster = &enterprise.Cluster{
+		DataNodes: []enterprise.DataNode{
+			{HTTPAddr: added.URL},
+		},
+	}
+	if _, err := cl.Query(context.Background(), chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+		t.Fatal("Unexpected error querying data node: err:", err)
+	}
+	if hits["added"] != 1 || hits["old"] != 0 {
+		t.Errorf("Expected the query to be sent to the refreshed data node but got %v", hits)
+	}
+}
+
+func hostOf(rawURL string) string {
+	u, _ := url.Parse(rawURL)
+	return u.Host
+}
+
+// countingCtrl counts the discoveries of data nodes and fails them with err
+type countingCtrl struct {
+	*ControlClient
+	discoveries int
+	err         error
+}
+
+func (cc *countingCtrl) ShowCluster(ctx context.Context) (*enterprise.Cluster, error) {
+	cc.discoveries++
+	if cc.err != nil {
+		return nil, cc.err
+	}
+	return cc.ControlClient.ShowCluster(ctx)
+}
+
+func Test_Enterprise_SharesClusterStateBetweenClients(t *testing.T) {
+	t.Parallel()
+
+	hits := map[string]int{}
+	newDataNode := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			hits[name]++
+			rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		}))
+	}
+	first, second := newDataNode("first"), newDataNode("second")
+	defer first.Close()
+	defer second.Close()
+
+	ctrl := &countingCtrl{
+		ControlClient: &ControlClient{
+			Cluster: &enterprise.Cluster{
+				DataNodes: []enterprise.DataNode{
+					{HTTPAddr: first.URL},
+					{HTTPAddr: second.URL},
+				},
+			},
+		},
+	}
+	state := enterprise.NewClusterState()
+	for i := 0; i < 2; i++ {
+		cl := &enterprise.Client{
+			Ctrl:   ctrl,
+			Logger: log.New(log.DebugLevel),
+			Nodes:  state,
+		}
+		if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+			t.Fatal("Unexpected error initializing client: err:", err)
+		}
+		if _, err := cl.Query(context.Background(), chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+			t.Fatal("Unexpected error querying data node: err:", err)
+		}
+	}
+
+	if ctrl.discoveries != 1 {
+		t.Errorf("Expected the data nodes to be discovered once but were discovered %d times", ctrl.discoveries)
+	}
+	if hits["first"] != 1 || hits["second"] != 1 {
+		t.Errorf("Expected the clients to take turns between the data nodes but got %v", hits)
+	}
+}
+
+func Test_Enterprise_FallsBackToSourceURL(t *testing.T) {
+	t.Parallel()
+
+	queries := 0
+	node := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		queries++
+		rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	defer node.Close()
+
+	ctrl := &countingCtrl{
+		ControlClient: &ControlClient{},
+		err:           fmt.Errorf("meta node unreachable"),
+	}
+	cl := &enterprise.Client{
+		Ctrl:   ctrl,
+		Logger: log.New(log.DebugLevel),
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{URL: node.URL}); err != nil {
+		t.Fatal("Unexpected error initializing client: err:", err)
+	}
+	if _, err := cl.Query(context.Background(), chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+		t.Fatal("Unexpected error querying the source: err:", err)
+	}
+	if queries != 1 {
+		t.Errorf("Expected the query to be sent to the URL of the source but it was sent %d times", queries)
+	}
+}
+
+func TestClusterState_CheckHealth(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	state := enterprise.NewClusterState()
+	state.CheckHealth(context.Background(), &chronograf.Source{URL: down.URL})
+	if state.Health.Healthy(hostOf(down.URL)) {
+		t.Errorf("Expected the data node failing its ping to be ejected")
+	}
+}
@@ -116,6 +116,23 @@ func (m *MetaClient) ShowCluster(ctx context.Context) (*Cluster, error) {
 	return out, nil
 }
 
+// ShowShards returns the shards of the cluster along with their owners
+func (m *MetaClient) ShowShards(ctx context.Context) ([]Shard, error) {
+	res, err := m.Do(ctx, "/show-shards", "GET", m.authorizer, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	dec := json.NewDecoder(res.Body)
+	out := []Shard{}
+	err = dec.Decode(&out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Users gets all the users.  If name is not nil it filters for a single user
 func (m *MetaClient) Users(ctx context.Context, name *string) (*Users, error) {
 	params := map[string]string{}
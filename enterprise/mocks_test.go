@@ -11,6 +11,7 @@ import (
 
 type ControlClient struct {
 	Cluster            *enterprise.Cluster
+	Shards             []enterprise.Shard
 	ShowClustersCalled bool
 }
 
@@ -36,6 +37,10 @@ func (cc *ControlClient) ShowCluster(context.Context) (*enterprise.Cluster, erro
 	return cc.Cluster, nil
 }
 
+func (cc *ControlClient) ShowShards(context.Context) ([]enterprise.Shard, error) {
+	return cc.Shards, nil
+}
+
 func (cc *ControlClient) User(ctx context.Context, name string) (*enterprise.User, error) {
 	return nil, nil
 }
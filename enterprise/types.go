@@ -24,6 +24,26 @@ type Node struct {
 	TCPAddr    string `json:"tcpAddr"`
 }
 
+// Shard is a shard of an Influx Enterprise cluster
+type Shard struct {
+	ID              string       `json:"id"`
+	Database        string       `json:"database"`
+	RetentionPolicy string       `json:"retention-policy"`
+	ReplicaN        int          `json:"replica-n"`
+	ShardGroupID    string       `json:"shard-group-id"`
+	StartTime       string       `json:"start-time"`
+	EndTime         string       `json:"end-time"`
+	ExpireTime      string       `json:"expire-time"`
+	TruncatedAt     string       `json:"truncated-at"`
+	Owners          []ShardOwner `json:"owners"`
+}
+
+// ShardOwner is a data node holding a copy of a shard
+type ShardOwner struct {
+	ID      string `json:"id"`
+	TCPAddr string `json:"tcpAddr"`
+}
+
 // Permissions maps resources to a set of permissions.
 // Specifically, it maps a database to a set of permissions
 type Permissions map[string][]string
@@ -782,6 +782,7 @@ func Test_ToChronograf(t *testing.T) {
 
 type mockCtrl struct {
 	showCluster    func(ctx context.Context) (*enterprise.Cluster, error)
+	showShards     func(ctx context.Context) ([]enterprise.Shard, error)
 	user           func(ctx context.Context, name string) (*enterprise.User, error)
 	createUser     func(ctx context.Context, name, passwd string) error
 	deleteUser     func(ctx context.Context, name string) error
@@ -805,6 +806,10 @@ func (m *mockCtrl) ShowCluster(ctx context.Context) (*enterprise.Cluster, error)
 	return m.showCluster(ctx)
 }
 
+func (m *mockCtrl) ShowShards(ctx context.Context) ([]enterprise.Shard, error) {
+	return m.showShards(ctx)
+}
+
 func (m *mockCtrl) User(ctx context.Context, name string) (*enterprise.User, error) {
 	return m.user(ctx, name)
 }
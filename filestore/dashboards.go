@@ -182,6 +182,16 @@ func (d *Dashboards) Update(ctx context.Context, dashboard chronograf.Dashboard)
 	return d.Create(file, dashboard)
 }
 
+// Search loads all dashboards from the directory and returns those matching the query
+func (d *Dashboards) Search(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+	dashboards, err := d.All(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	page, total := q.Apply(dashboards)
+	return page, total, nil
+}
+
 // idToFile takes an id and finds the associated filename
 func (d *Dashboards) idToFile(id chronograf.DashboardID) (chronograf.Dashboard, string, error) {
 	// Because the entire dashboard information is not known at this point, we need
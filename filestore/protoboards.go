@@ -3,6 +3,7 @@ package filestore
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
@@ -67,6 +68,21 @@ func (a *Protoboards) All(ctx context.Context) ([]chronograf.Protoboard, error)
 	return protoboards, nil
 }
 
+// Add is not supported by the protoboards directory
+func (a *Protoboards) Add(ctx context.Context, protoboard chronograf.Protoboard) (chronograf.Protoboard, error) {
+	return chronograf.Protoboard{}, fmt.Errorf("Add to filestore/protoboards not supported")
+}
+
+// Delete is not supported by the protoboards directory
+func (a *Protoboards) Delete(ctx context.Context, protoboard chronograf.Protoboard) error {
+	return fmt.Errorf("Delete to filestore/protoboards not supported")
+}
+
+// Update is not supported by the protoboards directory
+func (a *Protoboards) Update(ctx context.Context, protoboard chronograf.Protoboard) error {
+	return fmt.Errorf("Update to filestore/protoboards not supported")
+}
+
 // Get returns a protoboard file from the protoboard directory
 func (a *Protoboards) Get(ctx context.Context, ID string) (chronograf.Protoboard, error) {
 	l, file, err := a.idToFile(ID)
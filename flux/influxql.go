@@ -0,0 +1,810 @@
+package flux
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/influxql"
+)
+
+// Template variables Chronograf defines for Flux dashboard queries
+const (
+	DashboardTime      = "dashboardTime"
+	UpperDashboardTime = "upperDashboardTime"
+	AutoInterval       = "autoInterval"
+)
+
+// Placeholders substituted for the InfluxQL template variables so that the
+// query can be parsed. The interval is an unlikely duration, as in influx.Convert.
+const (
+	dashboardTimeIdent      = "__chronograf_dashboardTime__"
+	upperDashboardTimeIdent = "__chronograf_upperDashboardTime__"
+	intervalPlaceholder     = 8675309 * time.Microsecond
+)
+
+// Warning describes an InfluxQL construct that has no Flux translation
+type Warning struct {
+	Construct string `json:"construct"` // Construct is the InfluxQL that was left out of the translation
+	Message   string `json:"message"`   // Message explains why the construct was left out
+}
+
+// Translation is the Flux equivalent of an InfluxQL query. If Warnings is
+// not empty, parts of the InfluxQL query have been left out of the Flux.
+type Translation struct {
+	Flux     string    `json:"flux"`
+	Warnings []Warning `json:"warnings"`
+}
+
+// TranslateInfluxQL converts an InfluxQL SELECT statement into Flux. db and
+// rp are used when the measurement of the query is not fully qualified.
+// Constructs without a Flux equivalent are left out and reported as warnings;
+// an error is returned only if the query cannot be translated at all.
+func TranslateInfluxQL(influxQL, db, rp string) (Translation, error) {
+	q := strings.NewReplacer(
+		":dashboardTime:", dashboardTimeIdent,
+		":upperDashboardTime:", upperDashboardTimeIdent,
+		":interval:", "8675309u",
+	).Replace(influxQL)
+
+	query, err := influxql.ParseQuery(q)
+	if err != nil {
+		return Translation{}, err
+	}
+	if len(query.Statements) != 1 {
+		return Translation{}, fmt.Errorf("expected exactly one statement but found %d", len(query.Statements))
+	}
+	stmt, ok := query.Statements[0].(*influxql.SelectStatement)
+	if !ok {
+		return Translation{}, fmt.Errorf("only SELECT statements can be translated to Flux")
+	}
+
+	t := &translator{
+		db: db,
+		rp: rp,
+	}
+	script, err := t.translate(stmt)
+	if err != nil {
+		return Translation{}, err
+	}
+	return Translation{
+		Flux:     script,
+		Warnings: t.warnings,
+	}, nil
+}
+
+type translator struct {
+	db, rp   string
+	warnings []Warning
+}
+
+func (t *translator) warn(construct string, format string, args ...interface{}) {
+	w := Warning{
+		Construct: construct,
+		Message:   fmt.Sprintf(format, args...),
+	}
+	// Clauses shared by several fields are only reported once
+	for _, prev := range t.warnings {
+		if prev == w {
+			return
+		}
+	}
+	t.warnings = append(t.warnings, w)
+}
+
+// pipeline is the Flux for one or more fields of the SELECT clause that
+// share the same transformations
+type pipeline struct {
+	fields    []string // fields are the filtered field keys; empty means every field
+	regex     string   // regex filters the fields by a regular expression instead
+	steps     []string // steps are the transformations applied to the fields
+	window    bool     // window is set if the fields are aggregated into time windows
+	aggregate string   // aggregate is the InfluxQL function of the time windows
+	alias     string
+	name      string
+}
+
+func (t *translator) translate(stmt *influxql.SelectStatement) (string, error) {
+	bucket, measurements, err := t.sources(stmt.Sources)
+	if err != nil {
+		return "", err
+	}
+
+	if stmt.Target != nil {
+		t.warn(stmt.Target.String(), "writing results into another measurement is not supported")
+	}
+
+	start, stop, preds := t.condition(stmt.Condition)
+	preds = append([]string{measurements}, preds...)
+
+	every, tags, groupAll := t.dimensions(stmt.Dimensions)
+
+	pipes := []*pipeline{}
+	for _, f := range stmt.Fields {
+		p, ok := t.field(f, every)
+		if !ok {
+			continue
+		}
+		pipes = mergePipeline(pipes, p)
+	}
+	if len(pipes) == 0 {
+		return "", fmt.Errorf("no field of the query can be translated to Flux")
+	}
+
+	tail := t.limits(stmt)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "from(bucket: %s)\n", fluxString(bucket))
+	if stop != "" {
+		fmt.Fprintf(&buf, "  |> range(start: %s, stop: %s)\n", start, stop)
+	} else {
+		fmt.Fprintf(&buf, "  |> range(start: %s)\n", start)
+	}
+
+	if len(pipes) == 1 {
+		p := pipes[0]
+		if filter := p.fieldFilter(); filter != "" {
+			preds = append(preds, filter)
+		}
+		fmt.Fprintf(&buf, "  |> filter(fn: (r) => %s)\n", strings.Join(preds, " and "))
+		t.writePipeline(&buf, p, stmt, tags, groupAll, tail)
+		return buf.String(), nil
+	}
+
+	// Several pipelines share the same source, range and filter
+	fmt.Fprintf(&buf, "  |> filter(fn: (r) => %s)\n", strings.Join(preds, " and "))
+	script := "data = " + buf.String()
+	buf.Reset()
+	buf.WriteString(script)
+
+	names := map[string]int{}
+	for _, p := range pipes {
+		buf.WriteString("\ndata\n")
+		if filter := p.fieldFilter(); filter != "" {
+			fmt.Fprintf(&buf, "  |> filter(fn: (r) => %s)\n", filter)
+		}
+		t.writePipeline(&buf, p, stmt, tags, groupAll, tail)
+
+		name := p.name
+		if n := names[p.name]; n > 0 {
+			name = fmt.Sprintf("%s_%d", p.name, n)
+		}
+		names[p.name]++
+		fmt.Fprintf(&buf, "  |> yield(name: %s)\n", fluxString(name))
+	}
+	return buf.String(), nil
+}
+
+func (t *translator) writePipeline(buf *bytes.Buffer, p *pipeline, stmt *influxql.SelectStatement, tags []string, groupAll bool, tail []string) {
+	if !groupAll {
+		cols := append([]string{"_measurement", "_field"}, tags...)
+		quoted := make([]string, len(cols))
+		for i, c := range cols {
+			quoted[i] = fluxString(c)
+		}
+		fmt.Fprintf(buf, "  |> group(columns: [%s])\n", strings.Join(quoted, ", "))
+		// Merging series requires the points to be sorted by time again
+		if len(p.steps) == 0 && !(len(tail) > 0 && strings.HasPrefix(tail[0], "sort(")) {
+			buf.WriteString("  |> sort(columns: [\"_time\"])\n")
+		}
+	}
+
+	for _, step := range p.steps {
+		fmt.Fprintf(buf, "  |> %s\n", step)
+	}
+	if p.window {
+		for _, step := range t.fill(stmt, p) {
+			fmt.Fprintf(buf, "  |> %s\n", step)
+		}
+	}
+	if p.alias != "" {
+		fmt.Fprintf(buf, "  |> set(key: \"_field\", value: %s)\n", fluxString(p.alias))
+	}
+	for _, step := range tail {
+		fmt.Fprintf(buf, "  |> %s\n", step)
+	}
+}
+
+// mergePipeline adds p to pipes; fields without aliases that share the same
+// transformations are filtered and transformed together
+func mergePipeline(pipes []*pipeline, p *pipeline) []*pipeline {
+	if p.alias != "" || p.regex != "" || len(p.fields) == 0 {
+		return append(pipes, p)
+	}
+	for _, prev := range pipes {
+		if prev.alias != "" || prev.regex != "" || len(prev.fields) == 0 {
+			continue
+		}
+		if strings.Join(prev.steps, "|") == strings.Join(p.steps, "|") {
+			prev.fields = append(prev.fields, p.fields...)
+			return pipes
+		}
+	}
+	return append(pipes, p)
+}
+
+func (p *pipeline) fieldFilter() string {
+	if p.regex != "" {
+		return "r._field =~ " + p.regex
+	}
+	if len(p.fields) == 0 {
+		return ""
+	}
+	preds := make([]string, len(p.fields))
+	for i, f := range p.fields {
+		preds[i] = "r._field == " + fluxString(f)
+	}
+	if len(preds) == 1 {
+		return preds[0]
+	}
+	return "(" + strings.Join(preds, " or ") + ")"
+}
+
+func (t *translator) sources(sources influxql.Sources) (string, string, error) {
+	if len(sources) == 0 {
+		return "", "", fmt.Errorf("query has no measurement")
+	}
+
+	bucket := ""
+	preds := []string{}
+	for _, src := range sources {
+		m, ok := src.(*influxql.Measurement)
+		if !ok {
+			return "", "", fmt.Errorf("unsupported source %s", src.String())
+		}
+
+		db, rp := m.Database, m.RetentionPolicy
+		if db == "" {
+			db = t.db
+		}
+		if rp == "" {
+			rp = t.rp
+		}
+		if db == "" {
+			return "", "", fmt.Errorf("database of measurement %s is required", m.String())
+		}
+		if rp == "" {
+			rp = "autogen"
+			t.warn(m.String(), "no retention policy given; assuming autogen")
+		}
+
+		b := db + "/" + rp
+		if bucket != "" && b != bucket {
+			return "", "", fmt.Errorf("measurements from different retention policies cannot be translated")
+		}
+		bucket = b
+
+		if m.Regex != nil {
+			preds = append(preds, "r._measurement =~ "+fluxRegex(m.Regex.Val))
+		} else {
+			preds = append(preds, "r._measurement == "+fluxString(m.Name))
+		}
+	}
+
+	if len(preds) == 1 {
+		return bucket, preds[0], nil
+	}
+	return bucket, "(" + strings.Join(preds, " or ") + ")", nil
+}
+
+// condition splits the WHERE clause into the range bounds and the Flux
+// predicates of the remaining tag filters
+func (t *translator) condition(cond influxql.Expr) (start, stop string, preds []string) {
+	// Without a lower bound InfluxQL queries all the data
+	start = "1970-01-01T00:00:00Z"
+	preds = []string{}
+	if cond == nil {
+		return
+	}
+
+	for _, expr := range conjuncts(cond) {
+		if bin, ok := expr.(*influxql.BinaryExpr); ok && (isTimeRef(bin.LHS) || isTimeRef(bin.RHS)) {
+			op, bound := bin.Op, bin.RHS
+			if isTimeRef(bin.RHS) {
+				op, bound = flipOp(bin.Op), bin.LHS
+			}
+			b, ok := timeBound(bound)
+			switch {
+			case !ok:
+				t.warn(expr.String(), "time bound cannot be translated")
+			case op == influxql.GT || op == influxql.GTE:
+				start = b
+			case op == influxql.LT || op == influxql.LTE:
+				stop = b
+			default:
+				t.warn(expr.String(), "only time ranges are supported")
+			}
+			continue
+		}
+
+		if hasTimeRef(expr) {
+			t.warn(expr.String(), "time conditions may only be combined with AND")
+			continue
+		}
+
+		pred, ok := predicate(expr)
+		if !ok {
+			t.warn(expr.String(), "only tag comparisons with strings or regular expressions are supported")
+			continue
+		}
+		preds = append(preds, pred)
+	}
+	return
+}
+
+// conjuncts flattens the top-level AND expressions of a condition
+func conjuncts(expr influxql.Expr) []influxql.Expr {
+	switch e := expr.(type) {
+	case *influxql.ParenExpr:
+		if bin, ok := e.Expr.(*influxql.BinaryExpr); ok && bin.Op == influxql.AND {
+			return conjuncts(bin)
+		}
+	case *influxql.BinaryExpr:
+		if e.Op == influxql.AND {
+			return append(conjuncts(e.LHS), conjuncts(e.RHS)...)
+		}
+	}
+	return []influxql.Expr{expr}
+}
+
+func predicate(expr influxql.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *influxql.ParenExpr:
+		return predicate(e.Expr)
+	case *influxql.BinaryExpr:
+		switch e.Op {
+		case influxql.AND, influxql.OR:
+			lhs, ok := predicate(e.LHS)
+			if !ok {
+				return "", false
+			}
+			rhs, ok := predicate(e.RHS)
+			if !ok {
+				return "", false
+			}
+			op := "and"
+			if e.Op == influxql.OR {
+				op = "or"
+			}
+			return fmt.Sprintf("(%s %s %s)", lhs, op, rhs), true
+		case influxql.EQ, influxql.NEQ:
+			ref, lit := e.LHS, e.RHS
+			if _, ok := ref.(*influxql.VarRef); !ok {
+				ref, lit = lit, ref
+			}
+			v, ok := ref.(*influxql.VarRef)
+			if !ok {
+				return "", false
+			}
+			s, ok := lit.(*influxql.StringLiteral)
+			if !ok {
+				return "", false
+			}
+			op := "=="
+			if e.Op == influxql.NEQ {
+				op = "!="
+			}
+			return fmt.Sprintf("%s %s %s", fluxColumn(v.Val), op, fluxString(s.Val)), true
+		case influxql.EQREGEX, influxql.NEQREGEX:
+			v, ok := e.LHS.(*influxql.VarRef)
+			if !ok {
+				return "", false
+			}
+			re, ok := e.RHS.(*influxql.RegexLiteral)
+			if !ok {
+				return "", false
+			}
+			op := "=~"
+			if e.Op == influxql.NEQREGEX {
+				op = "!~"
+			}
+			return fmt.Sprintf("%s %s %s", fluxColumn(v.Val), op, fluxRegex(re.Val)), true
+		}
+	}
+	return "", false
+}
+
+func isTimeRef(expr influxql.Expr) bool {
+	if p, ok := expr.(*influxql.ParenExpr); ok {
+		return isTimeRef(p.Expr)
+	}
+	ref, ok := expr.(*influxql.VarRef)
+	return ok && strings.ToLower(ref.Val) == "time"
+}
+
+func hasTimeRef(expr influxql.Expr) bool {
+	found := false
+	influxql.WalkFunc(expr, func(n influxql.Node) {
+		if ref, ok := n.(*influxql.VarRef); ok && strings.ToLower(ref.Val) == "time" {
+			found = true
+		}
+	})
+	return found
+}
+
+func flipOp(op influxql.Token) influxql.Token {
+	switch op {
+	case influxql.GT:
+		return influxql.LT
+	case influxql.GTE:
+		return influxql.LTE
+	case influxql.LT:
+		return influxql.GT
+	case influxql.LTE:
+		return influxql.GTE
+	}
+	return op
+}
+
+// timeBound converts the time an InfluxQL time comparison is made against
+// into a Flux time or duration relative to now
+func timeBound(expr influxql.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *influxql.ParenExpr:
+		return timeBound(e.Expr)
+	case *influxql.VarRef:
+		switch e.Val {
+		case dashboardTimeIdent:
+			return DashboardTime, true
+		case upperDashboardTimeIdent:
+			return UpperDashboardTime, true
+		}
+	case *influxql.Call:
+		if strings.ToLower(e.Name) == "now" && len(e.Args) == 0 {
+			return "now()", true
+		}
+	case *influxql.BinaryExpr:
+		call, ok := e.LHS.(*influxql.Call)
+		if !ok || strings.ToLower(call.Name) != "now" {
+			return "", false
+		}
+		dur, ok := e.RHS.(*influxql.DurationLiteral)
+		if !ok {
+			return "", false
+		}
+		switch e.Op {
+		case influxql.SUB:
+			return "-" + fluxDuration(dur.Val), true
+		case influxql.ADD:
+			return fluxDuration(dur.Val), true
+		}
+	case *influxql.StringLiteral:
+		if tm, err := e.ToTimeLiteral(); err == nil {
+			return tm.Val.UTC().Format(time.RFC3339Nano), true
+		}
+	case *influxql.TimeLiteral:
+		return e.Val.UTC().Format(time.RFC3339Nano), true
+	case *influxql.IntegerLiteral:
+		return time.Unix(0, e.Val).UTC().Format(time.RFC3339Nano), true
+	}
+	return "", false
+}
+
+// dimensions returns the window duration and tags of the GROUP BY clause.
+// groupAll is set when grouping by every tag, which is how Flux already
+// groups the series.
+func (t *translator) dimensions(dims influxql.Dimensions) (every string, tags []string, groupAll bool) {
+	tags = []string{}
+	for _, dim := range dims {
+		switch d := dim.Expr.(type) {
+		case *influxql.Call:
+			if strings.ToLower(d.Name) != "time" || len(d.Args) == 0 {
+				t.warn(dim.String(), "unsupported GROUP BY")
+				continue
+			}
+			lit, ok := d.Args[0].(*influxql.DurationLiteral)
+			if !ok {
+				t.warn(dim.String(), "unsupported GROUP BY time interval")
+				continue
+			}
+			if lit.Val == intervalPlaceholder {
+				every = AutoInterval
+			} else {
+				every = fluxDuration(lit.Val)
+			}
+			if len(d.Args) > 1 {
+				t.warn(d.Args[1].String(), "GROUP BY time offsets are not supported")
+			}
+		case *influxql.VarRef:
+			tags = append(tags, d.Val)
+		case *influxql.Wildcard:
+			groupAll = true
+		default:
+			t.warn(dim.String(), "unsupported GROUP BY")
+		}
+	}
+	return
+}
+
+// aggregates maps InfluxQL functions to the Flux function used by
+// aggregateWindow. Selectors do not accept the columns parameter of
+// aggregateWindow and are wrapped into an anonymous function.
+var aggregates = map[string]string{
+	"count":  "count",
+	"mean":   "mean",
+	"sum":    "sum",
+	"spread": "spread",
+	"stddev": "stddev",
+	"median": "(columns, tables=<-) => tables |> median()",
+	"first":  "(columns, tables=<-) => tables |> first()",
+	"last":   "(columns, tables=<-) => tables |> last()",
+	"min":    "(columns, tables=<-) => tables |> min()",
+	"max":    "(columns, tables=<-) => tables |> max()",
+}
+
+func (t *translator) field(f *influxql.Field, every string) (*pipeline, bool) {
+	p := &pipeline{
+		alias: f.Alias,
+		name:  f.Name(),
+	}
+	if !t.expr(f.Expr, every, p) {
+		return nil, false
+	}
+	return p, true
+}
+
+// expr adds the transformations of a field expression to the pipeline
+func (t *translator) expr(expr influxql.Expr, every string, p *pipeline) bool {
+	switch e := expr.(type) {
+	case *influxql.ParenExpr:
+		return t.expr(e.Expr, every, p)
+	case *influxql.VarRef:
+		p.fields = append(p.fields, e.Val)
+		return true
+	case *influxql.Wildcard:
+		return true
+	case *influxql.RegexLiteral:
+		p.regex = fluxRegex(e.Val)
+		return true
+	case *influxql.Call:
+		return t.call(e, every, p)
+	}
+	t.warn(expr.String(), "only fields and functions of fields can be translated")
+	return false
+}
+
+func (t *translator) call(c *influxql.Call, every string, p *pipeline) bool {
+	name := strings.ToLower(c.Name)
+	if len(c.Args) == 0 {
+		t.warn(c.String(), "function requires arguments")
+		return false
+	}
+
+	switch name {
+	case "derivative", "non_negative_derivative", "difference", "non_negative_difference":
+		if !t.expr(c.Args[0], every, p) {
+			return false
+		}
+		switch name {
+		case "difference":
+			p.steps = append(p.steps, "difference()")
+		case "non_negative_difference":
+			p.steps = append(p.steps, "difference(nonNegative: true)")
+		default:
+			unit := "1s"
+			if len(c.Args) > 1 {
+				lit, ok := c.Args[1].(*influxql.DurationLiteral)
+				if !ok {
+					t.warn(c.String(), "derivative unit must be a duration")
+					return false
+				}
+				unit = fluxDuration(lit.Val)
+			}
+			p.steps = append(p.steps, fmt.Sprintf("derivative(unit: %s, nonNegative: %t)", unit, name == "non_negative_derivative"))
+		}
+		return true
+	}
+
+	if !isFieldArg(c.Args[0]) {
+		t.warn(c.String(), "only functions of fields can be translated")
+		return false
+	}
+
+	var fn string
+	switch name {
+	case "percentile":
+		q, ok := numberArg(c, 1)
+		if !ok {
+			t.warn(c.String(), "percentile requires a numeric argument")
+			return false
+		}
+		quantile := fmt.Sprintf("quantile(q: %s, method: \"exact_selector\")", fluxFloat(q/100))
+		if every == "" {
+			fn = quantile
+		} else {
+			fn = "(columns, tables=<-) => tables |> " + quantile
+		}
+	case "top", "bottom":
+		n, ok := numberArg(c, len(c.Args)-1)
+		if !ok || len(c.Args) != 2 {
+			t.warn(c.String(), "only %s of a single field by count can be translated", name)
+			return false
+		}
+		if every != "" {
+			t.warn(c.String(), "%s cannot be combined with GROUP BY time", name)
+			return false
+		}
+		fn = fmt.Sprintf("%s(n: %d)", name, int64(n))
+	default:
+		agg, ok := aggregates[name]
+		if !ok {
+			t.warn(c.String(), "function %s has no Flux equivalent", c.Name)
+			return false
+		}
+		fn = agg
+		if every == "" {
+			fn = name + "()"
+		}
+	}
+
+	if !t.expr(c.Args[0], every, p) {
+		return false
+	}
+	if every == "" {
+		p.steps = append(p.steps, fn)
+		return true
+	}
+
+	p.window = true
+	p.aggregate = name
+	p.steps = append(p.steps, fmt.Sprintf("aggregateWindow(every: %s, fn: %s)", every, fn))
+	return true
+}
+
+// fill translates the FILL clause of windowed aggregates
+func (t *translator) fill(stmt *influxql.SelectStatement, p *pipeline) []string {
+	last := len(p.steps) - 1
+	for i, step := range p.steps {
+		if strings.HasPrefix(step, "aggregateWindow(") {
+			last = i
+		}
+	}
+
+	switch stmt.Fill {
+	case influxql.NullFill:
+		return nil
+	case influxql.NoFill:
+		window := p.steps[last]
+		p.steps[last] = strings.TrimSuffix(window, ")") + ", createEmpty: false)"
+		return nil
+	case influxql.PreviousFill:
+		return []string{"fill(usePrevious: true)"}
+	case influxql.NumberFill:
+		var v float64
+		switch n := stmt.FillValue.(type) {
+		case int64:
+			v = float64(n)
+		case float64:
+			v = n
+		}
+		// Flux fills with a value of the same type as the column; counts
+		// are integers while every other aggregate is a float
+		if p.aggregate == "count" && v == float64(int64(v)) {
+			return []string{fmt.Sprintf("fill(value: %d)", int64(v))}
+		}
+		return []string{fmt.Sprintf("fill(value: %s)", fluxFloat(v))}
+	}
+	t.warn("fill("+fillString(stmt)+")", "fill option cannot be translated")
+	return nil
+}
+
+func fillString(stmt *influxql.SelectStatement) string {
+	switch stmt.Fill {
+	case influxql.LinearFill:
+		return "linear"
+	case influxql.NumberFill:
+		return fmt.Sprint(stmt.FillValue)
+	}
+	return ""
+}
+
+// limits translates the ORDER BY and LIMIT clauses which apply to every
+// series of the result
+func (t *translator) limits(stmt *influxql.SelectStatement) []string {
+	steps := []string{}
+	for _, sf := range stmt.SortFields {
+		if sf.Name != "" && strings.ToLower(sf.Name) != "time" {
+			t.warn(sf.String(), "only ordering by time is supported")
+			continue
+		}
+		if !sf.Ascending {
+			steps = append(steps, `sort(columns: ["_time"], desc: true)`)
+		}
+	}
+
+	if stmt.Limit > 0 {
+		if stmt.Offset > 0 {
+			steps = append(steps, fmt.Sprintf("limit(n: %d, offset: %d)", stmt.Limit, stmt.Offset))
+		} else {
+			steps = append(steps, fmt.Sprintf("limit(n: %d)", stmt.Limit))
+		}
+	} else if stmt.Offset > 0 {
+		t.warn(fmt.Sprintf("OFFSET %d", stmt.Offset), "OFFSET without LIMIT is not supported")
+	}
+
+	if stmt.SLimit > 0 {
+		t.warn(fmt.Sprintf("SLIMIT %d", stmt.SLimit), "limiting the number of series is not supported")
+	}
+	if stmt.SOffset > 0 {
+		t.warn(fmt.Sprintf("SOFFSET %d", stmt.SOffset), "offsetting series is not supported")
+	}
+	return steps
+}
+
+func isFieldArg(expr influxql.Expr) bool {
+	switch expr.(type) {
+	case *influxql.VarRef, *influxql.Wildcard, *influxql.RegexLiteral:
+		return true
+	}
+	return false
+}
+
+func numberArg(c *influxql.Call, i int) (float64, bool) {
+	if i >= len(c.Args) {
+		return 0, false
+	}
+	switch lit := c.Args[i].(type) {
+	case *influxql.IntegerLiteral:
+		return float64(lit.Val), true
+	case *influxql.NumberLiteral:
+		return lit.Val, true
+	}
+	return 0, false
+}
+
+var fluxIdentRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// fluxColumn references a column of the record r of a filter function
+func fluxColumn(name string) string {
+	if fluxIdentRe.MatchString(name) {
+		return "r." + name
+	}
+	return "r[" + fluxString(name) + "]"
+}
+
+func fluxString(s string) string {
+	return `"` + fluxStringEscaper.Replace(s) + `"`
+}
+
+var fluxStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func fluxRegex(re *regexp.Regexp) string {
+	return "/" + strings.Replace(re.String(), "/", `\/`, -1) + "/"
+}
+
+func fluxFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// fluxDuration formats d with the largest Flux duration unit that
+// represents it exactly
+func fluxDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	units := []struct {
+		unit string
+		dur  time.Duration
+	}{
+		{"w", 7 * 24 * time.Hour},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+	}
+	for _, u := range units {
+		if d%u.dur == 0 {
+			return fmt.Sprintf("%d%s", d/u.dur, u.unit)
+		}
+	}
+	return fmt.Sprintf("%dns", d)
+}
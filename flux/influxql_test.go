@@ -0,0 +1,131 @@
+package flux
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+)
+
+func TestTranslateInfluxQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		influxQL string
+		db       string
+		rp       string
+		want     string
+		warnings []Warning
+		wantErr  bool
+	}{
+		{
+			name:     "dashboard query with tag filter and group by",
+			influxQL: `SELECT mean("usage_idle") AS "mean_usage_idle" FROM "telegraf"."autogen"."cpu" WHERE time > :dashboardTime: AND "host"='server01' GROUP BY time(:interval:), "cpu" FILL(null)`,
+			want: `from(bucket: "telegraf/autogen")
+  |> range(start: dashboardTime)
+  |> filter(fn: (r) => r._measurement == "cpu" and r.host == "server01" and r._field == "usage_idle")
+  |> group(columns: ["_measurement", "_field", "cpu"])
+  |> aggregateWindow(every: autoInterval, fn: mean)
+  |> set(key: "_field", value: "mean_usage_idle")
+`,
+		},
+		{
+			name:     "raw fields with relative and absolute bounds",
+			influxQL: `SELECT "used", "free" FROM "mem" WHERE time > now() - 1h AND time < '2018-09-01T00:00:00Z' AND ("host" = 'a' OR "host" =~ /^b/) ORDER BY time DESC LIMIT 10`,
+			db:       "telegraf",
+			rp:       "autogen",
+			want: `from(bucket: "telegraf/autogen")
+  |> range(start: -1h, stop: 2018-09-01T00:00:00Z)
+  |> filter(fn: (r) => r._measurement == "mem" and (r.host == "a" or r.host =~ /^b/) and (r._field == "used" or r._field == "free"))
+  |> group(columns: ["_measurement", "_field"])
+  |> sort(columns: ["_time"], desc: true)
+  |> limit(n: 10)
+`,
+		},
+		{
+			name:     "several aggregates with fill and derivative",
+			influxQL: `SELECT max("n"), non_negative_derivative(mean("bytes"), 1s) FROM "telegraf"."autogen"."net" WHERE time > :dashboardTime: GROUP BY time(5m), * FILL(0)`,
+			want: `data = from(bucket: "telegraf/autogen")
+  |> range(start: dashboardTime)
+  |> filter(fn: (r) => r._measurement == "net")
+
+data
+  |> filter(fn: (r) => r._field == "n")
+  |> aggregateWindow(every: 5m, fn: (columns, tables=<-) => tables |> max())
+  |> fill(value: 0.0)
+  |> yield(name: "max")
+
+data
+  |> filter(fn: (r) => r._field == "bytes")
+  |> aggregateWindow(every: 5m, fn: mean)
+  |> derivative(unit: 1s, nonNegative: true)
+  |> fill(value: 0.0)
+  |> yield(name: "non_negative_derivative")
+`,
+		},
+		{
+			name:     "untranslatable constructs are reported",
+			influxQL: `SELECT mean("a") + mean("b"), count("c") FROM "db"."rp"."m" WHERE time > now() - 5m AND "value" > 3 GROUP BY time(1m) FILL(linear) SLIMIT 2`,
+			want: `from(bucket: "db/rp")
+  |> range(start: -5m)
+  |> filter(fn: (r) => r._measurement == "m" and r._field == "c")
+  |> group(columns: ["_measurement", "_field"])
+  |> aggregateWindow(every: 1m, fn: count)
+`,
+			warnings: []Warning{
+				{
+					Construct: `value > 3`,
+					Message:   "only tag comparisons with strings or regular expressions are supported",
+				},
+				{
+					Construct: `mean(a) + mean(b)`,
+					Message:   "only fields and functions of fields can be translated",
+				},
+				{
+					Construct: "SLIMIT 2",
+					Message:   "limiting the number of series is not supported",
+				},
+				{
+					Construct: "fill(linear)",
+					Message:   "fill option cannot be translated",
+				},
+			},
+		},
+		{
+			name:     "database is required",
+			influxQL: `SELECT "a" FROM "m"`,
+			wantErr:  true,
+		},
+		{
+			name:     "not a select statement",
+			influxQL: `SHOW DATABASES`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TranslateInfluxQL(tt.influxQL, tt.db, tt.rp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TranslateInfluxQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Flux != tt.want {
+				t.Errorf("TranslateInfluxQL() =\n%s\nwant\n%s", got.Flux, tt.want)
+			}
+			if !reflect.DeepEqual(got.Warnings, tt.warnings) {
+				t.Errorf("TranslateInfluxQL() warnings = %#v, want %#v", got.Warnings, tt.warnings)
+			}
+
+			// The translation must be valid Flux once the dashboard
+			// template variables are defined
+			script := "dashboardTime = -1h\nupperDashboardTime = now()\nautoInterval = 1m\n" + got.Flux
+			if _, err := flux.Compile(context.Background(), script, time.Now()); err != nil {
+				t.Errorf("TranslateInfluxQL() produced invalid Flux: %v\n%s", err, got.Flux)
+			}
+		})
+	}
+}
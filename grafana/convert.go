@@ -0,0 +1,771 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/chronograf"
+)
+
+const (
+	// gridColumns is the number of columns of the grid of Grafana
+	gridColumns = 24
+	// rowPixels is the height of a row of the grid of Grafana
+	rowPixels = 30
+	// defaultRowHeight is the height of rows of dashboards from before
+	// Grafana 5 without a height
+	defaultRowHeight = "250px"
+	// baseColorValue is the value of the base color of chronograf thresholds
+	baseColorValue = "-999999999999999999"
+)
+
+// Options of the conversion of a dashboard
+type Options struct {
+	// Source is the link of the source the queries of the dashboard run against
+	Source string
+	// Database qualifies the measurements of queries that do not name a
+	// database and is the database of the meta queries of variables
+	Database string
+}
+
+// Unconverted is a part of a Grafana dashboard that could not be converted,
+// either at all or only partially
+type Unconverted struct {
+	Panel    string `json:"panel,omitempty"`    // Panel is the title of the panel
+	Target   string `json:"target,omitempty"`   // Target is the refId of the query of the panel
+	Variable string `json:"variable,omitempty"` // Variable is the name of the template variable
+	Reason   string `json:"reason"`
+}
+
+// Report lists what could not be converted
+type Report struct {
+	Unconverted []Unconverted `json:"unconverted"`
+}
+
+// Parse decodes a Grafana dashboard. The dashboard is either on its own, as
+// exported from the Grafana UI, or under "dashboard", as returned by the
+// Grafana API.
+func Parse(b []byte) (Dashboard, error) {
+	var wrapped struct {
+		Dashboard *Dashboard `json:"dashboard"`
+	}
+	if err := json.Unmarshal(b, &wrapped); err != nil {
+		return Dashboard{}, err
+	}
+	if wrapped.Dashboard != nil {
+		return *wrapped.Dashboard, nil
+	}
+	var d Dashboard
+	err := json.Unmarshal(b, &d)
+	return d, err
+}
+
+// Convert maps the graph, singlestat, table and text panels of a Grafana
+// dashboard to cells, their InfluxQL targets to queries and its template
+// variables to templates. Everything else is reported as unconverted.
+// Templates are returned without IDs.
+func Convert(d Dashboard, opts Options) (chronograf.Dashboard, Report) {
+	c := &converter{
+		opts:   opts,
+		vars:   map[string]bool{},
+		inputs: map[string]string{},
+		report: Report{
+			Unconverted: []Unconverted{},
+		},
+	}
+	for _, in := range d.Inputs {
+		c.inputs[in.Name] = in.PluginID
+	}
+	for _, v := range d.Templating.List {
+		if c.supportedVariable(v) {
+			c.vars[v.Name] = true
+		}
+	}
+
+	dash := chronograf.Dashboard{
+		Name:        d.Title,
+		Description: d.Description,
+		Tags:        d.Tags,
+		Cells:       []chronograf.DashboardCell{},
+		Templates:   []chronograf.Template{},
+	}
+	for _, v := range d.Templating.List {
+		if t, ok := c.template(v); ok {
+			dash.Templates = append(dash.Templates, t)
+		}
+	}
+	for _, p := range panels(d) {
+		if cell, ok := c.cell(p); ok {
+			dash.Cells = append(dash.Cells, cell)
+		}
+	}
+	return dash, c.report
+}
+
+type converter struct {
+	opts   Options
+	vars   map[string]bool   // vars are the names of the variables converted to templates
+	inputs map[string]string // inputs are the types of the datasources exported with the dashboard by name
+	report Report
+}
+
+func (c *converter) unconverted(u Unconverted) {
+	c.report.Unconverted = append(c.report.Unconverted, u)
+}
+
+// mixedDatasource is the datasource of panels whose targets each have their
+// own datasource
+const mixedDatasource = "-- Mixed --"
+
+// inputRef matches the references to the datasources exported with a
+// dashboard, e.g. ${DS_INFLUXDB}
+var inputRef = regexp.MustCompile(`^\$\{(\w+)\}$`)
+
+// datasourceType returns the type of a datasource, e.g. influxdb. It is
+// empty when the type is unknown, as for the default datasource and for
+// datasources referenced by a name that is not exported with the dashboard.
+func (c *converter) datasourceType(ds Datasource) string {
+	switch {
+	case ds.Name == "-- Grafana --", ds.Type == "datasource" && ds.UID == "grafana":
+		return "grafana"
+	case ds.Name == "-- Dashboard --", ds.Type == "datasource" && ds.UID == "-- Dashboard --":
+		return "dashboard"
+	case ds.Type != "":
+		return ds.Type
+	}
+	if m := inputRef.FindStringSubmatch(ds.Name); m != nil {
+		return c.inputs[m[1]]
+	}
+	return ""
+}
+
+// influxQL returns false for datasources known not to be InfluxDB. Those of
+// unknown type are assumed to be the InfluxDB the dashboard is imported for.
+func (c *converter) influxQL(ds Datasource) bool {
+	typ := c.datasourceType(ds)
+	return typ == "" || typ == "influxdb"
+}
+
+// targetDatasource returns the datasource of a target, which is the
+// datasource of its panel unless set on the target or the panel is mixed
+func targetDatasource(p Panel, t Target) Datasource {
+	if t.Datasource != (Datasource{}) {
+		return t.Datasource
+	}
+	if p.Datasource.Name == mixedDatasource || p.Datasource.UID == mixedDatasource {
+		return Datasource{}
+	}
+	return p.Datasource
+}
+
+// panels positions the panels of rows from before Grafana 5 on the grid from
+// their span and the height of their row, and unfolds collapsed rows
+func panels(d Dashboard) []Panel {
+	ps := []Panel{}
+	for _, p := range d.Panels {
+		if p.Type == "row" {
+			ps = append(ps, p.Panels...)
+			continue
+		}
+		ps = append(ps, p)
+	}
+
+	y := 0
+	for _, r := range d.Rows {
+		h := rowHeight(r.Height)
+		x := 0
+		for _, p := range r.Panels {
+			w := int(p.Span * gridColumns / 12)
+			if w <= 0 || w > gridColumns {
+				w = gridColumns
+			}
+			if x+w > gridColumns {
+				x = 0
+				y += h
+			}
+			p.GridPos = &GridPos{X: x, Y: y, W: w, H: h}
+			x += w
+			ps = append(ps, p)
+		}
+		y += h
+	}
+	return ps
+}
+
+// rowHeight converts the height of a row in pixels to rows of the grid
+func rowHeight(height Value) int {
+	if height == "" {
+		height = defaultRowHeight
+	}
+	px, err := strconv.ParseFloat(strings.TrimSuffix(string(height), "px"), 64)
+	if err != nil || px <= 0 {
+		px, _ = strconv.ParseFloat(strings.TrimSuffix(defaultRowHeight, "px"), 64)
+	}
+	return int(math.Max(1, math.Round(px/rowPixels)))
+}
+
+// position scales a position on the 24 column grid of Grafana, with rows of
+// 30px, to the 12 column grid of chronograf, with rows about three times as
+// high. Edges are scaled rather than sizes so that neighbouring panels stay
+// next to each other.
+func position(g *GridPos) (x, y, w, h int32) {
+	if g == nil {
+		return 0, 0, 0, 0
+	}
+	left, right := g.X/2, (g.X+g.W+1)/2
+	top, bottom := (g.Y+1)/3, (g.Y+g.H+1)/3
+	return int32(left), int32(top), int32(max(1, right-left)), int32(max(1, bottom-top))
+}
+
+func panelName(p Panel) string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return fmt.Sprintf("panel %d", p.ID)
+}
+
+func (c *converter) cell(p Panel) (chronograf.DashboardCell, bool) {
+	cell := chronograf.DashboardCell{
+		Name:    p.Title,
+		Queries: []chronograf.DashboardQuery{},
+		Note:    p.Description,
+	}
+	cell.X, cell.Y, cell.W, cell.H = position(p.GridPos)
+
+	switch p.Type {
+	case "graph", "timeseries":
+		cell.Type = graphType(p)
+		cell.Axes = yAxes(p)
+	case "singlestat", "stat":
+		cell.Type = "single-stat"
+		if p.Gauge.Show {
+			cell.Type = "gauge"
+		}
+		if p.Prefix != "" || p.Postfix != "" {
+			cell.Axes = map[string]chronograf.Axis{
+				"y": {
+					Bounds: []string{"", ""},
+					Prefix: p.Prefix,
+					Suffix: p.Postfix,
+				},
+			}
+		}
+		if digits, err := strconv.Atoi(string(p.Decimals)); err == nil {
+			cell.DecimalPlaces = chronograf.DecimalPlaces{
+				IsEnforced: true,
+				Digits:     int32(digits),
+			}
+		}
+		cell.CellColors = c.thresholdColors(p)
+	case "gauge":
+		cell.Type = "gauge"
+	case "table", "table-old":
+		cell.Type = "table"
+	case "text":
+		cell.Type = "note"
+		cell.Note = p.Content
+		return cell, true
+	default:
+		c.unconverted(Unconverted{
+			Panel:  panelName(p),
+			Reason: fmt.Sprintf("%s panels are not supported", p.Type),
+		})
+		return cell, false
+	}
+
+	for _, t := range p.Targets {
+		if q, ok := c.query(p, t); ok {
+			cell.Queries = append(cell.Queries, q)
+		}
+	}
+	return cell, true
+}
+
+func graphType(p Panel) string {
+	lines := p.Lines == nil || *p.Lines
+	switch {
+	case p.Bars && !lines:
+		return "bar"
+	case p.Stack:
+		return "line-stacked"
+	case p.SteppedLine:
+		return "line-stepplot"
+	}
+	return "line"
+}
+
+// yAxes converts the left y axis of a graph
+func yAxes(p Panel) map[string]chronograf.Axis {
+	if len(p.Yaxes) == 0 {
+		return nil
+	}
+	y := p.Yaxes[0]
+	axis := chronograf.Axis{
+		Bounds: []string{string(y.Min), string(y.Max)},
+		Label:  y.Label,
+		Base:   "10",
+		Scale:  "linear",
+	}
+	if y.LogBase > 1 {
+		axis.Scale = "log"
+	}
+	return map[string]chronograf.Axis{
+		"y": axis,
+	}
+}
+
+// thresholdColors converts the thresholds of a singlestat colouring its value
+// or background to a base color and a color per threshold
+func (c *converter) thresholdColors(p Panel) []chronograf.CellColor {
+	thresholds := []string{}
+	for _, t := range strings.Split(p.Thresholds, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			thresholds = append(thresholds, t)
+		}
+	}
+	if len(thresholds) == 0 || !(p.ColorValue || p.ColorBackground) {
+		return nil
+	}
+	if len(p.Colors) < len(thresholds)+1 {
+		c.unconverted(Unconverted{
+			Panel:  panelName(p),
+			Reason: "thresholds without a color are not supported",
+		})
+		return nil
+	}
+
+	typ := "text"
+	if p.ColorBackground {
+		typ = "background"
+	}
+	values := append([]string{baseColorValue}, thresholds...)
+	colors := make([]chronograf.CellColor, 0, len(values))
+	for i, v := range values {
+		hex, err := hexColor(p.Colors[i])
+		if err != nil {
+			c.unconverted(Unconverted{
+				Panel:  panelName(p),
+				Reason: err.Error(),
+			})
+			return nil
+		}
+		id := fmt.Sprintf("%d", i)
+		if i == 0 {
+			id = "base"
+		}
+		colors = append(colors, chronograf.CellColor{
+			ID:    id,
+			Type:  typ,
+			Hex:   hex,
+			Name:  hex,
+			Value: v,
+		})
+	}
+	return colors
+}
+
+var (
+	hexPattern  = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	rgbaPattern = regexp.MustCompile(`^rgba?\(\s*(\d+)\s*,\s*(\d+)\s*,\s*(\d+)\s*(,\s*[0-9.]+\s*)?\)$`)
+)
+
+// hexColor converts #rrggbb and rgb(a) colors to #rrggbb
+func hexColor(color string) (string, error) {
+	color = strings.TrimSpace(color)
+	if hexPattern.MatchString(color) {
+		return strings.ToLower(color), nil
+	}
+	m := rgbaPattern.FindStringSubmatch(color)
+	if m == nil {
+		return "", fmt.Errorf("color %q is not supported", color)
+	}
+	hex := "#"
+	for _, c := range m[1:4] {
+		v, err := strconv.Atoi(c)
+		if err != nil || v > 255 {
+			return "", fmt.Errorf("color %q is not supported", color)
+		}
+		hex += fmt.Sprintf("%02x", v)
+	}
+	return hex, nil
+}
+
+func (c *converter) query(p Panel, t Target) (chronograf.DashboardQuery, bool) {
+	unconverted := func(reason string) {
+		c.unconverted(Unconverted{
+			Panel:  panelName(p),
+			Target: t.RefID,
+			Reason: reason,
+		})
+	}
+	if t.Hide {
+		unconverted("hidden queries are not converted")
+		return chronograf.DashboardQuery{}, false
+	}
+	if ds := targetDatasource(p, t); !c.influxQL(ds) {
+		unconverted(fmt.Sprintf("queries of %s datasources are not supported", c.datasourceType(ds)))
+		return chronograf.DashboardQuery{}, false
+	}
+
+	var command string
+	switch {
+	case t.RawQuery || (t.Measurement == "" && t.Query != ""):
+		command = qualify(t.Query, c.opts.Database)
+	case t.Measurement != "":
+		var err error
+		if command, err = c.build(t); err != nil {
+			unconverted(err.Error())
+			return chronograf.DashboardQuery{}, false
+		}
+	default:
+		unconverted("only InfluxQL queries are supported")
+		return chronograf.DashboardQuery{}, false
+	}
+	if t.Alias != "" {
+		unconverted(fmt.Sprintf("series alias %q is not supported", t.Alias))
+	}
+
+	command, unknown := c.translate(command)
+	for _, name := range unknown {
+		unconverted(fmt.Sprintf("variable $%s is not a template", name))
+	}
+	return chronograf.DashboardQuery{
+		Command: command,
+		Source:  c.opts.Source,
+		Type:    "influxql",
+	}, true
+}
+
+// variableRef matches the ways Grafana references variables in queries:
+// ${name}, ${name:format}, [[name]] and $name
+var variableRef = regexp.MustCompile(`\$\{(\w+)(?::[^}]*)?\}|\[\[(\w+)(?::[^\]]*)?\]\]|\$(\w+)`)
+
+// builtins are the variables Grafana provides to InfluxDB queries
+var builtins = map[string]string{
+	"timeFilter": "time > :dashboardTime: AND time < :upperDashboardTime:",
+	"__interval": ":interval:",
+	"interval":   ":interval:",
+}
+
+// translate replaces the variables of a Grafana query with chronograf
+// template variables and returns the names of the variables that are
+// neither templates nor built in
+func (c *converter) translate(query string) (string, []string) {
+	unknown := []string{}
+	query = variableRef.ReplaceAllStringFunc(query, func(ref string) string {
+		m := variableRef.FindStringSubmatch(ref)
+		name := m[1] + m[2] + m[3]
+		if c.vars[name] {
+			return ":" + name + ":"
+		}
+		if b, ok := builtins[name]; ok {
+			return b
+		}
+		unknown = append(unknown, name)
+		return ref
+	})
+	return query, unknown
+}
+
+// unqualifiedFrom matches measurements of FROM clauses without a database
+// or retention policy
+var unqualifiedFrom = regexp.MustCompile(`(?i)(\bFROM\s+)("(?:[^"\\]|\\.)*"|[A-Za-z_]\w*)([^\w."]|$)`)
+
+// qualify prefixes the measurements of a raw query that do not name a
+// database with the database and its default retention policy
+func qualify(query, db string) string {
+	if db == "" {
+		return query
+	}
+	return unqualifiedFrom.ReplaceAllString(query, "${1}"+quoteIdent(db)+"..${2}${3}")
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+func quoteString(s string) string {
+	return `'` + strings.Replace(s, `'`, `\'`, -1) + `'`
+}
+
+// build writes the InfluxQL query of a target edited with the query builder
+// of Grafana
+func (c *converter) build(t Target) (string, error) {
+	fields := []string{}
+	for _, parts := range t.Select {
+		field, err := selectField(parts)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		fields = append(fields, `mean("value")`)
+	}
+
+	measurement := t.Measurement
+	if !strings.HasPrefix(measurement, "/") {
+		measurement = quoteIdent(measurement)
+	}
+	from := measurement
+	switch {
+	case t.Policy != "" && t.Policy != "default":
+		from = quoteIdent(t.Policy) + "." + measurement
+		if c.opts.Database != "" {
+			from = quoteIdent(c.opts.Database) + "." + from
+		}
+	case c.opts.Database != "":
+		from = quoteIdent(c.opts.Database) + ".." + measurement
+	}
+
+	where := "$timeFilter"
+	if len(t.Tags) > 0 {
+		conditions := ""
+		for i, tag := range t.Tags {
+			if i > 0 {
+				condition := strings.ToUpper(tag.Condition)
+				if condition == "" {
+					condition = "AND"
+				}
+				conditions += " " + condition + " "
+			}
+			conditions += tagCondition(tag)
+		}
+		where = "(" + conditions + ") AND " + where
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", strings.Join(fields, ", "), from, where)
+
+	groupBy, fill := []string{}, ""
+	for _, part := range t.GroupBy {
+		param := ""
+		if len(part.Params) > 0 {
+			param = string(part.Params[0])
+		}
+		switch part.Type {
+		case "time":
+			if param == "" || param == "auto" {
+				param = "$__interval"
+			}
+			groupBy = append(groupBy, "time("+param+")")
+		case "tag":
+			groupBy = append(groupBy, quoteIdent(param))
+		case "fill":
+			fill = " fill(" + param + ")"
+		default:
+			return "", fmt.Errorf("group by %s is not supported", part.Type)
+		}
+	}
+	if len(groupBy) > 0 {
+		query += " GROUP BY " + strings.Join(groupBy, ", ")
+	}
+	return query + fill, nil
+}
+
+func tagCondition(tag Tag) string {
+	op := tag.Operator
+	if op == "" {
+		op = "="
+		if strings.HasPrefix(tag.Value, "/") {
+			op = "=~"
+		}
+	}
+	value := tag.Value
+	switch {
+	case op == "=~" || op == "!~" || op == "<" || op == ">":
+	case variableRef.MatchString(value) && variableRef.FindString(value) == value:
+	default:
+		value = quoteString(value)
+	}
+	return quoteIdent(tag.Key) + " " + op + " " + value
+}
+
+// selectField writes a field of a select from the field, functions, math
+// and alias of the select
+func selectField(parts []Part) (string, error) {
+	expr, alias := "", ""
+	for _, part := range parts {
+		params := make([]string, len(part.Params))
+		for i, p := range part.Params {
+			params[i] = string(p)
+		}
+		param := ""
+		if len(params) > 0 {
+			param = params[0]
+		}
+
+		switch part.Type {
+		case "field":
+			expr = param
+			if expr != "*" {
+				expr = quoteIdent(expr)
+			}
+		case "count", "distinct", "integral", "mean", "median", "mode", "sum", "spread", "stddev",
+			"first", "last", "max", "min", "percentile", "top", "bottom", "sample",
+			"derivative", "non_negative_derivative", "difference", "non_negative_difference",
+			"moving_average", "cumulative_sum", "elapsed", "holt_winters", "holt_winters_with_fit":
+			expr = part.Type + "(" + strings.Join(append([]string{expr}, params...), ", ") + ")"
+		case "math":
+			expr += " " + param
+		case "alias":
+			alias = param
+		default:
+			return "", fmt.Errorf("select %s is not supported", part.Type)
+		}
+	}
+	if alias != "" {
+		expr += " AS " + quoteIdent(alias)
+	}
+	return expr, nil
+}
+
+func (c *converter) supportedVariable(v Variable) bool {
+	switch v.Type {
+	case "custom", "interval", "constant", "textbox":
+		return true
+	case "query":
+		return c.influxQL(v.Datasource)
+	}
+	return false
+}
+
+func (c *converter) template(v Variable) (chronograf.Template, bool) {
+	t := chronograf.Template{
+		TemplateVar: chronograf.TemplateVar{
+			Var:    ":" + v.Name + ":",
+			Values: []chronograf.TemplateValue{},
+		},
+		Label: v.Label,
+	}
+	unconverted := func(reason string) {
+		c.unconverted(Unconverted{
+			Variable: v.Name,
+			Reason:   reason,
+		})
+	}
+
+	switch v.Type {
+	case "custom", "interval":
+		t.Type = "csv"
+		for _, value := range variableValues(v) {
+			t.Values = append(t.Values, chronograf.TemplateValue{
+				Value: value,
+				Type:  "csv",
+			})
+		}
+	case "constant":
+		t.Type = "constant"
+		t.Values = append(t.Values, chronograf.TemplateValue{
+			Value: string(v.Query),
+			Type:  "constant",
+		})
+	case "textbox":
+		value := v.Current.Value
+		if value == "" {
+			value = v.Query
+		}
+		t.Type = "text"
+		t.Values = append(t.Values, chronograf.TemplateValue{
+			Value: string(value),
+			Type:  "constant",
+		})
+	case "query":
+		if !c.influxQL(v.Datasource) {
+			unconverted(fmt.Sprintf("queries of %s datasources are not supported", c.datasourceType(v.Datasource)))
+			return t, false
+		}
+		command, unknown := c.translate(string(v.Query))
+		for _, name := range unknown {
+			unconverted(fmt.Sprintf("variable $%s is not a template", name))
+		}
+		t.Type, t.Query = metaQuery(command)
+		t.Query.DB = c.opts.Database
+	default:
+		unconverted(fmt.Sprintf("%s variables are not supported", v.Type))
+		return t, false
+	}
+
+	if v.Multi || v.IncludeAll {
+		unconverted("only one value of a template can be selected")
+	}
+	selectValue(t.Values, string(v.Current.Value))
+	return t, true
+}
+
+// variableValues lists the values of a custom or interval variable, leaving
+// out the values Grafana computes such as All and auto
+func variableValues(v Variable) []string {
+	values := []string{}
+	if len(v.Options) > 0 {
+		for _, o := range v.Options {
+			if !strings.HasPrefix(string(o.Value), "$__") {
+				values = append(values, string(o.Value))
+			}
+		}
+		return values
+	}
+	for _, value := range strings.Split(string(v.Query), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func selectValue(values []chronograf.TemplateValue, selected string) {
+	idx := 0
+	for i, v := range values {
+		if v.Value == selected {
+			idx = i
+			break
+		}
+	}
+	for i := range values {
+		values[i].Selected = i == idx
+	}
+}
+
+var (
+	showTagValues   = regexp.MustCompile(`(?i)^\s*SHOW\s+TAG\s+VALUES\b(?:.*?\bFROM\s+("(?:[^"\\]|\\.)*"|\S+))?.*?\bWITH\s+KEY\s*=\s*("(?:[^"\\]|\\.)*"|\w+)`)
+	showKeys        = regexp.MustCompile(`(?i)^\s*SHOW\s+(TAG|FIELD)\s+KEYS\b(?:.*?\bFROM\s+("(?:[^"\\]|\\.)*"|\S+))?`)
+	showMeasurement = regexp.MustCompile(`(?i)^\s*SHOW\s+MEASUREMENTS\b`)
+	showDatabases   = regexp.MustCompile(`(?i)^\s*SHOW\s+DATABASES\b`)
+)
+
+// metaQuery determines the type of template of the meta query of a
+// variable. The query is kept as is, so its conditions still apply.
+func metaQuery(command string) (string, *chronograf.TemplateQuery) {
+	q := &chronograf.TemplateQuery{
+		Command: command,
+	}
+	if m := showTagValues.FindStringSubmatch(command); m != nil {
+		q.Measurement = unquoteIdent(m[1])
+		q.TagKey = unquoteIdent(m[2])
+		return "tagValues", q
+	}
+	if m := showKeys.FindStringSubmatch(command); m != nil {
+		q.Measurement = unquoteIdent(m[2])
+		if strings.ToUpper(m[1]) == "TAG" {
+			return "tagKeys", q
+		}
+		return "fieldKeys", q
+	}
+	if showMeasurement.MatchString(command) {
+		return "measurements", q
+	}
+	if showDatabases.MatchString(command) {
+		return "databases", q
+	}
+	return "influxql", q
+}
+
+func unquoteIdent(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return strings.Replace(s[1:len(s)-1], `\"`, `"`, -1)
+	}
+	return s
+}
@@ -0,0 +1,346 @@
+package grafana
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+)
+
+const dashboardJSON = `{
+  "dashboard": {
+    "title": "Hosts",
+    "tags": ["telegraf"],
+    "panels": [
+      {
+        "id": 1,
+        "type": "graph",
+        "title": "CPU",
+        "gridPos": {"x": 0, "y": 0, "w": 12, "h": 9},
+        "stack": true,
+        "yaxes": [{"label": "%", "min": 0, "max": "100", "logBase": 1, "show": true}],
+        "targets": [
+          {
+            "refId": "A",
+            "measurement": "cpu",
+            "policy": "default",
+            "select": [[{"type": "field", "params": ["usage_idle"]}, {"type": "mean", "params": []}, {"type": "math", "params": ["* -1"]}, {"type": "alias", "params": ["idle"]}]],
+            "tags": [{"key": "host", "operator": "=~", "value": "/^$host$/"}, {"key": "cpu", "operator": "=", "value": "cpu-total", "condition": "AND"}],
+            "groupBy": [{"type": "time", "params": ["$__interval"]}, {"type": "tag", "params": ["host"]}, {"type": "fill", "params": ["null"]}]
+          },
+          {"refId": "B", "hide": true, "rawQuery": true, "query": "SELECT 1"}
+        ]
+      },
+      {
+        "id": 2,
+        "type": "singlestat",
+        "title": "Load",
+        "gridPos": {"x": 12, "y": 0, "w": 12, "h": 9},
+        "decimals": 2,
+        "postfix": " load",
+        "thresholds": "1,4",
+        "colorValue": true,
+        "colors": ["#299c46", "rgba(237, 129, 40, 0.89)", "#d44a3a"],
+        "targets": [
+          {"refId": "A", "rawQuery": true, "query": "SELECT last(\"load1\") FROM \"system\" WHERE \"host\" = '$host' AND $timeFilter"}
+        ]
+      },
+      {
+        "type": "row",
+        "title": "More",
+        "collapsed": true,
+        "panels": [
+          {"id": 3, "type": "text", "title": "Notes", "gridPos": {"x": 0, "y": 9, "w": 24, "h": 3}, "content": "# Hosts"},
+          {"id": 4, "type": "heatmap", "title": "Latency", "gridPos": {"x": 0, "y": 12, "w": 24, "h": 6}},
+          {"id": 5, "type": "table", "title": "Up", "gridPos": {"x": 0, "y": 18, "w": 24, "h": 6},
+           "targets": [{"refId": "A", "expr": "up"}]}
+        ]
+      }
+    ],
+    "templating": {
+      "list": [
+        {"name": "host", "label": "Host", "type": "query", "query": "SHOW TAG VALUES FROM \"system\" WITH KEY = \"host\"", "multi": true, "current": {"text": "server01", "value": ["server01"]}},
+        {"name": "env", "type": "custom", "query": "prod, dev", "current": {"value": "dev"}},
+        {"name": "ds", "type": "datasource", "query": "influxdb"}
+      ]
+    }
+  }
+}`
+
+func TestConvert(t *testing.T) {
+	d, err := Parse([]byte(dashboardJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, report := Convert(d, Options{
+		Source:   "/chronograf/v1/sources/1",
+		Database: "telegraf",
+	})
+
+	want := chronograf.Dashboard{
+		Name: "Hosts",
+		Tags: []string{"telegraf"},
+		Cells: []chronograf.DashboardCell{
+			{
+				X: 0, Y: 0, W: 6, H: 3,
+				Name: "CPU",
+				Type: "line-stacked",
+				Queries: []chronograf.DashboardQuery{
+					{
+						Command: `SELECT mean("usage_idle") * -1 AS "idle" FROM "telegraf".."cpu" WHERE ("host" =~ /^:host:$/ AND "cpu" = 'cpu-total') AND time > :dashboardTime: AND time < :upperDashboardTime: GROUP BY time(:interval:), "host" fill(null)`,
+						Source:  "/chronograf/v1/sources/1",
+						Type:    "influxql",
+					},
+				},
+				Axes: map[string]chronograf.Axis{
+					"y": {
+						Bounds: []string{"0", "100"},
+						Label:  "%",
+						Base:   "10",
+						Scale:  "linear",
+					},
+				},
+			},
+			{
+				X: 6, Y: 0, W: 6, H: 3,
+				Name: "Load",
+				Type: "single-stat",
+				Queries: []chronograf.DashboardQuery{
+					{
+						Command: `SELECT last("load1") FROM "telegraf".."system" WHERE "host" = ':host:' AND time > :dashboardTime: AND time < :upperDashboardTime:`,
+						Source:  "/chronograf/v1/sources/1",
+						Type:    "influxql",
+					},
+				},
+				Axes: map[string]chronograf.Axis{
+					"y": {
+						Bounds: []string{"", ""},
+						Suffix: " load",
+					},
+				},
+				DecimalPlaces: chronograf.DecimalPlaces{
+					IsEnforced: true,
+					Digits:     2,
+				},
+				CellColors: []chronograf.CellColor{
+					{ID: "base", Type: "text", Hex: "#299c46", Name: "#299c46", Value: "-999999999999999999"},
+					{ID: "1", Type: "text", Hex: "#ed8128", Name: "#ed8128", Value: "1"},
+					{ID: "2", Type: "text", Hex: "#d44a3a", Name: "#d44a3a", Value: "4"},
+				},
+			},
+			{
+				X: 0, Y: 3, W: 12, H: 1,
+				Name:    "Notes",
+				Type:    "note",
+				Queries: []chronograf.DashboardQuery{},
+				Note:    "# Hosts",
+			},
+			{
+				X: 0, Y: 6, W: 12, H: 2,
+				Name:    "Up",
+				Type:    "table",
+				Queries: []chronograf.DashboardQuery{},
+			},
+		},
+		Templates: []chronograf.Template{
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var:    ":host:",
+					Values: []chronograf.TemplateValue{},
+				},
+				Type:  "tagValues",
+				Label: "Host",
+				Query: &chronograf.TemplateQuery{
+					Command:     `SHOW TAG VALUES FROM "system" WITH KEY = "host"`,
+					DB:          "telegraf",
+					Measurement: "system",
+					TagKey:      "host",
+				},
+			},
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var: ":env:",
+					Values: []chronograf.TemplateValue{
+						{Value: "prod", Type: "csv"},
+						{Value: "dev", Type: "csv", Selected: true},
+					},
+				},
+				Type: "csv",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Convert() =\n%#v\nwant\n%#v", got, want)
+	}
+
+	wantReport := Report{
+		Unconverted: []Unconverted{
+			{Variable: "host", Reason: "only one value of a template can be selected"},
+			{Variable: "ds", Reason: "datasource variables are not supported"},
+			{Panel: "CPU", Target: "B", Reason: "hidden queries are not converted"},
+			{Panel: "Latency", Reason: "heatmap panels are not supported"},
+			{Panel: "Up", Target: "A", Reason: "only InfluxQL queries are supported"},
+		},
+	}
+	if !reflect.DeepEqual(report, wantReport) {
+		t.Errorf("Convert() report = %#v, want %#v", report, wantReport)
+	}
+}
+
+func TestConvert_Rows(t *testing.T) {
+	d, err := Parse([]byte(`{
+	  "title": "Legacy",
+	  "rows": [
+	    {"height": "300px", "panels": [
+	      {"type": "graph", "title": "A", "span": 4, "bars": true, "lines": false},
+	      {"type": "graph", "title": "B", "span": 8, "steppedLine": true},
+	      {"type": "singlestat", "title": "C", "span": 6, "gauge": {"show": true}}
+	    ]},
+	    {"panels": [
+	      {"type": "graph", "title": "D"}
+	    ]}
+	  ]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := Convert(d, Options{})
+
+	want := []struct {
+		typ        string
+		x, y, w, h int32
+	}{
+		{"bar", 0, 0, 4, 3},
+		{"line-stepplot", 4, 0, 8, 3},
+		{"gauge", 0, 3, 6, 4},
+		{"line", 0, 7, 12, 2},
+	}
+	if len(got.Cells) != len(want) {
+		t.Fatalf("Convert() cells = %d, want %d", len(got.Cells), len(want))
+	}
+	for i, w := range want {
+		c := got.Cells[i]
+		if c.Type != w.typ || c.X != w.x || c.Y != w.y || c.W != w.w || c.H != w.h {
+			t.Errorf("Convert() cell %d = %s at %d,%d %dx%d, want %s at %d,%d %dx%d",
+				i, c.Type, c.X, c.Y, c.W, c.H, w.typ, w.x, w.y, w.w, w.h)
+		}
+	}
+}
+
+func TestConvert_Datasources(t *testing.T) {
+	d, err := Parse([]byte(`{
+	  "__inputs": [
+	    {"name": "DS_INFLUXDB", "type": "datasource", "pluginId": "influxdb"},
+	    {"name": "DS_ES", "type": "datasource", "pluginId": "elasticsearch"}
+	  ],
+	  "title": "Logs",
+	  "panels": [
+	    {"type": "graph", "title": "Exported", "datasource": "${DS_INFLUXDB}",
+	     "targets": [{"refId": "A", "rawQuery": true, "query": "SELECT count(\"value\") FROM \"errors\""}]},
+	    {"type": "graph", "title": "Search", "datasource": "${DS_ES}",
+	     "targets": [{"refId": "A", "query": "level:error", "metrics": [{"type": "count"}]}]},
+	    {"type": "graph", "title": "Mixed", "datasource": {"type": "datasource", "uid": "-- Mixed --"},
+	     "targets": [
+	       {"refId": "A", "datasource": {"type": "influxdb", "uid": "P951FEA4DE68E13C5"}, "rawQuery": true, "query": "SELECT last(\"value\") FROM \"errors\""},
+	       {"refId": "B", "datasource": {"type": "loki", "uid": "P8E80F9AEF21F6940"}, "query": "{app=\"api\"}"}
+	     ]},
+	    {"type": "table", "title": "Inherited", "datasource": {"type": "elasticsearch", "uid": "es"},
+	     "targets": [{"refId": "A", "query": "level:warn"}]},
+	    {"type": "text", "title": "Annotations", "datasource": "-- Grafana --", "content": "none"}
+	  ],
+	  "templating": {
+	    "list": [
+	      {"name": "app", "type": "query", "datasource": {"type": "elasticsearch", "uid": "es"}, "query": "{\"find\": \"terms\", \"field\": \"app\"}"},
+	      {"name": "host", "type": "query", "datasource": "${DS_INFLUXDB}", "query": "SHOW TAG VALUES WITH KEY = \"host\""}
+	    ]
+	  }
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, report := Convert(d, Options{Database: "telegraf"})
+
+	queries := map[string]int{}
+	for _, c := range got.Cells {
+		queries[c.Name] = len(c.Queries)
+	}
+	wantQueries := map[string]int{"Exported": 1, "Search": 0, "Mixed": 1, "Inherited": 0, "Annotations": 0}
+	if !reflect.DeepEqual(queries, wantQueries) {
+		t.Errorf("Convert() queries by cell = %v, want %v", queries, wantQueries)
+	}
+	if len(got.Templates) != 1 || got.Templates[0].Var != ":host:" {
+		t.Errorf("Convert() templates = %v, want only the InfluxDB variable", got.Templates)
+	}
+
+	wantReport := Report{
+		Unconverted: []Unconverted{
+			{Variable: "app", Reason: "queries of elasticsearch datasources are not supported"},
+			{Panel: "Search", Target: "A", Reason: "queries of elasticsearch datasources are not supported"},
+			{Panel: "Mixed", Target: "B", Reason: "queries of loki datasources are not supported"},
+			{Panel: "Inherited", Target: "A", Reason: "queries of elasticsearch datasources are not supported"},
+		},
+	}
+	if !reflect.DeepEqual(report, wantReport) {
+		t.Errorf("Convert() report = %#v, want %#v", report, wantReport)
+	}
+}
+
+func TestQualify(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: `SELECT "v" FROM cpu WHERE $timeFilter`,
+			want:  `SELECT "v" FROM "db"..cpu WHERE $timeFilter`,
+		},
+		{
+			query: `SELECT "v" FROM "cpu"`,
+			want:  `SELECT "v" FROM "db".."cpu"`,
+		},
+		{
+			query: `SELECT "v" FROM "autogen"."cpu"`,
+			want:  `SELECT "v" FROM "autogen"."cpu"`,
+		},
+		{
+			query: `SELECT "v" FROM other.autogen.cpu`,
+			want:  `SELECT "v" FROM other.autogen.cpu`,
+		},
+		{
+			query: `SELECT "v" FROM /cpu.*/`,
+			want:  `SELECT "v" FROM /cpu.*/`,
+		},
+		{
+			query: `SELECT max("v") FROM (SELECT "v" FROM cpu)`,
+			want:  `SELECT max("v") FROM (SELECT "v" FROM "db"..cpu)`,
+		},
+	}
+	for _, tt := range tests {
+		if got := qualify(tt.query, "db"); got != tt.want {
+			t.Errorf("qualify(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMetaQuery(t *testing.T) {
+	tests := []struct {
+		command         string
+		wantType        string
+		wantMeasurement string
+		wantTagKey      string
+	}{
+		{`SHOW TAG VALUES WITH KEY = "host"`, "tagValues", "", "host"},
+		{`SHOW TAG VALUES FROM cpu WITH KEY = cpu WHERE host = ':host:'`, "tagValues", "cpu", "cpu"},
+		{`SHOW TAG KEYS FROM "cpu"`, "tagKeys", "cpu", ""},
+		{`SHOW FIELD KEYS`, "fieldKeys", "", ""},
+		{`show measurements`, "measurements", "", ""},
+		{`SHOW DATABASES`, "databases", "", ""},
+		{`SELECT DISTINCT("host") FROM (SELECT last("v"), "host" FROM "cpu")`, "influxql", "", ""},
+	}
+	for _, tt := range tests {
+		typ, q := metaQuery(tt.command)
+		if typ != tt.wantType || q.Measurement != tt.wantMeasurement || q.TagKey != tt.wantTagKey || q.Command != tt.command {
+			t.Errorf("metaQuery(%q) = %s, %#v, want %s from %q with key %q", tt.command, typ, q, tt.wantType, tt.wantMeasurement, tt.wantTagKey)
+		}
+	}
+}
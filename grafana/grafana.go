@@ -0,0 +1,209 @@
+// Package grafana converts Grafana dashboards into chronograf dashboards.
+package grafana
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Dashboard is the subset of a Grafana dashboard that can be converted.
+// Dashboards use either the grid positions of their panels or, before
+// Grafana 5, rows of panels.
+type Dashboard struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Tags        []string   `json:"tags"`
+	Panels      []Panel    `json:"panels"`
+	Rows        []Row      `json:"rows"`
+	Templating  Templating `json:"templating"`
+	Inputs      []Input    `json:"__inputs"` // Inputs are the datasources of dashboards exported for sharing externally
+}
+
+// Input is a datasource that a dashboard exported for sharing externally
+// references as ${name}
+type Input struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	PluginID string `json:"pluginId"` // PluginID is the type of the datasource, e.g. influxdb
+}
+
+// Datasource references the datasource of a panel, target or variable.
+// Grafana references datasources by name before version 8.3 and by their
+// type and UID since. Empty references are to the default datasource.
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+	Name string `json:"-"` // Name is the name of datasources referenced by name
+}
+
+// UnmarshalJSON decodes datasources referenced either by name or by their
+// type and UID. Other values reference the default datasource.
+func (d *Datasource) UnmarshalJSON(b []byte) error {
+	*d = Datasource{}
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		d.Name = name
+		return nil
+	}
+	type datasource Datasource
+	var ds datasource
+	if err := json.Unmarshal(b, &ds); err == nil {
+		*d = Datasource(ds)
+	}
+	return nil
+}
+
+// Row is a row of panels of a dashboard from before Grafana 5
+type Row struct {
+	Title  string  `json:"title"`
+	Height Value   `json:"height"` // Height is in pixels, e.g. "250px"
+	Panels []Panel `json:"panels"`
+}
+
+// GridPos is the position of a panel on the 24 column grid of Grafana
+type GridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// Panel is a visualization of a dashboard
+type Panel struct {
+	ID          int        `json:"id"`
+	Type        string     `json:"type"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	GridPos     *GridPos   `json:"gridPos"`
+	Span        float64    `json:"span"` // Span is the width of panels in rows, out of 12
+	Datasource  Datasource `json:"datasource"`
+	Targets     []Target   `json:"targets"`
+	Panels      []Panel    `json:"panels"` // Panels of a collapsed row panel
+
+	// graph panels
+	Bars        bool    `json:"bars"`
+	Lines       *bool   `json:"lines"`
+	Stack       bool    `json:"stack"`
+	SteppedLine bool    `json:"steppedLine"`
+	Yaxes       []YAxis `json:"yaxes"`
+
+	// singlestat panels
+	Gauge           Gauge    `json:"gauge"`
+	Decimals        Value    `json:"decimals"`
+	Prefix          string   `json:"prefix"`
+	Postfix         string   `json:"postfix"`
+	Thresholds      string   `json:"thresholds"`
+	Colors          []string `json:"colors"`
+	ColorValue      bool     `json:"colorValue"`
+	ColorBackground bool     `json:"colorBackground"`
+
+	// text panels
+	Content string `json:"content"`
+}
+
+// YAxis is a y axis of a graph panel
+type YAxis struct {
+	Label   string `json:"label"`
+	Min     Value  `json:"min"`
+	Max     Value  `json:"max"`
+	LogBase int    `json:"logBase"`
+	Show    bool   `json:"show"`
+}
+
+// Gauge are the options of singlestat panels shown as gauges
+type Gauge struct {
+	Show bool `json:"show"`
+}
+
+// Target is a query of a panel. InfluxDB targets are either raw queries or
+// built from their measurement, selects, tags and group bys.
+type Target struct {
+	RefID       string   `json:"refId"`
+	Hide        bool     `json:"hide"`
+	Query       string   `json:"query"`
+	RawQuery    bool     `json:"rawQuery"`
+	Measurement string   `json:"measurement"`
+	Policy      string   `json:"policy"`
+	Select      [][]Part `json:"select"`
+	Tags        []Tag    `json:"tags"`
+	GroupBy     []Part   `json:"groupBy"`
+	Alias       string   `json:"alias"`
+	Expr        string   `json:"expr"` // Expr is the query of Prometheus targets
+
+	Datasource Datasource `json:"datasource"` // Datasource is set on the targets of mixed panels and since Grafana 8.3
+}
+
+// Part is a function of the select or group by of a target
+type Part struct {
+	Type   string  `json:"type"`
+	Params []Value `json:"params"`
+}
+
+// Tag is a condition of the where clause of a target
+type Tag struct {
+	Key       string `json:"key"`
+	Operator  string `json:"operator"`
+	Value     string `json:"value"`
+	Condition string `json:"condition"`
+}
+
+// Templating holds the template variables of a dashboard
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a template variable of a dashboard
+type Variable struct {
+	Name       string   `json:"name"`
+	Label      string   `json:"label"`
+	Type       string   `json:"type"`
+	Query      Value    `json:"query"`
+	Multi      bool     `json:"multi"`
+	IncludeAll bool     `json:"includeAll"`
+	Current    Option   `json:"current"`
+	Options    []Option `json:"options"`
+
+	Datasource Datasource `json:"datasource"` // Datasource is the datasource of query variables
+}
+
+// Option is a value of a template variable
+type Option struct {
+	Text     Value `json:"text"`
+	Value    Value `json:"value"`
+	Selected bool  `json:"selected"`
+}
+
+// Value is a JSON value Grafana stores either as a string, a number or, for
+// variables with several selected values, an array. Arrays keep their first
+// element and objects, such as the queries of variables of recent Grafana
+// versions, keep their query.
+type Value string
+
+// UnmarshalJSON decodes strings, numbers, booleans and arrays as a string
+func (v *Value) UnmarshalJSON(b []byte) error {
+	var i interface{}
+	if err := json.Unmarshal(b, &i); err != nil {
+		return err
+	}
+	if a, ok := i.([]interface{}); ok {
+		if len(a) == 0 {
+			i = nil
+		} else {
+			i = a[0]
+		}
+	}
+	switch t := i.(type) {
+	case string:
+		*v = Value(t)
+	case float64:
+		*v = Value(strconv.FormatFloat(t, 'f', -1, 64))
+	case bool:
+		*v = Value(strconv.FormatBool(t))
+	case map[string]interface{}:
+		q, _ := t["query"].(string)
+		*v = Value(q)
+	default:
+		*v = ""
+	}
+	return nil
+}
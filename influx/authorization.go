@@ -1,6 +1,7 @@
 package influx
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -21,8 +22,51 @@ type NoAuthorization struct{}
 // Set does not add authorization
 func (n *NoAuthorization) Set(req *http.Request) error { return nil }
 
-// DefaultAuthorization creates either a shared JWT builder, basic auth or Noop
-func DefaultAuthorization(src *chronograf.Source) Authorizer {
+// CredentialsResolver looks up the InfluxDB identity of the Chronograf user
+// making a request
+type CredentialsResolver interface {
+	// Username is the name of the Chronograf user used to mint JWTs
+	Username() string
+	// Credentials are the InfluxDB credentials the user stored for the source
+	Credentials(src *chronograf.Source) (username, password string, err error)
+}
+
+type credentialsKey struct{}
+
+// WithCredentials returns a context carrying the resolver of the identity
+// used by DefaultAuthorization for sources with per-user authentication
+func WithCredentials(ctx context.Context, resolver CredentialsResolver) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, resolver)
+}
+
+// ErrorAuthorization fails every request with its error; it is used when the
+// identity of the caller cannot be resolved.
+type ErrorAuthorization struct {
+	Err error
+}
+
+// Set returns the error of the authorization
+func (e *ErrorAuthorization) Set(req *http.Request) error { return e.Err }
+
+// DefaultAuthorization creates either an InfluxDB 2.x token, a shared JWT
+// builder, basic auth or Noop. Sources authenticating each Chronograf user
+// use the identity of the caller resolved from the context; requests without
+// one fail rather than falling back to the shared credentials of the source.
+func DefaultAuthorization(ctx context.Context, src *chronograf.Source) Authorizer {
+	if src.Type == chronograf.InfluxDBv2 {
+		return &TokenAuth{
+			Token: src.Token,
+		}
+	}
+	if src.UserAuth != "" {
+		resolver, ok := ctx.Value(credentialsKey{}).(CredentialsResolver)
+		if !ok {
+			return &ErrorAuthorization{
+				Err: fmt.Errorf("source %d authenticates each user but the request has none", src.ID),
+			}
+		}
+		return userAuthorization(resolver, src)
+	}
 	// Optionally, add the shared secret JWT token creation
 	if src.Username != "" && src.SharedSecret != "" {
 		return &BearerJWT{
@@ -38,6 +82,30 @@ func DefaultAuthorization(src *chronograf.Source) Authorizer {
 	return &NoAuthorization{}
 }
 
+func userAuthorization(resolver CredentialsResolver, src *chronograf.Source) Authorizer {
+	switch src.UserAuth {
+	case chronograf.UserAuthJWT:
+		return &BearerJWT{
+			Username:     resolver.Username(),
+			SharedSecret: src.SharedSecret,
+		}
+	case chronograf.UserAuthCredentials:
+		username, password, err := resolver.Credentials(src)
+		if err != nil {
+			return &ErrorAuthorization{
+				Err: fmt.Errorf("no InfluxDB credentials for source %d: %v", src.ID, err),
+			}
+		}
+		return &BasicAuth{
+			Username: username,
+			Password: password,
+		}
+	}
+	return &ErrorAuthorization{
+		Err: fmt.Errorf("unknown user authentication %q", src.UserAuth),
+	}
+}
+
 // BasicAuth adds Authorization: Basic to the request header
 type BasicAuth struct {
 	Username string
@@ -50,6 +118,20 @@ func (b *BasicAuth) Set(r *http.Request) error {
 	return nil
 }
 
+// TokenAuth adds Authorization: Token to the request header as expected by
+// InfluxDB 2.x
+type TokenAuth struct {
+	Token string
+}
+
+// Set adds the API token to the request
+func (t *TokenAuth) Set(r *http.Request) error {
+	if t.Token != "" {
+		r.Header.Set("Authorization", "Token "+t.Token)
+	}
+	return nil
+}
+
 // BearerJWT is the default Bearer for InfluxDB
 type BearerJWT struct {
 	Username     string
@@ -1,8 +1,14 @@
 package influx
 
 import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/influxdata/chronograf"
 )
 
 func TestJWT(t *testing.T) {
@@ -42,3 +48,102 @@ func TestJWT(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultAuthorization_InfluxDBv2(t *testing.T) {
+	auth := DefaultAuthorization(context.Background(), &chronograf.Source{
+		Type:  chronograf.InfluxDBv2,
+		Org:   "my-org",
+		Token: "my-token",
+	})
+	req := httptest.NewRequest("GET", "/query", nil)
+	if err := auth.Set(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Token my-token" {
+		t.Errorf("DefaultAuthorization() Authorization = %q, want %q", got, "Token my-token")
+	}
+}
+
+type credentialsResolver struct {
+	username string
+	password string
+	err      error
+}
+
+func (c *credentialsResolver) Username() string { return "marty" }
+
+func (c *credentialsResolver) Credentials(src *chronograf.Source) (string, string, error) {
+	return c.username, c.password, c.err
+}
+
+func TestDefaultAuthorization_UserAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver CredentialsResolver
+		src      chronograf.Source
+		want     Authorizer
+	}{
+		{
+			name:     "Per-user credentials",
+			resolver: &credentialsResolver{username: "mcfly", password: "hoverboard"},
+			src: chronograf.Source{
+				Username: "admin",
+				Password: "admin",
+				UserAuth: chronograf.UserAuthCredentials,
+			},
+			want: &BasicAuth{
+				Username: "mcfly",
+				Password: "hoverboard",
+			},
+		},
+		{
+			name:     "Per-user JWT",
+			resolver: &credentialsResolver{},
+			src: chronograf.Source{
+				Username:     "admin",
+				SharedSecret: "secret",
+				UserAuth:     chronograf.UserAuthJWT,
+			},
+			want: &BearerJWT{
+				Username:     "marty",
+				SharedSecret: "secret",
+			},
+		},
+		{
+			name:     "Missing credentials fail the request",
+			resolver: &credentialsResolver{err: errors.New("not found")},
+			src: chronograf.Source{
+				ID:       1,
+				Username: "admin",
+				Password: "admin",
+				UserAuth: chronograf.UserAuthCredentials,
+			},
+			want: &ErrorAuthorization{
+				Err: errors.New("no InfluxDB credentials for source 1: not found"),
+			},
+		},
+		{
+			name: "Requests without a resolver fail rather than use the shared credentials",
+			src: chronograf.Source{
+				ID:       1,
+				Username: "admin",
+				Password: "admin",
+				UserAuth: chronograf.UserAuthCredentials,
+			},
+			want: &ErrorAuthorization{
+				Err: errors.New("source 1 authenticates each user but the request has none"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.resolver != nil {
+				ctx = WithCredentials(ctx, tt.resolver)
+			}
+			if got := DefaultAuthorization(ctx, &tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DefaultAuthorization() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
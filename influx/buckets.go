@@ -0,0 +1,211 @@
+package influx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+)
+
+// bucketsPageSize is the number of buckets requested at a time
+const bucketsPageSize = 100
+
+// Bucket is an InfluxDB 2.x bucket along with the database and retention
+// policy it is queried as through the 1.x compatible API
+type Bucket struct {
+	ID              string        // ID of the bucket
+	Name            string        // Name of the bucket
+	Retention       time.Duration // Retention of the bucket; zero keeps data forever
+	Database        string        // Database the bucket is mapped to
+	RetentionPolicy string        // RetentionPolicy the bucket is mapped to
+	Default         bool          // Default is true for the default retention policy of the database
+}
+
+// Buckets lists the buckets of the organization of an InfluxDB 2.x source.
+// Buckets are mapped to databases and retention policies by the DBRP
+// mappings of the organization. Buckets without mappings are mapped by
+// name: a bucket named db/rp is the retention policy rp of the database
+// db, any other bucket is the autogen retention policy of the database of
+// its name.
+func (c *Client) Buckets(ctx context.Context) ([]Bucket, error) {
+	if c.Org == "" {
+		return nil, fmt.Errorf("buckets are only supported by InfluxDB 2.x sources")
+	}
+
+	type bucket struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		RetentionRules []struct {
+			EverySeconds int64 `json:"everySeconds"`
+		} `json:"retentionRules"`
+	}
+	all := []bucket{}
+	for offset := 0; ; offset += bucketsPageSize {
+		params := url.Values{}
+		params.Set("org", c.Org)
+		params.Set("limit", strconv.Itoa(bucketsPageSize))
+		params.Set("offset", strconv.Itoa(offset))
+
+		var page struct {
+			Buckets []bucket `json:"buckets"`
+		}
+		if err := c.getV2(ctx, "/api/v2/buckets", params, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Buckets...)
+		if len(page.Buckets) < bucketsPageSize {
+			break
+		}
+	}
+
+	var dbrps struct {
+		Content []struct {
+			BucketID        string `json:"bucketID"`
+			Database        string `json:"database"`
+			RetentionPolicy string `json:"retention_policy"`
+			Default         bool   `json:"default"`
+		} `json:"content"`
+	}
+	params := url.Values{}
+	params.Set("org", c.Org)
+	if err := c.getV2(ctx, "/api/v2/dbrps", params, &dbrps); err != nil && err != errNotFound {
+		return nil, err
+	}
+
+	buckets := []Bucket{}
+	for _, b := range all {
+		bkt := Bucket{
+			ID:   b.ID,
+			Name: b.Name,
+		}
+		if len(b.RetentionRules) > 0 {
+			bkt.Retention = time.Duration(b.RetentionRules[0].EverySeconds) * time.Second
+		}
+
+		mapped := false
+		for _, m := range dbrps.Content {
+			if m.BucketID != b.ID {
+				continue
+			}
+			mapped = true
+			bkt.Database = m.Database
+			bkt.RetentionPolicy = m.RetentionPolicy
+			bkt.Default = m.Default
+			buckets = append(buckets, bkt)
+		}
+		if mapped {
+			continue
+		}
+
+		bkt.Database, bkt.RetentionPolicy = b.Name, "autogen"
+		if i := strings.LastIndex(b.Name, "/"); i > 0 && i < len(b.Name)-1 {
+			bkt.Database, bkt.RetentionPolicy = b.Name[:i], b.Name[i+1:]
+		}
+		bkt.Default = bkt.RetentionPolicy == "autogen"
+		buckets = append(buckets, bkt)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Database != buckets[j].Database {
+			return buckets[i].Database < buckets[j].Database
+		}
+		return buckets[i].RetentionPolicy < buckets[j].RetentionPolicy
+	})
+	return buckets, nil
+}
+
+// bucketDatabases lists the databases the buckets are mapped to
+func (c *Client) bucketDatabases(ctx context.Context) ([]chronograf.Database, error) {
+	buckets, err := c.Buckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs := []chronograf.Database{}
+	for i, b := range buckets {
+		if i > 0 && buckets[i-1].Database == b.Database {
+			continue
+		}
+		dbs = append(dbs, chronograf.Database{
+			Name: b.Database,
+		})
+	}
+	return dbs, nil
+}
+
+// bucketRetentionPolicies lists the retention policies the buckets of the
+// database are mapped to
+func (c *Client) bucketRetentionPolicies(ctx context.Context, db string) ([]chronograf.RetentionPolicy, error) {
+	buckets, err := c.Buckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rps := []chronograf.RetentionPolicy{}
+	for _, b := range buckets {
+		if b.Database != db {
+			continue
+		}
+		rps = append(rps, chronograf.RetentionPolicy{
+			Name:        b.RetentionPolicy,
+			Duration:    b.Retention.String(),
+			Replication: 1,
+			Default:     b.Default,
+		})
+	}
+	return rps, nil
+}
+
+// errNotFound is returned by getV2 when the endpoint does not exist
+var errNotFound = fmt.Errorf("not found")
+
+// getV2 decodes the response of an InfluxDB 2.x API endpoint into v
+func (c *Client) getV2(ctx context.Context, endpoint string, params url.Values, v interface{}) error {
+	u := withPath(c.URL, endpoint)
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if c.Authorizer != nil {
+		if err := c.Authorizer.Set(req); err != nil {
+			return err
+		}
+	}
+
+	hc := &http.Client{}
+	if c.InsecureSkipVerify {
+		hc.Transport = skipVerifyTransport
+	} else {
+		hc.Transport = defaultTransport
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return chronograf.ErrUpstreamTimeout
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		var res struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&res)
+		return fmt.Errorf("received status code %d from server: err: %s", resp.StatusCode, res.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,140 @@
+package influx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/log"
+)
+
+func newInfluxV2(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); r.URL.Path != "/ping" && auth != "Token my-token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			rw.Write([]byte(`{"code":"unauthorized","message":"unauthorized access"}`))
+			return
+		}
+		if org := r.URL.Query().Get("org"); r.URL.Path != "/ping" && org != "my-org" {
+			t.Errorf("Expected org my-org but was %s", org)
+		}
+		switch r.URL.Path {
+		case "/ping":
+			rw.Header().Set("X-Influxdb-Version", "2.0.4")
+			rw.WriteHeader(http.StatusNoContent)
+		case "/api/v2/buckets":
+			rw.Write([]byte(`{"buckets":[` +
+				`{"id":"01","name":"telegraf","retentionRules":[{"type":"expire","everySeconds":604800}]},` +
+				`{"id":"02","name":"telegraf/downsampled","retentionRules":[]},` +
+				`{"id":"03","name":"metrics","retentionRules":[]}]}`))
+		case "/api/v2/dbrps":
+			rw.Write([]byte(`{"content":[{"bucketID":"03","database":"app","retention_policy":"raw","default":true}]}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_Buckets(t *testing.T) {
+	ts := newInfluxV2(t)
+	defer ts.Close()
+
+	cl := &influx.Client{
+		Logger: log.New(log.DebugLevel),
+	}
+	err := cl.Connect(context.Background(), &chronograf.Source{
+		Type:  chronograf.InfluxDBv2,
+		URL:   ts.URL,
+		Org:   "my-org",
+		Token: "my-token",
+	})
+	if err != nil {
+		t.Fatal("Unexpected error connecting: err:", err)
+	}
+
+	buckets, err := cl.Buckets(context.Background())
+	if err != nil {
+		t.Fatal("Unexpected error listing buckets: err:", err)
+	}
+	want := []influx.Bucket{
+		{ID: "03", Name: "metrics", Database: "app", RetentionPolicy: "raw", Default: true},
+		{ID: "01", Name: "telegraf", Retention: 168 * time.Hour, Database: "telegraf", RetentionPolicy: "autogen", Default: true},
+		{ID: "02", Name: "telegraf/downsampled", Database: "telegraf", RetentionPolicy: "downsampled"},
+	}
+	if !reflect.DeepEqual(buckets, want) {
+		t.Errorf("Client.Buckets() =\n%#v\nwant\n%#v", buckets, want)
+	}
+
+	dbs, err := cl.AllDB(context.Background())
+	if err != nil {
+		t.Fatal("Unexpected error listing databases: err:", err)
+	}
+	if want := []chronograf.Database{{Name: "app"}, {Name: "telegraf"}}; !reflect.DeepEqual(dbs, want) {
+		t.Errorf("Client.AllDB() = %v, want %v", dbs, want)
+	}
+
+	rps, err := cl.AllRP(context.Background(), "telegraf")
+	if err != nil {
+		t.Fatal("Unexpected error listing retention policies: err:", err)
+	}
+	wantRPs := []chronograf.RetentionPolicy{
+		{Name: "autogen", Duration: "168h0m0s", Replication: 1, Default: true},
+		{Name: "downsampled", Duration: "0s", Replication: 1},
+	}
+	if !reflect.DeepEqual(rps, wantRPs) {
+		t.Errorf("Client.AllRP() = %v, want %v", rps, wantRPs)
+	}
+
+	if typ, err := cl.Type(context.Background()); err != nil || typ != chronograf.InfluxDBv2 {
+		t.Errorf("Client.Type() = %s, %v; want %s", typ, err, chronograf.InfluxDBv2)
+	}
+}
+
+func TestClient_BucketsBasePath(t *testing.T) {
+	ts := newInfluxV2(t)
+	defer ts.Close()
+	proxy := httptest.NewServer(http.StripPrefix("/influx", ts.Config.Handler))
+	defer proxy.Close()
+
+	cl := &influx.Client{
+		Logger: log.New(log.DebugLevel),
+	}
+	err := cl.Connect(context.Background(), &chronograf.Source{
+		Type:  chronograf.InfluxDBv2,
+		URL:   proxy.URL + "/influx",
+		Org:   "my-org",
+		Token: "my-token",
+	})
+	if err != nil {
+		t.Fatal("Unexpected error connecting: err:", err)
+	}
+
+	buckets, err := cl.Buckets(context.Background())
+	if err != nil {
+		t.Fatal("Unexpected error listing buckets: err:", err)
+	}
+	if len(buckets) != 3 {
+		t.Errorf("Client.Buckets() = %v, want the 3 buckets behind the base path", buckets)
+	}
+}
+
+func TestClient_BucketsRequireInfluxDBv2(t *testing.T) {
+	cl := &influx.Client{}
+	err := cl.Connect(context.Background(), &chronograf.Source{
+		URL:      "http://localhost:8086",
+		Username: "user",
+		Password: "pass",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.Buckets(context.Background()); err == nil {
+		t.Error("Expected an error listing buckets of an InfluxDB 1.x source")
+	}
+}
@@ -0,0 +1,284 @@
+package influx
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/influxdb/influxql"
+)
+
+// DashboardTime is the template variable used as the lower time bound of a
+// query config without a range
+const DashboardTime = ":dashboardTime:"
+
+// IntervalTime is the template variable used to GROUP BY time(auto)
+const IntervalTime = ":interval:"
+
+// AutoGroupBy is the GroupBy.Time of a query config grouping by :interval:
+const AutoGroupBy = "auto"
+
+// BuildQuery builds the InfluxQL SELECT statement of a QueryConfig. It is the
+// inverse of Convert: for any query config produced by Convert,
+// Convert(BuildQuery(qc)) results in the same query config. Query configs
+// with RawText set are returned verbatim.
+func BuildQuery(qc chronograf.QueryConfig) (string, error) {
+	if qc.RawText != nil {
+		return *qc.RawText, nil
+	}
+
+	lower, upper := DashboardTime, ""
+	if qc.Range != nil {
+		lower, upper = quoteIfTimestamp(qc.Range.Lower), quoteIfTimestamp(qc.Range.Upper)
+	}
+	return buildInfluxQL(qc, lower, upper, "")
+}
+
+// BuildShiftedQuery builds the InfluxQL SELECT statement of a QueryConfig
+// with its time range moved back by the shift. Function aliases are suffixed
+// with the shift so that the shifted series can be told apart from the
+// original ones.
+func BuildShiftedQuery(qc chronograf.QueryConfig, shift chronograf.TimeShift) (string, error) {
+	if qc.RawText != nil {
+		return "", fmt.Errorf("raw queries cannot be time shifted")
+	}
+
+	dur := shift.Quantity + shift.Unit
+	if _, err := influxql.ParseDuration(dur); err != nil {
+		return "", fmt.Errorf("invalid time shift %q: %v", dur, err)
+	}
+
+	lower, upper := DashboardTime, ""
+	if qc.Range != nil {
+		lower, upper = quoteIfTimestamp(qc.Range.Lower), quoteIfTimestamp(qc.Range.Upper)
+	}
+	if upper == "" {
+		upper = "now()"
+	}
+	lower = fmt.Sprintf("%s - %s", lower, dur)
+	upper = fmt.Sprintf("%s - %s", upper, dur)
+
+	suffix := fmt.Sprintf("_shifted__%s__%s", shift.Quantity, shift.Unit)
+	return buildInfluxQL(qc, lower, upper, suffix)
+}
+
+func buildInfluxQL(qc chronograf.QueryConfig, lower, upper, shift string) (string, error) {
+	if qc.Measurement == "" {
+		return "", fmt.Errorf("query config requires a measurement")
+	}
+	if len(qc.Fields) == 0 {
+		return "", fmt.Errorf("query config requires at least one field")
+	}
+
+	fields, err := buildFields(qc.Fields, shift, true)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "SELECT %s FROM %s", fields, buildMeasurement(qc))
+
+	conds := []string{}
+	if lower != "" {
+		conds = append(conds, "time > "+lower)
+	}
+	if upper != "" {
+		conds = append(conds, "time < "+upper)
+	}
+	conds = append(conds, buildTagFilters(qc.Tags, qc.AreTagsAccepted)...)
+	if len(conds) > 0 {
+		fmt.Fprintf(&buf, " WHERE %s", strings.Join(conds, " AND "))
+	}
+
+	dims := []string{}
+	if qc.GroupBy.Time != "" {
+		interval := qc.GroupBy.Time
+		if interval == AutoGroupBy {
+			interval = IntervalTime
+		} else if _, err := influxql.ParseDuration(interval); err != nil {
+			return "", fmt.Errorf("invalid group by time %q: %v", interval, err)
+		}
+		dims = append(dims, fmt.Sprintf("time(%s)", interval))
+	}
+	for _, tag := range qc.GroupBy.Tags {
+		dims = append(dims, quoteIdent(tag))
+	}
+	if len(dims) > 0 {
+		fmt.Fprintf(&buf, " GROUP BY %s", strings.Join(dims, ", "))
+	}
+
+	// FILL only applies when grouping by time
+	if qc.GroupBy.Time != "" {
+		fill, err := buildFill(qc.Fill)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, " FILL(%s)", fill)
+	}
+
+	return buf.String(), nil
+}
+
+func buildMeasurement(qc chronograf.QueryConfig) string {
+	if qc.Database != "" {
+		rp := ""
+		if qc.RetentionPolicy != "" {
+			rp = quoteIdent(qc.RetentionPolicy)
+		}
+		return fmt.Sprintf("%s.%s.%s", quoteIdent(qc.Database), rp, quoteIdent(qc.Measurement))
+	}
+	if qc.RetentionPolicy != "" {
+		return fmt.Sprintf("%s.%s", quoteIdent(qc.RetentionPolicy), quoteIdent(qc.Measurement))
+	}
+	return quoteIdent(qc.Measurement)
+}
+
+func buildFields(fields []chronograf.Field, shift string, useAlias bool) (string, error) {
+	clauses := make([]string, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case "field":
+			name, ok := f.Value.(string)
+			if !ok {
+				return "", fmt.Errorf("field value %v should be string but is %T", f.Value, f.Value)
+			}
+			clause := "*"
+			if name != "*" {
+				clause = quoteIdent(name)
+			}
+			if useAlias && f.Alias != "" {
+				clause = fmt.Sprintf("%s AS %s", clause, quoteIdent(f.Alias))
+			}
+			clauses[i] = clause
+		case "wildcard":
+			clauses[i] = "*"
+		case "regex":
+			re, ok := f.Value.(string)
+			if !ok {
+				return "", fmt.Errorf("regex value %v should be string but is %T", f.Value, f.Value)
+			}
+			clauses[i] = "/" + strings.Replace(re, "/", `\/`, -1) + "/"
+		case "integer", "number":
+			num, err := buildNumber(f)
+			if err != nil {
+				return "", err
+			}
+			clauses[i] = num
+		case "func":
+			name, ok := f.Value.(string)
+			if !ok {
+				return "", fmt.Errorf("function value %v should be string but is %T", f.Value, f.Value)
+			}
+			if !supportedFuncs[name] {
+				return "", fmt.Errorf("unsupported function %q", name)
+			}
+			args, err := buildFields(f.Args, "", false)
+			if err != nil {
+				return "", err
+			}
+			clause := fmt.Sprintf("%s(%s)", name, args)
+			if f.Alias != "" {
+				clause = fmt.Sprintf("%s AS %s", clause, quoteIdent(f.Alias+shift))
+			}
+			clauses[i] = clause
+		default:
+			return "", fmt.Errorf(`invalid field type "%s" ; expect func, field, integer, number, regex, wildcard`, f.Type)
+		}
+	}
+	return strings.Join(clauses, ", "), nil
+}
+
+// buildNumber formats integer and number arguments. Values decoded from JSON
+// may either be strings, as produced by Convert, or numbers.
+func buildNumber(f chronograf.Field) (string, error) {
+	var num string
+	switch v := f.Value.(type) {
+	case string:
+		num = v
+	case float64:
+		num = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		num = strconv.Itoa(v)
+	case int64:
+		num = strconv.FormatInt(v, 10)
+	default:
+		return "", fmt.Errorf("%s value %v should be numeric but is %T", f.Type, f.Value, f.Value)
+	}
+
+	var err error
+	if f.Type == "integer" {
+		_, err = strconv.ParseInt(num, 10, 64)
+	} else {
+		_, err = strconv.ParseFloat(num, 64)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value %q", f.Type, num)
+	}
+	return num, nil
+}
+
+// buildTagFilters combines the values of a single tag with OR and the
+// different tags with AND. Tags are sorted to keep the query stable.
+func buildTagFilters(tags map[string][]string, accepted bool) []string {
+	op := "!="
+	if accepted {
+		op = "="
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	filters := []string{}
+	for _, k := range keys {
+		values := tags[k]
+		if len(values) == 0 {
+			continue
+		}
+		inner := make([]string, len(values))
+		for i, v := range values {
+			inner[i] = fmt.Sprintf("%s%s%s", quoteIdent(k), op, influxql.QuoteString(v))
+		}
+		if len(inner) == 1 {
+			filters = append(filters, inner[0])
+			continue
+		}
+		filters = append(filters, "("+strings.Join(inner, " OR ")+")")
+	}
+	return filters
+}
+
+func buildFill(fill string) (string, error) {
+	switch fill {
+	case "":
+		return "null", nil
+	case "null", "none", "previous", "linear":
+		return fill, nil
+	}
+	if _, err := strconv.ParseFloat(fill, 64); err != nil {
+		return "", fmt.Errorf("invalid fill %q; expect null, none, previous, linear or a number", fill)
+	}
+	return fill, nil
+}
+
+// quoteIfTimestamp quotes absolute RFC3339 time bounds
+func quoteIfTimestamp(bound string) string {
+	if _, err := time.Parse(time.RFC3339Nano, bound); err == nil {
+		return "'" + bound + "'"
+	}
+	return bound
+}
+
+// quoteIdent always double quotes an identifier, unlike influxql.QuoteIdent,
+// so that the generated InfluxQL matches the one built by the UI
+func quoteIdent(ident string) string {
+	return `"` + identEscaper.Replace(ident) + `"`
+}
+
+var identEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
@@ -0,0 +1,258 @@
+package influx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/chronograf"
+)
+
+func TestBuildQuery_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		influxQL string
+	}{
+		{
+			name:     "Fields with aliases",
+			influxQL: `SELECT "usage_idle", "usage_user" AS "user" FROM "telegraf"."autogen"."cpu" WHERE time > :dashboardTime:`,
+		},
+		{
+			name:     "Functions grouped by auto interval",
+			influxQL: `SELECT mean("usage_idle") AS "mean_usage_idle", percentile("usage_user", 95) AS "p95" FROM "telegraf"."autogen"."cpu" WHERE time > :dashboardTime: GROUP BY time(:interval:) FILL(null)`,
+		},
+		{
+			name:     "Accepted tags grouped by time and tags",
+			influxQL: `SELECT max("used") FROM "telegraf"."autogen"."mem" WHERE time > now() - 1h AND ("host"='a' OR "host"='b') AND "region"='us-west' GROUP BY time(5m), "host" FILL(0)`,
+		},
+		{
+			name:     "Rejected tags",
+			influxQL: `SELECT count("n") FROM "telegraf".."disk" WHERE time > now() - 15m AND "path"!='/boot' GROUP BY time(1m) FILL(previous)`,
+		},
+		{
+			name:     "Regex, wildcard and number arguments",
+			influxQL: `SELECT top("value", 3), count(/used\/free/), last(*), percentile("value", 99.9) FROM "cpu" WHERE time > :dashboardTime: GROUP BY "host"`,
+		},
+		{
+			name:     "Raw text is not rebuilt",
+			influxQL: `SHOW DATABASES`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qc, err := Convert(tt.influxQL)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+
+			got, err := BuildQuery(qc)
+			if err != nil {
+				t.Fatalf("BuildQuery() error = %v", err)
+			}
+			if got != tt.influxQL {
+				t.Errorf("BuildQuery() = %s, want %s", got, tt.influxQL)
+			}
+
+			if qc.RawText != nil {
+				return
+			}
+			again, err := Convert(got)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if !cmp.Equal(qc, again) {
+				t.Errorf("Convert(BuildQuery()) differs: %s", cmp.Diff(qc, again))
+			}
+		})
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		qc      chronograf.QueryConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Absolute range is quoted",
+			qc: chronograf.QueryConfig{
+				Database:    "telegraf",
+				Measurement: "cpu",
+				Fields: []chronograf.Field{
+					{
+						Value: "usage_idle",
+						Type:  "field",
+					},
+				},
+				Range: &chronograf.DurationRange{
+					Lower: "2018-09-01T00:00:00Z",
+					Upper: "2018-09-02T00:00:00Z",
+				},
+			},
+			want: `SELECT "usage_idle" FROM "telegraf".."cpu" WHERE time > '2018-09-01T00:00:00Z' AND time < '2018-09-02T00:00:00Z'`,
+		},
+		{
+			name: "Absolute range with a time zone offset is quoted",
+			qc: chronograf.QueryConfig{
+				Database:    "telegraf",
+				Measurement: "cpu",
+				Fields: []chronograf.Field{
+					{
+						Value: "usage_idle",
+						Type:  "field",
+					},
+				},
+				Range: &chronograf.DurationRange{
+					Lower: "2018-09-01T02:00:00+02:00",
+					Upper: "2018-09-02T02:00:00.5+02:00",
+				},
+			},
+			want: `SELECT "usage_idle" FROM "telegraf".."cpu" WHERE time > '2018-09-01T02:00:00+02:00' AND time < '2018-09-02T02:00:00.5+02:00'`,
+		},
+		{
+			name: "Numeric arguments decoded from JSON",
+			qc: chronograf.QueryConfig{
+				Measurement: "cpu",
+				Fields: []chronograf.Field{
+					{
+						Value: "percentile",
+						Type:  "func",
+						Args: []chronograf.Field{
+							{
+								Value: "usage_idle",
+								Type:  "field",
+							},
+							{
+								Value: float64(99),
+								Type:  "integer",
+							},
+						},
+					},
+				},
+				Range: &chronograf.DurationRange{
+					Lower: "now() - 1h",
+				},
+				GroupBy: chronograf.GroupBy{
+					Time: "10m",
+				},
+				Fill: "none",
+			},
+			want: `SELECT percentile("usage_idle", 99) FROM "cpu" WHERE time > now() - 1h GROUP BY time(10m) FILL(none)`,
+		},
+		{
+			name: "Identifiers are escaped",
+			qc: chronograf.QueryConfig{
+				Measurement: `my "cpu"`,
+				Fields: []chronograf.Field{
+					{
+						Value: "usage",
+						Type:  "field",
+					},
+				},
+				Tags: map[string][]string{
+					"host": {"o'brien"},
+				},
+				AreTagsAccepted: true,
+			},
+			want: `SELECT "usage" FROM "my \"cpu\"" WHERE time > :dashboardTime: AND "host"='o\'brien'`,
+		},
+		{
+			name: "Missing measurement",
+			qc: chronograf.QueryConfig{
+				Fields: []chronograf.Field{
+					{
+						Value: "usage_idle",
+						Type:  "field",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported function",
+			qc: chronograf.QueryConfig{
+				Measurement: "cpu",
+				Fields: []chronograf.Field{
+					{
+						Value: "derivative",
+						Type:  "func",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid fill",
+			qc: chronograf.QueryConfig{
+				Measurement: "cpu",
+				Fields: []chronograf.Field{
+					{
+						Value: "usage_idle",
+						Type:  "field",
+					},
+				},
+				GroupBy: chronograf.GroupBy{
+					Time: "1m",
+				},
+				Fill: "sometimes",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildQuery(tt.qc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BuildQuery() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildShiftedQuery(t *testing.T) {
+	qc := chronograf.QueryConfig{
+		Database:        "telegraf",
+		RetentionPolicy: "autogen",
+		Measurement:     "cpu",
+		Fields: []chronograf.Field{
+			{
+				Value: "mean",
+				Type:  "func",
+				Alias: "mean_usage_idle",
+				Args: []chronograf.Field{
+					{
+						Value: "usage_idle",
+						Type:  "field",
+					},
+				},
+			},
+		},
+		GroupBy: chronograf.GroupBy{
+			Time: "auto",
+			Tags: []string{},
+		},
+		Fill: "null",
+	}
+	shift := chronograf.TimeShift{
+		Label:    "1d",
+		Unit:     "d",
+		Quantity: "1",
+	}
+
+	want := `SELECT mean("usage_idle") AS "mean_usage_idle_shifted__1__d" FROM "telegraf"."autogen"."cpu" WHERE time > :dashboardTime: - 1d AND time < now() - 1d GROUP BY time(:interval:) FILL(null)`
+	got, err := BuildShiftedQuery(qc, shift)
+	if err != nil {
+		t.Fatalf("BuildShiftedQuery() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("BuildShiftedQuery() = %s, want %s", got, want)
+	}
+
+	shift.Unit = "fortnights"
+	if _, err := BuildShiftedQuery(qc, shift); err == nil {
+		t.Errorf("BuildShiftedQuery() expected error for invalid shift unit")
+	}
+}
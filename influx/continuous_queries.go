@@ -0,0 +1,242 @@
+package influx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/influxdb/influxql"
+)
+
+// AllCQ returns all the continuous queries defined on a specific database
+func (c *Client) AllCQ(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+	return c.showContinuousQueries(ctx, db)
+}
+
+func (c *Client) getCQ(ctx context.Context, db, name string) (chronograf.ContinuousQuery, error) {
+	cqs, err := c.AllCQ(ctx, db)
+	if err != nil {
+		return chronograf.ContinuousQuery{}, err
+	}
+
+	for _, cq := range cqs {
+		if cq.Name == name {
+			return cq, nil
+		}
+	}
+	return chronograf.ContinuousQuery{}, fmt.Errorf("unknown continuous query")
+}
+
+// CreateCQ validates the continuous query with the InfluxQL parser and then
+// creates it on a specific database
+func (c *Client) CreateCQ(ctx context.Context, db string, cq *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	stmt, err := ContinuousQueryStatement(db, cq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.createCQ(ctx, stmt); err != nil {
+		return nil, err
+	}
+
+	res, err := c.getCQ(ctx, db, stmt.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateCQ replaces a continuous query of a specific database. InfluxDB
+// does not support altering continuous queries, so the existing query is
+// dropped and the updated one is created in its place. Should the creation
+// fail, the original continuous query is restored.
+func (c *Client) UpdateCQ(ctx context.Context, db string, name string, upd *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	orig, err := c.getCQ(ctx, db, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.Name == "" {
+		upd.Name = name
+	}
+
+	// Validate the update before touching the existing continuous query
+	stmt, err := ContinuousQueryStatement(db, upd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.DropCQ(ctx, db, name); err != nil {
+		return nil, err
+	}
+
+	if err := c.createCQ(ctx, stmt); err != nil {
+		// Put the original back; if that fails too the continuous query is gone
+		prev, rerr := ContinuousQueryStatement(db, &orig)
+		if rerr == nil {
+			rerr = c.createCQ(ctx, prev)
+		}
+		if rerr != nil {
+			return nil, fmt.Errorf("%v; restoring continuous query %s failed: %v", err, name, rerr)
+		}
+		return nil, err
+	}
+
+	res, err := c.getCQ(ctx, db, stmt.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DropCQ removes a continuous query from a specific database
+func (c *Client) DropCQ(ctx context.Context, db string, name string) error {
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: fmt.Sprintf(`DROP CONTINUOUS QUERY %s ON %s`, influxql.QuoteIdent(name), influxql.QuoteIdent(db)),
+		DB:      db,
+	})
+	if err != nil {
+		return err
+	}
+	return statementError(res)
+}
+
+func (c *Client) createCQ(ctx context.Context, stmt *influxql.CreateContinuousQueryStatement) error {
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: stmt.String(),
+		DB:      stmt.Database,
+	})
+	if err != nil {
+		return err
+	}
+	return statementError(res)
+}
+
+func (c *Client) showContinuousQueries(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+	cqs, err := c.Query(ctx, chronograf.Query{
+		Command: `SHOW CONTINUOUS QUERIES`,
+		DB:      db,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	octets, err := cqs.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	results := showResults{}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, err
+	}
+
+	return results.ContinuousQueries(db), nil
+}
+
+// ContinuousQueryStatement builds the CREATE CONTINUOUS QUERY statement of a
+// continuous query on db. The statement is run through the InfluxQL parser,
+// so an error is returned if the query is not a valid SELECT ... INTO
+// statement or if the resample intervals are invalid.
+func ContinuousQueryStatement(db string, cq *chronograf.ContinuousQuery) (*influxql.CreateContinuousQueryStatement, error) {
+	if cq.Name == "" {
+		return nil, fmt.Errorf("continuous query name is required")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "CREATE CONTINUOUS QUERY %s ON %s ", influxql.QuoteIdent(cq.Name), influxql.QuoteIdent(db))
+	if cq.ResampleEvery != "" || cq.ResampleFor != "" {
+		buf.WriteString("RESAMPLE ")
+		if cq.ResampleEvery != "" {
+			fmt.Fprintf(&buf, "EVERY %s ", cq.ResampleEvery)
+		}
+		if cq.ResampleFor != "" {
+			fmt.Fprintf(&buf, "FOR %s ", cq.ResampleFor)
+		}
+	}
+	fmt.Fprintf(&buf, "BEGIN %s END", cq.Query)
+
+	stmt, err := influxql.ParseStatement(buf.String())
+	if err != nil {
+		return nil, err
+	}
+
+	create, ok := stmt.(*influxql.CreateContinuousQueryStatement)
+	if !ok {
+		return nil, fmt.Errorf("not a continuous query: %s", cq.Query)
+	}
+	return create, nil
+}
+
+// ParseContinuousQuery converts a CREATE CONTINUOUS QUERY statement, as
+// reported by SHOW CONTINUOUS QUERIES, into a chronograf.ContinuousQuery
+func ParseContinuousQuery(influxQL string) (chronograf.ContinuousQuery, error) {
+	stmt, err := influxql.ParseStatement(influxQL)
+	if err != nil {
+		return chronograf.ContinuousQuery{}, err
+	}
+
+	create, ok := stmt.(*influxql.CreateContinuousQueryStatement)
+	if !ok {
+		return chronograf.ContinuousQuery{}, fmt.Errorf("not a continuous query: %s", influxQL)
+	}
+
+	cq := chronograf.ContinuousQuery{
+		Name:     create.Name,
+		Database: create.Database,
+		Query:    create.Source.String(),
+	}
+	if create.ResampleEvery > 0 {
+		cq.ResampleEvery = influxql.FormatDuration(create.ResampleEvery)
+	}
+	if create.ResampleFor > 0 {
+		cq.ResampleFor = influxql.FormatDuration(create.ResampleFor)
+	}
+
+	sources := []string{}
+	for _, src := range create.Source.Sources {
+		if m, ok := src.(*influxql.Measurement); ok {
+			sources = append(sources, m.String())
+		}
+	}
+	cq.Source = strings.Join(sources, ", ")
+
+	if create.Source.Target != nil {
+		cq.Target = strings.TrimPrefix(create.Source.Target.String(), "INTO ")
+	}
+
+	return cq, nil
+}
+
+// statementError returns the first error InfluxDB reported within the
+// results of a query. Statements such as CREATE CONTINUOUS QUERY respond
+// with a 200 and put the error within the results themselves.
+func statementError(res chronograf.Response) error {
+	octets, err := res.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	// An empty response has no results to inspect
+	if len(octets) == 0 {
+		return nil
+	}
+
+	results := make([]struct{ Error string }, 0)
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Error != "" {
+			return errors.New(r.Error)
+		}
+	}
+	return nil
+}
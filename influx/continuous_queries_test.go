@@ -0,0 +1,253 @@
+package influx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+)
+
+func TestParseContinuousQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    chronograf.ContinuousQuery
+		wantErr bool
+	}{
+		{
+			name:  "basic continuous query",
+			query: `CREATE CONTINUOUS QUERY cpu_1h ON telegraf BEGIN SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h) END`,
+			want: chronograf.ContinuousQuery{
+				Name:     "cpu_1h",
+				Database: "telegraf",
+				Source:   "telegraf.autogen.cpu",
+				Target:   "telegraf.autogen.cpu_1h",
+				Query:    "SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h)",
+			},
+		},
+		{
+			name:  "resampled continuous query with backreference",
+			query: `CREATE CONTINUOUS QUERY "downsample" ON "telegraf" RESAMPLE EVERY 30m FOR 2h BEGIN SELECT mean(*) INTO "rollup".:MEASUREMENT FROM /.*/ GROUP BY time(1h), * END`,
+			want: chronograf.ContinuousQuery{
+				Name:          "downsample",
+				Database:      "telegraf",
+				ResampleEvery: "30m",
+				ResampleFor:   "2h",
+				Source:        "/.*/",
+				Target:        "rollup.:MEASUREMENT",
+				Query:         "SELECT mean(*) INTO rollup.:MEASUREMENT FROM /.*/ GROUP BY time(1h), *",
+			},
+		},
+		{
+			name:    "not a continuous query",
+			query:   `SELECT mean(usage_user) FROM cpu`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseContinuousQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseContinuousQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseContinuousQuery() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContinuousQueryStatement(t *testing.T) {
+	tests := []struct {
+		name    string
+		cq      chronograf.ContinuousQuery
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "valid continuous query",
+			cq: chronograf.ContinuousQuery{
+				Name:          "cpu 1h",
+				ResampleEvery: "30m",
+				Query:         "SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h)",
+			},
+			want: `CREATE CONTINUOUS QUERY "cpu 1h" ON telegraf RESAMPLE EVERY 30m BEGIN SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h) END`,
+		},
+		{
+			name: "missing INTO clause",
+			cq: chronograf.ContinuousQuery{
+				Name:  "cpu_1h",
+				Query: "SELECT mean(usage_user) FROM cpu GROUP BY time(1h)",
+			},
+			wantErr: true,
+		},
+		{
+			name: "aggregate without GROUP BY time",
+			cq: chronograf.ContinuousQuery{
+				Name:  "cpu_1h",
+				Query: "SELECT mean(usage_user) INTO cpu_1h FROM cpu",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid resample interval",
+			cq: chronograf.ContinuousQuery{
+				Name:          "cpu_1h",
+				ResampleEvery: "often",
+				Query:         "SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h)",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing name",
+			cq: chronograf.ContinuousQuery{
+				Query: "SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h)",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContinuousQueryStatement("telegraf", &tt.cq)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ContinuousQueryStatement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ContinuousQueryStatement() = %s, want %s", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_AllCQ(t *testing.T) {
+	t.Parallel()
+	showCQs := []byte(`{"results":[{"statement_id":0,"series":[{"name":"_internal","columns":["name","query"]},{"name":"telegraf","columns":["name","query"],"values":[["cpu_1h","CREATE CONTINUOUS QUERY cpu_1h ON telegraf BEGIN SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h) END"]]}]}]}`)
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "SHOW CONTINUOUS QUERIES" {
+			t.Errorf("Expected SHOW CONTINUOUS QUERIES but was %s", q)
+		}
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(showCQs)
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	c := &Client{
+		URL:    u,
+		Logger: log.New(log.DebugLevel),
+	}
+
+	got, err := c.AllCQ(context.Background(), "telegraf")
+	if err != nil {
+		t.Fatalf("Client.AllCQ() unexpected error %v", err)
+	}
+	want := []chronograf.ContinuousQuery{
+		{
+			Name:     "cpu_1h",
+			Database: "telegraf",
+			Source:   "telegraf.autogen.cpu",
+			Target:   "telegraf.autogen.cpu_1h",
+			Query:    "SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h)",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.AllCQ() = %#v, want %#v", got, want)
+	}
+}
+
+func TestClient_CreateCQ(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		create      []byte
+		wantQueries []string
+		wantErr     bool
+	}{
+		{
+			name:   "Create continuous query",
+			create: []byte(`{"results":[{"statement_id":0}]}`),
+			wantQueries: []string{
+				`CREATE CONTINUOUS QUERY cpu_1h ON telegraf BEGIN SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h) END`,
+				`SHOW CONTINUOUS QUERIES`,
+			},
+		},
+		{
+			name:   "Continuous query already exists",
+			create: []byte(`{"results":[{"statement_id":0,"error":"continuous query already exists"}]}`),
+			wantQueries: []string{
+				`CREATE CONTINUOUS QUERY cpu_1h ON telegraf BEGIN SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h) END`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		queries := []string{}
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+			rw.WriteHeader(http.StatusOK)
+			if q == "SHOW CONTINUOUS QUERIES" {
+				rw.Write([]byte(`{"results":[{"series":[{"name":"telegraf","columns":["name","query"],"values":[["cpu_1h","CREATE CONTINUOUS QUERY cpu_1h ON telegraf BEGIN SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h) END"]]}]}]}`))
+				return
+			}
+			rw.Write(tt.create)
+		}))
+		u, _ := url.Parse(ts.URL)
+		c := &Client{
+			URL:    u,
+			Logger: log.New(log.DebugLevel),
+		}
+
+		_, err := c.CreateCQ(context.Background(), "telegraf", &chronograf.ContinuousQuery{
+			Name:  "cpu_1h",
+			Query: "SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h)",
+		})
+		ts.Close()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q. Client.CreateCQ() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(queries, tt.wantQueries) {
+			t.Errorf("%q. Client.CreateCQ() queries = %v, want %v", tt.name, queries, tt.wantQueries)
+		}
+	}
+}
+
+func TestClient_UpdateCQ_RestoreFails(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		rw.WriteHeader(http.StatusOK)
+		switch {
+		case q == "SHOW CONTINUOUS QUERIES":
+			rw.Write([]byte(`{"results":[{"series":[{"name":"telegraf","columns":["name","query"],"values":[["cpu_1h","CREATE CONTINUOUS QUERY cpu_1h ON telegraf BEGIN SELECT mean(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h) END"]]}]}]}`))
+		case strings.HasPrefix(q, "DROP"):
+			rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		default:
+			rw.Write([]byte(`{"results":[{"statement_id":0,"error":"shard is read only"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	c := &Client{
+		URL:    u,
+		Logger: log.New(log.DebugLevel),
+	}
+
+	_, err := c.UpdateCQ(context.Background(), "telegraf", "cpu_1h", &chronograf.ContinuousQuery{
+		Query: "SELECT max(usage_user) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h)",
+	})
+	if err == nil || !strings.Contains(err.Error(), "restoring continuous query cpu_1h failed") {
+		t.Errorf("Client.UpdateCQ() error = %v, want the failed restore", err)
+	}
+}
@@ -5,12 +5,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/influxdb/influxql"
 )
 
-// AllDB returns all databases from within Influx
+// AllDB returns all databases from within Influx. The databases of
+// InfluxDB 2.x are those its buckets are mapped to.
 func (c *Client) AllDB(ctx context.Context) ([]chronograf.Database, error) {
+	if c.Org != "" {
+		return c.bucketDatabases(ctx)
+	}
 	return c.showDatabases(ctx)
 }
 
@@ -42,6 +48,9 @@ func (c *Client) DropDB(ctx context.Context, db string) error {
 
 // AllRP returns all the retention policies for a specific database
 func (c *Client) AllRP(ctx context.Context, db string) ([]chronograf.RetentionPolicy, error) {
+	if c.Org != "" {
+		return c.bucketRetentionPolicies(ctx, db)
+	}
 	return c.showRetentionPolicies(ctx, db)
 }
 
@@ -112,24 +121,10 @@ func (c *Client) UpdateRP(ctx context.Context, db string, rp string, upd *chrono
 	}
 
 	// The ALTER RETENTION POLICIES statements puts the error within the results itself
-	// So, we have to crack open the results to see what happens
-	octets, err := queryRes.MarshalJSON()
-	if err != nil {
-		return nil, err
-	}
-
-	results := make([]struct{ Error string }, 0)
-	if err := json.Unmarshal(octets, &results); err != nil {
+	if err := statementError(queryRes); err != nil {
 		return nil, err
 	}
 
-	// At last, we can check if there are any error strings
-	for _, r := range results {
-		if r.Error != "" {
-			return nil, fmt.Errorf(r.Error)
-		}
-	}
-
 	res, err := c.getRP(ctx, db, upd.Name)
 	if err != nil {
 		return nil, err
@@ -200,6 +195,191 @@ func (c *Client) showRetentionPolicies(ctx context.Context, db string) ([]chrono
 	return results.RetentionPolicies(), nil
 }
 
+// AllShards returns the shards of a specific database along with their
+// sizes on disk
+func (c *Client) AllShards(ctx context.Context, db string) ([]chronograf.Shard, error) {
+	if c.Org != "" {
+		return nil, fmt.Errorf("shards are not supported by InfluxDB 2.x sources")
+	}
+	return c.showShards(ctx, db)
+}
+
+// DropShard removes a shard and its data
+func (c *Client) DropShard(ctx context.Context, id uint64) error {
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: fmt.Sprintf(`DROP SHARD %d`, id),
+	})
+	if err != nil {
+		return err
+	}
+	return statementError(res)
+}
+
+// AllSubscriptions returns the subscriptions of every retention policy of a
+// specific database
+func (c *Client) AllSubscriptions(ctx context.Context, db string) ([]chronograf.Subscription, error) {
+	if c.Org != "" {
+		return nil, fmt.Errorf("subscriptions are not supported by InfluxDB 2.x sources")
+	}
+	return c.showSubscriptions(ctx, db)
+}
+
+func (c *Client) getSubscription(ctx context.Context, db, rp, name string) (chronograf.Subscription, error) {
+	subs, err := c.AllSubscriptions(ctx, db)
+	if err != nil {
+		return chronograf.Subscription{}, err
+	}
+
+	for _, sub := range subs {
+		if sub.RetentionPolicy == rp && sub.Name == name {
+			return sub, nil
+		}
+	}
+	return chronograf.Subscription{}, fmt.Errorf("unknown subscription")
+}
+
+// CreateSubscription creates a subscription on a retention policy of a
+// specific database
+func (c *Client) CreateSubscription(ctx context.Context, db string, sub *chronograf.Subscription) (*chronograf.Subscription, error) {
+	if err := c.createSubscription(ctx, db, sub); err != nil {
+		return nil, err
+	}
+
+	res, err := c.getSubscription(ctx, db, sub.RetentionPolicy, sub.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateSubscription replaces a subscription of a retention policy of a
+// specific database. InfluxDB does not support altering subscriptions, so
+// the existing subscription is dropped and the updated one is created in
+// its place. Should the creation fail, the original subscription is restored.
+func (c *Client) UpdateSubscription(ctx context.Context, db, rp, name string, upd *chronograf.Subscription) (*chronograf.Subscription, error) {
+	orig, err := c.getSubscription(ctx, db, rp, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.Name == "" {
+		upd.Name = name
+	}
+	if upd.RetentionPolicy == "" {
+		upd.RetentionPolicy = rp
+	}
+
+	if err := c.DropSubscription(ctx, db, rp, name); err != nil {
+		return nil, err
+	}
+
+	if err := c.createSubscription(ctx, db, upd); err != nil {
+		// Put the original back; if that fails too the subscription is gone
+		if rerr := c.createSubscription(ctx, db, &orig); rerr != nil {
+			return nil, fmt.Errorf("%v; restoring subscription %s failed: %v", err, name, rerr)
+		}
+		return nil, err
+	}
+
+	res, err := c.getSubscription(ctx, db, upd.RetentionPolicy, upd.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DropSubscription removes a subscription from a retention policy of a
+// specific database
+func (c *Client) DropSubscription(ctx context.Context, db, rp, name string) error {
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: fmt.Sprintf(`DROP SUBSCRIPTION %s ON %s.%s`, influxql.QuoteIdent(name), influxql.QuoteIdent(db), influxql.QuoteIdent(rp)),
+		DB:      db,
+		RP:      rp,
+	})
+	if err != nil {
+		return err
+	}
+	return statementError(res)
+}
+
+func (c *Client) createSubscription(ctx context.Context, db string, sub *chronograf.Subscription) error {
+	dests := make([]string, len(sub.Destinations))
+	for i, dest := range sub.Destinations {
+		dests[i] = influxql.QuoteString(dest)
+	}
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: fmt.Sprintf(`CREATE SUBSCRIPTION %s ON %s.%s DESTINATIONS %s %s`,
+			influxql.QuoteIdent(sub.Name), influxql.QuoteIdent(db), influxql.QuoteIdent(sub.RetentionPolicy),
+			strings.ToUpper(sub.Mode), strings.Join(dests, ", ")),
+		DB: db,
+		RP: sub.RetentionPolicy,
+	})
+	if err != nil {
+		return err
+	}
+	return statementError(res)
+}
+
+func (c *Client) showShards(ctx context.Context, db string) ([]chronograf.Shard, error) {
+	shards, err := c.Query(ctx, chronograf.Query{
+		Command: `SHOW SHARDS`,
+	})
+	if err != nil {
+		return nil, err
+	}
+	octets, err := shards.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	results := showResults{}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, err
+	}
+
+	// The sizes of the shards are only reported by the statistics of the
+	// shards stored by the node queried
+	stats, err := c.Query(ctx, chronograf.Query{
+		Command: `SHOW STATS FOR 'shard'`,
+	})
+	if err != nil {
+		return nil, err
+	}
+	octets, err = stats.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	statsResults := showResults{}
+	if err := json.Unmarshal(octets, &statsResults); err != nil {
+		return nil, err
+	}
+
+	return results.Shards(db, statsResults.ShardSizes()), nil
+}
+
+func (c *Client) showSubscriptions(ctx context.Context, db string) ([]chronograf.Subscription, error) {
+	subs, err := c.Query(ctx, chronograf.Query{
+		Command: `SHOW SUBSCRIPTIONS`,
+	})
+	if err != nil {
+		return nil, err
+	}
+	octets, err := subs.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	results := showResults{}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, err
+	}
+
+	return results.Subscriptions(db), nil
+}
+
 func (c *Client) showMeasurements(ctx context.Context, db string, limit, offset int) ([]chronograf.Measurement, error) {
 	show := fmt.Sprintf(`SHOW MEASUREMENTS ON "%s"`, db)
 	if limit > 0 {
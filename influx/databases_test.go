@@ -0,0 +1,173 @@
+package influx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+)
+
+func TestClient_AllShards(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+		switch q := r.URL.Query().Get("q"); q {
+		case "SHOW SHARDS":
+			rw.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"_internal","columns":["id","database","retention_policy","shard_group","start_time","end_time","expiry_time","owners"],"values":[[1,"_internal","monitor",1,"2018-09-01T00:00:00Z","2018-09-02T00:00:00Z","2018-09-09T00:00:00Z",""]]},{"name":"telegraf","columns":["id","database","retention_policy","shard_group","start_time","end_time","expiry_time","owners"],"values":[[2,"telegraf","autogen",2,"2018-08-27T00:00:00Z","2018-09-03T00:00:00Z","2018-09-03T00:00:00Z","4,5"]]}]}]}`))
+		case "SHOW STATS FOR 'shard'":
+			rw.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"shard","tags":{"database":"_internal","id":"1","retentionPolicy":"monitor"},"columns":["diskBytes","writePointsOk"],"values":[[1024,10]]},{"name":"shard","tags":{"database":"telegraf","id":"2","retentionPolicy":"autogen"},"columns":["diskBytes","writePointsOk"],"values":[[4096,20]]}]}]}`))
+		default:
+			t.Errorf("Unexpected query %s", q)
+		}
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	c := &Client{
+		URL:    u,
+		Logger: log.New(log.DebugLevel),
+	}
+
+	got, err := c.AllShards(context.Background(), "telegraf")
+	if err != nil {
+		t.Fatalf("Client.AllShards() unexpected error %v", err)
+	}
+	want := []chronograf.Shard{
+		{
+			ID:              2,
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			ShardGroup:      2,
+			StartTime:       "2018-08-27T00:00:00Z",
+			EndTime:         "2018-09-03T00:00:00Z",
+			ExpiryTime:      "2018-09-03T00:00:00Z",
+			Owners:          []string{"4", "5"},
+			Size:            4096,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.AllShards() = %#v, want %#v", got, want)
+	}
+}
+
+func TestClient_AllSubscriptions(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "SHOW SUBSCRIPTIONS" {
+			t.Errorf("Expected SHOW SUBSCRIPTIONS but was %s", q)
+		}
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"telegraf","columns":["retention_policy","name","mode","destinations"],"values":[["autogen","kapacitor-1","ANY",["http://kapa:9092"]]]}]}]}`))
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	c := &Client{
+		URL:    u,
+		Logger: log.New(log.DebugLevel),
+	}
+
+	got, err := c.AllSubscriptions(context.Background(), "telegraf")
+	if err != nil {
+		t.Fatalf("Client.AllSubscriptions() unexpected error %v", err)
+	}
+	want := []chronograf.Subscription{
+		{
+			Name:            "kapacitor-1",
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			Mode:            "ANY",
+			Destinations:    []string{"http://kapa:9092"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.AllSubscriptions() = %#v, want %#v", got, want)
+	}
+}
+
+func TestClient_UpdateSubscription(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		create      []byte
+		restore     []byte
+		wantQueries []string
+		wantErr     string
+	}{
+		{
+			name:   "Replace subscription",
+			create: []byte(`{"results":[{"statement_id":0}]}`),
+			wantQueries: []string{
+				`SHOW SUBSCRIPTIONS`,
+				`DROP SUBSCRIPTION "kapacitor-1" ON telegraf.autogen`,
+				`CREATE SUBSCRIPTION "kapacitor-1" ON telegraf.autogen DESTINATIONS ALL 'http://kapa:9092', 'http://kapa2:9092'`,
+				`SHOW SUBSCRIPTIONS`,
+			},
+		},
+		{
+			name:   "Original subscription is restored",
+			create: []byte(`{"results":[{"statement_id":0,"error":"invalid destination"}]}`),
+			wantQueries: []string{
+				`SHOW SUBSCRIPTIONS`,
+				`DROP SUBSCRIPTION "kapacitor-1" ON telegraf.autogen`,
+				`CREATE SUBSCRIPTION "kapacitor-1" ON telegraf.autogen DESTINATIONS ALL 'http://kapa:9092', 'http://kapa2:9092'`,
+				`CREATE SUBSCRIPTION "kapacitor-1" ON telegraf.autogen DESTINATIONS ANY 'http://kapa:9092'`,
+			},
+			wantErr: "invalid destination",
+		},
+		{
+			name:    "Failed restore is reported",
+			create:  []byte(`{"results":[{"statement_id":0,"error":"invalid destination"}]}`),
+			restore: []byte(`{"results":[{"statement_id":0,"error":"timeout"}]}`),
+			wantQueries: []string{
+				`SHOW SUBSCRIPTIONS`,
+				`DROP SUBSCRIPTION "kapacitor-1" ON telegraf.autogen`,
+				`CREATE SUBSCRIPTION "kapacitor-1" ON telegraf.autogen DESTINATIONS ALL 'http://kapa:9092', 'http://kapa2:9092'`,
+				`CREATE SUBSCRIPTION "kapacitor-1" ON telegraf.autogen DESTINATIONS ANY 'http://kapa:9092'`,
+			},
+			wantErr: "invalid destination; restoring subscription kapacitor-1 failed: timeout",
+		},
+	}
+	for _, tt := range tests {
+		queries := []string{}
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+			rw.WriteHeader(http.StatusOK)
+			switch {
+			case q == "SHOW SUBSCRIPTIONS":
+				rw.Write([]byte(`{"results":[{"series":[{"name":"telegraf","columns":["retention_policy","name","mode","destinations"],"values":[["autogen","kapacitor-1","ANY",["http://kapa:9092"]]]}]}]}`))
+			case len(queries) == 3:
+				rw.Write(tt.create)
+			case len(queries) == 4 && tt.restore != nil:
+				rw.Write(tt.restore)
+			default:
+				rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+			}
+		}))
+		u, _ := url.Parse(ts.URL)
+		c := &Client{
+			URL:    u,
+			Logger: log.New(log.DebugLevel),
+		}
+
+		_, err := c.UpdateSubscription(context.Background(), "telegraf", "autogen", "kapacitor-1", &chronograf.Subscription{
+			Mode:         "all",
+			Destinations: []string{"http://kapa:9092", "http://kapa2:9092"},
+		})
+		ts.Close()
+		if gotErr := fmt.Sprint(err); (err != nil || tt.wantErr != "") && gotErr != tt.wantErr {
+			t.Errorf("%q. Client.UpdateSubscription() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(queries, tt.wantQueries) {
+			t.Errorf("%q. Client.UpdateSubscription() queries = %v, want %v", tt.name, queries, tt.wantQueries)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package influx
+
+import (
+	"net"
+	"strings"
+
+	"github.com/influxdata/chronograf"
+)
+
+// IsServerFailure distinguishes errors of the server itself, such as an
+// unreachable host or a server error, from errors of the query. Clients
+// spreading queries over several servers use it to decide whether a
+// server is unhealthy.
+func IsServerFailure(err error) bool {
+	if err == chronograf.ErrUpstreamTimeout {
+		return true
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	return strings.HasPrefix(err.Error(), "received status code 5")
+}
+
+// IsReadOnly reports whether every statement of the command only reads, so
+// that it may safely be retried on another server
+func IsReadOnly(command string) bool {
+	for _, stmt := range strings.Split(command, ";") {
+		stmt = strings.ToUpper(strings.TrimSpace(stmt))
+		if stmt == "" {
+			continue
+		}
+		if strings.HasPrefix(stmt, "SHOW ") {
+			continue
+		}
+		if strings.HasPrefix(stmt, "SELECT ") && !strings.Contains(stmt, " INTO ") {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package influx_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+func TestIsServerFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "timeout", err: chronograf.ErrUpstreamTimeout, want: true},
+		{name: "unreachable", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "server error", err: errors.New("received status code 503 from server: err: "), want: true},
+		{name: "query error", err: errors.New("received status code 400 from server: err: error parsing query"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := influx.IsServerFailure(tt.err); got != tt.want {
+				t.Errorf("IsServerFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{command: "SELECT * FROM cpu", want: true},
+		{command: "show databases; select mean(value) from cpu;", want: true},
+		{command: "SELECT * INTO cpu_copy FROM cpu", want: false},
+		{command: "SHOW DATABASES; DROP DATABASE telegraf", want: false},
+		{command: "CREATE DATABASE telegraf", want: false},
+	}
+	for _, tt := range tests {
+		if got := influx.IsReadOnly(tt.command); got != tt.want {
+			t.Errorf("IsReadOnly(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
 
 	"github.com/influxdata/chronograf"
@@ -32,6 +33,7 @@ type Client struct {
 	Authorizer         Authorizer
 	InsecureSkipVerify bool
 	Logger             chronograf.Logger
+	Org                string // Org is the organization of InfluxDB 2.x sources
 }
 
 // Response is a partial JSON decoded InfluxQL response used
@@ -46,8 +48,15 @@ func (r Response) MarshalJSON() ([]byte, error) {
 	return r.Results, nil
 }
 
+// withPath returns a copy of the URL of an endpoint below its base path
+func withPath(u *url.URL, endpoint string) *url.URL {
+	eu := *u
+	eu.Path = path.Join(u.Path, endpoint)
+	return &eu
+}
+
 func (c *Client) query(u *url.URL, q chronograf.Query) (chronograf.Response, error) {
-	u.Path = "query"
+	u = withPath(u, "query")
 	req, err := http.NewRequest("POST", u.String(), nil)
 	if err != nil {
 		return nil, err
@@ -154,11 +163,15 @@ func (c *Client) Connect(ctx context.Context, src *chronograf.Source) error {
 	if err != nil {
 		return err
 	}
-	c.Authorizer = DefaultAuthorization(src)
+	c.Authorizer = DefaultAuthorization(ctx, src)
 	// Only allow acceptance of all certs if the scheme is https AND the user opted into to the setting.
 	if u.Scheme == "https" && src.InsecureSkipVerify {
 		c.InsecureSkipVerify = src.InsecureSkipVerify
 	}
+	c.Org = ""
+	if src.Type == chronograf.InfluxDBv2 {
+		c.Org = src.Org
+	}
 
 	c.URL = u
 	return nil
@@ -214,7 +227,7 @@ type pingResult struct {
 }
 
 func (c *Client) ping(u *url.URL) (string, string, error) {
-	u.Path = "ping"
+	u = withPath(u, "ping")
 
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
@@ -249,6 +262,9 @@ func (c *Client) ping(u *url.URL) (string, string, error) {
 		return version, chronograf.InfluxEnterprise, nil
 	}
 	version = resp.Header.Get("X-Influxdb-Version")
+	if strings.HasPrefix(strings.TrimPrefix(version, "v"), "2.") {
+		return version, chronograf.InfluxDBv2, nil
+	}
 	if strings.Contains(version, "-c") {
 		return version, chronograf.InfluxEnterprise, nil
 	} else if strings.Contains(version, "relay") {
@@ -301,7 +317,7 @@ func (c *Client) writePoint(ctx context.Context, point *chronograf.Point) error
 }
 
 func (c *Client) write(ctx context.Context, u *url.URL, db, rp, lp string) error {
-	u.Path = "write"
+	u = withPath(u, "write")
 	req, err := http.NewRequest("POST", u.String(), strings.NewReader(lp))
 	if err != nil {
 		return err
@@ -0,0 +1,319 @@
+package influx
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+)
+
+// DefaultIngestBatchSize is the number of lines written to InfluxDB at a time
+const DefaultIngestBatchSize = 5000
+
+// maxLineSize is the longest line of an upload
+const maxLineSize = 1024 * 1024
+
+// precisions are the multipliers of the write precisions from nanoseconds
+var precisions = map[string]int64{
+	"":   1,
+	"n":  1,
+	"ns": 1,
+	"u":  int64(time.Microsecond),
+	"ms": int64(time.Millisecond),
+	"s":  int64(time.Second),
+	"m":  int64(time.Minute),
+	"h":  int64(time.Hour),
+}
+
+// ValidPrecision checks that InfluxDB accepts the precision of a write
+func ValidPrecision(precision string) error {
+	if _, ok := precisions[precision]; !ok {
+		return fmt.Errorf("invalid precision %s; must be one of n, u, ms, s, m or h", precision)
+	}
+	return nil
+}
+
+// LineError is a line of an upload that is not valid
+type LineError struct {
+	Line  int    `json:"line"`  // Line is the number of the line in the upload starting at 1
+	Error string `json:"error"` // Error is why the line is not valid
+}
+
+// ParseLineProtocol validates every line of an upload of line protocol.
+// Blank lines and comments are skipped. The valid lines are returned along
+// with the errors of the others.
+func ParseLineProtocol(r io.Reader) ([]string, []LineError, error) {
+	lines, errs := []string{}, []LineError{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := ParseLine(line); err != nil {
+			errs = append(errs, LineError{Line: n, Error: err.Error()})
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return lines, errs, nil
+}
+
+// CSVMapping maps the columns of a CSV upload to points
+type CSVMapping struct {
+	Measurement       string            // Measurement of every row
+	MeasurementColumn string            // MeasurementColumn is the column of the measurement of each row; it overrides Measurement
+	Tags              []string          // Tags are the columns written as tags
+	Fields            map[string]string // Fields are the columns written as fields by type: float, integer, unsigned, boolean, string or empty to infer it
+	Time              string            // Time is the column of the timestamp; rows are timestamped by InfluxDB without it
+	TimeFormat        string            // TimeFormat is rfc3339 or empty for epoch timestamps in the precision of the write
+}
+
+// ParseCSV converts the rows of a CSV upload into line protocol of the
+// precision. The first row is the header naming the columns. The valid
+// lines are returned along with the errors of the other rows.
+func ParseCSV(r io.Reader, m CSVMapping, precision string) ([]string, []LineError, error) {
+	if err := ValidPrecision(precision); err != nil {
+		return nil, nil, err
+	}
+	if m.Measurement == "" && m.MeasurementColumn == "" {
+		return nil, nil, fmt.Errorf("measurement or measurement column required")
+	}
+	if len(m.Fields) == 0 {
+		return nil, nil, fmt.Errorf("at least one field column required")
+	}
+	switch m.TimeFormat {
+	case "", "rfc3339":
+	default:
+		return nil, nil, fmt.Errorf("invalid time format %s; must be rfc3339 or empty for epoch timestamps", m.TimeFormat)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	mapped := append([]string{}, m.Tags...)
+	for name := range m.Fields {
+		mapped = append(mapped, name)
+	}
+	if m.MeasurementColumn != "" {
+		mapped = append(mapped, m.MeasurementColumn)
+	}
+	if m.Time != "" {
+		mapped = append(mapped, m.Time)
+	}
+	for _, name := range mapped {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("unknown CSV column %s", name)
+		}
+	}
+
+	lines, errs := []string{}, []LineError{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		n, _ := reader.FieldPos(0)
+		if err != nil {
+			if pe, ok := err.(*csv.ParseError); ok {
+				errs = append(errs, LineError{Line: pe.Line, Error: pe.Err.Error()})
+				continue
+			}
+			return nil, nil, err
+		}
+		if len(row) != len(header) {
+			errs = append(errs, LineError{Line: n, Error: fmt.Sprintf("expected %d columns but got %d", len(header), len(row))})
+			continue
+		}
+
+		point, err := csvPoint(row, columns, m, precision)
+		if err != nil {
+			errs = append(errs, LineError{Line: n, Error: err.Error()})
+			continue
+		}
+		lp, err := toLineProtocol(point)
+		if err == nil {
+			_, err = ParseLine(lp)
+		}
+		if err != nil {
+			errs = append(errs, LineError{Line: n, Error: err.Error()})
+			continue
+		}
+		lines = append(lines, lp)
+	}
+	return lines, errs, nil
+}
+
+// csvPoint maps a row of a CSV upload to a point
+func csvPoint(row []string, columns map[string]int, m CSVMapping, precision string) (*chronograf.Point, error) {
+	point := &chronograf.Point{
+		Measurement: m.Measurement,
+		Tags:        map[string]string{},
+		Fields:      map[string]interface{}{},
+	}
+	if m.MeasurementColumn != "" {
+		point.Measurement = strings.TrimSpace(row[columns[m.MeasurementColumn]])
+	}
+	for _, tag := range m.Tags {
+		point.Tags[tag] = strings.TrimSpace(row[columns[tag]])
+	}
+
+	for field, typ := range m.Fields {
+		raw := strings.TrimSpace(row[columns[field]])
+		if raw == "" {
+			continue
+		}
+		value, err := csvValue(raw, typ)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of field %s: %v", field, err)
+		}
+		point.Fields[field] = value
+	}
+
+	if m.Time == "" {
+		return point, nil
+	}
+	raw := strings.TrimSpace(row[columns[m.Time]])
+	if m.TimeFormat == "rfc3339" {
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %s", raw)
+		}
+		point.Time = t.UnixNano() / precisions[precision]
+		return point, nil
+	}
+	t, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %s", raw)
+	}
+	point.Time = t
+	return point, nil
+}
+
+// csvValue converts a CSV value to the type of its field. Without a type
+// numbers are floats, true and false are booleans and the rest strings.
+func csvValue(raw, typ string) (interface{}, error) {
+	switch typ {
+	case "float":
+		return strconv.ParseFloat(raw, 64)
+	case "integer":
+		return strconv.ParseInt(raw, 10, 64)
+	case "unsigned":
+		return strconv.ParseUint(raw, 10, 64)
+	case "boolean":
+		return strconv.ParseBool(raw)
+	case "string":
+		return raw, nil
+	case "":
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f, nil
+		}
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b, nil
+		}
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown field type %s", typ)
+	}
+}
+
+// WriteLines writes line protocol of the precision to a database and
+// retention policy in gzipped batches of DefaultIngestBatchSize lines. It
+// returns the number of lines written before any error.
+func (c *Client) WriteLines(ctx context.Context, db, rp, precision string, lines []string) (int, error) {
+	if err := ValidPrecision(precision); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	for written < len(lines) {
+		end := written + DefaultIngestBatchSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if err := c.writeBatch(ctx, db, rp, precision, lines[written:end]); err != nil {
+			return written, err
+		}
+		written = end
+	}
+	return written, nil
+}
+
+func (c *Client) writeBatch(ctx context.Context, db, rp, precision string, lines []string) error {
+	var body bytes.Buffer
+	gz := gzip.NewWriter(&body)
+	for _, line := range lines {
+		if _, err := io.WriteString(gz, line+"\n"); err != nil {
+			return err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	u := withPath(c.URL, "write")
+	params := u.Query()
+	params.Set("db", db)
+	params.Set("rp", rp)
+	if precision != "" {
+		params.Set("precision", precision)
+	}
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("Content-Encoding", "gzip")
+	if c.Authorizer != nil {
+		if err := c.Authorizer.Set(req); err != nil {
+			return err
+		}
+	}
+
+	hc := &http.Client{}
+	if c.InsecureSkipVerify {
+		hc.Transport = skipVerifyTransport
+	} else {
+		hc.Transport = defaultTransport
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return chronograf.ErrUpstreamTimeout
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	var response Response
+	json.NewDecoder(resp.Body).Decode(&response)
+	return fmt.Errorf("received status code %d from server: err: %s", resp.StatusCode, response.Err)
+}
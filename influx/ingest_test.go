@@ -0,0 +1,135 @@
+package influx
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLineProtocol(t *testing.T) {
+	upload := `# comment
+cpu,host=a usage_idle=99.5 1535760000000000000
+
+cpu,host=b usage_idle=
+mem,host=a used=12i,free=4u
+disk path="/"extra`
+
+	lines, errs, err := ParseLineProtocol(strings.NewReader(upload))
+	if err != nil {
+		t.Fatal("Unexpected error parsing: err:", err)
+	}
+	wantLines := []string{
+		"cpu,host=a usage_idle=99.5 1535760000000000000",
+		"mem,host=a used=12i,free=4u",
+	}
+	if !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("ParseLineProtocol() lines = %v, want %v", lines, wantLines)
+	}
+	if len(errs) != 2 || errs[0].Line != 4 || errs[1].Line != 6 {
+		t.Errorf("ParseLineProtocol() errors = %v, want lines 4 and 6", errs)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	upload := `time,host,region,usage,count,up
+2018-09-01T00:00:00Z,a,west,99.5,3,true
+2018-09-01T00:00:10Z,b,east,high,4,false
+yesterday,c,east,12,5,true
+2018-09-01T00:00:20Z,"d e",east,,6,true
+`
+	m := CSVMapping{
+		Measurement: "cpu",
+		Tags:        []string{"host", "region"},
+		Fields: map[string]string{
+			"usage": "float",
+			"count": "integer",
+			"up":    "",
+		},
+		Time:       "time",
+		TimeFormat: "rfc3339",
+	}
+	lines, errs, err := ParseCSV(strings.NewReader(upload), m, "s")
+	if err != nil {
+		t.Fatal("Unexpected error parsing: err:", err)
+	}
+	wantLines := []string{
+		"cpu,host=a,region=west count=3i,up=true,usage=99.500000 1535760000",
+		`cpu,host=d\ e,region=east count=6i,up=true 1535760020`,
+	}
+	if !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("ParseCSV() lines = %v, want %v", lines, wantLines)
+	}
+	if len(errs) != 2 || errs[0].Line != 3 || errs[1].Line != 4 {
+		t.Errorf("ParseCSV() errors = %v, want lines 3 and 4", errs)
+	}
+
+	m.Tags = append(m.Tags, "zone")
+	if _, _, err := ParseCSV(strings.NewReader(upload), m, "s"); err == nil {
+		t.Error("Expected an error mapping an unknown column")
+	}
+}
+
+func TestClient_WriteLines(t *testing.T) {
+	var batches []string
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		if r.URL.Path != "/write" || params.Get("db") != "telegraf" || params.Get("rp") != "autogen" || params.Get("precision") != "s" {
+			t.Errorf("Unexpected write %s", r.URL)
+		}
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Error("Expected a gzipped write")
+		}
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatal("Unexpected error reading write: err:", err)
+		}
+		body, _ := ioutil.ReadAll(gz)
+		batches = append(batches, string(body))
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	c := &Client{URL: u}
+
+	lines := make([]string, DefaultIngestBatchSize+1)
+	for i := range lines {
+		lines[i] = "cpu value=1"
+	}
+	n, err := c.WriteLines(context.Background(), "telegraf", "autogen", "s", lines)
+	if err != nil {
+		t.Fatal("Unexpected error writing: err:", err)
+	}
+	if n != len(lines) {
+		t.Errorf("WriteLines() = %d, want %d", n, len(lines))
+	}
+	if len(batches) != 2 || batches[1] != "cpu value=1\n" {
+		t.Errorf("WriteLines() wrote %d batches, want 2", len(batches))
+	}
+
+	if _, err := c.WriteLines(context.Background(), "telegraf", "autogen", "y", lines); err == nil {
+		t.Error("Expected an error writing with an invalid precision")
+	}
+}
+
+func TestClient_WriteLinesBasePath(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/influx/write" {
+			t.Errorf("Unexpected write to %s", r.URL.Path)
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL + "/influx")
+	c := &Client{URL: u}
+	if _, err := c.WriteLines(context.Background(), "telegraf", "autogen", "s", []string{"cpu value=1"}); err != nil {
+		t.Fatal("Unexpected error writing: err:", err)
+	}
+}
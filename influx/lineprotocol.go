@@ -2,7 +2,9 @@ package influx
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/influxdata/chronograf"
@@ -82,3 +84,150 @@ func toLineProtocol(point *chronograf.Point) (string, error) {
 	}
 	return lp, nil
 }
+
+// ParseLine parses a single line of line protocol into a point. It accepts
+// the lines written by toLineProtocol as well as those of other writers.
+func ParseLine(line string) (*chronograf.Point, error) {
+	l := &lineScanner{s: line}
+	measurement := l.until(", ")
+	if measurement == "" {
+		return nil, fmt.Errorf("missing measurement")
+	}
+	point := &chronograf.Point{
+		Measurement: measurement,
+		Tags:        map[string]string{},
+		Fields:      map[string]interface{}{},
+	}
+
+	for l.peek() == ',' {
+		l.i++
+		key := l.until("=, ")
+		if key == "" || l.peek() != '=' {
+			return nil, fmt.Errorf("invalid tag at column %d", l.i+1)
+		}
+		l.i++
+		value := l.until(", ")
+		if value == "" {
+			return nil, fmt.Errorf("missing value of tag %s", key)
+		}
+		point.Tags[key] = value
+	}
+
+	if l.peek() != ' ' {
+		return nil, fmt.Errorf("invalid measurement at column %d", l.i+1)
+	}
+	l.skipSpaces()
+	for {
+		key := l.until("=, ")
+		if key == "" || l.peek() != '=' {
+			return nil, fmt.Errorf("invalid field at column %d", l.i+1)
+		}
+		l.i++
+		value, err := l.fieldValue()
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of field %s: %v", key, err)
+		}
+		point.Fields[key] = value
+		if l.peek() != ',' {
+			break
+		}
+		l.i++
+	}
+
+	if l.peek() != ' ' && l.peek() != 0 {
+		return nil, fmt.Errorf("invalid field at column %d", l.i+1)
+	}
+	if ts := strings.TrimSpace(l.s[l.i:]); ts != "" {
+		t, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %s", ts)
+		}
+		point.Time = t
+	}
+	return point, nil
+}
+
+// lineScanner reads the elements of a line of line protocol
+type lineScanner struct {
+	s string
+	i int
+}
+
+// peek returns the next byte or 0 at the end of the line
+func (l *lineScanner) peek() byte {
+	if l.i >= len(l.s) {
+		return 0
+	}
+	return l.s[l.i]
+}
+
+func (l *lineScanner) skipSpaces() {
+	for l.peek() == ' ' {
+		l.i++
+	}
+}
+
+// until returns the unescaped text up to the first unescaped stop byte
+func (l *lineScanner) until(stops string) string {
+	var b strings.Builder
+	for l.i < len(l.s) {
+		c := l.s[l.i]
+		if c == '\\' && l.i+1 < len(l.s) && strings.IndexByte(`, ="`, l.s[l.i+1]) >= 0 {
+			b.WriteByte(l.s[l.i+1])
+			l.i += 2
+			continue
+		}
+		if strings.IndexByte(stops, c) >= 0 {
+			break
+		}
+		b.WriteByte(c)
+		l.i++
+	}
+	return b.String()
+}
+
+// fieldValue parses a string, integer, unsigned, boolean or float field value
+func (l *lineScanner) fieldValue() (interface{}, error) {
+	if l.peek() == '"' {
+		var b strings.Builder
+		for l.i++; l.i < len(l.s); l.i++ {
+			c := l.s[l.i]
+			if c == '\\' && l.i+1 < len(l.s) && (l.s[l.i+1] == '"' || l.s[l.i+1] == '\\') {
+				l.i++
+				b.WriteByte(l.s[l.i])
+				continue
+			}
+			if c == '"' {
+				l.i++
+				return b.String(), nil
+			}
+			b.WriteByte(c)
+		}
+		return nil, fmt.Errorf("unterminated string")
+	}
+
+	start := l.i
+	for l.i < len(l.s) && l.s[l.i] != ',' && l.s[l.i] != ' ' {
+		l.i++
+	}
+	raw := l.s[start:l.i]
+	switch {
+	case raw == "":
+		return nil, fmt.Errorf("missing value")
+	case strings.HasSuffix(raw, "i"):
+		return strconv.ParseInt(raw[:len(raw)-1], 10, 64)
+	case strings.HasSuffix(raw, "u"):
+		return strconv.ParseUint(raw[:len(raw)-1], 10, 64)
+	}
+	switch raw {
+	case "t", "T", "true", "True", "TRUE":
+		return true, nil
+	case "f", "F", "false", "False", "FALSE":
+		return false, nil
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("invalid number %s", raw)
+	}
+	return f, nil
+}
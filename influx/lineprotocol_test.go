@@ -1,6 +1,7 @@
 package influx
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -127,3 +128,102 @@ func Test_toLineProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    *chronograf.Point
+		wantErr bool
+	}{
+		{
+			name: "all influx data types",
+			line: `telegraf,doc=brown,marty=mcfly float=88.000000,int=19i,string="mph",time_machine=true,uint=85u 497115501000000000`,
+			want: &chronograf.Point{
+				Measurement: "telegraf",
+				Tags:        map[string]string{"doc": "brown", "marty": "mcfly"},
+				Fields: map[string]interface{}{
+					"float":        88.0,
+					"int":          int64(19),
+					"string":       "mph",
+					"time_machine": true,
+					"uint":         uint64(85),
+				},
+				Time: 497115501000000000,
+			},
+		},
+		{
+			name: "escaped measurement, tags and strings",
+			line: `cpu\ load,host\=name=a\,b value="say \"hi\" \\o/",e=1e3`,
+			want: &chronograf.Point{
+				Measurement: "cpu load",
+				Tags:        map[string]string{"host=name": "a,b"},
+				Fields: map[string]interface{}{
+					"value": `say "hi" \o/`,
+					"e":     1000.0,
+				},
+			},
+		},
+		{
+			name:    "requires fields",
+			line:    "cpu,host=a",
+			wantErr: true,
+		},
+		{
+			name:    "rejects missing tag values",
+			line:    "cpu,host= value=1",
+			wantErr: true,
+		},
+		{
+			name:    "rejects invalid numbers",
+			line:    "cpu value=1.2.3",
+			wantErr: true,
+		},
+		{
+			name:    "rejects unterminated strings",
+			line:    `cpu value="open`,
+			wantErr: true,
+		},
+		{
+			name:    "rejects invalid timestamps",
+			line:    "cpu value=1 yesterday",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLine_toLineProtocol(t *testing.T) {
+	point := &chronograf.Point{
+		Measurement: "my measurement,with commas",
+		Tags:        map[string]string{"tag key": "tag=value,with spaces"},
+		Fields: map[string]interface{}{
+			"int":    int64(-7),
+			"uint":   uint64(7),
+			"string": `with "quotes" and \backslashes\`,
+			"bool":   false,
+		},
+		Time: 1535760000000000000,
+	}
+	lp, err := toLineProtocol(point)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseLine(lp)
+	if err != nil {
+		t.Fatalf("ParseLine(%s) error = %v", lp, err)
+	}
+	if !reflect.DeepEqual(got, point) {
+		t.Errorf("ParseLine(%s) = %#v, want %#v", lp, got, point)
+	}
+}
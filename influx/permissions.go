@@ -3,6 +3,8 @@ package influx
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/influxdata/chronograf"
 )
@@ -45,7 +47,10 @@ func (c *Client) Permissions(context.Context) chronograf.Permissions {
 // showResults is used to deserialize InfluxQL SHOW commands
 type showResults []struct {
 	Series []struct {
-		Values [][]interface{} `json:"values"`
+		Name    string            `json:"name"`
+		Tags    map[string]string `json:"tags"`
+		Columns []string          `json:"columns"`
+		Values  [][]interface{}   `json:"values"`
 	} `json:"series"`
 }
 
@@ -142,6 +147,197 @@ func (r *showResults) Measurements() []chronograf.Measurement {
 	return res
 }
 
+// ContinuousQueries converts SHOW CONTINUOUS QUERIES to the chronograf
+// ContinuousQueries of a database. Queries that cannot be parsed are still
+// returned with their raw InfluxQL.
+func (r *showResults) ContinuousQueries(db string) []chronograf.ContinuousQuery {
+	res := []chronograf.ContinuousQuery{}
+	for _, u := range *r {
+		for _, s := range u.Series {
+			// SHOW CONTINUOUS QUERIES returns one series per database
+			if s.Name != db {
+				continue
+			}
+			for _, v := range s.Values {
+				if len(v) < 2 {
+					continue
+				} else if name, ok := v[0].(string); !ok {
+					continue
+				} else if query, ok := v[1].(string); !ok {
+					continue
+				} else if cq, err := ParseContinuousQuery(query); err != nil {
+					res = append(res, chronograf.ContinuousQuery{
+						Name:     name,
+						Database: db,
+						Query:    query,
+					})
+				} else {
+					res = append(res, cq)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// Shards converts SHOW SHARDS to the chronograf Shards of a database.
+// Columns are matched by name as InfluxDB Enterprise reports the owners of
+// the shards. Sizes are those of the shard ids as reported by ShardSizes.
+func (r *showResults) Shards(db string, sizes map[uint64]int64) []chronograf.Shard {
+	res := []chronograf.Shard{}
+	for _, u := range *r {
+		for _, s := range u.Series {
+			// SHOW SHARDS returns one series per database
+			if s.Name != db {
+				continue
+			}
+			for _, v := range s.Values {
+				shard := chronograf.Shard{
+					Database: db,
+					Owners:   []string{},
+				}
+				for i, col := range s.Columns {
+					if i >= len(v) {
+						break
+					}
+					switch col {
+					case "id":
+						if id, ok := v[i].(float64); ok {
+							shard.ID = uint64(id)
+						}
+					case "retention_policy":
+						shard.RetentionPolicy, _ = v[i].(string)
+					case "shard_group":
+						if group, ok := v[i].(float64); ok {
+							shard.ShardGroup = uint64(group)
+						}
+					case "start_time":
+						shard.StartTime, _ = v[i].(string)
+					case "end_time":
+						shard.EndTime, _ = v[i].(string)
+					case "expiry_time":
+						shard.ExpiryTime, _ = v[i].(string)
+					case "owners":
+						owners, _ := v[i].(string)
+						for _, owner := range strings.Split(owners, ",") {
+							if owner != "" {
+								shard.Owners = append(shard.Owners, owner)
+							}
+						}
+					}
+				}
+				shard.Size = sizes[shard.ID]
+				res = append(res, shard)
+			}
+		}
+	}
+	return res
+}
+
+// ShardSizes converts SHOW STATS FOR 'shard' to the sizes on disk of the
+// shards by id
+func (r *showResults) ShardSizes() map[uint64]int64 {
+	res := map[uint64]int64{}
+	for _, u := range *r {
+		for _, s := range u.Series {
+			id, err := strconv.ParseUint(s.Tags["id"], 10, 64)
+			if s.Name != "shard" || err != nil {
+				continue
+			}
+			for i, col := range s.Columns {
+				if col != "diskBytes" {
+					continue
+				}
+				for _, v := range s.Values {
+					if i >= len(v) {
+						continue
+					}
+					if size, ok := v[i].(float64); ok {
+						res[id] = int64(size)
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// Subscriptions converts SHOW SUBSCRIPTIONS to the chronograf Subscriptions
+// of a database
+func (r *showResults) Subscriptions(db string) []chronograf.Subscription {
+	res := []chronograf.Subscription{}
+	for _, u := range *r {
+		for _, s := range u.Series {
+			// SHOW SUBSCRIPTIONS returns one series per database
+			if s.Name != db {
+				continue
+			}
+			for _, v := range s.Values {
+				if len(v) < 4 {
+					continue
+				} else if rp, ok := v[0].(string); !ok {
+					continue
+				} else if name, ok := v[1].(string); !ok {
+					continue
+				} else if mode, ok := v[2].(string); !ok {
+					continue
+				} else if dests, ok := v[3].([]interface{}); !ok {
+					continue
+				} else {
+					sub := chronograf.Subscription{
+						Name:            name,
+						Database:        db,
+						RetentionPolicy: rp,
+						Mode:            mode,
+						Destinations:    []string{},
+					}
+					for _, dest := range dests {
+						if d, ok := dest.(string); ok {
+							sub.Destinations = append(sub.Destinations, d)
+						}
+					}
+					res = append(res, sub)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// RunningQueries converts SHOW QUERIES to chronograf RunningQueries. Columns
+// are matched by name as newer versions of InfluxDB report more of them.
+func (r *showResults) RunningQueries() []chronograf.RunningQuery {
+	res := []chronograf.RunningQuery{}
+	for _, u := range *r {
+		for _, s := range u.Series {
+			for _, v := range s.Values {
+				q := chronograf.RunningQuery{}
+				for i, col := range s.Columns {
+					if i >= len(v) {
+						break
+					}
+					switch col {
+					case "qid":
+						if id, ok := v[i].(float64); ok {
+							q.ID = uint64(id)
+						}
+					case "query":
+						q.Query, _ = v[i].(string)
+					case "database":
+						q.Database, _ = v[i].(string)
+					case "duration":
+						q.Duration, _ = v[i].(string)
+					case "status":
+						q.Status, _ = v[i].(string)
+					}
+				}
+				res = append(res, q)
+			}
+		}
+	}
+	return res
+}
+
 // Permissions converts SHOW GRANTS to chronograf.Permissions
 func (r *showResults) Permissions() chronograf.Permissions {
 	res := []chronograf.Permission{}
@@ -0,0 +1,55 @@
+package influx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/chronograf"
+)
+
+var _ chronograf.QueryManager = &Client{}
+
+// RunningQueries returns the queries currently executing within InfluxDB
+func (c *Client) RunningQueries(ctx context.Context) ([]chronograf.RunningQuery, error) {
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: `SHOW QUERIES`,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	octets, err := res.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	results := showResults{}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, err
+	}
+
+	queries := results.RunningQueries()
+	for i := range queries {
+		queries[i].Host = c.URL.Host
+	}
+	return queries, nil
+}
+
+// KillQuery terminates a running query within InfluxDB. The host is passed
+// along so that clustered InfluxDB can delegate the kill to the data node
+// executing the query.
+func (c *Client) KillQuery(ctx context.Context, id uint64, host string) error {
+	kill := fmt.Sprintf(`KILL QUERY %d`, id)
+	if host != "" && host != c.URL.Host {
+		kill = fmt.Sprintf(`%s ON %s`, kill, quoteIdent(host))
+	}
+
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: kill,
+	})
+	if err != nil {
+		return err
+	}
+	return statementError(res)
+}
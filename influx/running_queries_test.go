@@ -0,0 +1,137 @@
+package influx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+)
+
+func TestClient_RunningQueries(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		showQueries []byte
+		want        []chronograf.RunningQuery
+	}{
+		{
+			name:        "InfluxDB 1.1 columns",
+			showQueries: []byte(`{"results":[{"series":[{"columns":["qid","query","database","duration"],"values":[[37,"SHOW QUERIES","","1s"],[38,"SELECT * FROM cpu","telegraf","2m3s"]]}]}]}`),
+			want: []chronograf.RunningQuery{
+				{
+					ID:       37,
+					Query:    "SHOW QUERIES",
+					Duration: "1s",
+				},
+				{
+					ID:       38,
+					Query:    "SELECT * FROM cpu",
+					Database: "telegraf",
+					Duration: "2m3s",
+				},
+			},
+		},
+		{
+			name:        "InfluxDB 1.7 columns",
+			showQueries: []byte(`{"results":[{"series":[{"columns":["qid","query","database","duration","status"],"values":[[38,"SELECT * FROM cpu","telegraf","2m3s","running"]]}]}]}`),
+			want: []chronograf.RunningQuery{
+				{
+					ID:       38,
+					Query:    "SELECT * FROM cpu",
+					Database: "telegraf",
+					Duration: "2m3s",
+					Status:   "running",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if q := r.URL.Query().Get("q"); q != "SHOW QUERIES" {
+				t.Errorf("Expected SHOW QUERIES but was %s", q)
+			}
+			rw.WriteHeader(http.StatusOK)
+			rw.Write(tt.showQueries)
+		}))
+		u, _ := url.Parse(ts.URL)
+		c := &Client{
+			URL:    u,
+			Logger: log.New(log.DebugLevel),
+		}
+
+		got, err := c.RunningQueries(context.Background())
+		ts.Close()
+		if err != nil {
+			t.Errorf("%q. Client.RunningQueries() unexpected error %v", tt.name, err)
+			continue
+		}
+		for i := range tt.want {
+			tt.want[i].Host = u.Host
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q. Client.RunningQueries() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClient_KillQuery(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		host     string
+		response []byte
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Kill query",
+			response: []byte(`{"results":[{"statement_id":0}]}`),
+			want:     `KILL QUERY 38`,
+		},
+		{
+			name:     "Kill query on another data node",
+			host:     "data-2:8086",
+			response: []byte(`{"results":[{"statement_id":0}]}`),
+			want:     `KILL QUERY 38 ON "data-2:8086"`,
+		},
+		{
+			name:     "Kill query on a host with quotes",
+			host:     `data-2"; DROP DATABASE "telegraf`,
+			response: []byte(`{"results":[{"statement_id":0}]}`),
+			want:     `KILL QUERY 38 ON "data-2\"; DROP DATABASE \"telegraf"`,
+		},
+		{
+			name:     "Unknown query",
+			response: []byte(`{"results":[{"statement_id":0,"error":"no such query id: 38"}]}`),
+			want:     `KILL QUERY 38`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		var got string
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			got = r.URL.Query().Get("q")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write(tt.response)
+		}))
+		u, _ := url.Parse(ts.URL)
+		c := &Client{
+			URL:    u,
+			Logger: log.New(log.DebugLevel),
+		}
+
+		err := c.KillQuery(context.Background(), 38, tt.host)
+		ts.Close()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q. Client.KillQuery() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("%q. Client.KillQuery() query = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
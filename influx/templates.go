@@ -0,0 +1,432 @@
+package influx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+)
+
+// UpperDashboardTime is the template variable used as the upper time bound
+// of a dashboard query
+const UpperDashboardTime = ":upperDashboardTime:"
+
+// DesiredPointsPerGraph is the number of points :interval: aims for when no
+// resolution is given; it is the same default as the one used by the UI.
+const DesiredPointsPerGraph = 360
+
+// templateValueTypes maps the type of a template to the type of the values
+// produced by its query
+var templateValueTypes = map[string]string{
+	"csv":          "csv",
+	"map":          "map",
+	"databases":    "database",
+	"measurements": "measurement",
+	"fieldKeys":    "fieldKey",
+	"tagKeys":      "tagKey",
+	"tagValues":    "tagValue",
+	"influxql":     "influxql",
+	"text":         "constant",
+}
+
+// TimeTemplates returns the :dashboardTime:, :upperDashboardTime: and
+// :interval: template variables of a time range. The interval splits the
+// range into resolution points, or DesiredPointsPerGraph if resolution is
+// not positive.
+func TimeTemplates(r chronograf.DurationRange, resolution int64, now time.Time) ([]chronograf.TemplateVar, error) {
+	if r.Lower == "" {
+		return nil, fmt.Errorf("time range requires a lower bound")
+	}
+	lower, upper := quoteIfTimestamp(r.Lower), quoteIfTimestamp(r.Upper)
+	if upper == "" {
+		upper = "now()"
+	}
+
+	dur, err := ParseTime(fmt.Sprintf("SELECT x FROM y WHERE time > %s AND time < %s", lower, upper), now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time range: %v", err)
+	}
+
+	if resolution <= 0 {
+		resolution = DesiredPointsPerGraph
+	}
+	interval := (dur / time.Duration(resolution)).Round(time.Millisecond)
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+
+	constant := func(name, value string) chronograf.TemplateVar {
+		return chronograf.TemplateVar{
+			Var: name,
+			Values: []chronograf.TemplateValue{
+				{
+					Value:    value,
+					Type:     "constant",
+					Selected: true,
+				},
+			},
+		}
+	}
+	return []chronograf.TemplateVar{
+		constant(DashboardTime, lower),
+		constant(UpperDashboardTime, upper),
+		constant(IntervalTime, fmt.Sprintf("%dms", interval/time.Millisecond)),
+	}, nil
+}
+
+// RenderTemplate replaces the template variable within the query by its
+// selected value. Database, measurement and key values are double quoted,
+// tag values and timestamps are single quoted, and all other values are
+// inserted as is. Values within regular expressions are never quoted.
+func RenderTemplate(query string, t chronograf.TemplateVar) (string, error) {
+	if !strings.Contains(query, t.Var) {
+		return query, nil
+	}
+
+	var selected *chronograf.TemplateValue
+	for i := range t.Values {
+		if t.Values[i].Selected {
+			selected = &t.Values[i]
+			break
+		}
+	}
+	if selected == nil {
+		return query, nil
+	}
+
+	switch selected.Type {
+	case "tagKey", "fieldKey", "measurement", "database":
+		q, err := replaceInRegex(query, t.Var, selected.Value)
+		if err != nil {
+			return "", err
+		}
+		return strings.Replace(q, t.Var, `"`+selected.Value+`"`, -1), nil
+	case "tagValue", "timeStamp":
+		q, err := replaceInRegex(query, t.Var, selected.Value)
+		if err != nil {
+			return "", err
+		}
+		return strings.Replace(q, t.Var, `'`+selected.Value+`'`, -1), nil
+	case "csv", "constant", "influxql", "map":
+		return strings.Replace(query, t.Var, selected.Value, -1), nil
+	}
+	return query, nil
+}
+
+// RenderTemplates replaces each template variable within the query in order.
+// A template whose values reference other templates must come before them.
+func RenderTemplates(query string, templates []chronograf.TemplateVar) (string, error) {
+	for _, t := range templates {
+		var err error
+		if query, err = RenderTemplate(query, t); err != nil {
+			return "", err
+		}
+	}
+	return query, nil
+}
+
+// replaceInRegex replaces the template variable within the regular
+// expressions following =~ and !~
+func replaceInRegex(query, name, value string) (string, error) {
+	for i := 0; i < len(query)-1; i++ {
+		if op := query[i : i+2]; op != "=~" && op != "!~" {
+			continue
+		}
+		start := strings.Index(query[i:], "/")
+		if start == -1 {
+			return "", fmt.Errorf("expected regular expression after %q", query[i:i+2])
+		}
+		start += i + 1
+		end := strings.Index(query[start:], "/")
+		if end == -1 {
+			return "", fmt.Errorf("unterminated regular expression in %q", query[start-1:])
+		}
+		end += start
+
+		re := strings.Replace(query[start:end], name, value, -1)
+		query = query[:start] + re + query[end:]
+		i = start + len(re)
+	}
+	return query, nil
+}
+
+// TemplateQueryCommand is the meta query used to populate the values of a
+// query backed template. The :database:, :measurement: and :tagKey:
+// variables are filled from the template query unless it is a custom
+// influxql meta query; those are free to use these names for templates of
+// their own.
+func TemplateQueryCommand(t chronograf.Template) string {
+	if t.Query == nil {
+		return ""
+	}
+	if t.Type == "influxql" {
+		return t.Query.Command
+	}
+	return strings.NewReplacer(
+		":database:", `"`+t.Query.DB+`"`,
+		":measurement:", `"`+t.Query.Measurement+`"`,
+		":tagKey:", `"`+t.Query.TagKey+`"`,
+	).Replace(t.Query.Command)
+}
+
+// templateDependencies lists the template variable names referenced by the
+// query of the template or, for templates without a query, by its values
+func templateDependencies(t chronograf.Template) []string {
+	if t.Query != nil && t.Query.Command != "" {
+		return variableNames(t.Query.Command)
+	}
+	names := []string{}
+	for _, v := range t.Values {
+		names = append(names, variableNames(v.Value)...)
+	}
+	return names
+}
+
+// variableToken matches the :name: tokens of template variables
+var variableToken = regexp.MustCompile(`:[\w-]+:`)
+
+// variableNames finds all :name: tokens of s
+func variableNames(s string) []string {
+	names := variableToken.FindAllString(s, -1)
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
+
+// SortTemplates orders the templates so that every template comes after the
+// templates it depends on. Cyclic dependencies are an error.
+func SortTemplates(templates []chronograf.Template) ([]chronograf.Template, error) {
+	byVar := map[string]int{}
+	for i, t := range templates {
+		byVar[t.Var] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(templates))
+	sorted := make([]chronograf.Template, 0, len(templates))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			return fmt.Errorf("cyclic dependency in template %q", templates[i].Var)
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		for _, name := range templateDependencies(templates[i]) {
+			if j, ok := byVar[name]; ok {
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+		state[i] = visited
+		sorted = append(sorted, templates[i])
+		return nil
+	}
+
+	for i := range templates {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// metaQueryColumns is the column holding the values of each meta query
+var metaQueryColumns = map[string]string{
+	"SHOW DATABASES":    "name",
+	"SHOW MEASUREMENTS": "name",
+	"SHOW SERIES":       "key",
+	"SHOW TAG VALUES":   "value",
+	"SHOW FIELD KEYS":   "fieldKey",
+	"SHOW TAG KEYS":     "tagKey",
+}
+
+// metaQueryPrefix returns the SHOW statement of a meta query
+func metaQueryPrefix(query string) (string, bool) {
+	words := strings.Fields(strings.ToUpper(query))
+	for _, n := range []int{2, 3} {
+		if len(words) < n {
+			break
+		}
+		prefix := strings.Join(words[:n], " ")
+		if _, ok := metaQueryColumns[prefix]; ok {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// ParseMetaQuery extracts the distinct values of the response to a meta
+// query such as SHOW TAG VALUES or SHOW MEASUREMENTS
+func ParseMetaQuery(query string, res chronograf.Response) ([]string, error) {
+	prefix, ok := metaQueryPrefix(query)
+	if !ok {
+		return nil, fmt.Errorf("could not find parser for meta query %q", query)
+	}
+
+	octets, err := res.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	results := []struct {
+		Error  string `json:"error"`
+		Series []struct {
+			Columns []string        `json:"columns"`
+			Values  [][]interface{} `json:"values"`
+		} `json:"series"`
+	}{}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	seen := map[string]bool{}
+	for _, r := range results {
+		if r.Error != "" {
+			return nil, errors.New(r.Error)
+		}
+		for _, s := range r.Series {
+			col := 0
+			for i, c := range s.Columns {
+				if c == metaQueryColumns[prefix] {
+					col = i
+				}
+			}
+			for _, v := range s.Values {
+				if len(v) <= col {
+					continue
+				}
+				value, ok := v[col].(string)
+				if !ok || seen[value] {
+					continue
+				}
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+	return values, nil
+}
+
+// HydrateTemplates resolves the values of the templates of a dashboard.
+// Templates with a query have their values replaced by the result of the
+// query, rendered with the templates it depends on and with vars. The
+// selections, keyed by template variable, override the selected values;
+// the value of a map template may be selected by its key. A selection that
+// is not among the values is ignored; if the previously selected value has
+// disappeared from the query results the first value is selected instead.
+// The templates are returned in dependency order.
+func HydrateTemplates(ctx context.Context, ts chronograf.TimeSeries, templates []chronograf.Template, selections map[string]string, vars []chronograf.TemplateVar) ([]chronograf.Template, error) {
+	sorted, err := SortTemplates(templates)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]chronograf.TemplateVar, 0, len(sorted)+len(vars))
+	for i := range sorted {
+		t := &sorted[i]
+
+		selection, hasSelection := selections[t.Var]
+		if !hasSelection {
+			for _, v := range t.Values {
+				if v.Selected {
+					selection = v.Value
+				}
+			}
+		}
+
+		if t.Query != nil && t.Query.Command != "" {
+			if ts == nil {
+				return nil, fmt.Errorf("template %s requires a source to query", t.Var)
+			}
+			// dependencies are resolved first, so they are rendered in reverse
+			deps := make([]chronograf.TemplateVar, 0, len(resolved))
+			for j := len(resolved) - 1; j >= 0; j-- {
+				deps = append(deps, resolved[j])
+			}
+			command, err := RenderTemplates(TemplateQueryCommand(*t), append(deps, vars...))
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %v", t.Var, err)
+			}
+			res, err := ts.Query(ctx, chronograf.Query{
+				Command: command,
+				DB:      t.Query.DB,
+				RP:      t.Query.RP,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %v", t.Var, err)
+			}
+			values, err := ParseMetaQuery(command, res)
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %v", t.Var, err)
+			}
+
+			t.Values = make([]chronograf.TemplateValue, len(values))
+			for j, v := range values {
+				t.Values[j] = chronograf.TemplateValue{
+					Value: v,
+					Type:  templateValueTypes[t.Type],
+				}
+			}
+		} else {
+			t.Values = append([]chronograf.TemplateValue(nil), t.Values...)
+		}
+
+		selectTemplateValue(t.Values, selection)
+		resolved = append(resolved, t.TemplateVar)
+	}
+	return sorted, nil
+}
+
+// selectTemplateValue marks the value (or map key) matching selection as
+// selected, falling back to the current selection and then the first value
+func selectTemplateValue(values []chronograf.TemplateValue, selection string) {
+	if len(values) == 0 {
+		return
+	}
+	idx := -1
+	for i, v := range values {
+		if v.Value == selection || (v.Key != "" && v.Key == selection) {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		for i, v := range values {
+			if v.Selected {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx == -1 {
+		idx = 0
+	}
+	for i := range values {
+		values[i].Selected = i == idx
+	}
+}
+
+// RenderQuery replaces the template variables of a dashboard query. The
+// templates must be in the dependency order returned by HydrateTemplates;
+// vars, usually the TimeTemplates of the dashboard, are rendered last.
+func RenderQuery(query string, templates []chronograf.Template, vars []chronograf.TemplateVar) (string, error) {
+	all := make([]chronograf.TemplateVar, 0, len(templates)+len(vars))
+	for i := len(templates) - 1; i >= 0; i-- {
+		all = append(all, templates[i].TemplateVar)
+	}
+	return RenderTemplates(query, append(all, vars...))
+}
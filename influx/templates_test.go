@@ -0,0 +1,368 @@
+package influx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestTimeTemplates(t *testing.T) {
+	now := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		r          chronograf.DurationRange
+		resolution int64
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name: "Relative range with the default resolution",
+			r: chronograf.DurationRange{
+				Lower: "now() - 1h",
+			},
+			want: []string{"now() - 1h", "now()", "10000ms"},
+		},
+		{
+			name: "Absolute range with a resolution",
+			r: chronograf.DurationRange{
+				Lower: "2018-09-01T00:00:00Z",
+				Upper: "2018-09-01T01:00:00Z",
+			},
+			resolution: 1000,
+			want:       []string{"'2018-09-01T00:00:00Z'", "'2018-09-01T01:00:00Z'", "3600ms"},
+		},
+		{
+			name: "Interval is at least a millisecond",
+			r: chronograf.DurationRange{
+				Lower: "now() - 1s",
+			},
+			resolution: 5000,
+			want:       []string{"now() - 1s", "now()", "1ms"},
+		},
+		{
+			name:    "Missing lower bound",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TimeTemplates(tt.r, tt.resolution, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TimeTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			values := make([]string, len(got))
+			for i, v := range got {
+				values[i] = v.Values[0].Value
+			}
+			if !reflect.DeepEqual(values, tt.want) {
+				t.Errorf("TimeTemplates() = %v, want %v", values, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		t       chronograf.TemplateVar
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Tag values are single quoted",
+			query: `SELECT "usage_idle" FROM "cpu" WHERE "host" = :host: AND time > :dashboardTime:`,
+			t: chronograf.TemplateVar{
+				Var: ":host:",
+				Values: []chronograf.TemplateValue{
+					{Value: "a", Type: "tagValue"},
+					{Value: "b", Type: "tagValue", Selected: true},
+				},
+			},
+			want: `SELECT "usage_idle" FROM "cpu" WHERE "host" = 'b' AND time > :dashboardTime:`,
+		},
+		{
+			name:  "Measurements are double quoted except within regular expressions",
+			query: `SELECT * FROM :m: WHERE "name" =~ /^:m:$/`,
+			t: chronograf.TemplateVar{
+				Var: ":m:",
+				Values: []chronograf.TemplateValue{
+					{Value: "cpu", Type: "measurement", Selected: true},
+				},
+			},
+			want: `SELECT * FROM "cpu" WHERE "name" =~ /^cpu$/`,
+		},
+		{
+			name:  "Constants are inserted as is",
+			query: `SELECT mean(:field:) FROM "cpu" GROUP BY time(:interval:)`,
+			t: chronograf.TemplateVar{
+				Var: ":field:",
+				Values: []chronograf.TemplateValue{
+					{Value: `"usage_user"`, Type: "csv", Selected: true},
+				},
+			},
+			want: `SELECT mean("usage_user") FROM "cpu" GROUP BY time(:interval:)`,
+		},
+		{
+			name:  "Templates without a selection are left alone",
+			query: `SELECT * FROM "cpu" WHERE "host" = :host:`,
+			t: chronograf.TemplateVar{
+				Var: ":host:",
+				Values: []chronograf.TemplateValue{
+					{Value: "a", Type: "tagValue"},
+				},
+			},
+			want: `SELECT * FROM "cpu" WHERE "host" = :host:`,
+		},
+		{
+			name:  "Unterminated regular expression",
+			query: `SELECT * FROM "cpu" WHERE "host" =~ /:host:`,
+			t: chronograf.TemplateVar{
+				Var: ":host:",
+				Values: []chronograf.TemplateValue{
+					{Value: "a", Type: "tagValue", Selected: true},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate(tt.query, tt.t)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderTemplate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortTemplates(t *testing.T) {
+	templates := []chronograf.Template{
+		{
+			TemplateVar: chronograf.TemplateVar{Var: ":host:"},
+			Type:        "influxql",
+			Query: &chronograf.TemplateQuery{
+				Command: `SHOW TAG VALUES ON :db: FROM :m: WITH KEY = "host"`,
+			},
+		},
+		{
+			TemplateVar: chronograf.TemplateVar{Var: ":m:"},
+			Type:        "influxql",
+			Query: &chronograf.TemplateQuery{
+				Command: `SHOW MEASUREMENTS ON :db:`,
+			},
+		},
+		{
+			TemplateVar: chronograf.TemplateVar{
+				Var: ":db:",
+				Values: []chronograf.TemplateValue{
+					{Value: "telegraf", Type: "constant", Selected: true},
+				},
+			},
+			Type: "constant",
+		},
+	}
+
+	got, err := SortTemplates(templates)
+	if err != nil {
+		t.Fatalf("SortTemplates() error = %v", err)
+	}
+	vars := []string{}
+	for _, t := range got {
+		vars = append(vars, t.Var)
+	}
+	if want := []string{":db:", ":m:", ":host:"}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("SortTemplates() = %v, want %v", vars, want)
+	}
+
+	templates[2].Values[0].Value = ":host:"
+	if _, err := SortTemplates(templates); err == nil {
+		t.Errorf("SortTemplates() expected error for cyclic dependency")
+	}
+}
+
+func TestVariableNames(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{
+			s:    `SHOW TAG VALUES ON :db: FROM :m: WITH KEY = "host"`,
+			want: []string{":db:", ":m:"},
+		},
+		{
+			s:    `SHOW TAG VALUES WITH KEY = "host" WHERE "port" = 'http:' AND "dc" = :dc-name:`,
+			want: []string{":dc-name:"},
+		},
+		{
+			s:    `SHOW MEASUREMENTS`,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		if got := variableNames(tt.s); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("variableNames(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseMetaQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		res     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "Tag values of several measurements",
+			query: `SHOW TAG VALUES ON "telegraf" WITH KEY = "host"`,
+			res:   `[{"series":[{"name":"cpu","columns":["key","value"],"values":[["host","a"],["host","b"]]},{"name":"mem","columns":["key","value"],"values":[["host","b"]]}]}]`,
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "Measurements",
+			query: `show measurements on "telegraf"`,
+			res:   `[{"series":[{"name":"measurements","columns":["name"],"values":[["cpu"],["mem"]]}]}]`,
+			want:  []string{"cpu", "mem"},
+		},
+		{
+			name:  "Field keys",
+			query: `SHOW FIELD KEYS ON "telegraf" FROM "cpu"`,
+			res:   `[{"series":[{"name":"cpu","columns":["fieldKey","fieldType"],"values":[["usage_idle","float"]]}]}]`,
+			want:  []string{"usage_idle"},
+		},
+		{
+			name:    "Not a meta query",
+			query:   `SELECT * FROM "cpu"`,
+			res:     `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "Query error",
+			query:   `SHOW DATABASES`,
+			res:     `[{"error":"not authorized"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMetaQuery(tt.query, mocks.NewResponse(tt.res, nil))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMetaQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMetaQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHydrateTemplates(t *testing.T) {
+	templates := []chronograf.Template{
+		{
+			TemplateVar: chronograf.TemplateVar{
+				Var: ":host:",
+				Values: []chronograf.TemplateValue{
+					{Value: "gone", Type: "tagValue", Selected: true},
+				},
+			},
+			Type: "tagValues",
+			Query: &chronograf.TemplateQuery{
+				Command:     `SHOW TAG VALUES ON :database: FROM :measurement: WITH KEY=:tagKey: WHERE "region" = :region: AND time > :dashboardTime:`,
+				DB:          "telegraf",
+				Measurement: "cpu",
+				TagKey:      "host",
+			},
+		},
+		{
+			TemplateVar: chronograf.TemplateVar{
+				Var: ":region:",
+				Values: []chronograf.TemplateValue{
+					{Value: "us-east", Type: "map", Key: "east", Selected: true},
+					{Value: "us-west", Type: "map", Key: "west"},
+				},
+			},
+			Type: "map",
+		},
+	}
+	vars := []chronograf.TemplateVar{
+		{
+			Var: DashboardTime,
+			Values: []chronograf.TemplateValue{
+				{Value: "now() - 1h", Type: "constant", Selected: true},
+			},
+		},
+	}
+
+	var queries []chronograf.Query
+	ts := &mocks.TimeSeries{
+		QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+			queries = append(queries, q)
+			return mocks.NewResponse(`[{"series":[{"name":"cpu","columns":["key","value"],"values":[["host","a"],["host","b"]]}]}]`, nil), nil
+		},
+	}
+
+	got, err := HydrateTemplates(context.Background(), ts, templates, map[string]string{":region:": "west"}, vars)
+	if err != nil {
+		t.Fatalf("HydrateTemplates() error = %v", err)
+	}
+
+	wantQueries := []chronograf.Query{
+		{
+			Command: `SHOW TAG VALUES ON "telegraf" FROM "cpu" WITH KEY="host" WHERE "region" = us-west AND time > now() - 1h`,
+			DB:      "telegraf",
+		},
+	}
+	if !reflect.DeepEqual(queries, wantQueries) {
+		t.Errorf("HydrateTemplates() queries = %v, want %v", queries, wantQueries)
+	}
+
+	want := []chronograf.Template{
+		{
+			TemplateVar: chronograf.TemplateVar{
+				Var: ":region:",
+				Values: []chronograf.TemplateValue{
+					{Value: "us-east", Type: "map", Key: "east"},
+					{Value: "us-west", Type: "map", Key: "west", Selected: true},
+				},
+			},
+			Type: "map",
+		},
+		{
+			TemplateVar: chronograf.TemplateVar{
+				Var: ":host:",
+				Values: []chronograf.TemplateValue{
+					{Value: "a", Type: "tagValue", Selected: true},
+					{Value: "b", Type: "tagValue"},
+				},
+			},
+			Type:  "tagValues",
+			Query: templates[0].Query,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HydrateTemplates() = %#v, want %#v", got, want)
+	}
+	if !templates[1].Values[0].Selected {
+		t.Errorf("HydrateTemplates() modified the selection of the original templates")
+	}
+
+	rendered, err := RenderQuery(`SELECT "usage_idle" FROM "cpu" WHERE "host" = :host: AND time > :dashboardTime:`, got, vars)
+	if err != nil {
+		t.Fatalf("RenderQuery() error = %v", err)
+	}
+	if want := `SELECT "usage_idle" FROM "cpu" WHERE "host" = 'a' AND time > now() - 1h`; rendered != want {
+		t.Errorf("RenderQuery() = %s, want %s", rendered, want)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/id"
@@ -279,6 +282,32 @@ func (c *Client) All(ctx context.Context) (map[string]*Task, error) {
 	return all, nil
 }
 
+// IsChronografTask reports whether the task was generated by Chronograf from
+// a rule. Such tasks are identified by the Prefix of their ID.
+func IsChronografTask(id string) bool {
+	return strings.HasPrefix(id, Prefix)
+}
+
+// ChronografTasks returns the tasks of kapacitor generated by Chronograf
+// sorted by ID
+func (c *Client) ChronografTasks(ctx context.Context) ([]*Task, error) {
+	all, err := c.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*Task{}
+	for id, task := range all {
+		if IsChronografTask(id) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
 // Reverse builds a chronograf.AlertRule and its QueryConfig from a tickscript
 func (c *Client) Reverse(id string, script chronograf.TICKScript) chronograf.AlertRule {
 	rule, err := Reverse(script)
@@ -401,6 +430,26 @@ func toTask(q *chronograf.QueryConfig) client.TaskType {
 
 // NewKapaClient creates a Kapacitor client connection
 func NewKapaClient(url, username, password string, insecureSkipVerify bool) (KapaClient, error) {
+	clnt, err := newClient(url, username, password, insecureSkipVerify, 0)
+	if err != nil {
+		return clnt, err
+	}
+
+	return &PaginatingKapaClient{clnt, FetchRate}, nil
+}
+
+// Ping checks that Kapacitor can be reached within the timeout and returns
+// its version
+func Ping(url, username, password string, insecureSkipVerify bool, timeout time.Duration) (string, error) {
+	clnt, err := newClient(url, username, password, insecureSkipVerify, timeout)
+	if err != nil {
+		return "", err
+	}
+	_, version, err := clnt.Ping()
+	return version, err
+}
+
+func newClient(url, username, password string, insecureSkipVerify bool, timeout time.Duration) (*client.Client, error) {
 	var creds *client.Credentials
 	if username != "" {
 		creds = &client.Credentials{
@@ -418,16 +467,11 @@ func NewKapaClient(url, username, password string, insecureSkipVerify bool) (Kap
 		transport = defaultTransport
 	}
 
-	clnt, err := client.New(client.Config{
+	return client.New(client.Config{
 		URL:                url,
+		Timeout:            timeout,
 		Credentials:        creds,
 		InsecureSkipVerify: insecureSkipVerify,
 		Transport:          transport,
 	})
-
-	if err != nil {
-		return clnt, err
-	}
-
-	return &PaginatingKapaClient{clnt, FetchRate}, nil
 }
@@ -1651,3 +1651,36 @@ trigger
 		})
 	}
 }
+
+func TestClient_ChronografTasks(t *testing.T) {
+	kapa := &MockKapa{
+		ResTasks: []client.Task{
+			{ID: "chronograf-v1-b", Status: client.Enabled, Link: client.Link{Href: "/kapacitor/v1/tasks/chronograf-v1-b"}},
+			{ID: "cpu_alert", Status: client.Enabled, Link: client.Link{Href: "/kapacitor/v1/tasks/cpu_alert"}},
+			{ID: "chronograf-v1-a", Status: client.Disabled, Link: client.Link{Href: "/kapacitor/v1/tasks/chronograf-v1-a"}},
+		},
+	}
+	c := &Client{
+		kapaClient: func(url, username, password string, insecureSkipVerify bool) (KapaClient, error) {
+			return kapa, nil
+		},
+	}
+
+	tasks, err := c.ChronografTasks(context.Background())
+	if err != nil {
+		t.Fatal("Client.ChronografTasks() unexpected error:", err)
+	}
+	got := []string{}
+	for _, task := range tasks {
+		got = append(got, task.Href)
+	}
+	want := []string{"/kapacitor/v1/tasks/chronograf-v1-a", "/kapacitor/v1/tasks/chronograf-v1-b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.ChronografTasks() = %v, want %v", got, want)
+	}
+
+	kapa.ListError = fmt.Errorf("connection refused")
+	if _, err := c.ChronografTasks(context.Background()); err == nil {
+		t.Error("Client.ChronografTasks() expected an error when kapacitor is unreachable")
+	}
+}
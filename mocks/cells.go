@@ -10,6 +10,7 @@ var _ platform.CellService = &CellService{}
 
 type CellService struct {
 	CreateCellF   func(context.Context, *platform.Cell) error
+	PutCellF      func(context.Context, *platform.Cell) error
 	FindCellByIDF func(context.Context, platform.ID) (*platform.Cell, error)
 	FindCellsF    func(context.Context, platform.CellFilter) ([]*platform.Cell, int, error)
 	UpdateCellF   func(context.Context, platform.ID, platform.CellUpdate) (*platform.Cell, error)
@@ -28,6 +29,10 @@ func (s *CellService) CreateCell(ctx context.Context, b *platform.Cell) error {
 	return s.CreateCellF(ctx, b)
 }
 
+func (s *CellService) PutCell(ctx context.Context, b *platform.Cell) error {
+	return s.PutCellF(ctx, b)
+}
+
 func (s *CellService) UpdateCell(ctx context.Context, id platform.ID, upd platform.CellUpdate) (*platform.Cell, error) {
 	return s.UpdateCellF(ctx, id, upd)
 }
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/influxdata/chronograf"
+)
+
+var _ chronograf.DashboardSharesStore = &DashboardSharesStore{}
+
+// DashboardSharesStore mock allows all functions to be set for testing
+type DashboardSharesStore struct {
+	AllF    func(context.Context, chronograf.DashboardID) ([]chronograf.DashboardShare, error)
+	AddF    func(context.Context, chronograf.DashboardShare) (chronograf.DashboardShare, error)
+	GetF    func(context.Context, string) (chronograf.DashboardShare, error)
+	DeleteF func(context.Context, chronograf.DashboardShare) error
+}
+
+// All lists the shares of a dashboard
+func (s *DashboardSharesStore) All(ctx context.Context, id chronograf.DashboardID) ([]chronograf.DashboardShare, error) {
+	return s.AllF(ctx, id)
+}
+
+// Add creates a new share and returns it with its ID
+func (s *DashboardSharesStore) Add(ctx context.Context, share chronograf.DashboardShare) (chronograf.DashboardShare, error) {
+	return s.AddF(ctx, share)
+}
+
+// Get retrieves a share by its ID
+func (s *DashboardSharesStore) Get(ctx context.Context, id string) (chronograf.DashboardShare, error) {
+	return s.GetF(ctx, id)
+}
+
+// Delete removes the share, revoking its tokens
+func (s *DashboardSharesStore) Delete(ctx context.Context, share chronograf.DashboardShare) error {
+	return s.DeleteF(ctx, share)
+}
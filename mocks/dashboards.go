@@ -15,6 +15,7 @@ type DashboardsStore struct {
 	DeleteF func(ctx context.Context, target chronograf.Dashboard) error
 	GetF    func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error)
 	UpdateF func(ctx context.Context, target chronograf.Dashboard) error
+	SearchF func(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error)
 }
 
 func (d *DashboardsStore) Add(ctx context.Context, newDashboard chronograf.Dashboard) (chronograf.Dashboard, error) {
@@ -37,10 +38,15 @@ func (d *DashboardsStore) Update(ctx context.Context, target chronograf.Dashboar
 	return d.UpdateF(ctx, target)
 }
 
+func (d *DashboardsStore) Search(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+	return d.SearchF(ctx, q)
+}
+
 var _ platform.DashboardService = &DashboardService{}
 
 type DashboardService struct {
 	CreateDashboardF   func(context.Context, *platform.Dashboard) error
+	PutDashboardF      func(context.Context, *platform.Dashboard) error
 	FindDashboardByIDF func(context.Context, platform.ID) (*platform.Dashboard, error)
 	FindDashboardsF    func(context.Context, platform.DashboardFilter) ([]*platform.Dashboard, int, error)
 	UpdateDashboardF   func(context.Context, platform.ID, platform.DashboardUpdate) (*platform.Dashboard, error)
@@ -59,6 +65,10 @@ func (s *DashboardService) CreateDashboard(ctx context.Context, b *platform.Dash
 	return s.CreateDashboardF(ctx, b)
 }
 
+func (s *DashboardService) PutDashboard(ctx context.Context, b *platform.Dashboard) error {
+	return s.PutDashboardF(ctx, b)
+}
+
 func (s *DashboardService) UpdateDashboard(ctx context.Context, id platform.ID, upd platform.DashboardUpdate) (*platform.Dashboard, error) {
 	return s.UpdateDashboardF(ctx, id, upd)
 }
@@ -21,6 +21,24 @@ type Databases struct {
 	DropRPF   func(context.Context, string, string) error
 
 	GetMeasurementsF func(ctx context.Context, db string, limit, offset int) ([]chronograf.Measurement, error)
+
+	AllCQF    func(context.Context, string) ([]chronograf.ContinuousQuery, error)
+	CreateCQF func(context.Context, string, *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error)
+	UpdateCQF func(context.Context, string, string, *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error)
+	DropCQF   func(context.Context, string, string) error
+
+	AllShardsF func(context.Context, string) ([]chronograf.Shard, error)
+	DropShardF func(context.Context, uint64) error
+
+	AllSubscriptionsF   func(context.Context, string) ([]chronograf.Subscription, error)
+	CreateSubscriptionF func(context.Context, string, *chronograf.Subscription) (*chronograf.Subscription, error)
+	UpdateSubscriptionF func(ctx context.Context, db, rp, name string, sub *chronograf.Subscription) (*chronograf.Subscription, error)
+	DropSubscriptionF   func(ctx context.Context, db, rp, name string) error
+}
+
+// New implements server.DatabasesClient
+func (d *Databases) New(chronograf.Source, chronograf.Logger) chronograf.Databases {
+	return d
 }
 
 // AllDB lists all databases in the current data source
@@ -67,3 +85,53 @@ func (d *Databases) DropRP(ctx context.Context, rpX string, rpY string) error {
 func (d *Databases) GetMeasurements(ctx context.Context, db string, limit, offset int) ([]chronograf.Measurement, error) {
 	return d.GetMeasurementsF(ctx, db, limit, offset)
 }
+
+// AllCQ lists all continuous queries of a database in the current data source
+func (d *Databases) AllCQ(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+	return d.AllCQF(ctx, db)
+}
+
+// CreateCQ creates a continuous query in a database of the current data source
+func (d *Databases) CreateCQ(ctx context.Context, db string, cq *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	return d.CreateCQF(ctx, db, cq)
+}
+
+// UpdateCQ replaces a continuous query in a database of the current data source
+func (d *Databases) UpdateCQ(ctx context.Context, db string, name string, cq *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	return d.UpdateCQF(ctx, db, name, cq)
+}
+
+// DropCQ drops a continuous query from a database of the current data source
+func (d *Databases) DropCQ(ctx context.Context, db string, name string) error {
+	return d.DropCQF(ctx, db, name)
+}
+
+// AllShards lists all shards of a database in the current data source
+func (d *Databases) AllShards(ctx context.Context, db string) ([]chronograf.Shard, error) {
+	return d.AllShardsF(ctx, db)
+}
+
+// DropShard drops a shard from the current data source
+func (d *Databases) DropShard(ctx context.Context, id uint64) error {
+	return d.DropShardF(ctx, id)
+}
+
+// AllSubscriptions lists all subscriptions of a database in the current data source
+func (d *Databases) AllSubscriptions(ctx context.Context, db string) ([]chronograf.Subscription, error) {
+	return d.AllSubscriptionsF(ctx, db)
+}
+
+// CreateSubscription creates a subscription in a database of the current data source
+func (d *Databases) CreateSubscription(ctx context.Context, db string, sub *chronograf.Subscription) (*chronograf.Subscription, error) {
+	return d.CreateSubscriptionF(ctx, db, sub)
+}
+
+// UpdateSubscription replaces a subscription of a retention policy in a database of the current data source
+func (d *Databases) UpdateSubscription(ctx context.Context, db, rp, name string, sub *chronograf.Subscription) (*chronograf.Subscription, error) {
+	return d.UpdateSubscriptionF(ctx, db, rp, name, sub)
+}
+
+// DropSubscription drops a subscription of a retention policy from a database of the current data source
+func (d *Databases) DropSubscription(ctx context.Context, db, rp, name string) error {
+	return d.DropSubscriptionF(ctx, db, rp, name)
+}
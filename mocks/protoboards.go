@@ -9,8 +9,11 @@ import (
 var _ chronograf.ProtoboardsStore = &ProtoboardsStore{}
 
 type ProtoboardsStore struct {
-	AllF func(ctx context.Context) ([]chronograf.Protoboard, error)
-	GetF func(ctx context.Context, id string) (chronograf.Protoboard, error)
+	AllF    func(ctx context.Context) ([]chronograf.Protoboard, error)
+	AddF    func(ctx context.Context, protoboard chronograf.Protoboard) (chronograf.Protoboard, error)
+	DeleteF func(ctx context.Context, protoboard chronograf.Protoboard) error
+	GetF    func(ctx context.Context, id string) (chronograf.Protoboard, error)
+	UpdateF func(ctx context.Context, protoboard chronograf.Protoboard) error
 }
 
 func (s *ProtoboardsStore) All(ctx context.Context) ([]chronograf.Protoboard, error) {
@@ -20,3 +23,15 @@ func (s *ProtoboardsStore) All(ctx context.Context) ([]chronograf.Protoboard, er
 func (s *ProtoboardsStore) Get(ctx context.Context, id string) (chronograf.Protoboard, error) {
 	return s.GetF(ctx, id)
 }
+
+func (s *ProtoboardsStore) Add(ctx context.Context, protoboard chronograf.Protoboard) (chronograf.Protoboard, error) {
+	return s.AddF(ctx, protoboard)
+}
+
+func (s *ProtoboardsStore) Delete(ctx context.Context, protoboard chronograf.Protoboard) error {
+	return s.DeleteF(ctx, protoboard)
+}
+
+func (s *ProtoboardsStore) Update(ctx context.Context, protoboard chronograf.Protoboard) error {
+	return s.UpdateF(ctx, protoboard)
+}
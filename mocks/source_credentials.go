@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/influxdata/chronograf"
+)
+
+var _ chronograf.SourceCredentialsStore = &SourceCredentialsStore{}
+
+// SourceCredentialsStore mock allows all functions to be set for testing
+type SourceCredentialsStore struct {
+	GetF          func(context.Context, int, uint64) (chronograf.SourceCredentials, error)
+	PutF          func(context.Context, chronograf.SourceCredentials) error
+	DeleteF       func(context.Context, int, uint64) error
+	DeleteSourceF func(context.Context, int) error
+}
+
+// Get retrieves the credentials of a user for a source
+func (s *SourceCredentialsStore) Get(ctx context.Context, srcID int, userID uint64) (chronograf.SourceCredentials, error) {
+	return s.GetF(ctx, srcID, userID)
+}
+
+// Put creates or replaces the credentials of a user for a source
+func (s *SourceCredentialsStore) Put(ctx context.Context, creds chronograf.SourceCredentials) error {
+	return s.PutF(ctx, creds)
+}
+
+// Delete removes the credentials of a user for a source
+func (s *SourceCredentialsStore) Delete(ctx context.Context, srcID int, userID uint64) error {
+	return s.DeleteF(ctx, srcID, userID)
+}
+
+// DeleteSource removes the credentials of every user for a source
+func (s *SourceCredentialsStore) DeleteSource(ctx context.Context, srcID int) error {
+	return s.DeleteSourceF(ctx, srcID)
+}
@@ -19,6 +19,8 @@ type Store struct {
 	OrganizationsStore      chronograf.OrganizationsStore
 	ConfigStore             chronograf.ConfigStore
 	OrganizationConfigStore chronograf.OrganizationConfigStore
+	SourceCredentialsStore  chronograf.SourceCredentialsStore
+	DashboardSharesStore    chronograf.DashboardSharesStore
 	CellService             platform.CellService
 	DashboardService        platform.DashboardService
 }
@@ -63,6 +65,14 @@ func (s *Store) OrganizationConfig(ctx context.Context) chronograf.OrganizationC
 	return s.OrganizationConfigStore
 }
 
+func (s *Store) SourceCredentials(ctx context.Context) chronograf.SourceCredentialsStore {
+	return s.SourceCredentialsStore
+}
+
+func (s *Store) DashboardShares(ctx context.Context) chronograf.DashboardSharesStore {
+	return s.DashboardSharesStore
+}
+
 func (s *Store) Cells(ctx context.Context) platform.CellService {
 	return s.CellService
 }
@@ -95,3 +95,39 @@ func (multi *DashboardsStore) Update(ctx context.Context, dashboard chronograf.D
 	}
 	return err
 }
+
+// Search merges the matching dashboards of all contained Stores before
+// sorting and paging them, so that dashboards found in several Stores are
+// returned and counted once
+func (multi *DashboardsStore) Search(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+	matching := q
+	matching.Offset, matching.Limit = 0, 0
+
+	all := []chronograf.Dashboard{}
+	boardSet := map[chronograf.DashboardID]struct{}{}
+
+	ok := false
+	var err error
+	for _, store := range multi.Stores {
+		var boards []chronograf.Dashboard
+		boards, _, err = store.Search(ctx, matching)
+		if err != nil {
+			// If this Store is unable to search dashboards, skip to the next Store.
+			continue
+		}
+		ok = true // We've received a response from at least one Store
+		for _, board := range boards {
+			// Enforce that the dashboard has a unique ID
+			if _, okay := boardSet[board.ID]; !okay {
+				boardSet[board.ID] = struct{}{}
+				all = append(all, board)
+			}
+		}
+	}
+	if !ok {
+		return nil, 0, err
+	}
+
+	page, total := q.Apply(all)
+	return page, total, nil
+}
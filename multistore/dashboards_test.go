@@ -0,0 +1,43 @@
+package multistore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestDashboardsStore_Search(t *testing.T) {
+	store := func(boards ...chronograf.Dashboard) chronograf.DashboardsStore {
+		return &mocks.DashboardsStore{
+			SearchF: func(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+				if q.Offset != 0 || q.Limit != 0 {
+					t.Errorf("Search() paged a contained store with offset %d and limit %d", q.Offset, q.Limit)
+				}
+				return boards, len(boards), nil
+			},
+		}
+	}
+	multi := &DashboardsStore{
+		Stores: []chronograf.DashboardsStore{
+			store(chronograf.Dashboard{ID: 1, Name: "Clock Tower"}, chronograf.Dashboard{ID: 2, Name: "Twin Pines Mall"}),
+			store(chronograf.Dashboard{ID: 2, Name: "Twin Pines Mall"}, chronograf.Dashboard{ID: 3, Name: "Cafe 80s"}),
+		},
+	}
+
+	got, total, err := multi.Search(context.Background(), chronograf.DashboardsQuery{
+		SortBy: chronograf.DashboardsByName,
+		Offset: 1,
+		Limit:  1,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 3 {
+		t.Errorf("Search() total = %d, want 3 once duplicates are removed", total)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("Search() = %v, want the second dashboard by name", got)
+	}
+}
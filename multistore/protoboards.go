@@ -2,7 +2,6 @@ package multistore
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/influxdata/chronograf"
 )
@@ -56,17 +55,41 @@ func (s *Protoboards) Get(ctx context.Context, ID string) (chronograf.Protoboard
 	return chronograf.Protoboard{}, err
 }
 
-// Add creates a new protoboard in the protoboardsStore.
+// Add creates a new protoboard in the protoboardsStore.  Tries each store sequentially until success.
 func (s *Protoboards) Add(ctx context.Context, protoboard chronograf.Protoboard) (chronograf.Protoboard, error) {
-	return chronograf.Protoboard{}, fmt.Errorf("Add to multistore/protoboards not supported")
+	var err error
+	for _, store := range s.Stores {
+		var p chronograf.Protoboard
+		p, err = store.Add(ctx, protoboard)
+		if err == nil {
+			return p, nil
+		}
+	}
+	return chronograf.Protoboard{}, err
 }
 
-// Delete the protoboard from the store.
+// Delete the protoboard from the store.  Searches through all stores to find the protoboard and
+// then deletes from that store.
 func (s *Protoboards) Delete(ctx context.Context, protoboard chronograf.Protoboard) error {
-	return fmt.Errorf("Delete to multistore/protoboards not supported")
+	var err error
+	for _, store := range s.Stores {
+		err = store.Delete(ctx, protoboard)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
 }
 
-// Update the protoboard in the store.
+// Update the protoboard in the store.  Searches through all stores to find the protoboard and
+// then updates it in that store.
 func (s *Protoboards) Update(ctx context.Context, protoboard chronograf.Protoboard) error {
-	return fmt.Errorf("Update to multistore/protoboards not supported")
+	var err error
+	for _, store := range s.Stores {
+		err = store.Update(ctx, protoboard)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
 }
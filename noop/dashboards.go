@@ -31,3 +31,7 @@ func (s *DashboardsStore) Get(ctx context.Context, ID chronograf.DashboardID) (c
 func (s *DashboardsStore) Update(context.Context, chronograf.Dashboard) error {
 	return fmt.Errorf("failed to update dashboard")
 }
+
+func (s *DashboardsStore) Search(context.Context, chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+	return nil, 0, fmt.Errorf("no dashboards found")
+}
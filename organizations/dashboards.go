@@ -110,3 +110,14 @@ func (s *DashboardsStore) Update(ctx context.Context, d chronograf.Dashboard) er
 
 	return s.store.Update(ctx, d)
 }
+
+// Search returns the dashboards of the organization that match the query
+func (s *DashboardsStore) Search(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+	err := validOrganization(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q.Organization = s.organization
+	return s.store.Search(ctx, q)
+}
@@ -337,3 +337,33 @@ func TestDashboards_Update(t *testing.T) {
 		}
 	}
 }
+
+func TestDashboards_Search(t *testing.T) {
+	store := &mocks.DashboardsStore{
+		SearchF: func(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+			if q.Organization != "1337" {
+				return nil, 0, fmt.Errorf("query of organization %q", q.Organization)
+			}
+			return []chronograf.Dashboard{
+				{
+					Name:         "howdy",
+					Organization: "1337",
+				},
+			}, 1, nil
+		},
+	}
+	s := organizations.NewDashboardsStore(store, "1337")
+
+	if _, _, err := s.Search(context.Background(), chronograf.DashboardsQuery{}); err == nil {
+		t.Errorf("DashboardsStore.Search() without organization in context should fail")
+	}
+
+	ctx := context.WithValue(context.Background(), organizations.ContextKey, "1337")
+	got, total, err := s.Search(ctx, chronograf.DashboardsQuery{Name: "how", Organization: "1338"})
+	if err != nil {
+		t.Fatalf("DashboardsStore.Search() error = %v", err)
+	}
+	if total != 1 || len(got) != 1 || got[0].Name != "howdy" {
+		t.Errorf("DashboardsStore.Search() = %v, %d, want the dashboard of the organization", got, total)
+	}
+}
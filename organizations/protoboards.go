@@ -0,0 +1,114 @@
+package organizations
+
+import (
+	"context"
+
+	"github.com/influxdata/chronograf"
+)
+
+// ensure that ProtoboardsStore implements chronograf.ProtoboardsStore
+var _ chronograf.ProtoboardsStore = &ProtoboardsStore{}
+
+// ProtoboardsStore facade on a ProtoboardsStore that filters the protoboards
+// defined by users by organization. Built-in protoboards belong to no
+// organization and are visible to every organization but cannot be changed.
+type ProtoboardsStore struct {
+	store        chronograf.ProtoboardsStore
+	organization string
+}
+
+// NewProtoboardsStore creates a new ProtoboardsStore from an existing
+// chronograf.ProtoboardsStore and an organization string
+func NewProtoboardsStore(s chronograf.ProtoboardsStore, org string) *ProtoboardsStore {
+	return &ProtoboardsStore{
+		store:        s,
+		organization: org,
+	}
+}
+
+// All retrieves the built-in protoboards and the protoboards of the
+// organization from the underlying ProtoboardsStore.
+func (s *ProtoboardsStore) All(ctx context.Context) ([]chronograf.Protoboard, error) {
+	err := validOrganization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := s.store.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	protoboards := ps[:0]
+	for _, p := range ps {
+		if p.Organization == "" || p.Organization == s.organization {
+			protoboards = append(protoboards, p)
+		}
+	}
+
+	return protoboards, nil
+}
+
+// Add creates a new Protoboard in the ProtoboardsStore with protoboard.Organization
+// set to be the organization from the protoboards store.
+func (s *ProtoboardsStore) Add(ctx context.Context, p chronograf.Protoboard) (chronograf.Protoboard, error) {
+	err := validOrganization(ctx)
+	if err != nil {
+		return chronograf.Protoboard{}, err
+	}
+
+	p.Organization = s.organization
+	return s.store.Add(ctx, p)
+}
+
+// Delete the protoboard of the organization from ProtoboardsStore
+func (s *ProtoboardsStore) Delete(ctx context.Context, p chronograf.Protoboard) error {
+	p, err := s.owned(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, p)
+}
+
+// Get returns a Protoboard if the id exists and is either built-in or belongs
+// to the organization that is set.
+func (s *ProtoboardsStore) Get(ctx context.Context, id string) (chronograf.Protoboard, error) {
+	err := validOrganization(ctx)
+	if err != nil {
+		return chronograf.Protoboard{}, err
+	}
+
+	p, err := s.store.Get(ctx, id)
+	if err != nil {
+		return chronograf.Protoboard{}, err
+	}
+
+	if p.Organization != "" && p.Organization != s.organization {
+		return chronograf.Protoboard{}, chronograf.ErrProtoboardNotFound
+	}
+
+	return p, nil
+}
+
+// Update the protoboard of the organization in ProtoboardsStore.
+func (s *ProtoboardsStore) Update(ctx context.Context, p chronograf.Protoboard) error {
+	if _, err := s.owned(ctx, p.ID); err != nil {
+		return err
+	}
+
+	p.Organization = s.organization
+	return s.store.Update(ctx, p)
+}
+
+// owned returns the protoboard if it was defined by the organization
+func (s *ProtoboardsStore) owned(ctx context.Context, id string) (chronograf.Protoboard, error) {
+	p, err := s.Get(ctx, id)
+	if err != nil {
+		return chronograf.Protoboard{}, err
+	}
+	if p.Organization == "" {
+		return chronograf.Protoboard{}, chronograf.ErrProtoboardReadOnly
+	}
+	return p, nil
+}
@@ -0,0 +1,95 @@
+package organizations_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/organizations"
+)
+
+func TestProtoboards(t *testing.T) {
+	var updated, deleted []string
+	store := &mocks.ProtoboardsStore{
+		AllF: func(ctx context.Context) ([]chronograf.Protoboard, error) {
+			return []chronograf.Protoboard{
+				{ID: "system"},
+				{ID: "howdy", Organization: "1337"},
+				{ID: "doody", Organization: "1338"},
+			}, nil
+		},
+		GetF: func(ctx context.Context, id string) (chronograf.Protoboard, error) {
+			switch id {
+			case "system":
+				return chronograf.Protoboard{ID: id}, nil
+			case "howdy":
+				return chronograf.Protoboard{ID: id, Organization: "1337"}, nil
+			case "doody":
+				return chronograf.Protoboard{ID: id, Organization: "1338"}, nil
+			}
+			return chronograf.Protoboard{}, chronograf.ErrProtoboardNotFound
+		},
+		AddF: func(ctx context.Context, p chronograf.Protoboard) (chronograf.Protoboard, error) {
+			p.ID = "new"
+			return p, nil
+		},
+		UpdateF: func(ctx context.Context, p chronograf.Protoboard) error {
+			updated = append(updated, p.ID+"@"+p.Organization)
+			return nil
+		},
+		DeleteF: func(ctx context.Context, p chronograf.Protoboard) error {
+			deleted = append(deleted, p.ID)
+			return nil
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), organizations.ContextKey, "1337")
+	s := organizations.NewProtoboardsStore(store, "1337")
+
+	all, err := s.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []chronograf.Protoboard{{ID: "system"}, {ID: "howdy", Organization: "1337"}}
+	if diff := cmp.Diff(all, want); diff != "" {
+		t.Errorf("All() = %s", diff)
+	}
+
+	if _, err := s.Get(ctx, "doody"); err != chronograf.ErrProtoboardNotFound {
+		t.Errorf("Get() protoboard of another organization error = %v, want %v", err, chronograf.ErrProtoboardNotFound)
+	}
+
+	p, err := s.Add(ctx, chronograf.Protoboard{Organization: "1338"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Organization != "1337" {
+		t.Errorf("Add() organization = %s, want 1337", p.Organization)
+	}
+
+	for _, id := range []string{"system", "doody", "howdy"} {
+		err := s.Update(ctx, chronograf.Protoboard{ID: id})
+		wantErr := map[string]error{
+			"system": chronograf.ErrProtoboardReadOnly,
+			"doody":  chronograf.ErrProtoboardNotFound,
+		}[id]
+		if err != wantErr {
+			t.Errorf("Update(%s) error = %v, want %v", id, err, wantErr)
+		}
+		if err := s.Delete(ctx, chronograf.Protoboard{ID: id}); err != wantErr {
+			t.Errorf("Delete(%s) error = %v, want %v", id, err, wantErr)
+		}
+	}
+	if diff := cmp.Diff(updated, []string{"howdy@1337"}); diff != "" {
+		t.Errorf("Update() = %s", diff)
+	}
+	if diff := cmp.Diff(deleted, []string{"howdy"}); diff != "" {
+		t.Errorf("Delete() = %s", diff)
+	}
+
+	if _, err := s.All(context.Background()); err == nil {
+		t.Errorf("All() without organization expected error")
+	}
+}
@@ -0,0 +1,293 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/influxdb/influxql"
+)
+
+// metaQuery answers InfluxQL SHOW queries from the labels and series of
+// Prometheus. Metric names are measurements, labels are tags and every
+// metric has a single float field named value.
+func (c *Client) metaQuery(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+	stmt, err := influxql.ParseStatement(q.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []series
+	switch s := stmt.(type) {
+	case *influxql.ShowDatabasesStatement:
+		res = []series{{
+			Name:    "databases",
+			Columns: []string{"name"},
+			Values:  [][]interface{}{{Database}},
+		}}
+	case *influxql.ShowMeasurementsStatement:
+		res, err = c.showMeasurements(ctx, s)
+	case *influxql.ShowTagKeysStatement:
+		res, err = c.showTagKeys(ctx, s)
+	case *influxql.ShowTagValuesStatement:
+		res, err = c.showTagValues(ctx, s)
+	case *influxql.ShowFieldKeysStatement:
+		res, err = c.showFieldKeys(ctx, s)
+	case *influxql.ShowSeriesStatement:
+		res, err = c.showSeries(ctx, s)
+	default:
+		return nil, fmt.Errorf("query is not supported by Prometheus: %s", q.Command)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return Response{{Series: res}}, nil
+}
+
+func (c *Client) showMeasurements(ctx context.Context, s *influxql.ShowMeasurementsStatement) ([]series, error) {
+	names, err := c.labelValues(ctx, "__name__")
+	if err != nil {
+		return nil, err
+	}
+
+	values := [][]interface{}{}
+	for _, name := range names {
+		if m, ok := s.Source.(*influxql.Measurement); ok && !matchMeasurement(m, name) {
+			continue
+		}
+		values = append(values, []interface{}{name})
+	}
+	return []series{{
+		Name:    "measurements",
+		Columns: []string{"name"},
+		Values:  values,
+	}}, nil
+}
+
+func (c *Client) showTagKeys(ctx context.Context, s *influxql.ShowTagKeysStatement) ([]series, error) {
+	if len(s.Sources) == 0 {
+		var labels []string
+		if err := c.get(ctx, "/api/v1/labels", nil, &labels); err != nil {
+			return nil, err
+		}
+		return []series{{
+			Columns: []string{"tagKey"},
+			Values:  tagRows(labels),
+		}}, nil
+	}
+
+	metrics, err := c.series(ctx, s.Sources)
+	if err != nil {
+		return nil, err
+	}
+	res := []series{}
+	for _, name := range metricNames(metrics) {
+		keys := map[string]bool{}
+		for _, m := range metrics {
+			if m["__name__"] != name {
+				continue
+			}
+			for k := range m {
+				keys[k] = true
+			}
+		}
+		res = append(res, series{
+			Name:    name,
+			Columns: []string{"tagKey"},
+			Values:  tagRows(sortedKeys(keys)),
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) showTagValues(ctx context.Context, s *influxql.ShowTagValuesStatement) ([]series, error) {
+	var keys []string
+	switch lit := s.TagKeyExpr.(type) {
+	case *influxql.StringLiteral:
+		if s.Op == influxql.EQ {
+			keys = []string{lit.Val}
+		}
+	case *influxql.ListLiteral:
+		if s.Op == influxql.IN {
+			keys = lit.Vals
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("tag values of Prometheus must select keys with = or IN")
+	}
+
+	if len(s.Sources) == 0 {
+		values := [][]interface{}{}
+		for _, key := range keys {
+			vals, err := c.labelValues(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range vals {
+				values = append(values, []interface{}{key, v})
+			}
+		}
+		return []series{{
+			Columns: []string{"key", "value"},
+			Values:  values,
+		}}, nil
+	}
+
+	metrics, err := c.series(ctx, s.Sources)
+	if err != nil {
+		return nil, err
+	}
+	res := []series{}
+	for _, name := range metricNames(metrics) {
+		values := [][]interface{}{}
+		for _, key := range keys {
+			vals := map[string]bool{}
+			for _, m := range metrics {
+				if v, ok := m[key]; ok && m["__name__"] == name {
+					vals[v] = true
+				}
+			}
+			for _, v := range sortedKeys(vals) {
+				values = append(values, []interface{}{key, v})
+			}
+		}
+		res = append(res, series{
+			Name:    name,
+			Columns: []string{"key", "value"},
+			Values:  values,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) showFieldKeys(ctx context.Context, s *influxql.ShowFieldKeysStatement) ([]series, error) {
+	names := []string{}
+	if len(s.Sources) == 0 {
+		all, err := c.labelValues(ctx, "__name__")
+		if err != nil {
+			return nil, err
+		}
+		names = all
+	} else {
+		metrics, err := c.series(ctx, s.Sources)
+		if err != nil {
+			return nil, err
+		}
+		names = metricNames(metrics)
+	}
+
+	res := []series{}
+	for _, name := range names {
+		res = append(res, series{
+			Name:    name,
+			Columns: []string{"fieldKey", "fieldType"},
+			Values:  [][]interface{}{{"value", "float"}},
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) showSeries(ctx context.Context, s *influxql.ShowSeriesStatement) ([]series, error) {
+	metrics, err := c.series(ctx, s.Sources)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for _, m := range metrics {
+		tags := map[string]string{}
+		for k, v := range m {
+			if k != "__name__" {
+				tags[k] = v
+			}
+		}
+		keys[seriesKey(series{Name: m["__name__"], Tags: tags})] = true
+	}
+	return []series{{
+		Columns: []string{"key"},
+		Values:  tagRows(sortedKeys(keys)),
+	}}, nil
+}
+
+// labelValues lists the values of a label
+func (c *Client) labelValues(ctx context.Context, label string) ([]string, error) {
+	var values []string
+	if err := c.get(ctx, "/api/v1/label/"+url.PathEscape(label)+"/values", nil, &values); err != nil {
+		return nil, err
+	}
+	sort.Strings(values)
+	return values, nil
+}
+
+// series lists the label sets of the metrics matching the measurements.
+// All metrics are listed without measurements.
+func (c *Client) series(ctx context.Context, sources influxql.Sources) ([]map[string]string, error) {
+	params := url.Values{}
+	for _, src := range sources {
+		m, ok := src.(*influxql.Measurement)
+		if !ok {
+			return nil, fmt.Errorf("subqueries are not supported by Prometheus")
+		}
+		params.Add("match[]", matcher(m))
+	}
+	if len(params) == 0 {
+		params.Add("match[]", `{__name__=~".+"}`)
+	}
+
+	var metrics []map[string]string
+	if err := c.get(ctx, "/api/v1/series", params, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// matcher selects the metrics of the measurement by name or regex. Regexes
+// of Prometheus are anchored while those of InfluxQL are not.
+func matcher(m *influxql.Measurement) string {
+	if m.Regex != nil {
+		return fmt.Sprintf("{__name__=~%s}", strconv.Quote(".*(?:"+m.Regex.Val.String()+").*"))
+	}
+	return fmt.Sprintf("{__name__=%s}", strconv.Quote(m.Name))
+}
+
+func matchMeasurement(m *influxql.Measurement, name string) bool {
+	if m.Regex != nil {
+		return m.Regex.Val.MatchString(name)
+	}
+	return m.Name == "" || m.Name == name
+}
+
+func metricNames(metrics []map[string]string) []string {
+	names := map[string]bool{}
+	for _, m := range metrics {
+		names[m["__name__"]] = true
+	}
+	return sortedKeys(names)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		if k != "__name__" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func tagRows(values []string) [][]interface{} {
+	rows := [][]interface{}{}
+	for _, v := range values {
+		if strings.HasPrefix(v, "__") {
+			continue
+		}
+		rows = append(rows, []interface{}{v})
+	}
+	return rows
+}
@@ -0,0 +1,381 @@
+package prometheus
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/noop"
+)
+
+var _ chronograf.TimeSeries = &Client{}
+var _ chronograf.TSDBStatus = &Client{}
+
+const (
+	// Database is the only database of a Prometheus source
+	Database = "prometheus"
+
+	// DefaultRange is queried when a query does not specify its start
+	DefaultRange = time.Hour
+
+	// DesiredPoints is the number of points of a query without a step
+	DesiredPoints = 360
+)
+
+// Shared transports for all clients to prevent leaking connections
+var (
+	skipVerifyTransport = &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	defaultTransport = &http.Transport{}
+)
+
+// Client is a device for retrieving time series data from Prometheus with
+// PromQL over the Prometheus HTTP API
+type Client struct {
+	URL                *url.URL
+	Username           string
+	Password           string
+	InsecureSkipVerify bool
+	Logger             chronograf.Logger
+	Now                func() time.Time
+}
+
+// Connect caches the URL and the optional basic authentication of the source
+func (c *Client) Connect(ctx context.Context, src *chronograf.Source) error {
+	u, err := url.Parse(src.URL)
+	if err != nil {
+		return err
+	}
+	// Only allow acceptance of all certs if the scheme is https AND the user opted into to the setting.
+	if u.Scheme == "https" && src.InsecureSkipVerify {
+		c.InsecureSkipVerify = src.InsecureSkipVerify
+	}
+	c.URL = u
+	c.Username = src.Username
+	c.Password = src.Password
+	return nil
+}
+
+// Query runs the PromQL query over the time range of the query. SHOW
+// queries listing databases, measurements, tag keys and values, field keys
+// and series are answered from the labels and series of Prometheus so that
+// the data explorer and template variables work. The response has the
+// shape of InfluxQL results.
+func (c *Client) Query(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+	if c.URL == nil {
+		return nil, chronograf.ErrUninitialized
+	}
+	if isMetaQuery(q.Command) {
+		return c.metaQuery(ctx, q)
+	}
+
+	start, end, step, err := c.queryRange(q)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("query", q.Command)
+	params.Set("start", formatTime(start))
+	params.Set("end", formatTime(end))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	var data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][]interface{}   `json:"values"`
+		} `json:"result"`
+	}
+	if err := c.get(ctx, "/api/v1/query_range", params, &data); err != nil {
+		return nil, err
+	}
+	if data.ResultType != "matrix" {
+		return nil, fmt.Errorf("unexpected result type %s from Prometheus", data.ResultType)
+	}
+
+	res := result{
+		Series: []series{},
+	}
+	for _, r := range data.Result {
+		s := series{
+			Name:    r.Metric["__name__"],
+			Tags:    map[string]string{},
+			Columns: []string{"time", "value"},
+			Values:  [][]interface{}{},
+		}
+		if s.Name == "" {
+			s.Name = q.Command
+		}
+		for k, v := range r.Metric {
+			if k != "__name__" {
+				s.Tags[k] = v
+			}
+		}
+		for _, v := range r.Values {
+			if len(v) != 2 {
+				continue
+			}
+			t, err := sampleTime(v[0], q.Epoch)
+			if err != nil {
+				return nil, err
+			}
+			s.Values = append(s.Values, []interface{}{t, sampleValue(v[1])})
+		}
+		res.Series = append(res.Series, s)
+	}
+	sort.Slice(res.Series, func(i, j int) bool {
+		return seriesKey(res.Series[i]) < seriesKey(res.Series[j])
+	})
+
+	return Response{res}, nil
+}
+
+// Write is not supported as Prometheus scrapes its targets
+func (c *Client) Write(context.Context, []chronograf.Point) error {
+	return fmt.Errorf("writing points is not supported by Prometheus")
+}
+
+// Users are not supported by Prometheus
+func (c *Client) Users(context.Context) chronograf.UsersStore {
+	return &noop.UsersStore{}
+}
+
+// Permissions are not supported by Prometheus
+func (c *Client) Permissions(context.Context) chronograf.Permissions {
+	return chronograf.Permissions{}
+}
+
+// Roles are not supported by Prometheus
+func (c *Client) Roles(context.Context) (chronograf.RolesStore, error) {
+	return nil, fmt.Errorf("Roles are not supported by Prometheus")
+}
+
+// Ping checks that Prometheus evaluates queries
+func (c *Client) Ping(ctx context.Context) error {
+	if c.URL == nil {
+		return chronograf.ErrUninitialized
+	}
+	params := url.Values{}
+	params.Set("query", "time()")
+	var data json.RawMessage
+	return c.get(ctx, "/api/v1/query", params, &data)
+}
+
+// Version returns the version reported by the build information of
+// Prometheus
+func (c *Client) Version(ctx context.Context) (string, error) {
+	if c.URL == nil {
+		return "", chronograf.ErrUninitialized
+	}
+	var data struct {
+		Version string `json:"version"`
+	}
+	if err := c.get(ctx, "/api/v1/status/buildinfo", nil, &data); err != nil {
+		return "", err
+	}
+	return data.Version, nil
+}
+
+// Type returns prometheus if Prometheus can be reached
+func (c *Client) Type(ctx context.Context) (string, error) {
+	if err := c.Ping(ctx); err != nil {
+		return "", err
+	}
+	return chronograf.Prometheus, nil
+}
+
+// apiResponse is the envelope of all responses of the Prometheus HTTP API
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+}
+
+// get decodes the data of a Prometheus HTTP API response into data
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values, data interface{}) error {
+	u := *c.URL
+	u.Path = path.Join(u.Path, endpoint)
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	hc := &http.Client{}
+	if c.InsecureSkipVerify {
+		hc.Transport = skipVerifyTransport
+	} else {
+		hc.Transport = defaultTransport
+	}
+
+	if c.Logger != nil {
+		c.Logger.
+			WithField("component", "prometheus").
+			WithField("host", u.Host).
+			WithField("endpoint", endpoint).
+			WithField("query", params.Get("query")).
+			Debug("query")
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return chronograf.ErrUpstreamTimeout
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	var res apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("received status code %d from server: err: %v", resp.StatusCode, err)
+	}
+	if res.Status != "success" {
+		return fmt.Errorf("received status code %d from server: err: %s", resp.StatusCode, res.Error)
+	}
+	return json.Unmarshal(res.Data, data)
+}
+
+// queryRange returns the start, end and step of the query. Queries without
+// a start cover the last hour and queries without a step are split into
+// DesiredPoints points.
+func (c *Client) queryRange(q chronograf.Query) (time.Time, time.Time, time.Duration, error) {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+
+	end := now()
+	if q.End != "" {
+		t, err := parseTime(q.End)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("invalid end %q: %v", q.End, err)
+		}
+		end = t
+	}
+
+	start := end.Add(-DefaultRange)
+	if q.Start != "" {
+		t, err := parseTime(q.Start)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("invalid start %q: %v", q.Start, err)
+		}
+		start = t
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("start must be before end")
+	}
+
+	step := end.Sub(start) / DesiredPoints
+	if q.Step != "" {
+		d, err := parseStep(q.Step)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("invalid step %q: %v", q.Step, err)
+		}
+		step = d
+	}
+	if step < time.Second {
+		step = time.Second
+	}
+	return start, end, step.Truncate(time.Second), nil
+}
+
+// parseTime parses RFC3339 times or unix seconds
+func parseTime(s string) (time.Time, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// parseStep parses durations or seconds
+func parseStep(s string) (time.Duration, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+}
+
+// sampleTime converts the unix seconds of a sample into the epoch of the
+// query. Without an epoch InfluxDB returns RFC3339 timestamps, but the UI
+// always asks for milliseconds, so milliseconds are the default here.
+func sampleTime(v interface{}, epoch string) (interface{}, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("invalid sample time %v", v)
+	}
+	ns := int64(math.Round(f * float64(time.Second)))
+	switch epoch {
+	case "", "ms":
+		return ns / int64(time.Millisecond), nil
+	case "ns", "n":
+		return ns, nil
+	case "u", "µ":
+		return ns / int64(time.Microsecond), nil
+	case "s":
+		return ns / int64(time.Second), nil
+	case "m":
+		return ns / int64(time.Minute), nil
+	case "h":
+		return ns / int64(time.Hour), nil
+	case "rfc3339":
+		return time.Unix(0, ns).UTC().Format(time.RFC3339Nano), nil
+	default:
+		return nil, fmt.Errorf("unknown epoch %s", epoch)
+	}
+}
+
+// sampleValue parses the string value of a sample. NaN and infinite
+// values cannot be encoded as JSON and are returned as nil.
+func sampleValue(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return f
+}
+
+func seriesKey(s series) string {
+	keys := make([]string, 0, len(s.Tags))
+	for k := range s.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	key := s.Name
+	for _, k := range keys {
+		key += "," + k + "=" + s.Tags[k]
+	}
+	return key
+}
+
+// isMetaQuery is true for InfluxQL SHOW queries which are never PromQL
+func isMetaQuery(command string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(command)), "SHOW ")
+}
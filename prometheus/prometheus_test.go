@@ -0,0 +1,213 @@
+package prometheus_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/prometheus"
+)
+
+func newPrometheus(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "prom" || pass != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			rw.Write([]byte(`{"status":"error","errorType":"unauthorized","error":"bad credentials"}`))
+			return
+		}
+		params := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v1/query_range":
+			if params.Get("query") == "bad(" {
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+				return
+			}
+			if params.Get("start") != "1535760000" || params.Get("end") != "1535760060" || params.Get("step") != "30" {
+				t.Errorf("Unexpected range %v", params)
+			}
+			rw.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"__name__":"up","job":"node","instance":"b:9100"},"values":[[1535760000,"1"],[1535760030,"0"]]},` +
+				`{"metric":{"__name__":"up","job":"node","instance":"a:9100"},"values":[[1535760000,"1"],[1535760030.5,"NaN"]]}]}}`))
+		case "/api/v1/label/__name__/values":
+			rw.Write([]byte(`{"status":"success","data":["up","node_load1"]}`))
+		case "/api/v1/labels":
+			rw.Write([]byte(`{"status":"success","data":["__name__","instance","job"]}`))
+		case "/api/v1/series":
+			if m := params["match[]"]; len(m) != 1 || m[0] != `{__name__="up"}` {
+				t.Errorf("Unexpected matchers %v", m)
+			}
+			rw.Write([]byte(`{"status":"success","data":[` +
+				`{"__name__":"up","job":"node","instance":"b:9100"},` +
+				`{"__name__":"up","job":"prometheus","instance":"a:9090"}]}`))
+		case "/api/v1/query":
+			rw.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1535760000,"1535760000"]}}`))
+		case "/api/v1/status/buildinfo":
+			rw.Write([]byte(`{"status":"success","data":{"version":"2.4.0","revision":"068eaa"}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func connect(t *testing.T, u string) *prometheus.Client {
+	cl := &prometheus.Client{
+		Logger: log.New(log.DebugLevel),
+	}
+	err := cl.Connect(context.Background(), &chronograf.Source{
+		URL:      u,
+		Username: "prom",
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatal("Unexpected error connecting: err:", err)
+	}
+	return cl
+}
+
+func TestClient_Query(t *testing.T) {
+	ts := newPrometheus(t)
+	defer ts.Close()
+	cl := connect(t, ts.URL)
+
+	tests := []struct {
+		name    string
+		query   chronograf.Query
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "PromQL over a range",
+			query: chronograf.Query{
+				Command: "up",
+				Start:   "2018-09-01T00:00:00Z",
+				End:     "1535760060",
+				Step:    "30s",
+			},
+			want: `[{"statement_id":0,"series":[` +
+				`{"name":"up","tags":{"instance":"a:9100","job":"node"},"columns":["time","value"],"values":[[1535760000000,1],[1535760030500,null]]},` +
+				`{"name":"up","tags":{"instance":"b:9100","job":"node"},"columns":["time","value"],"values":[[1535760000000,1],[1535760030000,0]]}]}]`,
+		},
+		{
+			name: "PromQL errors",
+			query: chronograf.Query{
+				Command: "bad(",
+				Start:   "1535760000",
+				End:     "1535760060",
+				Step:    "30",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Databases",
+			query: chronograf.Query{
+				Command: "SHOW DATABASES",
+			},
+			want: `[{"statement_id":0,"series":[{"name":"databases","columns":["name"],"values":[["prometheus"]]}]}]`,
+		},
+		{
+			name: "Measurements are metric names",
+			query: chronograf.Query{
+				Command: "SHOW MEASUREMENTS",
+			},
+			want: `[{"statement_id":0,"series":[{"name":"measurements","columns":["name"],"values":[["node_load1"],["up"]]}]}]`,
+		},
+		{
+			name: "Tag keys are labels",
+			query: chronograf.Query{
+				Command: "show tag keys",
+			},
+			want: `[{"statement_id":0,"series":[{"columns":["tagKey"],"values":[["instance"],["job"]]}]}]`,
+		},
+		{
+			name: "Tag values of a measurement",
+			query: chronograf.Query{
+				Command: `SHOW TAG VALUES FROM "up" WITH KEY = "job"`,
+			},
+			want: `[{"statement_id":0,"series":[{"name":"up","columns":["key","value"],"values":[["job","node"],["job","prometheus"]]}]}]`,
+		},
+		{
+			name: "Series of a measurement",
+			query: chronograf.Query{
+				Command: `SHOW SERIES FROM "up"`,
+			},
+			want: `[{"statement_id":0,"series":[{"columns":["key"],"values":[["up,instance=a:9090,job=prometheus"],["up,instance=b:9100,job=node"]]}]}]`,
+		},
+		{
+			name: "Unsupported InfluxQL",
+			query: chronograf.Query{
+				Command: "SHOW RETENTION POLICIES",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := cl.Query(context.Background(), tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.Query() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := res.MarshalJSON()
+			if err != nil {
+				t.Fatal("Unexpected error marshaling response: err:", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Client.Query() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_DefaultRange(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		if params.Get("start") != "1535756400" || params.Get("end") != "1535760000" || params.Get("step") != "10" {
+			t.Errorf("Expected the last hour in 360 steps but got %v", params)
+		}
+		rw.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+	}))
+	defer ts.Close()
+
+	cl := &prometheus.Client{
+		Logger: log.New(log.DebugLevel),
+		Now: func() time.Time {
+			return time.Unix(1535760000, 0)
+		},
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{URL: ts.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.Query(context.Background(), chronograf.Query{Command: "up"}); err != nil {
+		t.Fatal("Unexpected error querying: err:", err)
+	}
+}
+
+func TestClient_Status(t *testing.T) {
+	ts := newPrometheus(t)
+	defer ts.Close()
+	cl := connect(t, ts.URL)
+
+	if err := cl.Ping(context.Background()); err != nil {
+		t.Error("Unexpected error pinging: err:", err)
+	}
+	if v, err := cl.Version(context.Background()); err != nil || v != "2.4.0" {
+		t.Errorf("Client.Version() = %s, %v; want 2.4.0", v, err)
+	}
+	if typ, err := cl.Type(context.Background()); err != nil || typ != chronograf.Prometheus {
+		t.Errorf("Client.Type() = %s, %v; want %s", typ, err, chronograf.Prometheus)
+	}
+
+	unauthorized := &prometheus.Client{}
+	unauthorized.Connect(context.Background(), &chronograf.Source{URL: ts.URL})
+	if err := unauthorized.Ping(context.Background()); err == nil {
+		t.Error("Expected an error pinging without credentials")
+	}
+}
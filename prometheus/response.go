@@ -0,0 +1,23 @@
+package prometheus
+
+import "encoding/json"
+
+// Response is a set of results in the shape of InfluxQL results
+type Response []result
+
+// MarshalJSON encodes the results as InfluxDB would
+func (r Response) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]result(r))
+}
+
+type result struct {
+	StatementID int      `json:"statement_id"`
+	Series      []series `json:"series"`
+}
+
+type series struct {
+	Name    string            `json:"name,omitempty"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Columns []string          `json:"columns"`
+	Values  [][]interface{}   `json:"values"`
+}
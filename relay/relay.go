@@ -0,0 +1,478 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/influx"
+)
+
+var _ chronograf.TimeSeries = &Client{}
+var _ chronograf.Databases = &Client{}
+
+// Client is a device for retrieving time series data from the InfluxDBs
+// behind an InfluxDB Relay. The relay only accepts writes and replicates
+// them to every backend, so reads are sent to a healthy backend and retried
+// on the others should it fail. Everything else, such as the administration
+// of databases and retention policies, is fanned out to all backends to keep
+// them consistent.
+type Client struct {
+	Logger chronograf.Logger
+	// Health tracks the health of the backends. It is shared by the clients
+	// of every request to the source so that failed backends are remembered;
+	// every client has its own when nil.
+	Health *enterprise.Health
+
+	mu       sync.Mutex
+	relay    *influx.Client
+	backends []backend
+}
+
+// backend is a backend InfluxDB along with the URL it is configured with
+type backend struct {
+	url string
+	*influx.Client
+}
+
+// Connect prepares the Client to write to the relay at the URL of the source
+// and to query its backends. The backends use the credentials of the source.
+func (c *Client) Connect(ctx context.Context, src *chronograf.Source) error {
+	relay := &influx.Client{
+		Logger: c.Logger,
+	}
+	if err := relay.Connect(ctx, src); err != nil {
+		return err
+	}
+
+	urls := src.Backends
+	if len(urls) == 0 {
+		return fmt.Errorf("no backend InfluxDBs configured for relay")
+	}
+	backends := make([]backend, len(urls))
+	for i, u := range urls {
+		backendSrc := *src
+		backendSrc.Type = chronograf.InfluxDB
+		backendSrc.URL = u
+		cl := &influx.Client{
+			Logger: c.Logger,
+		}
+		if err := cl.Connect(ctx, &backendSrc); err != nil {
+			return fmt.Errorf("invalid backend %s: %v", u, err)
+		}
+		backends[i] = backend{
+			url:    u,
+			Client: cl,
+		}
+	}
+
+	c.mu.Lock()
+	c.relay = relay
+	c.backends = backends
+	c.mu.Unlock()
+	return nil
+}
+
+// Query sends reads to a healthy backend, failing over to the others. Any
+// other statement is executed on every backend and the response of the
+// first backend is returned.
+func (c *Client) Query(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+	var res chronograf.Response
+	if influx.IsReadOnly(q.Command) {
+		err := c.read(ctx, func(b *influx.Client) (err error) {
+			res, err = b.Query(ctx, q)
+			return err
+		})
+		return res, err
+	}
+
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		r, err := b.Query(ctx, q)
+		if i == 0 {
+			res = r
+		}
+		return err
+	})
+	return res, err
+}
+
+// Write sends points to the relay, which replicates them to the backends
+func (c *Client) Write(ctx context.Context, points []chronograf.Point) error {
+	relay, _, err := c.clients()
+	if err != nil {
+		return err
+	}
+	return relay.Write(ctx, points)
+}
+
+// WriteLines sends line protocol to the relay, which replicates it to the
+// backends
+func (c *Client) WriteLines(ctx context.Context, db, rp, precision string, lines []string) (int, error) {
+	relay, _, err := c.clients()
+	if err != nil {
+		return 0, err
+	}
+	return relay.WriteLines(ctx, db, rp, precision, lines)
+}
+
+// Users are those of the first backend. Users are not replicated by the
+// relay and must be administered on every backend separately.
+func (c *Client) Users(ctx context.Context) chronograf.UsersStore {
+	_, backends, err := c.clients()
+	if err != nil {
+		return nil
+	}
+	return backends[0].Client.Users(ctx)
+}
+
+// Permissions are those of open-source InfluxDB
+func (c *Client) Permissions(ctx context.Context) chronograf.Permissions {
+	return (&influx.Client{}).Permissions(ctx)
+}
+
+// Roles aren't supported by the open-source InfluxDBs behind a relay
+func (c *Client) Roles(ctx context.Context) (chronograf.RolesStore, error) {
+	return nil, fmt.Errorf("Roles not supported by InfluxDB Relay")
+}
+
+// AllDB lists the databases of a healthy backend
+func (c *Client) AllDB(ctx context.Context) ([]chronograf.Database, error) {
+	var dbs []chronograf.Database
+	err := c.read(ctx, func(b *influx.Client) (err error) {
+		dbs, err = b.AllDB(ctx)
+		return err
+	})
+	return dbs, err
+}
+
+// CreateDB creates the database on every backend
+func (c *Client) CreateDB(ctx context.Context, db *chronograf.Database) (*chronograf.Database, error) {
+	var res *chronograf.Database
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		created, err := b.CreateDB(ctx, db)
+		if i == 0 {
+			res = created
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DropDB drops the database from every backend
+func (c *Client) DropDB(ctx context.Context, db string) error {
+	return c.fanOut(ctx, func(_ int, b *influx.Client) error {
+		return b.DropDB(ctx, db)
+	})
+}
+
+// AllRP lists the retention policies of the database on a healthy backend
+func (c *Client) AllRP(ctx context.Context, db string) ([]chronograf.RetentionPolicy, error) {
+	var rps []chronograf.RetentionPolicy
+	err := c.read(ctx, func(b *influx.Client) (err error) {
+		rps, err = b.AllRP(ctx, db)
+		return err
+	})
+	return rps, err
+}
+
+// CreateRP creates the retention policy on every backend
+func (c *Client) CreateRP(ctx context.Context, db string, rp *chronograf.RetentionPolicy) (*chronograf.RetentionPolicy, error) {
+	var res *chronograf.RetentionPolicy
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		created, err := b.CreateRP(ctx, db, rp)
+		if i == 0 {
+			res = created
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateRP updates the retention policy on every backend
+func (c *Client) UpdateRP(ctx context.Context, db string, rp string, upd *chronograf.RetentionPolicy) (*chronograf.RetentionPolicy, error) {
+	var res *chronograf.RetentionPolicy
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		updated, err := b.UpdateRP(ctx, db, rp, upd)
+		if i == 0 {
+			res = updated
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DropRP drops the retention policy from every backend
+func (c *Client) DropRP(ctx context.Context, db string, rp string) error {
+	return c.fanOut(ctx, func(_ int, b *influx.Client) error {
+		return b.DropRP(ctx, db, rp)
+	})
+}
+
+// GetMeasurements lists the measurements of the database on a healthy backend
+func (c *Client) GetMeasurements(ctx context.Context, db string, limit, offset int) ([]chronograf.Measurement, error) {
+	var ms []chronograf.Measurement
+	err := c.read(ctx, func(b *influx.Client) (err error) {
+		ms, err = b.GetMeasurements(ctx, db, limit, offset)
+		return err
+	})
+	return ms, err
+}
+
+// AllCQ lists the continuous queries of the database on a healthy backend
+func (c *Client) AllCQ(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+	var cqs []chronograf.ContinuousQuery
+	err := c.read(ctx, func(b *influx.Client) (err error) {
+		cqs, err = b.AllCQ(ctx, db)
+		return err
+	})
+	return cqs, err
+}
+
+// CreateCQ creates the continuous query on every backend
+func (c *Client) CreateCQ(ctx context.Context, db string, cq *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	var res *chronograf.ContinuousQuery
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		created, err := b.CreateCQ(ctx, db, cq)
+		if i == 0 {
+			res = created
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateCQ replaces the continuous query on every backend
+func (c *Client) UpdateCQ(ctx context.Context, db string, name string, upd *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	var res *chronograf.ContinuousQuery
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		updated, err := b.UpdateCQ(ctx, db, name, upd)
+		if i == 0 {
+			res = updated
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DropCQ drops the continuous query from every backend
+func (c *Client) DropCQ(ctx context.Context, db string, name string) error {
+	return c.fanOut(ctx, func(_ int, b *influx.Client) error {
+		return b.DropCQ(ctx, db, name)
+	})
+}
+
+// AllShards lists the shards of the database on a healthy backend
+func (c *Client) AllShards(ctx context.Context, db string) ([]chronograf.Shard, error) {
+	var shards []chronograf.Shard
+	err := c.read(ctx, func(b *influx.Client) (err error) {
+		shards, err = b.AllShards(ctx, db)
+		return err
+	})
+	return shards, err
+}
+
+// DropShard is not supported as every backend numbers its shards independently
+func (c *Client) DropShard(ctx context.Context, id uint64) error {
+	return fmt.Errorf("shards cannot be dropped through InfluxDB Relay as their ids differ between backends")
+}
+
+// AllSubscriptions lists the subscriptions of the database on a healthy backend
+func (c *Client) AllSubscriptions(ctx context.Context, db string) ([]chronograf.Subscription, error) {
+	var subs []chronograf.Subscription
+	err := c.read(ctx, func(b *influx.Client) (err error) {
+		subs, err = b.AllSubscriptions(ctx, db)
+		return err
+	})
+	return subs, err
+}
+
+// CreateSubscription creates the subscription on every backend
+func (c *Client) CreateSubscription(ctx context.Context, db string, sub *chronograf.Subscription) (*chronograf.Subscription, error) {
+	var res *chronograf.Subscription
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		created, err := b.CreateSubscription(ctx, db, sub)
+		if i == 0 {
+			res = created
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateSubscription replaces the subscription on every backend
+func (c *Client) UpdateSubscription(ctx context.Context, db, rp, name string, upd *chronograf.Subscription) (*chronograf.Subscription, error) {
+	var res *chronograf.Subscription
+	err := c.fanOut(ctx, func(i int, b *influx.Client) error {
+		updated, err := b.UpdateSubscription(ctx, db, rp, name, upd)
+		if i == 0 {
+			res = updated
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DropSubscription drops the subscription from every backend
+func (c *Client) DropSubscription(ctx context.Context, db, rp, name string) error {
+	return c.fanOut(ctx, func(_ int, b *influx.Client) error {
+		return b.DropSubscription(ctx, db, rp, name)
+	})
+}
+
+// BackendError is the failure of an operation on a single backend
+type BackendError struct {
+	Backend string // Backend is the URL of the backend
+	Err     error
+}
+
+// BackendErrors are the failures of an operation fanned out to the backends
+type BackendErrors []BackendError
+
+func (e BackendErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, be := range e {
+		msgs[i] = fmt.Sprintf("%s: %v", be.Backend, be.Err)
+	}
+	return "relay backends failed: " + strings.Join(msgs, "; ")
+}
+
+func (c *Client) clients() (*influx.Client, []backend, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.relay == nil || len(c.backends) == 0 {
+		return nil, nil, chronograf.ErrUninitialized
+	}
+	return c.relay, c.backends, nil
+}
+
+// health returns the tracker of the backend health of the client
+func (c *Client) health() *enterprise.Health {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Health == nil {
+		c.Health = enterprise.NewHealth()
+	}
+	return c.Health
+}
+
+// CheckHealth pings every backend and records its health. Checking
+// periodically keeps the health of the backends current between reads.
+func (c *Client) CheckHealth(ctx context.Context) {
+	_, backends, err := c.clients()
+	if err != nil {
+		return
+	}
+	for _, b := range backends {
+		if err := b.Ping(ctx); err != nil {
+			c.backendFailed(b, err)
+			continue
+		}
+		c.health().Success(b.URL.Host)
+	}
+}
+
+// read runs fn on the backends in order, healthy ones first, until it
+// succeeds or fails because of something other than the backend itself
+func (c *Client) read(ctx context.Context, fn func(*influx.Client) error) error {
+	_, backends, err := c.clients()
+	if err != nil {
+		return err
+	}
+
+	healthy, ejected := []backend{}, []backend{}
+	for _, b := range backends {
+		if c.health().Healthy(b.URL.Host) {
+			healthy = append(healthy, b)
+		} else {
+			ejected = append(ejected, b)
+		}
+	}
+
+	var lastErr error
+	for _, b := range append(healthy, ejected...) {
+		err := fn(b.Client)
+		if err == nil {
+			c.health().Success(b.URL.Host)
+			return nil
+		}
+		if !influx.IsServerFailure(err) {
+			return err
+		}
+		c.backendFailed(b, err)
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return lastErr
+}
+
+// fanOut runs fn on every backend. Every backend is pinged first so that
+// nothing is changed unless all of them are reachable. Since the statements
+// fanned out are idempotent, retrying after a partial failure converges.
+func (c *Client) fanOut(ctx context.Context, fn func(int, *influx.Client) error) error {
+	_, backends, err := c.clients()
+	if err != nil {
+		return err
+	}
+
+	var errs BackendErrors
+	for _, b := range backends {
+		if err := b.Ping(ctx); err != nil {
+			c.backendFailed(b, err)
+			errs = append(errs, BackendError{Backend: b.url, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for i, b := range backends {
+		if err := fn(i, b.Client); err != nil {
+			if influx.IsServerFailure(err) {
+				c.backendFailed(b, err)
+			}
+			errs = append(errs, BackendError{Backend: b.url, Err: err})
+			continue
+		}
+		c.health().Success(b.URL.Host)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *Client) backendFailed(b backend, err error) {
+	c.health().Failure(b.URL.Host, err)
+	if c.Logger != nil {
+		c.Logger.
+			WithField("component", "relay").
+			WithField("backend", b.URL.Host).
+			Error("Ejecting relay backend: ", err)
+	}
+}
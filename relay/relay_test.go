@@ -0,0 +1,201 @@
+package relay_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/relay"
+)
+
+// backend is an InfluxDB recording the statements it receives
+type backend struct {
+	*httptest.Server
+	mu         sync.Mutex
+	statements []string
+	status     int // status is returned for every query if set
+}
+
+func newBackend() *backend {
+	b := &backend{}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			rw.Header().Set("X-Influxdb-Version", "1.6.2")
+			rw.WriteHeader(http.StatusNoContent)
+		case "/query":
+			b.mu.Lock()
+			b.statements = append(b.statements, r.URL.Query().Get("q"))
+			status := b.status
+			b.mu.Unlock()
+			if status != 0 {
+				rw.WriteHeader(status)
+				rw.Write([]byte(`{"error":"backend unavailable"}`))
+				return
+			}
+			rw.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"databases","columns":["name"],"values":[["telegraf"]]}]}]}`))
+		}
+	}))
+	return b
+}
+
+func (b *backend) received() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.statements...)
+}
+
+func connect(t *testing.T, relayURL string, backends ...string) *relay.Client {
+	cl := &relay.Client{
+		Logger: log.New(log.DebugLevel),
+		Health: enterprise.NewHealth(),
+	}
+	err := cl.Connect(context.Background(), &chronograf.Source{
+		Type:     chronograf.InfluxRelay,
+		URL:      relayURL,
+		Backends: backends,
+	})
+	if err != nil {
+		t.Fatal("Unexpected error connecting: err:", err)
+	}
+	return cl
+}
+
+func TestClient_QueryFailsOver(t *testing.T) {
+	down, up := newBackend(), newBackend()
+	defer down.Close()
+	defer up.Close()
+	down.status = http.StatusServiceUnavailable
+
+	cl := connect(t, "http://relay:9096", down.URL, up.URL)
+	res, err := cl.Query(context.Background(), chronograf.Query{Command: "SELECT mean(usage_idle) FROM cpu"})
+	if err != nil {
+		t.Fatal("Unexpected error querying: err:", err)
+	}
+	if _, err := res.MarshalJSON(); err != nil {
+		t.Fatal("Unexpected error marshaling response: err:", err)
+	}
+	if got := len(up.received()); got != 1 {
+		t.Errorf("Expected the healthy backend to be queried once but was %d times", got)
+	}
+
+	// The failing backend is ejected and no longer queried first
+	if _, err := cl.AllDB(context.Background()); err != nil {
+		t.Fatal("Unexpected error listing databases: err:", err)
+	}
+	if got := len(down.received()); got != 1 {
+		t.Errorf("Expected the ejected backend to be skipped but it was queried %d times", got)
+	}
+}
+
+func TestClient_FansOutAdministration(t *testing.T) {
+	a, b := newBackend(), newBackend()
+	defer a.Close()
+	defer b.Close()
+
+	cl := connect(t, "http://relay:9096", a.URL, b.URL)
+	if _, err := cl.CreateDB(context.Background(), &chronograf.Database{Name: "telegraf"}); err != nil {
+		t.Fatal("Unexpected error creating database: err:", err)
+	}
+	if err := cl.DropRP(context.Background(), "telegraf", "autogen"); err != nil {
+		t.Fatal("Unexpected error dropping retention policy: err:", err)
+	}
+
+	want := []string{`CREATE DATABASE "telegraf"`, `DROP RETENTION POLICY "autogen" ON "telegraf"`}
+	for _, be := range []*backend{a, b} {
+		got := be.received()
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("Backend %s received %v, want %v", be.URL, got, want)
+		}
+	}
+}
+
+func TestClient_ReportsBackendErrors(t *testing.T) {
+	ok, failing, down := newBackend(), newBackend(), newBackend()
+	defer ok.Close()
+	defer failing.Close()
+	failing.status = http.StatusInternalServerError
+
+	cl := connect(t, "http://relay:9096", ok.URL, failing.URL)
+	err := cl.DropDB(context.Background(), "telegraf")
+	errs, isBackendErrs := err.(relay.BackendErrors)
+	if !isBackendErrs || len(errs) != 1 || errs[0].Backend != failing.URL {
+		t.Fatalf("Expected the failing backend to be reported but got %v", err)
+	}
+
+	// Nothing is changed while a backend is unreachable
+	down.Close()
+	cl = connect(t, "http://relay:9096", ok.URL, down.URL)
+	err = cl.DropDB(context.Background(), "telegraf")
+	if errs, isBackendErrs := err.(relay.BackendErrors); !isBackendErrs || len(errs) != 1 || errs[0].Backend != down.URL {
+		t.Fatalf("Expected the unreachable backend to be reported but got %v", err)
+	}
+	if got := len(ok.received()); got != 1 {
+		t.Errorf("Expected no statement while a backend is unreachable but got %d", got-1)
+	}
+}
+
+func TestClient_WritesToRelay(t *testing.T) {
+	var writes int
+	rly := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/write" || r.URL.Query().Get("db") != "telegraf" {
+			t.Errorf("Unexpected request to relay %s", r.URL)
+		}
+		writes++
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer rly.Close()
+	be := newBackend()
+	defer be.Close()
+
+	cl := connect(t, rly.URL, be.URL)
+	err := cl.Write(context.Background(), []chronograf.Point{{
+		Database:    "telegraf",
+		Measurement: "cpu",
+		Fields:      map[string]interface{}{"usage_idle": 99.0},
+	}})
+	if err != nil {
+		t.Fatal("Unexpected error writing: err:", err)
+	}
+	if _, err := cl.WriteLines(context.Background(), "telegraf", "", "s", []string{"cpu usage_idle=99"}); err != nil {
+		t.Fatal("Unexpected error writing lines: err:", err)
+	}
+	if writes != 2 {
+		t.Errorf("Expected two writes to the relay but got %d", writes)
+	}
+	if got := be.received(); len(got) != 0 {
+		t.Errorf("Expected no requests to the backend but got %v", got)
+	}
+}
+
+func TestClient_RequiresBackends(t *testing.T) {
+	cl := &relay.Client{}
+	err := cl.Connect(context.Background(), &chronograf.Source{
+		Type: chronograf.InfluxRelay,
+		URL:  "http://relay:9096",
+	})
+	if err == nil {
+		t.Error("Expected an error connecting to a relay without backends")
+	}
+}
+
+func TestClient_CheckHealth(t *testing.T) {
+	down, up := newBackend(), newBackend()
+	defer up.Close()
+	down.Close()
+
+	cl := connect(t, "http://relay:9096", down.URL, up.URL)
+	cl.CheckHealth(context.Background())
+
+	if cl.Health.Healthy(down.Listener.Addr().String()) {
+		t.Errorf("Expected the unreachable backend to be ejected")
+	}
+	if !cl.Health.Healthy(up.Listener.Addr().String()) {
+		t.Errorf("Expected the reachable backend to be healthy")
+	}
+}
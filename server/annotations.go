@@ -150,7 +150,7 @@ func (s *Service) Annotations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
@@ -195,7 +195,7 @@ func (s *Service) Annotation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
@@ -286,7 +286,7 @@ func (s *Service) NewAnnotation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
@@ -348,7 +348,7 @@ func (s *Service) RemoveAnnotation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
@@ -441,7 +441,7 @@ func (s *Service) UpdateAnnotation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
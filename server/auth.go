@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/bouk/httprouter"
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
 	"github.com/influxdata/chronograf/oauth2"
 	"github.com/influxdata/chronograf/organizations"
 	"github.com/influxdata/chronograf/roles"
@@ -56,6 +59,63 @@ func AuthorizedToken(auth oauth2.Authenticator, logger chronograf.Logger, next h
 	})
 }
 
+// AuthorizedShare validates the share token in the path of the request and
+// checks that its share has not been revoked. If valid, the next handler is
+// run on behalf of the organization of the shared dashboard with the share on
+// the request's Context; the next handler must restrict the request to the
+// dashboard of the share. On failure, will return http.StatusForbidden.
+func AuthorizedShare(store DataStore, secret string, logger chronograf.Logger, next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		serverCtx := serverContext(ctx)
+
+		log := logger.
+			WithField("component", "share_auth").
+			WithField("remote_addr", r.RemoteAddr).
+			WithField("method", r.Method)
+
+		now := time.Now()
+		claims, err := parseShareToken(secret, httprouter.GetParamFromContext(ctx, "token"), now)
+		if err != nil {
+			log.Error(fmt.Sprintf("Invalid share token: %v", err))
+			Error(w, http.StatusForbidden, "Share is not authorized", logger)
+			return
+		}
+
+		share, err := store.DashboardShares(serverCtx).Get(serverCtx, claims.Id)
+		if err != nil {
+			log.Error(fmt.Sprintf("Share %s has been revoked", claims.Id))
+			Error(w, http.StatusForbidden, "Share is not authorized", logger)
+			return
+		}
+		if share.DashboardID != claims.Dashboard || share.Organization != claims.Organization || !now.Before(share.ExpiresAt) {
+			log.Error(fmt.Sprintf("Share %s does not match its token", claims.Id))
+			Error(w, http.StatusForbidden, "Share is not authorized", logger)
+			return
+		}
+
+		// Sources authenticating each user are queried as the user who shared
+		// the dashboard
+		if share.CreatedByID != 0 {
+			u, err := store.Users(serverCtx).Get(serverCtx, chronograf.UserQuery{ID: &share.CreatedByID})
+			if err == nil {
+				ctx = influx.WithCredentials(ctx, &userCredentials{
+					ctx:   serverCtx,
+					store: store.SourceCredentials(serverCtx),
+					user:  u,
+				})
+			}
+		}
+
+		// Shared dashboards are read by viewers of the organization of the
+		// dashboard; there is no user on context.
+		ctx = context.WithValue(ctx, organizations.ContextKey, share.Organization)
+		ctx = context.WithValue(ctx, roles.ContextKey, roles.ViewerRoleName)
+		ctx = context.WithValue(ctx, ShareContextKey, share)
+		next(w, r.WithContext(ctx))
+	})
+}
+
 // RawStoreAccess gives a super admin access to the data store without a facade.
 func RawStoreAccess(logger chronograf.Logger, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -164,6 +224,12 @@ func AuthorizedUser(
 		// In particular this is used by sever/users.go so that we know when and when not to
 		// allow users to make someone a super admin
 		ctx = context.WithValue(ctx, UserContextKey, u)
+		// Sources authenticating each user query InfluxDB as the user
+		ctx = influx.WithCredentials(ctx, &userCredentials{
+			ctx:   serverCtx,
+			store: store.SourceCredentials(serverCtx),
+			user:  u,
+		})
 
 		if u.SuperAdmin {
 			// To access resources (servers, sources, databases, layouts) within a DataStore,
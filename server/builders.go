@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/canned"
 	"github.com/influxdata/chronograf/filestore"
@@ -33,20 +35,53 @@ func (builder *MultiLayoutBuilder) Build(db chronograf.LayoutsStore) (*multistor
 	// Acts as a front-end to both the bolt layouts, filesystem layouts and binary statically compiled layouts.
 	// The idea here is that these stores form a hierarchy in which each is tried sequentially until
 	// the operation has success.  So, the database is preferred over filesystem over binary data.
+	// Canned layouts from the filesystem and binary data cannot be changed through the API.
 	layouts := &multistore.Layouts{
 		Stores: []chronograf.LayoutsStore{
 			db,
-			apps,
-			binApps,
+			&cannedLayouts{
+				LayoutsStore: &multistore.Layouts{
+					Stores: []chronograf.LayoutsStore{
+						apps,
+						binApps,
+					},
+				},
+			},
 		},
 	}
 
 	return layouts, nil
 }
 
+// cannedLayouts prevents canned layouts from being added, changed or removed
+type cannedLayouts struct {
+	chronograf.LayoutsStore
+}
+
+// Add is not supported for canned layouts
+func (c *cannedLayouts) Add(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+	return chronograf.Layout{}, chronograf.ErrLayoutReadOnly
+}
+
+// Delete returns ErrLayoutReadOnly if the layout is canned
+func (c *cannedLayouts) Delete(ctx context.Context, layout chronograf.Layout) error {
+	if _, err := c.Get(ctx, layout.ID); err != nil {
+		return err
+	}
+	return chronograf.ErrLayoutReadOnly
+}
+
+// Update returns ErrLayoutReadOnly if the layout is canned
+func (c *cannedLayouts) Update(ctx context.Context, layout chronograf.Layout) error {
+	if _, err := c.Get(ctx, layout.ID); err != nil {
+		return err
+	}
+	return chronograf.ErrLayoutReadOnly
+}
+
 // ProtoboardsBuilder is responsible for building Protoboards
 type ProtoboardsBuilder interface {
-	Build() (*multistore.Protoboards, error)
+	Build(chronograf.ProtoboardsStore) (*multistore.Protoboards, error)
 }
 
 // MultiProtoboardsBuilder implements LayoutBuilder and will return a Layouts
@@ -56,9 +91,9 @@ type MultiProtoboardsBuilder struct {
 	ProtoboardsPath string
 }
 
-// Build will construct a Layouts of canned and db-backed personalized
-// layouts
-func (builder *MultiProtoboardsBuilder) Build() (*multistore.Protoboards, error) {
+// Build will construct a Protoboards of db-backed user-defined, filesystem
+// and canned protoboards
+func (builder *MultiProtoboardsBuilder) Build(db chronograf.ProtoboardsStore) (*multistore.Protoboards, error) {
 	// These apps are those handled from a directory
 	filesystemPBs := filestore.NewProtoboards(builder.ProtoboardsPath, builder.UUID, builder.Logger)
 	// These apps are statically compiled into chronograf
@@ -70,6 +105,7 @@ func (builder *MultiProtoboardsBuilder) Build() (*multistore.Protoboards, error)
 	// the operation has success.  So, the database is preferred over filesystem over binary data.
 	protoboards := &multistore.Protoboards{
 		Stores: []chronograf.ProtoboardsStore{
+			db,
 			filesystemPBs,
 			binPBs,
 		},
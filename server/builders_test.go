@@ -1,8 +1,12 @@
 package server_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
 	"github.com/influxdata/chronograf/server"
 )
 
@@ -18,6 +22,40 @@ func TestLayoutBuilder(t *testing.T) {
 	}
 }
 
+func TestLayoutBuilder_CannedReadOnly(t *testing.T) {
+	db := &mocks.LayoutsStore{
+		GetF: func(ctx context.Context, id string) (chronograf.Layout, error) {
+			return chronograf.Layout{}, chronograf.ErrLayoutNotFound
+		},
+		UpdateF: func(ctx context.Context, layout chronograf.Layout) error {
+			return chronograf.ErrLayoutNotFound
+		},
+		DeleteF: func(ctx context.Context, layout chronograf.Layout) error {
+			return chronograf.ErrLayoutNotFound
+		},
+	}
+	l := &server.MultiLayoutBuilder{
+		Logger:     log.New(log.DebugLevel),
+		CannedPath: "../canned",
+	}
+	layouts, err := l.Build(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cpu := chronograf.Layout{ID: "0fa47984-825b-46f1-9ca5-0366e3281cc5"}
+	if err := layouts.Update(ctx, cpu); err != chronograf.ErrLayoutReadOnly {
+		t.Errorf("Update() of a canned layout error = %v, want %v", err, chronograf.ErrLayoutReadOnly)
+	}
+	if err := layouts.Delete(ctx, cpu); err != chronograf.ErrLayoutReadOnly {
+		t.Errorf("Delete() of a canned layout error = %v, want %v", err, chronograf.ErrLayoutReadOnly)
+	}
+	if err := layouts.Update(ctx, chronograf.Layout{ID: "missing"}); err != chronograf.ErrLayoutNotFound {
+		t.Errorf("Update() of a missing layout error = %v, want %v", err, chronograf.ErrLayoutNotFound)
+	}
+}
+
 func TestSourcesStoresBuilder(t *testing.T) {
 	var b server.SourcesBuilder = &server.MultiSourceBuilder{}
 	sources, err := b.Build(nil)
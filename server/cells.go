@@ -169,7 +169,7 @@ func HasCorrectQueryType(c *chronograf.DashboardCell) error {
 		if c.Queries[i].Type == "" {
 			c.Queries[i].Type = "influxql"
 		}
-		if c.Queries[i].Type != "flux" && c.Queries[i].Type != "influxql" {
+		if !oneOf(c.Queries[i].Type, "flux", "influxql", "promql") {
 			return chronograf.ErrInvalidCellQueryType
 		}
 	}
@@ -248,6 +248,9 @@ func (s *Service) DashboardCells(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, e, chronograf.DashboardViewPermission) {
+		return
+	}
 
 	boards := newDashboardResponse(e)
 	cells := boards.Cells
@@ -268,6 +271,9 @@ func (s *Service) NewDashboardCell(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardEditPermission) {
+		return
+	}
 	var cell chronograf.DashboardCell
 	if err := json.NewDecoder(r.Body).Decode(&cell); err != nil {
 		invalidJSON(w, s.Logger)
@@ -318,6 +324,9 @@ func (s *Service) DashboardCellID(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardViewPermission) {
+		return
+	}
 
 	boards := newDashboardResponse(dash)
 	cid := httprouter.GetParamFromContext(ctx, "cid")
@@ -344,6 +353,9 @@ func (s *Service) RemoveDashboardCell(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardEditPermission) {
+		return
+	}
 
 	cid := httprouter.GetParamFromContext(ctx, "cid")
 	cellid := -1
@@ -381,6 +393,9 @@ func (s *Service) ReplaceDashboardCell(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardEditPermission) {
+		return
+	}
 
 	cid := httprouter.GetParamFromContext(ctx, "cid")
 	cellid := -1
@@ -1116,7 +1116,7 @@ func TestHasCorrectQueryType(t *testing.T) {
 		c       *chronograf.DashboardCell
 	}{
 		{
-			name:    "Should error if type is not flux or influxql",
+			name:    "Should error if type is not flux, influxql or promql",
 			wantErr: true,
 			c: &chronograf.DashboardCell{
 				Queries: []chronograf.DashboardQuery{
@@ -1146,6 +1146,16 @@ func TestHasCorrectQueryType(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "A promql query type",
+			c: &chronograf.DashboardCell{
+				Queries: []chronograf.DashboardQuery{
+					{
+						Type: "promql",
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+)
+
+// clusterOverviewer is implemented by sources able to describe their cluster
+type clusterOverviewer interface {
+	Overview(context.Context) (*enterprise.ClusterOverview, error)
+}
+
+type clusterLinks struct {
+	Self string `json:"self"`
+}
+
+type clusterResponse struct {
+	*enterprise.ClusterOverview
+	Links clusterLinks `json:"links"`
+}
+
+// ClusterOverview returns the meta and data nodes of an InfluxDB Enterprise
+// source along with their health, statistics and shards
+func (s *Service) ClusterOverview(w http.ResponseWriter, r *http.Request) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	if src.Type != chronograf.InfluxEnterprise || src.MetaURL == "" {
+		msg := fmt.Sprintf("Source %d is not an InfluxDB Enterprise cluster with a meta URL", srcID)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	ts, err := s.TimeSeries(ctx, src)
+	if err == nil {
+		err = ts.Connect(ctx, &src)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	co, ok := ts.(clusterOverviewer)
+	if !ok {
+		msg := fmt.Sprintf("Source %d does not support cluster overviews", srcID)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	overview, err := co.Overview(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to describe cluster of source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	res := clusterResponse{
+		ClusterOverview: overview,
+		Links: clusterLinks{
+			Self: fmt.Sprintf("/chronograf/v1/sources/%d/cluster", srcID),
+		},
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
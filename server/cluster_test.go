@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+type clusterTimeSeries struct {
+	mocks.TimeSeries
+	OverviewF func(context.Context) (*enterprise.ClusterOverview, error)
+}
+
+func (c *clusterTimeSeries) New(context.Context, chronograf.Source, chronograf.Logger) (chronograf.TimeSeries, error) {
+	return c, nil
+}
+
+func (c *clusterTimeSeries) Overview(ctx context.Context) (*enterprise.ClusterOverview, error) {
+	return c.OverviewF(ctx)
+}
+
+func TestService_ClusterOverview(t *testing.T) {
+	connected := mocks.TimeSeries{
+		ConnectF: func(context.Context, *chronograf.Source) error {
+			return nil
+		},
+	}
+	tests := []struct {
+		name             string
+		source           chronograf.Source
+		timeSeriesClient TimeSeriesClient
+		wantStatus       int
+		wantBody         string
+	}{
+		{
+			name: "Overview of an enterprise cluster",
+			source: chronograf.Source{
+				ID:      1,
+				Type:    chronograf.InfluxEnterprise,
+				MetaURL: "http://meta:8091",
+			},
+			timeSeriesClient: &clusterTimeSeries{
+				TimeSeries: connected,
+				OverviewF: func(context.Context) (*enterprise.ClusterOverview, error) {
+					return &enterprise.ClusterOverview{
+						MetaNodes: []enterprise.MetaNodeOverview{
+							{
+								Node: enterprise.Node{
+									ID:   1,
+									Addr: "meta:8091",
+								},
+								Reachable: true,
+								Version:   "1.6.2-c1.6.2",
+							},
+						},
+						DataNodes: []enterprise.DataNodeOverview{
+							{
+								DataNode: enterprise.DataNode{
+									ID:       2,
+									HTTPAddr: "data:8086",
+								},
+								Error: "connection refused",
+							},
+						},
+						Databases: []enterprise.DatabaseShards{},
+					}, nil
+				},
+			},
+			wantStatus: 200,
+			wantBody: `{"metaNodes":[{"id":1,"addr":"meta:8091","httpScheme":"","tcpAddr":"","reachable":true,"version":"1.6.2-c1.6.2"}],"dataNodes":[{"id":2,"tcpAddr":"","httpAddr":"data:8086","httpScheme":"","reachable":false,"error":"connection refused"}],"databases":[],"links":{"self":"/chronograf/v1/sources/1/cluster"}}
+`,
+		},
+		{
+			name: "Open source InfluxDB has no cluster",
+			source: chronograf.Source{
+				ID:   1,
+				Type: chronograf.InfluxDB,
+			},
+			timeSeriesClient: &connected,
+			wantStatus:       400,
+			wantBody:         `{"code":400,"message":"Source 1 is not an InfluxDB Enterprise cluster with a meta URL"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return tt.source, nil
+						},
+					},
+				},
+				TimeSeriesClient: tt.timeSeriesClient,
+				Logger:           &mocks.TestLogger{},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/chronograf/v1/sources/1/cluster", nil)
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "1",
+					},
+				}))
+
+			s.ClusterOverview(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Service.ClusterOverview() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("Service.ClusterOverview() =\n%s\nwant\n%s", string(body), tt.wantBody)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+
+	"github.com/influxdata/chronograf"
 )
 
 type serverContextKey string
@@ -28,3 +30,20 @@ func hasServerContext(ctx context.Context) bool {
 func serverContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, ServerContextKey, true)
 }
+
+type shareContextKey string
+
+// ShareContextKey is the key used to store the dashboard share that
+// authorized a request made with a share token
+const ShareContextKey = shareContextKey("share")
+
+// hasShareContext retrieves the dashboard share of a request made with a
+// share token
+func hasShareContext(ctx context.Context) (chronograf.DashboardShare, bool) {
+	// prevents panic in case of nil context
+	if ctx == nil {
+		return chronograf.DashboardShare{}, false
+	}
+	share, ok := ctx.Value(ShareContextKey).(chronograf.DashboardShare)
+	return share, ok
+}
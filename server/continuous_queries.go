@@ -0,0 +1,252 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+)
+
+type cqLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type cqResponse struct {
+	chronograf.ContinuousQuery
+	Links cqLinks `json:"links"` // Links are URI locations related to the continuous query
+}
+
+func newCQResponse(srcID int, db string, cq chronograf.ContinuousQuery) cqResponse {
+	base := "/chronograf/v1/sources"
+	cq.Database = db
+	return cqResponse{
+		ContinuousQuery: cq,
+		Links: cqLinks{
+			Self: fmt.Sprintf("%s/%d/dbs/%s/cqs/%s", base, srcID, db, cq.Name),
+		},
+	}
+}
+
+type cqsResponse struct {
+	ContinuousQueries []cqResponse `json:"continuousQueries"`
+}
+
+// ContinuousQueries lists continuous queries within a database
+func (s *Service) ContinuousQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	cqs, err := dbsvc.AllCQ(ctx, db)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to get continuous queries %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	res := cqsResponse{
+		ContinuousQueries: make([]cqResponse, len(cqs)),
+	}
+	for i, cq := range cqs {
+		res.ContinuousQueries[i] = newCQResponse(srcID, db, cq)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// ContinuousQueryID returns a single continuous query of a database
+func (s *Service) ContinuousQueryID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	name := httprouter.GetParamFromContext(ctx, "cq")
+	cqs, err := dbsvc.AllCQ(ctx, db)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to get continuous queries %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	for _, cq := range cqs {
+		if cq.Name == name {
+			encodeJSON(w, http.StatusOK, newCQResponse(srcID, db, cq), s.Logger)
+			return
+		}
+	}
+	notFound(w, name, s.Logger)
+}
+
+// NewContinuousQuery creates a new continuous query within a database
+func (s *Service) NewContinuousQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	postedCQ := &chronograf.ContinuousQuery{}
+	if err := json.NewDecoder(r.Body).Decode(postedCQ); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidContinuousQueryRequest(postedCQ); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	cq, err := dbsvc.CreateCQ(ctx, db, postedCQ)
+	if err != nil {
+		databasesError(w, err, s.Logger)
+		return
+	}
+
+	res := newCQResponse(srcID, db, *cq)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateContinuousQuery replaces an existing continuous query of a database
+func (s *Service) UpdateContinuousQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	name := httprouter.GetParamFromContext(ctx, "cq")
+
+	postedCQ := &chronograf.ContinuousQuery{}
+	if err := json.NewDecoder(r.Body).Decode(postedCQ); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if postedCQ.Name == "" {
+		postedCQ.Name = name
+	}
+	if err := ValidContinuousQueryRequest(postedCQ); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	cq, err := dbsvc.UpdateCQ(ctx, db, name, postedCQ)
+	if err != nil {
+		databasesError(w, err, s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newCQResponse(srcID, db, *cq), s.Logger)
+}
+
+// DropContinuousQuery removes a continuous query from a database
+func (s *Service) DropContinuousQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	name := httprouter.GetParamFromContext(ctx, "cq")
+	if err := dbsvc.DropCQ(ctx, db, name); err != nil {
+		databasesError(w, err, s.Logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidContinuousQueryRequest checks if a continuous query is valid on POST or PUT
+func ValidContinuousQueryRequest(cq *chronograf.ContinuousQuery) error {
+	if len(cq.Name) == 0 {
+		return fmt.Errorf("name is required")
+	}
+	if len(cq.Query) == 0 {
+		return fmt.Errorf("query is required")
+	}
+	return nil
+}
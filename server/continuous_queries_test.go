@@ -0,0 +1,236 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_ContinuousQueries(t *testing.T) {
+	type fields struct {
+		SourcesStore chronograf.SourcesStore
+		Databases    *mocks.Databases
+	}
+	type wants struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		wants  wants
+	}{
+		{
+			name: "Lists the continuous queries of a database",
+			fields: fields{
+				SourcesStore: &mocks.SourcesStore{
+					GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+						return chronograf.Source{
+							ID: 0,
+						}, nil
+					},
+				},
+				Databases: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					AllCQF: func(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+						return []chronograf.ContinuousQuery{
+							{
+								Name:   "cpu_1h",
+								Source: "cpu",
+								Target: "cpu_1h",
+								Query:  "SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h)",
+							},
+						}, nil
+					},
+				},
+			},
+			wants: wants{
+				statusCode: 200,
+				body: `{"continuousQueries":[{"name":"cpu_1h","db":"pineapples","source":"cpu","target":"cpu_1h","query":"SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h)","links":{"self":"/chronograf/v1/sources/0/dbs/pineapples/cqs/cpu_1h"}}]}
+`,
+			},
+		},
+		{
+			name: "Fails when the source cannot be reached",
+			fields: fields{
+				SourcesStore: &mocks.SourcesStore{
+					GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+						return chronograf.Source{
+							ID: 0,
+						}, nil
+					},
+				},
+				Databases: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					AllCQF: func(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+						return nil, chronograf.ErrUpstreamTimeout
+					},
+				},
+			},
+			wants: wants{
+				statusCode: 400,
+				body:       `{"code":400,"message":"Unable to get continuous queries 0: request to backend timed out"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Service{
+				Store: &mocks.Store{
+					SourcesStore: tt.fields.SourcesStore,
+				},
+				Logger:          log.New(log.DebugLevel),
+				DatabasesClient: tt.fields.Databases,
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "0",
+					},
+					{
+						Key:   "db",
+						Value: "pineapples",
+					},
+				}))
+
+			h.ContinuousQueries(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+
+			if tt.wants.statusCode != resp.StatusCode {
+				t.Errorf("%q. StatusCode:\nwant\n%v\ngot\n%v", tt.name, tt.wants.statusCode, resp.StatusCode)
+			}
+			if tt.wants.body != string(body) {
+				t.Errorf("%q. Body:\nwant\n*%s*\ngot\n*%s*", tt.name, tt.wants.body, string(body))
+			}
+		})
+	}
+}
+
+func TestService_NewContinuousQuery(t *testing.T) {
+	type fields struct {
+		SourcesStore chronograf.SourcesStore
+		Databases    *mocks.Databases
+	}
+	type wants struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		body   string
+		wants  wants
+	}{
+		{
+			name: "Creates a continuous query",
+			body: `{"name":"cpu_1h","resampleEvery":"30m","query":"SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h)"}`,
+			fields: fields{
+				SourcesStore: &mocks.SourcesStore{
+					GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+						return chronograf.Source{
+							ID: 0,
+						}, nil
+					},
+				},
+				Databases: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					CreateCQF: func(ctx context.Context, db string, cq *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+						return &chronograf.ContinuousQuery{
+							Name:          cq.Name,
+							Database:      db,
+							ResampleEvery: cq.ResampleEvery,
+							Source:        "cpu",
+							Target:        "cpu_1h",
+							Query:         cq.Query,
+						}, nil
+					},
+				},
+			},
+			wants: wants{
+				statusCode: 201,
+				body: `{"name":"cpu_1h","db":"pineapples","resampleEvery":"30m","source":"cpu","target":"cpu_1h","query":"SELECT mean(usage_user) INTO cpu_1h FROM cpu GROUP BY time(1h)","links":{"self":"/chronograf/v1/sources/0/dbs/pineapples/cqs/cpu_1h"}}
+`,
+			},
+		},
+		{
+			name: "Fails without a query",
+			body: `{"name":"cpu_1h"}`,
+			fields: fields{
+				SourcesStore: &mocks.SourcesStore{
+					GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+						return chronograf.Source{
+							ID: 0,
+						}, nil
+					},
+				},
+				Databases: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+				},
+			},
+			wants: wants{
+				statusCode: 422,
+				body:       `{"code":422,"message":"query is required"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Service{
+				Store: &mocks.Store{
+					SourcesStore: tt.fields.SourcesStore,
+				},
+				Logger:          log.New(log.DebugLevel),
+				DatabasesClient: tt.fields.Databases,
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://any.url", bytes.NewBufferString(tt.body))
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "0",
+					},
+					{
+						Key:   "db",
+						Value: "pineapples",
+					},
+				}))
+
+			h.NewContinuousQuery(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+
+			if tt.wants.statusCode != resp.StatusCode {
+				t.Errorf("%q. StatusCode:\nwant\n%v\ngot\n%v", tt.name, tt.wants.statusCode, resp.StatusCode)
+			}
+			if tt.wants.body != string(body) {
+				t.Errorf("%q. Body:\nwant\n*%s*\ngot\n*%s*", tt.name, tt.wants.body, string(body))
+			}
+		})
+	}
+}
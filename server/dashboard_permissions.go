@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/roles"
+)
+
+type dashboardPermissionsLinks struct {
+	Self      string `json:"self"`      // Self link mapping to this resource
+	Dashboard string `json:"dashboard"` // Dashboard link to the dashboard of the permissions
+}
+
+type dashboardPermissionsRequest struct {
+	Permissions []chronograf.DashboardPermission `json:"permissions"`
+	Locked      bool                             `json:"locked"`
+}
+
+type dashboardPermissionsResponse struct {
+	Permissions []chronograf.DashboardPermission `json:"permissions"`
+	Locked      bool                             `json:"locked"`
+	Owner       string                           `json:"owner"`
+	OwnerID     uint64                           `json:"ownerID,string,omitempty"`
+	Links       dashboardPermissionsLinks        `json:"links"`
+}
+
+func newDashboardPermissionsResponse(d chronograf.Dashboard) *dashboardPermissionsResponse {
+	base := fmt.Sprintf("/chronograf/v1/dashboards/%d", d.ID)
+	permissions := d.Permissions
+	if permissions == nil {
+		permissions = []chronograf.DashboardPermission{}
+	}
+	return &dashboardPermissionsResponse{
+		Permissions: permissions,
+		Locked:      d.Locked,
+		Owner:       d.Owner,
+		OwnerID:     d.OwnerID,
+		Links: dashboardPermissionsLinks{
+			Self:      fmt.Sprintf("%s/permissions", base),
+			Dashboard: base,
+		},
+	}
+}
+
+// dashboardUser returns the ID and organization role of the user of the
+// request. Users are identified by ID, as users of different providers may
+// share a name. Requests without auth, from the server, from super admins
+// and from admins of the organization are not restricted by the permissions
+// of dashboards.
+func dashboardUser(ctx context.Context) (userID uint64, role string, restricted bool) {
+	if hasServerContext(ctx) {
+		return 0, "", false
+	}
+	u, ok := hasUserContext(ctx)
+	if !ok {
+		return 0, "", false
+	}
+	role, _ = hasRoleContext(ctx)
+	if u.SuperAdmin || role == roles.AdminRoleName {
+		return u.ID, role, false
+	}
+	return u.ID, role, true
+}
+
+// authorizeDashboard responds with not found if the user of the request may
+// not view the dashboard. When the permission is edit, it responds with
+// forbidden if the dashboard is locked or the user may not edit it. It
+// returns false when it responded.
+func (s *Service) authorizeDashboard(w http.ResponseWriter, ctx context.Context, d chronograf.Dashboard, permission string) bool {
+	userID, role, restricted := dashboardUser(ctx)
+	if restricted && !d.Allows(userID, role, chronograf.DashboardViewPermission) {
+		notFound(w, d.ID, s.Logger)
+		return false
+	}
+	if permission != chronograf.DashboardEditPermission {
+		return true
+	}
+	if d.Locked {
+		Error(w, http.StatusForbidden, fmt.Sprintf("Dashboard %d is locked", d.ID), s.Logger)
+		return false
+	}
+	if restricted && !d.Allows(userID, role, chronograf.DashboardEditPermission) {
+		Error(w, http.StatusForbidden, "User is not authorized to edit this dashboard", s.Logger)
+		return false
+	}
+	return true
+}
+
+// DashboardPermissions returns the permissions of a dashboard
+func (s *Service) DashboardPermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardViewPermission) {
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newDashboardPermissionsResponse(dash), s.Logger)
+}
+
+// ReplaceDashboardPermissions replaces the permissions of a dashboard and
+// locks or unlocks it. Only the owner of the dashboard and admins may change
+// its permissions, including when the dashboard is locked.
+func (s *Service) ReplaceDashboardPermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardViewPermission) {
+		return
+	}
+	if userID, _, restricted := dashboardUser(ctx); restricted && dash.OwnerID != userID {
+		Error(w, http.StatusForbidden, "Only the owner of the dashboard or an admin may change its permissions", s.Logger)
+		return
+	}
+
+	var req dashboardPermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidDashboardPermissionsRequest(req.Permissions); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	// Users are granted permissions by ID and named after the stored user
+	for i, p := range req.Permissions {
+		if p.UserID == 0 {
+			continue
+		}
+		u, err := s.Store.Users(ctx).Get(ctx, chronograf.UserQuery{ID: &p.UserID})
+		if err != nil {
+			invalidData(w, fmt.Errorf("user %d is not a user of the organization", p.UserID), s.Logger)
+			return
+		}
+		req.Permissions[i].User = u.Name
+	}
+
+	dash.Permissions = req.Permissions
+	dash.Locked = req.Locked
+	if err := s.Store.Dashboards(ctx).Update(ctx, dash); err != nil {
+		msg := fmt.Sprintf("Error updating permissions of dashboard ID %d: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newDashboardPermissionsResponse(dash), s.Logger)
+}
+
+// ValidDashboardPermissionsRequest checks that every permission grants view
+// or edit to either a user ID or an organization role
+func ValidDashboardPermissionsRequest(permissions []chronograf.DashboardPermission) error {
+	for _, p := range permissions {
+		if (p.UserID == 0) == (p.Role == "") {
+			return fmt.Errorf("permission must be granted to either a user ID or a role")
+		}
+		switch p.Role {
+		case "", roles.ViewerRoleName, roles.EditorRoleName, roles.AdminRoleName:
+		default:
+			return fmt.Errorf("invalid role %q; role must be viewer, editor or admin", p.Role)
+		}
+		switch p.Permission {
+		case chronograf.DashboardViewPermission, chronograf.DashboardEditPermission:
+		default:
+			return fmt.Errorf("invalid permission %q; permission must be view or edit", p.Permission)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,401 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func dashboardRequest(method, body string, user *chronograf.User, role string) *http.Request {
+	r := httptest.NewRequest(method, "http://any.url", ioutil.NopCloser(bytes.NewReader([]byte(body))))
+	ctx := context.WithValue(context.Background(), UserContextKey, user)
+	ctx = context.WithValue(ctx, roles.ContextKey, role)
+	return r.WithContext(httprouter.WithParams(
+		ctx,
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "1",
+			},
+		}))
+}
+
+func TestService_DashboardPermissionsEnforced(t *testing.T) {
+	production := chronograf.Dashboard{
+		ID:      1,
+		Name:    "Production",
+		Owner:   "doc",
+		OwnerID: 1,
+		Permissions: []chronograf.DashboardPermission{
+			{
+				UserID:     2,
+				User:       "marty",
+				Permission: chronograf.DashboardEditPermission,
+			},
+			{
+				Role:       roles.EditorRoleName,
+				Permission: chronograf.DashboardViewPermission,
+			},
+		},
+	}
+	locked := production
+	locked.Locked = true
+	viewers := chronograf.Dashboard{
+		ID:      1,
+		Name:    "Lobby",
+		OwnerID: 1,
+		Permissions: []chronograf.DashboardPermission{
+			{
+				Role:       roles.ViewerRoleName,
+				Permission: chronograf.DashboardViewPermission,
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		dashboard  chronograf.Dashboard
+		user       string
+		userID     uint64
+		role       string
+		edit       bool
+		wantStatus int
+	}{
+		{
+			name:       "Dashboards without permissions follow the organization role",
+			dashboard:  chronograf.Dashboard{ID: 1, Name: "Staging"},
+			user:       "biff",
+			userID:     3,
+			role:       roles.EditorRoleName,
+			edit:       true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Users without a permission cannot view the dashboard",
+			dashboard:  production,
+			user:       "biff",
+			userID:     3,
+			role:       roles.ViewerRoleName,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Roles granted view can view the dashboard",
+			dashboard:  production,
+			user:       "biff",
+			userID:     3,
+			role:       roles.EditorRoleName,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Roles above the role granted view can view the dashboard",
+			dashboard:  viewers,
+			user:       "biff",
+			userID:     3,
+			role:       roles.EditorRoleName,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Roles below the role granted view cannot view the dashboard",
+			dashboard:  viewers,
+			user:       "biff",
+			userID:     3,
+			role:       roles.MemberRoleName,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Roles granted view cannot edit the dashboard",
+			dashboard:  production,
+			user:       "biff",
+			userID:     3,
+			role:       roles.EditorRoleName,
+			edit:       true,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Users granted edit can edit the dashboard",
+			dashboard:  production,
+			user:       "marty",
+			userID:     2,
+			role:       roles.EditorRoleName,
+			edit:       true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Owners can edit the dashboard",
+			dashboard:  production,
+			user:       "doc",
+			userID:     1,
+			role:       roles.EditorRoleName,
+			edit:       true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Users sharing the name of the owner cannot edit the dashboard",
+			dashboard:  production,
+			user:       "doc",
+			userID:     5,
+			role:       roles.EditorRoleName,
+			edit:       true,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Users sharing the name of a grantee cannot edit the dashboard",
+			dashboard:  production,
+			user:       "marty",
+			userID:     6,
+			role:       roles.EditorRoleName,
+			edit:       true,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Admins can edit the dashboard",
+			dashboard:  production,
+			user:       "strickland",
+			userID:     4,
+			role:       roles.AdminRoleName,
+			edit:       true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Locked dashboards can be viewed",
+			dashboard:  locked,
+			user:       "marty",
+			userID:     2,
+			role:       roles.EditorRoleName,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Locked dashboards cannot be edited, even by admins",
+			dashboard:  locked,
+			user:       "strickland",
+			userID:     4,
+			role:       roles.AdminRoleName,
+			edit:       true,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							return tt.dashboard, nil
+						},
+						UpdateF: func(ctx context.Context, target chronograf.Dashboard) error {
+							return nil
+						},
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+
+			w := httptest.NewRecorder()
+			user := &chronograf.User{ID: tt.userID, Name: tt.user}
+			if tt.edit {
+				s.UpdateDashboard(w, dashboardRequest("PATCH", `{"name":"Renamed"}`, user, tt.role))
+			} else {
+				s.DashboardID(w, dashboardRequest("GET", "", user, tt.role))
+			}
+
+			if got := w.Result().StatusCode; got != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestService_Dashboards_FiltersByPermissions(t *testing.T) {
+	var query chronograf.DashboardsQuery
+	s := &Service{
+		Store: &mocks.Store{
+			DashboardsStore: &mocks.DashboardsStore{
+				SearchF: func(ctx context.Context, q chronograf.DashboardsQuery) ([]chronograf.Dashboard, int, error) {
+					query = q
+					return nil, 0, nil
+				},
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+
+	w := httptest.NewRecorder()
+	s.Dashboards(w, dashboardRequest("GET", "", &chronograf.User{ID: 3, Name: "biff"}, roles.ViewerRoleName))
+	if query.UserID != 3 || query.Role != roles.ViewerRoleName {
+		t.Errorf("Dashboards() searched as user %d with role %q, want user 3 with role viewer", query.UserID, query.Role)
+	}
+
+	w = httptest.NewRecorder()
+	s.Dashboards(w, dashboardRequest("GET", "", &chronograf.User{ID: 4, Name: "strickland"}, roles.AdminRoleName))
+	if query.UserID != 0 {
+		t.Errorf("Dashboards() searched as user %d, want admins to see all dashboards", query.UserID)
+	}
+}
+
+func TestService_ReplaceDashboardPermissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       string
+		userID     uint64
+		role       string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantStored *chronograf.Dashboard
+	}{
+		{
+			name:       "Owners can change the permissions",
+			user:       "doc",
+			userID:     1,
+			role:       roles.EditorRoleName,
+			body:       `{"permissions":[{"userID":"2","permission":"edit"}],"locked":true}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"permissions":[{"userID":"2","user":"marty","permission":"edit"}],"locked":true,"owner":"doc","ownerID":"1","links":{"self":"/chronograf/v1/dashboards/1/permissions","dashboard":"/chronograf/v1/dashboards/1"}}
+`,
+			wantStored: &chronograf.Dashboard{
+				ID:      1,
+				Owner:   "doc",
+				OwnerID: 1,
+				Permissions: []chronograf.DashboardPermission{
+					{
+						UserID:     2,
+						User:       "marty",
+						Permission: chronograf.DashboardEditPermission,
+					},
+				},
+				Locked: true,
+			},
+		},
+		{
+			name:       "Other editors cannot change the permissions",
+			user:       "biff",
+			userID:     3,
+			role:       roles.EditorRoleName,
+			body:       `{"permissions":[]}`,
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"code":403,"message":"Only the owner of the dashboard or an admin may change its permissions"}`,
+		},
+		{
+			name:       "Users sharing the name of the owner cannot change the permissions",
+			user:       "doc",
+			userID:     5,
+			role:       roles.EditorRoleName,
+			body:       `{"permissions":[]}`,
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"code":403,"message":"Only the owner of the dashboard or an admin may change its permissions"}`,
+		},
+		{
+			name:       "Permissions are granted to either a user or a role",
+			user:       "strickland",
+			userID:     4,
+			role:       roles.AdminRoleName,
+			body:       `{"permissions":[{"userID":"2","role":"viewer","permission":"view"}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":422,"message":"permission must be granted to either a user ID or a role"}`,
+		},
+		{
+			name:       "Permissions are granted to users of the organization",
+			user:       "strickland",
+			userID:     4,
+			role:       roles.AdminRoleName,
+			body:       `{"permissions":[{"userID":"7","permission":"view"}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":422,"message":"user 7 is not a user of the organization"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored *chronograf.Dashboard
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							return chronograf.Dashboard{ID: id, Owner: "doc", OwnerID: 1}, nil
+						},
+						UpdateF: func(ctx context.Context, target chronograf.Dashboard) error {
+							stored = &target
+							return nil
+						},
+					},
+					UsersStore: &mocks.UsersStore{
+						GetF: func(ctx context.Context, q chronograf.UserQuery) (*chronograf.User, error) {
+							if q.ID == nil || *q.ID != 2 {
+								return nil, chronograf.ErrUserNotFound
+							}
+							return &chronograf.User{ID: 2, Name: "marty"}, nil
+						},
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+
+			w := httptest.NewRecorder()
+			s.ReplaceDashboardPermissions(w, dashboardRequest("PUT", tt.body, &chronograf.User{ID: tt.userID, Name: tt.user}, tt.role))
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("Body:\nwant\n%s\ngot\n%s", tt.wantBody, body)
+			}
+			if diff := cmp.Diff(stored, tt.wantStored); diff != "" {
+				t.Errorf("Stored -got/+want diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidDashboardPermissionsRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []chronograf.DashboardPermission
+		wantErr     bool
+	}{
+		{
+			name: "Users and roles",
+			permissions: []chronograf.DashboardPermission{
+				{UserID: 2, Permission: chronograf.DashboardEditPermission},
+				{Role: roles.ViewerRoleName, Permission: chronograf.DashboardViewPermission},
+			},
+		},
+		{
+			name:        "Neither user nor role",
+			permissions: []chronograf.DashboardPermission{{Permission: chronograf.DashboardViewPermission}},
+			wantErr:     true,
+		},
+		{
+			name:        "Users are granted permissions by ID",
+			permissions: []chronograf.DashboardPermission{{User: "marty", Permission: chronograf.DashboardViewPermission}},
+			wantErr:     true,
+		},
+		{
+			name:        "Members cannot view dashboards",
+			permissions: []chronograf.DashboardPermission{{Role: roles.MemberRoleName, Permission: chronograf.DashboardViewPermission}},
+			wantErr:     true,
+		},
+		{
+			name:        "Unknown permission",
+			permissions: []chronograf.DashboardPermission{{UserID: 2, Permission: "delete"}},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidDashboardPermissionsRequest(tt.permissions); (err != nil) != tt.wantErr {
+				t.Errorf("ValidDashboardPermissionsRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,513 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/bouk/httprouter"
+	gojwt "github.com/dgrijalva/jwt-go"
+	"github.com/influxdata/chronograf"
+)
+
+const (
+	// defaultShareDuration is how long a share is valid when the request
+	// does not specify a duration
+	defaultShareDuration = 24 * time.Hour
+	// shareAudience distinguishes share tokens from the tokens of users
+	// signed with the same secret
+	shareAudience = "chronograf-dashboard-share"
+)
+
+// ErrNoShareSecret is returned when sharing a dashboard without a secret to
+// sign share tokens with
+const ErrNoShareSecret = chronograf.Error("a token secret is required to share dashboards")
+
+type dashboardShareLinks struct {
+	Self   string `json:"self"`   // Self link mapping to this resource
+	Shared string `json:"shared"` // Shared link to the read-only dashboard of the share
+}
+
+type dashboardShareResponse struct {
+	chronograf.DashboardShare
+	Token string              `json:"token"`
+	Links dashboardShareLinks `json:"links"`
+}
+
+type dashboardSharesResponse struct {
+	Shares []dashboardShareResponse `json:"shares"`
+}
+
+type dashboardShareRequest struct {
+	Name     string `json:"name"`
+	Duration string `json:"duration,omitempty"` // Duration the share is valid for, e.g. "720h"; defaults to 24h
+}
+
+// shareClaims are the claims of a share token. The share ID is the token ID
+// so that deleting the share revokes the token.
+type shareClaims struct {
+	gojwt.StandardClaims
+	Dashboard    chronograf.DashboardID `json:"dsh"`
+	Organization string                 `json:"org"`
+}
+
+// newShareToken signs a token granting access to the dashboard of the share
+// until the share expires
+func newShareToken(secret string, share chronograf.DashboardShare) (string, error) {
+	if secret == "" {
+		return "", ErrNoShareSecret
+	}
+	claims := shareClaims{
+		StandardClaims: gojwt.StandardClaims{
+			Id:        share.ID,
+			Audience:  shareAudience,
+			IssuedAt:  share.CreatedAt.Unix(),
+			ExpiresAt: share.ExpiresAt.Unix(),
+		},
+		Dashboard:    share.DashboardID,
+		Organization: share.Organization,
+	}
+	return gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// parseShareToken checks the signature, audience and expiry of a share token
+func parseShareToken(secret, token string, now time.Time) (*shareClaims, error) {
+	if secret == "" {
+		return nil, ErrNoShareSecret
+	}
+	// Claims are validated below against now rather than the package wide
+	// time function of jwt-go
+	parser := &gojwt.Parser{
+		ValidMethods:         []string{gojwt.SigningMethodHS256.Alg()},
+		SkipClaimsValidation: true,
+	}
+	var claims shareClaims
+	if _, err := parser.ParseWithClaims(token, &claims, func(*gojwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}); err != nil {
+		return nil, err
+	}
+	if !claims.VerifyAudience(shareAudience, true) {
+		return nil, fmt.Errorf("token is not a share token")
+	}
+	if !claims.VerifyExpiresAt(now.Unix(), true) {
+		return nil, fmt.Errorf("share token is expired")
+	}
+	if claims.Id == "" {
+		return nil, fmt.Errorf("share token has no ID")
+	}
+	return &claims, nil
+}
+
+func (s *Service) newDashboardShareResponse(share chronograf.DashboardShare) (*dashboardShareResponse, error) {
+	token, err := newShareToken(s.ShareSecret, share)
+	if err != nil {
+		return nil, err
+	}
+	return &dashboardShareResponse{
+		DashboardShare: share,
+		Token:          token,
+		Links: dashboardShareLinks{
+			Self:   fmt.Sprintf("/chronograf/v1/dashboards/%d/shares/%s", share.DashboardID, share.ID),
+			Shared: fmt.Sprintf("/chronograf/v1/shared/%s", token),
+		},
+	}, nil
+}
+
+// authorizeDashboardShares responds with forbidden unless the user of the
+// request may edit the dashboard or owns it, as its shares hold live tokens.
+// Shares do not change the dashboard, so locked dashboards may be shared. It
+// returns false when it responded.
+func (s *Service) authorizeDashboardShares(w http.ResponseWriter, ctx context.Context, d chronograf.Dashboard) bool {
+	if !s.authorizeDashboard(w, ctx, d, chronograf.DashboardViewPermission) {
+		return false
+	}
+	userID, role, restricted := dashboardUser(ctx)
+	if restricted && !d.Allows(userID, role, chronograf.DashboardEditPermission) {
+		Error(w, http.StatusForbidden, "User is not authorized to share this dashboard", s.Logger)
+		return false
+	}
+	return true
+}
+
+// DashboardShares returns the read-only shares of a dashboard along with
+// their tokens
+func (s *Service) DashboardShares(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboardShares(w, ctx, dash) {
+		return
+	}
+
+	shares, err := s.Store.DashboardShares(ctx).All(ctx, dash.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "Error loading dashboard shares", s.Logger)
+		return
+	}
+
+	res := dashboardSharesResponse{
+		Shares: make([]dashboardShareResponse, 0, len(shares)),
+	}
+	for _, share := range shares {
+		sr, err := s.newDashboardShareResponse(share)
+		if err != nil {
+			unknownErrorWithMessage(w, err, s.Logger)
+			return
+		}
+		res.Shares = append(res.Shares, *sr)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// NewDashboardShare creates a read-only share of a dashboard and returns it
+// with its token
+func (s *Service) NewDashboardShare(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboardShares(w, ctx, dash) {
+		return
+	}
+	if s.ShareSecret == "" {
+		Error(w, http.StatusBadRequest, ErrNoShareSecret.Error(), s.Logger)
+		return
+	}
+
+	var req dashboardShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	duration, err := ValidDashboardShareRequest(req)
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	share := chronograf.DashboardShare{
+		DashboardID:  dash.ID,
+		Organization: dash.Organization,
+		Name:         req.Name,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(duration),
+	}
+	if u, ok := hasUserContext(ctx); ok {
+		share.CreatedBy, share.CreatedByID = u.Name, u.ID
+	}
+
+	share, err = s.Store.DashboardShares(ctx).Add(ctx, share)
+	if err != nil {
+		msg := fmt.Sprintf("Error sharing dashboard ID %d: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return
+	}
+
+	res, err := s.newDashboardShareResponse(share)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// RemoveDashboardShare deletes a share of a dashboard, revoking its token
+func (s *Service) RemoveDashboardShare(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboardShares(w, ctx, dash) {
+		return
+	}
+
+	sid := httprouter.GetParamFromContext(ctx, "sid")
+	share, err := s.Store.DashboardShares(ctx).Get(ctx, sid)
+	if err != nil || share.DashboardID != dash.ID {
+		notFound(w, sid, s.Logger)
+		return
+	}
+
+	if err := s.Store.DashboardShares(ctx).Delete(ctx, share); err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidDashboardShareRequest checks that the share has a name and returns
+// how long it is valid for
+func ValidDashboardShareRequest(req dashboardShareRequest) (time.Duration, error) {
+	if req.Name == "" {
+		return 0, fmt.Errorf("share must have a name")
+	}
+	if req.Duration == "" {
+		return defaultShareDuration, nil
+	}
+	d, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %v", req.Duration, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
+
+type sharedDashboardLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type sharedCellLinks struct {
+	Query string `json:"query"` // Query link runs the queries of the cell
+}
+
+type sharedCellResponse struct {
+	chronograf.DashboardCell
+	Links sharedCellLinks `json:"links"`
+}
+
+type sharedDashboardResponse struct {
+	ID          chronograf.DashboardID `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Cells       []sharedCellResponse   `json:"cells"`
+	Templates   []chronograf.Template  `json:"templates"`
+	ExpiresAt   time.Time              `json:"expiresAt"`
+	Links       sharedDashboardLinks   `json:"links"`
+}
+
+// sharedDashboard loads the dashboard of the share of the request
+func (s *Service) sharedDashboard(w http.ResponseWriter, ctx context.Context) (chronograf.Dashboard, chronograf.DashboardShare, bool) {
+	share, ok := hasShareContext(ctx)
+	if !ok {
+		Error(w, http.StatusForbidden, "Share is not authorized", s.Logger)
+		return chronograf.Dashboard{}, share, false
+	}
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, share.DashboardID)
+	if err != nil {
+		notFound(w, share.DashboardID, s.Logger)
+		return chronograf.Dashboard{}, share, false
+	}
+	return dash, share, true
+}
+
+// SharedDashboard returns the read-only definition of the dashboard of the
+// share token of the request
+func (s *Service) SharedDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	dash, share, ok := s.sharedDashboard(w, ctx)
+	if !ok {
+		return
+	}
+
+	base := path.Join("/chronograf/v1/shared", httprouter.GetParamFromContext(ctx, "token"))
+	dd := AddQueryConfigs(DashboardDefaults(dash))
+	res := sharedDashboardResponse{
+		ID:          dd.ID,
+		Name:        dd.Name,
+		Description: dd.Description,
+		Cells:       make([]sharedCellResponse, 0, len(dd.Cells)),
+		Templates:   dd.Templates,
+		ExpiresAt:   share.ExpiresAt,
+		Links: sharedDashboardLinks{
+			Self: base,
+		},
+	}
+	if res.Templates == nil {
+		res.Templates = []chronograf.Template{}
+	}
+	for _, c := range newCellResponses(dd.ID, dd.Cells) {
+		res.Cells = append(res.Cells, sharedCellResponse{
+			DashboardCell: c.DashboardCell,
+			Links: sharedCellLinks{
+				Query: fmt.Sprintf("%s/cells/%s/query", base, c.ID),
+			},
+		})
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// SharedQueryResult is the response of the source to a query of a shared cell
+type SharedQueryResult struct {
+	Query   string                `json:"query"` // Query is the original query of the cell
+	Source  string                `json:"source"`
+	Results chronograf.Response   `json:"results,omitempty"`
+	Shifted []SharedShiftedResult `json:"shifted,omitempty"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// SharedShiftedResult is the response of the source to a time shifted query
+// of a shared cell
+type SharedShiftedResult struct {
+	Shift   chronograf.TimeShift `json:"shift"`
+	Results chronograf.Response  `json:"results,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// SharedCellQueryResponse holds the results of the queries of a shared cell
+type SharedCellQueryResponse struct {
+	Queries []SharedQueryResult `json:"queries"`
+}
+
+// relativeLower matches the relative lower bounds of dashboard time ranges
+var relativeLower = regexp.MustCompile(`^now\(\) - [0-9]+(ns|u|µ|ms|s|m|h|d|w)$`)
+
+// ValidSharedRange checks that the bounds of a time range are relative to now
+// or timestamps so that they cannot change the queries of a shared cell
+func ValidSharedRange(r chronograf.DurationRange) error {
+	if !relativeLower.MatchString(r.Lower) {
+		if _, err := time.Parse(time.RFC3339Nano, r.Lower); err != nil {
+			return fmt.Errorf("invalid lower bound %q; must be now() - duration or an RFC3339 timestamp", r.Lower)
+		}
+	}
+	switch r.Upper {
+	case "", "now()":
+	default:
+		if _, err := time.Parse(time.RFC3339Nano, r.Upper); err != nil {
+			return fmt.Errorf("invalid upper bound %q; must be now() or an RFC3339 timestamp", r.Upper)
+		}
+	}
+	return nil
+}
+
+// SharedCellQuery runs the queries of a cell of the dashboard of the share
+// token of the request against their sources. Only the time range, the
+// resolution and the selected values of the dashboard templates may be
+// chosen; the queries themselves always come from the cell.
+func (s *Service) SharedCellQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	dash, _, ok := s.sharedDashboard(w, ctx)
+	if !ok {
+		return
+	}
+
+	cid := httprouter.GetParamFromContext(ctx, "cid")
+	var cell *chronograf.DashboardCell
+	for i := range dash.Cells {
+		if dash.Cells[i].ID == cid {
+			cell = &dash.Cells[i]
+			break
+		}
+	}
+	if cell == nil {
+		notFound(w, cid, s.Logger)
+		return
+	}
+
+	var req RenderCellRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidSharedRange(req.Range); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	// Template queries always run against the sources of the cell
+	req.Source = ""
+
+	rendered, ok := s.renderCell(w, ctx, dash, *cell, req)
+	if !ok {
+		return
+	}
+
+	sources := map[int]chronograf.TimeSeries{}
+	res := SharedCellQueryResponse{
+		Queries: make([]SharedQueryResult, 0, len(rendered.Queries)),
+	}
+	for i, rq := range rendered.Queries {
+		result := SharedQueryResult{
+			Query:  rq.Query,
+			Source: rq.Source,
+		}
+		ts, err := s.sharedTimeSeries(ctx, sources, rq)
+		if err != nil {
+			result.Error = err.Error()
+			res.Queries = append(res.Queries, result)
+			continue
+		}
+
+		q := chronograf.Query{
+			Command: rq.Rendered,
+			DB:      cell.Queries[i].QueryConfig.Database,
+			RP:      cell.Queries[i].QueryConfig.RetentionPolicy,
+		}
+		if result.Results, err = ts.Query(ctx, q); err != nil {
+			result.Error = err.Error()
+		}
+		for _, shifted := range rq.Shifted {
+			sr := SharedShiftedResult{
+				Shift: shifted.Shift,
+			}
+			q.Command = shifted.Query
+			if sr.Results, err = ts.Query(ctx, q); err != nil {
+				sr.Error = err.Error()
+			}
+			result.Shifted = append(result.Shifted, sr)
+		}
+		res.Queries = append(res.Queries, result)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// sharedTimeSeries connects to the source of an influxql query of a shared
+// cell, reusing the connections of earlier queries of the cell
+func (s *Service) sharedTimeSeries(ctx context.Context, sources map[int]chronograf.TimeSeries, rq RenderedQuery) (chronograf.TimeSeries, error) {
+	if rq.Type != "" && rq.Type != "influxql" {
+		return nil, fmt.Errorf("%s queries cannot be run from shared dashboards", rq.Type)
+	}
+	srcID, err := strconv.Atoi(path.Base(rq.Source))
+	if rq.Source == "" || err != nil {
+		return nil, fmt.Errorf("query has no source")
+	}
+	if ts, ok := sources[srcID]; ok {
+		return ts, nil
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		return nil, fmt.Errorf("source %d not found", srcID)
+	}
+	// Sources authenticating each user are queried as the user who shared
+	// the dashboard, whose credentials are on the context of the share
+	ts, err := s.TimeSeries(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to source %d: %v", srcID, err)
+	}
+	sources[srcID] = ts
+	return ts, nil
+}
@@ -0,0 +1,493 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bouk/httprouter"
+	gojwt "github.com/dgrijalva/jwt-go"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/oauth2"
+	"github.com/influxdata/chronograf/organizations"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func TestShareToken(t *testing.T) {
+	now := time.Date(1985, 10, 26, 1, 20, 0, 0, time.UTC)
+	share := chronograf.DashboardShare{
+		ID:           "lobby",
+		DashboardID:  1,
+		Organization: "default",
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	token, err := newShareToken("flux capacitor", share)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := parseShareToken("flux capacitor", token, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Id != share.ID || claims.Dashboard != share.DashboardID || claims.Organization != share.Organization {
+		t.Errorf("parseShareToken() = %#v, want the claims of the share", claims)
+	}
+
+	if _, err := parseShareToken("biff", token, now); err == nil {
+		t.Errorf("parseShareToken() with another secret expected error")
+	}
+	if _, err := parseShareToken("flux capacitor", token, now.Add(2*time.Hour)); err == nil {
+		t.Errorf("parseShareToken() after expiry expected error")
+	}
+
+	// The tokens of users are signed with the same secret but are not shares
+	login, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &oauth2.Claims{
+		StandardClaims: gojwt.StandardClaims{
+			Id:        "lobby",
+			Subject:   "marty",
+			ExpiresAt: now.Add(time.Hour).Unix(),
+		},
+		Organization: "default",
+	}).SignedString([]byte("flux capacitor"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseShareToken("flux capacitor", login, now); err == nil {
+		t.Errorf("parseShareToken() of a user token expected error")
+	}
+}
+
+func TestAuthorizedShare(t *testing.T) {
+	now := time.Now().UTC()
+	valid := chronograf.DashboardShare{
+		ID:           "lobby",
+		DashboardID:  1,
+		Organization: "default",
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	expired := valid
+	expired.ExpiresAt = now.Add(-time.Hour)
+	moved := valid
+	moved.DashboardID = 2
+	byDoc := valid
+	byDoc.CreatedBy, byDoc.CreatedByID = "doc", 1
+
+	tests := []struct {
+		name       string
+		share      chronograf.DashboardShare // share signed into the token
+		stored     *chronograf.DashboardShare
+		token      string
+		wantStatus int
+		wantUser   string // wantUser queries sources authenticating each user
+	}{
+		{
+			name:       "Valid share",
+			share:      valid,
+			stored:     &valid,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Share queries run as the user who shared the dashboard",
+			share:      byDoc,
+			stored:     &byDoc,
+			wantStatus: http.StatusOK,
+			wantUser:   "doc",
+		},
+		{
+			name:       "Revoked share",
+			share:      valid,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Expired share",
+			share:      expired,
+			stored:     &expired,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Share of another dashboard",
+			share:      valid,
+			stored:     &moved,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Garbage token",
+			token:      "1.21.gigawatts",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.token
+			if token == "" {
+				var err error
+				if token, err = newShareToken("flux capacitor", tt.share); err != nil {
+					t.Fatal(err)
+				}
+			}
+			store := &mocks.Store{
+				DashboardSharesStore: &mocks.DashboardSharesStore{
+					GetF: func(ctx context.Context, id string) (chronograf.DashboardShare, error) {
+						if tt.stored == nil || tt.stored.ID != id {
+							return chronograf.DashboardShare{}, chronograf.ErrDashboardShareNotFound
+						}
+						return *tt.stored, nil
+					},
+				},
+				UsersStore: &mocks.UsersStore{
+					GetF: func(ctx context.Context, q chronograf.UserQuery) (*chronograf.User, error) {
+						if q.ID == nil || *q.ID != 1 {
+							return nil, chronograf.ErrUserNotFound
+						}
+						return &chronograf.User{ID: 1, Name: "doc"}, nil
+					},
+				},
+			}
+
+			var ctx context.Context
+			next := func(w http.ResponseWriter, r *http.Request) {
+				ctx = r.Context()
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+			r = r.WithContext(httprouter.WithParams(
+				r.Context(),
+				httprouter.Params{
+					{
+						Key:   "token",
+						Value: token,
+					},
+				}))
+
+			AuthorizedShare(store, "flux capacitor", &mocks.TestLogger{}, next)(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("AuthorizedShare() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if ctx != nil {
+					t.Errorf("AuthorizedShare() called next handler")
+				}
+				return
+			}
+			if share, ok := hasShareContext(ctx); !ok || share.ID != valid.ID {
+				t.Errorf("AuthorizedShare() share on context = %v, want %v", share, valid)
+			}
+			if org := ctx.Value(organizations.ContextKey); org != valid.Organization {
+				t.Errorf("AuthorizedShare() organization on context = %v, want %s", org, valid.Organization)
+			}
+			if role, _ := hasRoleContext(ctx); role != roles.ViewerRoleName {
+				t.Errorf("AuthorizedShare() role on context = %s, want viewer", role)
+			}
+			if hasServerContext(ctx) {
+				t.Errorf("AuthorizedShare() gave raw access to the store")
+			}
+
+			auth := influx.DefaultAuthorization(ctx, &chronograf.Source{
+				UserAuth:     chronograf.UserAuthJWT,
+				SharedSecret: "einstein",
+			})
+			user := ""
+			if jwt, ok := auth.(*influx.BearerJWT); ok {
+				user = jwt.Username
+			}
+			if user != tt.wantUser {
+				t.Errorf("AuthorizedShare() queries sources authenticating each user as %q, want %q", user, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestService_DashboardSharesRequireEdit(t *testing.T) {
+	production := chronograf.Dashboard{
+		ID:      1,
+		Name:    "Production",
+		Owner:   "doc",
+		OwnerID: 1,
+		Locked:  true,
+		Permissions: []chronograf.DashboardPermission{
+			{
+				UserID:     2,
+				User:       "marty",
+				Permission: chronograf.DashboardEditPermission,
+			},
+			{
+				Role:       roles.EditorRoleName,
+				Permission: chronograf.DashboardViewPermission,
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		user       *chronograf.User
+		wantStatus int
+	}{
+		{
+			name:       "Owners share locked dashboards",
+			user:       &chronograf.User{ID: 1, Name: "doc"},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Users granted edit share the dashboard",
+			user:       &chronograf.User{ID: 2, Name: "marty"},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Users granted view cannot share the dashboard",
+			user:       &chronograf.User{ID: 3, Name: "biff"},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var added *chronograf.DashboardShare
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							return production, nil
+						},
+					},
+					DashboardSharesStore: &mocks.DashboardSharesStore{
+						AllF: func(ctx context.Context, id chronograf.DashboardID) ([]chronograf.DashboardShare, error) {
+							return []chronograf.DashboardShare{{ID: "lobby", DashboardID: id}}, nil
+						},
+						AddF: func(ctx context.Context, share chronograf.DashboardShare) (chronograf.DashboardShare, error) {
+							share.ID = "lobby"
+							added = &share
+							return share, nil
+						},
+						GetF: func(ctx context.Context, id string) (chronograf.DashboardShare, error) {
+							return chronograf.DashboardShare{ID: id, DashboardID: 1}, nil
+						},
+						DeleteF: func(ctx context.Context, share chronograf.DashboardShare) error {
+							return nil
+						},
+					},
+				},
+				ShareSecret: "flux capacitor",
+				Logger:      &mocks.TestLogger{},
+			}
+
+			w := httptest.NewRecorder()
+			s.NewDashboardShare(w, dashboardRequest("POST", `{"name":"Lobby TV"}`, tt.user, roles.EditorRoleName))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("NewDashboardShare() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if added != nil && (added.CreatedBy != tt.user.Name || added.CreatedByID != tt.user.ID) {
+				t.Errorf("NewDashboardShare() created by %s (%d), want %s (%d)", added.CreatedBy, added.CreatedByID, tt.user.Name, tt.user.ID)
+			}
+
+			// Listing and revoking shares is restricted the same way
+			wantStatus := tt.wantStatus
+			if wantStatus == http.StatusCreated {
+				wantStatus = http.StatusOK
+			}
+			w = httptest.NewRecorder()
+			s.DashboardShares(w, dashboardRequest("GET", "", tt.user, roles.EditorRoleName))
+			if w.Code != wantStatus {
+				t.Errorf("DashboardShares() status = %d, want %d", w.Code, wantStatus)
+			}
+
+			if wantStatus == http.StatusOK {
+				wantStatus = http.StatusNoContent
+			}
+			w = httptest.NewRecorder()
+			r := dashboardRequest("DELETE", "", tt.user, roles.EditorRoleName)
+			r = r.WithContext(httprouter.WithParams(r.Context(), httprouter.Params{
+				{Key: "id", Value: "1"},
+				{Key: "sid", Value: "lobby"},
+			}))
+			s.RemoveDashboardShare(w, r)
+			if w.Code != wantStatus {
+				t.Errorf("RemoveDashboardShare() status = %d, want %d", w.Code, wantStatus)
+			}
+		})
+	}
+}
+
+func TestService_SharedCellQuery(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:   1,
+		Name: "Status",
+		Cells: []chronograf.DashboardCell{
+			{
+				ID: "cpu",
+				Queries: []chronograf.DashboardQuery{
+					{
+						Command: `SELECT mean("usage_idle") FROM "cpu" WHERE time > :dashboardTime: AND "host" = :host: GROUP BY time(:interval:)`,
+						Source:  "/chronograf/v1/sources/1",
+						Type:    "influxql",
+						QueryConfig: chronograf.QueryConfig{
+							Database:        "telegraf",
+							RetentionPolicy: "autogen",
+						},
+					},
+					{
+						Command: `from(bucket: "telegraf")`,
+						Source:  "/chronograf/v1/sources/1",
+						Type:    "flux",
+					},
+				},
+			},
+		},
+		Templates: []chronograf.Template{
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var: ":host:",
+					Values: []chronograf.TemplateValue{
+						{Value: "server01", Type: "tagValue", Selected: true},
+						{Value: "server02", Type: "tagValue"},
+					},
+				},
+				ID:   "host",
+				Type: "constant",
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cid        string
+		body       string
+		wantStatus int
+		want       []string
+	}{
+		{
+			name:       "Runs the queries of the cell",
+			cid:        "cpu",
+			body:       `{"range":{"lower":"2018-09-01T00:00:00Z","upper":"2018-09-01T01:00:00Z"},"resolution":60,"selections":{":host:":"server02"}}`,
+			wantStatus: http.StatusOK,
+			want: []string{
+				`SELECT mean("usage_idle") FROM "cpu" WHERE time > '2018-09-01T00:00:00Z' AND "host" = 'server02' GROUP BY time(60000ms)`,
+			},
+		},
+		{
+			name:       "Selections are limited to the values of the templates",
+			cid:        "cpu",
+			body:       `{"range":{"lower":"now() - 1h"},"resolution":60,"selections":{":host:":"' OR 1=1; DROP DATABASE \"telegraf\""}}`,
+			wantStatus: http.StatusOK,
+			want: []string{
+				`SELECT mean("usage_idle") FROM "cpu" WHERE time > now() - 1h AND "host" = 'server01' GROUP BY time(60000ms)`,
+			},
+		},
+		{
+			name:       "Time ranges cannot change the queries",
+			cid:        "cpu",
+			body:       `{"range":{"lower":"now() - 1h; DROP DATABASE \"telegraf\""}}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Unknown cell",
+			cid:        "mem",
+			body:       `{"range":{"lower":"now() - 1h"}}`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []chronograf.Query
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							if id != dashboard.ID {
+								return chronograf.Dashboard{}, chronograf.ErrDashboardNotFound
+							}
+							return dashboard, nil
+						},
+					},
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID: ID,
+							}, nil
+						},
+					},
+				},
+				TimeSeriesClient: &mocks.TimeSeries{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+						queries = append(queries, q)
+						return mocks.NewResponse(`[{"statement_id":0}]`, nil), nil
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/chronograf/v1/shared/token/cells/"+tt.cid+"/query", bytes.NewBufferString(tt.body))
+			ctx := context.WithValue(context.Background(), ShareContextKey, chronograf.DashboardShare{
+				ID:          "lobby",
+				DashboardID: 1,
+			})
+			r = r.WithContext(httprouter.WithParams(
+				ctx,
+				httprouter.Params{
+					{
+						Key:   "token",
+						Value: "token",
+					},
+					{
+						Key:   "cid",
+						Value: tt.cid,
+					},
+				}))
+
+			s.SharedCellQuery(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Service.SharedCellQuery() status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if len(queries) != 0 {
+					t.Errorf("Service.SharedCellQuery() ran %v", queries)
+				}
+				return
+			}
+
+			if len(queries) != len(tt.want) {
+				t.Fatalf("Service.SharedCellQuery() ran %d queries, want %d", len(queries), len(tt.want))
+			}
+			for i, q := range queries {
+				if q.Command != tt.want[i] || q.DB != "telegraf" || q.RP != "autogen" {
+					t.Errorf("Service.SharedCellQuery() query %d = %#v, want %s on telegraf.autogen", i, q, tt.want[i])
+				}
+			}
+
+			var res struct {
+				Queries []struct {
+					Results json.RawMessage `json:"results"`
+					Error   string          `json:"error"`
+				} `json:"queries"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("unable to decode response: %v", err)
+			}
+			if len(res.Queries) != 2 || res.Queries[0].Error != "" || string(res.Queries[0].Results) != `[{"statement_id":0}]` || res.Queries[1].Error == "" {
+				t.Errorf("Service.SharedCellQuery() = %#v, want results for influxql and an error for flux", res)
+			}
+		})
+	}
+}
+
+func TestService_SharedDashboard_RequiresShare(t *testing.T) {
+	s := &Service{
+		Store:  &mocks.Store{},
+		Logger: &mocks.TestLogger{},
+	}
+	w := httptest.NewRecorder()
+	s.SharedDashboard(w, httptest.NewRequest("GET", "/chronograf/v1/shared/token", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Service.SharedDashboard() without a share status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
@@ -4,14 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/influxdata/chronograf"
 )
 
 type dashboardLinks struct {
-	Self      string `json:"self"`      // Self link mapping to this resource
-	Cells     string `json:"cells"`     // Cells link to the cells endpoint
-	Templates string `json:"templates"` // Templates link to the templates endpoint
+	Self        string `json:"self"`        // Self link mapping to this resource
+	Cells       string `json:"cells"`       // Cells link to the cells endpoint
+	Templates   string `json:"templates"`   // Templates link to the templates endpoint
+	Permissions string `json:"permissions"` // Permissions link to the permissions endpoint
+	Shares      string `json:"shares"`      // Shares link to the read-only shares endpoint
 }
 
 type dashboardResponse struct {
@@ -20,11 +27,18 @@ type dashboardResponse struct {
 	Templates    []templateResponse      `json:"templates"`
 	Name         string                  `json:"name"`
 	Organization string                  `json:"organization"`
+	Description  string                  `json:"description"`
+	Tags         []string                `json:"tags"`
+	Folder       string                  `json:"folder"`
+	Owner        string                  `json:"owner"`
+	OwnerID      uint64                  `json:"ownerID,string,omitempty"`
+	Locked       bool                    `json:"locked"`
 	Links        dashboardLinks          `json:"links"`
 }
 
 type getDashboardsResponse struct {
 	Dashboards []*dashboardResponse `json:"dashboards"`
+	Total      int                  `json:"total"` // Total is the number of dashboards matching the query across all pages
 }
 
 func newDashboardResponse(d chronograf.Dashboard) *dashboardResponse {
@@ -32,6 +46,10 @@ func newDashboardResponse(d chronograf.Dashboard) *dashboardResponse {
 	dd := AddQueryConfigs(DashboardDefaults(d))
 	cells := newCellResponses(dd.ID, dd.Cells)
 	templates := newTemplateResponses(dd.ID, dd.Templates)
+	tags := d.Tags
+	if tags == nil {
+		tags = []string{}
+	}
 
 	return &dashboardResponse{
 		ID:           dd.ID,
@@ -39,18 +57,36 @@ func newDashboardResponse(d chronograf.Dashboard) *dashboardResponse {
 		Cells:        cells,
 		Templates:    templates,
 		Organization: d.Organization,
+		Description:  d.Description,
+		Tags:         tags,
+		Folder:       d.Folder,
+		Owner:        d.Owner,
+		OwnerID:      d.OwnerID,
+		Locked:       d.Locked,
 		Links: dashboardLinks{
-			Self:      fmt.Sprintf("%s/%d", base, dd.ID),
-			Cells:     fmt.Sprintf("%s/%d/cells", base, dd.ID),
-			Templates: fmt.Sprintf("%s/%d/templates", base, dd.ID),
+			Self:        fmt.Sprintf("%s/%d", base, dd.ID),
+			Cells:       fmt.Sprintf("%s/%d/cells", base, dd.ID),
+			Templates:   fmt.Sprintf("%s/%d/templates", base, dd.ID),
+			Permissions: fmt.Sprintf("%s/%d/permissions", base, dd.ID),
+			Shares:      fmt.Sprintf("%s/%d/shares", base, dd.ID),
 		},
 	}
 }
 
-// Dashboards returns all dashboards within the store
+// Dashboards returns the dashboards within the store that match the name,
+// tag and folder query parameters, sorted and paged as requested
 func (s *Service) Dashboards(w http.ResponseWriter, r *http.Request) {
+	q, err := validDashboardsQuery(r.URL.Query())
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
 	ctx := r.Context()
-	dashboards, err := s.Store.Dashboards(ctx).All(ctx)
+	if userID, role, restricted := dashboardUser(ctx); restricted {
+		q.UserID, q.Role = userID, role
+	}
+	dashboards, total, err := s.Store.Dashboards(ctx).Search(ctx, q)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "Error loading dashboards", s.Logger)
 		return
@@ -58,6 +94,7 @@ func (s *Service) Dashboards(w http.ResponseWriter, r *http.Request) {
 
 	res := getDashboardsResponse{
 		Dashboards: []*dashboardResponse{},
+		Total:      total,
 	}
 
 	for _, dashboard := range dashboards {
@@ -80,6 +117,9 @@ func (s *Service) DashboardID(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, e, chronograf.DashboardViewPermission) {
+		return
+	}
 
 	res := newDashboardResponse(e)
 	encodeJSON(w, http.StatusOK, res, s.Logger)
@@ -106,6 +146,14 @@ func (s *Service) NewDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Permissions are changed through the permissions of the new dashboard
+	dashboard.Permissions = nil
+	dashboard.Locked = false
+	dashboard.Owner, dashboard.OwnerID = "", 0
+	if user, ok := hasUserContext(ctx); ok {
+		dashboard.Owner, dashboard.OwnerID = user.Name, user.ID
+	}
+
 	if dashboard, err = s.Store.Dashboards(ctx).Add(r.Context(), dashboard); err != nil {
 		msg := fmt.Errorf("Error storing dashboard %v: %v", dashboard, err)
 		unknownErrorWithMessage(w, msg, s.Logger)
@@ -117,7 +165,7 @@ func (s *Service) NewDashboard(w http.ResponseWriter, r *http.Request) {
 	encodeJSON(w, http.StatusCreated, res, s.Logger)
 }
 
-// RemoveDashboard deletes a dashboard
+// RemoveDashboard deletes a dashboard along with its shares
 func (s *Service) RemoveDashboard(w http.ResponseWriter, r *http.Request) {
 	id, err := paramID("id", r)
 	if err != nil {
@@ -131,6 +179,9 @@ func (s *Service) RemoveDashboard(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, e, chronograf.DashboardEditPermission) {
+		return
+	}
 
 	if err := s.Store.Dashboards(ctx).Delete(ctx, e); err != nil {
 		unknownErrorWithMessage(w, err, s.Logger)
@@ -149,11 +200,14 @@ func (s *Service) ReplaceDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 	id := chronograf.DashboardID(idParam)
 
-	_, err = s.Store.Dashboards(ctx).Get(ctx, id)
+	orig, err := s.Store.Dashboards(ctx).Get(ctx, id)
 	if err != nil {
 		Error(w, http.StatusNotFound, fmt.Sprintf("ID %d not found", id), s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, orig, chronograf.DashboardEditPermission) {
+		return
+	}
 
 	var req chronograf.Dashboard
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -161,6 +215,9 @@ func (s *Service) ReplaceDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	req.ID = id
+	req.Owner, req.OwnerID = orig.Owner, orig.OwnerID
+	req.Permissions = orig.Permissions
+	req.Locked = orig.Locked
 
 	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
 	if err != nil {
@@ -183,6 +240,14 @@ func (s *Service) ReplaceDashboard(w http.ResponseWriter, r *http.Request) {
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
 
+// updateDashboardRequest is a dashboard whose description and folder are only
+// changed when present, so that an empty one clears them
+type updateDashboardRequest struct {
+	chronograf.Dashboard
+	Description *string `json:"description"`
+	Folder      *string `json:"folder"`
+}
+
 // UpdateDashboard completely updates either the dashboard name or the cells
 func (s *Service) UpdateDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -199,14 +264,30 @@ func (s *Service) UpdateDashboard(w http.ResponseWriter, r *http.Request) {
 		Error(w, http.StatusNotFound, fmt.Sprintf("ID %d not found", id), s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, orig, chronograf.DashboardEditPermission) {
+		return
+	}
 
-	var req chronograf.Dashboard
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var patch updateDashboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
 		invalidJSON(w, s.Logger)
 		return
 	}
+	req := patch.Dashboard
 	req.ID = id
 
+	// Description, tags and folder may be updated along with the name or cells
+	details := patch.Description != nil || req.Tags != nil || patch.Folder != nil
+	if patch.Description != nil {
+		orig.Description = *patch.Description
+	}
+	if req.Tags != nil {
+		orig.Tags = normalizeTags(req.Tags)
+	}
+	if patch.Folder != nil {
+		orig.Folder = normalizeFolder(*patch.Folder)
+	}
+
 	if req.Name != "" {
 		orig.Name = req.Name
 	} else if len(req.Cells) > 0 {
@@ -220,8 +301,8 @@ func (s *Service) UpdateDashboard(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		orig.Cells = req.Cells
-	} else {
-		invalidData(w, fmt.Errorf("Update must include either name or cells"), s.Logger)
+	} else if !details {
+		invalidData(w, fmt.Errorf("Update must include either name, cells, description, tags or folder"), s.Logger)
 		return
 	}
 
@@ -251,10 +332,81 @@ func ValidDashboardRequest(d *chronograf.Dashboard, defaultOrgID string) error {
 			return err
 		}
 	}
+	d.Tags = normalizeTags(d.Tags)
+	d.Folder = normalizeFolder(d.Folder)
 	(*d) = DashboardDefaults(*d)
 	return nil
 }
 
+// normalizeTags trims and sorts the tags and removes empty and duplicate tags
+func normalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	var res []string
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		res = append(res, t)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// normalizeFolder cleans the folder path and removes its leading and
+// trailing slashes, e.g. "/infra//hosts/" becomes "infra/hosts"
+func normalizeFolder(folder string) string {
+	folder = strings.TrimSpace(folder)
+	if folder == "" {
+		return ""
+	}
+	return strings.Trim(path.Clean("/"+folder), "/")
+}
+
+// validDashboardsQuery parses the filters, sorting and paging of dashboards
+func validDashboardsQuery(query url.Values) (chronograf.DashboardsQuery, error) {
+	q := chronograf.DashboardsQuery{
+		Name:   query.Get("name"),
+		Tags:   normalizeTags(query["tag"]),
+		Folder: normalizeFolder(query.Get("folder")),
+		SortBy: chronograf.DashboardsByName,
+	}
+
+	if sortBy := query.Get("sortBy"); sortBy != "" {
+		switch sortBy {
+		case chronograf.DashboardsByName, chronograf.DashboardsByFolder, chronograf.DashboardsByID:
+			q.SortBy = sortBy
+		default:
+			return q, fmt.Errorf("sortBy must be one of name, folder or id")
+		}
+	}
+
+	switch order := query.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		q.Descending = true
+	default:
+		return q, fmt.Errorf("order must be either asc or desc")
+	}
+
+	if limit := query.Get(limitQuery); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return q, fmt.Errorf("limit must be a positive integer")
+		}
+		q.Limit = n
+	}
+	if offset := query.Get(offsetQuery); offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return q, fmt.Errorf("offset must be a positive integer")
+		}
+		q.Offset = n
+	}
+	return q, nil
+}
+
 // DashboardDefaults updates the dashboard with the default values
 // if none are specified
 func DashboardDefaults(d chronograf.Dashboard) (newDash chronograf.Dashboard) {
@@ -262,6 +414,13 @@ func DashboardDefaults(d chronograf.Dashboard) (newDash chronograf.Dashboard) {
 	newDash.Templates = d.Templates
 	newDash.Name = d.Name
 	newDash.Organization = d.Organization
+	newDash.Description = d.Description
+	newDash.Tags = d.Tags
+	newDash.Folder = d.Folder
+	newDash.Owner = d.Owner
+	newDash.OwnerID = d.OwnerID
+	newDash.Permissions = d.Permissions
+	newDash.Locked = d.Locked
 	newDash.Cells = make([]chronograf.DashboardCell, len(d.Cells))
 
 	for i, c := range d.Cells {
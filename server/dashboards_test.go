@@ -1,11 +1,18 @@
 package server
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
 )
 
 func TestCorrectWidthHeight(t *testing.T) {
@@ -196,6 +203,20 @@ func TestValidDashboardRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Normalizes tags and folder",
+			d: chronograf.Dashboard{
+				Organization: "1337",
+				Tags:         []string{" society", "clock", "", "society"},
+				Folder:       "/hill-valley//1955/",
+			},
+			want: chronograf.Dashboard{
+				Organization: "1337",
+				Tags:         []string{"clock", "society"},
+				Folder:       "hill-valley/1955",
+				Cells:        []chronograf.DashboardCell{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		// TODO(desa): this Okay?
@@ -220,6 +241,10 @@ func Test_newDashboardResponse(t *testing.T) {
 			name: "creates a dashboard response",
 			d: chronograf.Dashboard{
 				Organization: "0",
+				Description:  "donations to the clock tower fund",
+				Tags:         []string{"fundraising"},
+				Folder:       "hill-valley/society",
+				Owner:        "goldie",
 				Cells: []chronograf.DashboardCell{
 					{
 						ID: "a",
@@ -265,6 +290,10 @@ func Test_newDashboardResponse(t *testing.T) {
 			},
 			want: &dashboardResponse{
 				Organization: "0",
+				Description:  "donations to the clock tower fund",
+				Tags:         []string{"fundraising"},
+				Folder:       "hill-valley/society",
+				Owner:        "goldie",
 				Templates:    []templateResponse{},
 				Cells: []dashboardCellResponse{
 					dashboardCellResponse{
@@ -363,9 +392,11 @@ func Test_newDashboardResponse(t *testing.T) {
 					},
 				},
 				Links: dashboardLinks{
-					Self:      "/chronograf/v1/dashboards/0",
-					Cells:     "/chronograf/v1/dashboards/0/cells",
-					Templates: "/chronograf/v1/dashboards/0/templates",
+					Self:        "/chronograf/v1/dashboards/0",
+					Cells:       "/chronograf/v1/dashboards/0/cells",
+					Templates:   "/chronograf/v1/dashboards/0/templates",
+					Permissions: "/chronograf/v1/dashboards/0/permissions",
+					Shares:      "/chronograf/v1/dashboards/0/shares",
 				},
 			},
 		},
@@ -376,3 +407,140 @@ func Test_newDashboardResponse(t *testing.T) {
 		}
 	}
 }
+
+func Test_validDashboardsQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   url.Values
+		want    chronograf.DashboardsQuery
+		wantErr bool
+	}{
+		{
+			name:  "defaults to all dashboards sorted by name",
+			query: url.Values{},
+			want: chronograf.DashboardsQuery{
+				SortBy: chronograf.DashboardsByName,
+			},
+		},
+		{
+			name: "parses filters, sorting and paging",
+			query: url.Values{
+				"name":   []string{"clock"},
+				"tag":    []string{"society", "1955"},
+				"folder": []string{"/hill-valley/"},
+				"sortBy": []string{"folder"},
+				"order":  []string{"desc"},
+				"limit":  []string{"20"},
+				"offset": []string{"40"},
+			},
+			want: chronograf.DashboardsQuery{
+				Name:       "clock",
+				Tags:       []string{"1955", "society"},
+				Folder:     "hill-valley",
+				SortBy:     chronograf.DashboardsByFolder,
+				Descending: true,
+				Limit:      20,
+				Offset:     40,
+			},
+		},
+		{
+			name:    "unknown sort field",
+			query:   url.Values{"sortBy": []string{"owner"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown order",
+			query:   url.Values{"order": []string{"sideways"}},
+			wantErr: true,
+		},
+		{
+			name:    "negative limit",
+			query:   url.Values{"limit": []string{"-1"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid offset",
+			query:   url.Values{"offset": []string{"doc"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validDashboardsQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validDashboardsQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("validDashboardsQuery() got/want diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestService_UpdateDashboardDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want chronograf.Dashboard
+	}{
+		{
+			name: "Absent details are kept",
+			body: `{"name":"Clock Tower"}`,
+			want: chronograf.Dashboard{
+				ID:          1,
+				Name:        "Clock Tower",
+				Description: "donations to the clock tower fund",
+				Tags:        []string{"fundraising"},
+				Folder:      "hill-valley/society",
+			},
+		},
+		{
+			name: "Empty details are cleared",
+			body: `{"description":"","tags":[],"folder":""}`,
+			want: chronograf.Dashboard{
+				ID:          1,
+				Name:        "Fundraiser",
+				Description: "",
+				Tags:        nil,
+				Folder:      "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored chronograf.Dashboard
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							return chronograf.Dashboard{
+								ID:          id,
+								Name:        "Fundraiser",
+								Description: "donations to the clock tower fund",
+								Tags:        []string{"fundraising"},
+								Folder:      "hill-valley/society",
+							}, nil
+						},
+						UpdateF: func(ctx context.Context, target chronograf.Dashboard) error {
+							stored = target
+							return nil
+						},
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+
+			w := httptest.NewRecorder()
+			s.UpdateDashboard(w, dashboardRequest("PATCH", tt.body, &chronograf.User{Name: "doc"}, roles.EditorRoleName))
+			if got := w.Result().StatusCode; got != http.StatusOK {
+				t.Fatalf("StatusCode = %d, want %d", got, http.StatusOK)
+			}
+			if diff := cmp.Diff(stored, tt.want); diff != "" {
+				t.Errorf("UpdateDashboard() stored got/want diff:\n%s", diff)
+			}
+		})
+	}
+}
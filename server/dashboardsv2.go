@@ -8,6 +8,7 @@ import (
 
 	"github.com/bouk/httprouter"
 	platform "github.com/influxdata/chronograf/v2"
+	"github.com/influxdata/chronograf/v2/migrate"
 )
 
 type dashboardV2Links struct {
@@ -24,6 +25,9 @@ func newDashboardV2Response(d *platform.Dashboard) dashboardV2Response {
 	if d.Cells == nil {
 		d.Cells = []platform.DashboardCell{}
 	}
+	if d.Templates == nil {
+		d.Templates = []platform.Template{}
+	}
 	return dashboardV2Response{
 		Links: dashboardV2Links{
 			Self: fmt.Sprintf("/chronograf/v2/dashboards/%s", d.ID),
@@ -95,6 +99,9 @@ func decodePostDashboardRequest(ctx context.Context, r *http.Request) (*postDash
 	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
 		return nil, err
 	}
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
 	return &postDashboardRequest{
 		Dashboard: c,
 	}, nil
@@ -234,3 +241,17 @@ func (r *patchDashboardRequest) Valid() error {
 func (s *Service) encodePatchDashboardResponse(w http.ResponseWriter, dashboard *platform.Dashboard) {
 	encodeJSON(w, http.StatusOK, newDashboardV2Response(dashboard), s.Logger)
 }
+
+// MigrateDashboardsV2 converts the v1 dashboards of the organization into v2
+// dashboards and cells. Dashboards migrated by an earlier run are skipped.
+func (s *Service) MigrateDashboardsV2(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := migrate.Dashboards(ctx, s.Store.Dashboards(ctx), s.Store.DashboardsV2(ctx), s.Store.Cells(ctx))
+	if err != nil {
+		Error(w, http.StatusInternalServerError, fmt.Sprintf("Error migrating dashboards: %v", err), s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, report, s.Logger)
+}
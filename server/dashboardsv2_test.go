@@ -75,6 +75,11 @@ func TestService_DashboardsV2(t *testing.T) {
     {
       "id": "0",
       "name": "hello",
+      "description": "",
+      "templates": [],
+      "timeRange": {
+        "lower": ""
+      },
       "cells": [
         {
           "x": 1,
@@ -91,6 +96,11 @@ func TestService_DashboardsV2(t *testing.T) {
     {
       "id": "2",
       "name": "example",
+      "description": "",
+      "templates": [],
+      "timeRange": {
+        "lower": ""
+      },
       "cells": [],
       "links": {
         "self": "/chronograf/v2/dashboards/2"
@@ -220,6 +230,11 @@ func TestService_DashboardIDV2(t *testing.T) {
 {
   "id": "2",
   "name": "hello",
+  "description": "",
+  "templates": [],
+  "timeRange": {
+    "lower": ""
+  },
   "cells": [
     {
       "x": 1,
@@ -347,6 +362,11 @@ func TestService_NewDashboardV2(t *testing.T) {
 {
   "id": "2",
   "name": "hello",
+  "description": "",
+  "templates": [],
+  "timeRange": {
+    "lower": ""
+  },
   "cells": [
     {
       "x": 1,
@@ -568,6 +588,11 @@ func TestService_UpdateDashboardV2(t *testing.T) {
 {
   "id": "2",
   "name": "example",
+  "description": "",
+  "templates": [],
+  "timeRange": {
+    "lower": ""
+  },
   "cells": [
     {
       "x": 1,
@@ -645,6 +670,11 @@ func TestService_UpdateDashboardV2(t *testing.T) {
 {
   "id": "2",
   "name": "hello",
+  "description": "",
+  "templates": [],
+  "timeRange": {
+    "lower": ""
+  },
   "cells": [
     {
       "x": 1,
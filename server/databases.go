@@ -10,6 +10,7 @@ import (
 
 	"github.com/bouk/httprouter"
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/relay"
 )
 
 const (
@@ -18,9 +19,12 @@ const (
 )
 
 type dbLinks struct {
-	Self         string `json:"self"`              // Self link mapping to this resource
-	RPs          string `json:"retentionPolicies"` // URL for retention policies for this database
-	Measurements string `json:"measurements"`      // URL for measurements for this database
+	Self              string `json:"self"`              // Self link mapping to this resource
+	RPs               string `json:"retentionPolicies"` // URL for retention policies for this database
+	Measurements      string `json:"measurements"`      // URL for measurements for this database
+	ContinuousQueries string `json:"continuousQueries"` // URL for continuous queries for this database
+	Shards            string `json:"shards"`            // URL for shards for this database
+	Subscriptions     string `json:"subscriptions"`     // URL for subscriptions for this database
 }
 
 type dbResponse struct {
@@ -39,9 +43,12 @@ func newDBResponse(srcID int, db string, rps []rpResponse) dbResponse {
 		Name: db,
 		RPs:  rps,
 		Links: dbLinks{
-			Self:         fmt.Sprintf("%s/%d/dbs/%s", base, srcID, db),
-			RPs:          fmt.Sprintf("%s/%d/dbs/%s/rps", base, srcID, db),
-			Measurements: fmt.Sprintf("%s/%d/dbs/%s/measurements?limit=100&offset=0", base, srcID, db),
+			Self:              fmt.Sprintf("%s/%d/dbs/%s", base, srcID, db),
+			RPs:               fmt.Sprintf("%s/%d/dbs/%s/rps", base, srcID, db),
+			Measurements:      fmt.Sprintf("%s/%d/dbs/%s/measurements?limit=100&offset=0", base, srcID, db),
+			ContinuousQueries: fmt.Sprintf("%s/%d/dbs/%s/cqs", base, srcID, db),
+			Shards:            fmt.Sprintf("%s/%d/dbs/%s/shards", base, srcID, db),
+			Subscriptions:     fmt.Sprintf("%s/%d/dbs/%s/subscriptions", base, srcID, db),
 		},
 	}
 }
@@ -117,7 +124,7 @@ func (h *Service) GetDatabases(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbsvc := h.Databases
+	dbsvc := h.databases(&src)
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, h.Logger)
@@ -163,7 +170,7 @@ func (h *Service) NewDatabase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbsvc := h.Databases
+	dbsvc := h.databases(&src)
 
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
@@ -184,7 +191,7 @@ func (h *Service) NewDatabase(w http.ResponseWriter, r *http.Request) {
 
 	database, err := dbsvc.CreateDB(ctx, postedDB)
 	if err != nil {
-		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		databasesError(w, err, h.Logger)
 		return
 	}
 
@@ -213,7 +220,7 @@ func (h *Service) DropDatabase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbsvc := h.Databases
+	dbsvc := h.databases(&src)
 
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
@@ -225,7 +232,7 @@ func (h *Service) DropDatabase(w http.ResponseWriter, r *http.Request) {
 
 	dropErr := dbsvc.DropDB(ctx, db)
 	if dropErr != nil {
-		Error(w, http.StatusBadRequest, dropErr.Error(), h.Logger)
+		databasesError(w, dropErr, h.Logger)
 		return
 	}
 
@@ -248,7 +255,7 @@ func (h *Service) RetentionPolicies(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbsvc := h.Databases
+	dbsvc := h.databases(&src)
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, h.Logger)
@@ -302,7 +309,7 @@ func (h *Service) NewRetentionPolicy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbsvc := h.Databases
+	dbsvc := h.databases(&src)
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, h.Logger)
@@ -322,7 +329,7 @@ func (h *Service) NewRetentionPolicy(w http.ResponseWriter, r *http.Request) {
 	db := httprouter.GetParamFromContext(ctx, "db")
 	rp, err := dbsvc.CreateRP(ctx, db, postedRP)
 	if err != nil {
-		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		databasesError(w, err, h.Logger)
 		return
 	}
 	res := rpResponse{
@@ -352,7 +359,7 @@ func (h *Service) UpdateRetentionPolicy(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	dbsvc := h.Databases
+	dbsvc := h.databases(&src)
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, h.Logger)
@@ -374,7 +381,7 @@ func (h *Service) UpdateRetentionPolicy(w http.ResponseWriter, r *http.Request)
 	p, err := dbsvc.UpdateRP(ctx, db, rp, postedRP)
 
 	if err != nil {
-		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		databasesError(w, err, h.Logger)
 		return
 	}
 
@@ -405,7 +412,7 @@ func (s *Service) DropRetentionPolicy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbsvc := s.Databases
+	dbsvc := s.databases(&src)
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
@@ -416,7 +423,7 @@ func (s *Service) DropRetentionPolicy(w http.ResponseWriter, r *http.Request) {
 	rp := httprouter.GetParamFromContext(ctx, "rp")
 	dropErr := dbsvc.DropRP(ctx, db, rp)
 	if dropErr != nil {
-		Error(w, http.StatusBadRequest, dropErr.Error(), s.Logger)
+		databasesError(w, dropErr, s.Logger)
 		return
 	}
 
@@ -445,7 +452,7 @@ func (h *Service) Measurements(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbsvc := h.Databases
+	dbsvc := h.databases(&src)
 	if err = dbsvc.Connect(ctx, &src); err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, h.Logger)
@@ -519,3 +526,41 @@ func ValidRetentionPolicyRequest(rp *chronograf.RetentionPolicy) error {
 	}
 	return nil
 }
+
+type backendErrorResponse struct {
+	Backend string `json:"backend"` // Backend is the URL of the failing backend
+	Message string `json:"message"` // Message is the error of the backend
+}
+
+type backendsErrorResponse struct {
+	Code     int                    `json:"code"`
+	Message  string                 `json:"message"`
+	Backends []backendErrorResponse `json:"backends"` // Backends are the failures of each backend
+}
+
+// databasesError responds with the failure to administer databases. The
+// failures of the backends of InfluxDB Relay sources are reported one by one.
+func databasesError(w http.ResponseWriter, err error, logger chronograf.Logger) {
+	errs, ok := err.(relay.BackendErrors)
+	if !ok {
+		Error(w, http.StatusBadRequest, err.Error(), logger)
+		return
+	}
+
+	res := backendsErrorResponse{
+		Code:     http.StatusBadRequest,
+		Message:  err.Error(),
+		Backends: make([]backendErrorResponse, len(errs)),
+	}
+	for i, e := range errs {
+		res.Backends[i] = backendErrorResponse{
+			Backend: e.Backend,
+			Message: e.Err.Error(),
+		}
+	}
+	logger.
+		WithField("component", "server").
+		WithField("http_status ", res.Code).
+		Error("Error message ", res.Message)
+	encodeJSON(w, res.Code, res, logger)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,7 @@ import (
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/log"
 	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/relay"
 )
 
 func TestService_GetDatabases(t *testing.T) {
@@ -24,7 +26,7 @@ func TestService_GetDatabases(t *testing.T) {
 		TimeSeriesClient TimeSeriesClient
 		Logger           chronograf.Logger
 		UseAuth          bool
-		Databases        chronograf.Databases
+		Databases        *mocks.Databases
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -50,7 +52,7 @@ func TestService_GetDatabases(t *testing.T) {
 				TimeSeriesClient: tt.fields.TimeSeriesClient,
 				Logger:           tt.fields.Logger,
 				UseAuth:          tt.fields.UseAuth,
-				Databases:        tt.fields.Databases,
+				DatabasesClient:  tt.fields.Databases,
 			}
 			h.GetDatabases(tt.args.w, tt.args.r)
 		})
@@ -67,7 +69,7 @@ func TestService_NewDatabase(t *testing.T) {
 		TimeSeriesClient TimeSeriesClient
 		Logger           chronograf.Logger
 		UseAuth          bool
-		Databases        chronograf.Databases
+		Databases        *mocks.Databases
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -93,7 +95,7 @@ func TestService_NewDatabase(t *testing.T) {
 				TimeSeriesClient: tt.fields.TimeSeriesClient,
 				Logger:           tt.fields.Logger,
 				UseAuth:          tt.fields.UseAuth,
-				Databases:        tt.fields.Databases,
+				DatabasesClient:  tt.fields.Databases,
 			}
 			h.NewDatabase(tt.args.w, tt.args.r)
 		})
@@ -110,7 +112,7 @@ func TestService_DropDatabase(t *testing.T) {
 		TimeSeriesClient TimeSeriesClient
 		Logger           chronograf.Logger
 		UseAuth          bool
-		Databases        chronograf.Databases
+		Databases        *mocks.Databases
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -136,7 +138,7 @@ func TestService_DropDatabase(t *testing.T) {
 				TimeSeriesClient: tt.fields.TimeSeriesClient,
 				Logger:           tt.fields.Logger,
 				UseAuth:          tt.fields.UseAuth,
-				Databases:        tt.fields.Databases,
+				DatabasesClient:  tt.fields.Databases,
 			}
 			h.DropDatabase(tt.args.w, tt.args.r)
 		})
@@ -153,7 +155,7 @@ func TestService_RetentionPolicies(t *testing.T) {
 		TimeSeriesClient TimeSeriesClient
 		Logger           chronograf.Logger
 		UseAuth          bool
-		Databases        chronograf.Databases
+		Databases        *mocks.Databases
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -179,7 +181,7 @@ func TestService_RetentionPolicies(t *testing.T) {
 				TimeSeriesClient: tt.fields.TimeSeriesClient,
 				Logger:           tt.fields.Logger,
 				UseAuth:          tt.fields.UseAuth,
-				Databases:        tt.fields.Databases,
+				DatabasesClient:  tt.fields.Databases,
 			}
 			h.RetentionPolicies(tt.args.w, tt.args.r)
 		})
@@ -196,7 +198,7 @@ func TestService_NewRetentionPolicy(t *testing.T) {
 		TimeSeriesClient TimeSeriesClient
 		Logger           chronograf.Logger
 		UseAuth          bool
-		Databases        chronograf.Databases
+		Databases        *mocks.Databases
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -222,7 +224,7 @@ func TestService_NewRetentionPolicy(t *testing.T) {
 				TimeSeriesClient: tt.fields.TimeSeriesClient,
 				Logger:           tt.fields.Logger,
 				UseAuth:          tt.fields.UseAuth,
-				Databases:        tt.fields.Databases,
+				DatabasesClient:  tt.fields.Databases,
 			}
 			h.NewRetentionPolicy(tt.args.w, tt.args.r)
 		})
@@ -239,7 +241,7 @@ func TestService_UpdateRetentionPolicy(t *testing.T) {
 		TimeSeriesClient TimeSeriesClient
 		Logger           chronograf.Logger
 		UseAuth          bool
-		Databases        chronograf.Databases
+		Databases        *mocks.Databases
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -265,7 +267,7 @@ func TestService_UpdateRetentionPolicy(t *testing.T) {
 				TimeSeriesClient: tt.fields.TimeSeriesClient,
 				Logger:           tt.fields.Logger,
 				UseAuth:          tt.fields.UseAuth,
-				Databases:        tt.fields.Databases,
+				DatabasesClient:  tt.fields.Databases,
 			}
 			h.UpdateRetentionPolicy(tt.args.w, tt.args.r)
 		})
@@ -282,7 +284,7 @@ func TestService_DropRetentionPolicy(t *testing.T) {
 		TimeSeriesClient TimeSeriesClient
 		Logger           chronograf.Logger
 		UseAuth          bool
-		Databases        chronograf.Databases
+		Databases        *mocks.Databases
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -308,7 +310,7 @@ func TestService_DropRetentionPolicy(t *testing.T) {
 				TimeSeriesClient: tt.fields.TimeSeriesClient,
 				Logger:           tt.fields.Logger,
 				UseAuth:          tt.fields.UseAuth,
-				Databases:        tt.fields.Databases,
+				DatabasesClient:  tt.fields.Databases,
 			}
 			h.DropRetentionPolicy(tt.args.w, tt.args.r)
 		})
@@ -319,7 +321,7 @@ func TestService_Measurements(t *testing.T) {
 	type fields struct {
 		SourcesStore chronograf.SourcesStore
 		Logger       chronograf.Logger
-		Databases    chronograf.Databases
+		Databases    *mocks.Databases
 	}
 	type args struct {
 		queryParams map[string]string
@@ -552,8 +554,8 @@ func TestService_Measurements(t *testing.T) {
 				Store: &mocks.Store{
 					SourcesStore: tt.fields.SourcesStore,
 				},
-				Logger:    logger,
-				Databases: tt.fields.Databases,
+				Logger:          logger,
+				DatabasesClient: tt.fields.Databases,
 			}
 
 			w := httptest.NewRecorder()
@@ -646,3 +648,20 @@ func TestValidRetentionPolicyRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_databasesError(t *testing.T) {
+	w := httptest.NewRecorder()
+	databasesError(w, relay.BackendErrors{
+		{Backend: "http://influx-b:8086", Err: fmt.Errorf("connection refused")},
+	}, log.New(log.DebugLevel))
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("databasesError() status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	want := `{"code":400,"message":"relay backends failed: http://influx-b:8086: connection refused","backends":[{"backend":"http://influx-b:8086","message":"connection refused"}]}` + "\n"
+	if string(body) != want {
+		t.Errorf("databasesError() =\n%s\nwant\n%s", body, want)
+	}
+}
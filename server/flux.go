@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/influx"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
@@ -150,17 +151,20 @@ func (s *Service) ProxyFlux(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fluxEnabled, err := hasFlux(ctx, src)
-	if err != nil {
-		msg := fmt.Sprintf("Error flux service unavailable: %v", err)
-		Error(w, http.StatusServiceUnavailable, msg, s.Logger)
-		return
-	}
+	// InfluxDB 2.x always has flux
+	if src.Type != chronograf.InfluxDBv2 {
+		fluxEnabled, err := hasFlux(ctx, src)
+		if err != nil {
+			msg := fmt.Sprintf("Error flux service unavailable: %v", err)
+			Error(w, http.StatusServiceUnavailable, msg, s.Logger)
+			return
+		}
 
-	if !fluxEnabled {
-		msg := fmt.Sprintf("Error flux not enabled: %v", err)
-		Error(w, http.StatusBadRequest, msg, s.Logger)
-		return
+		if !fluxEnabled {
+			msg := fmt.Sprintf("Error flux not enabled: %v", err)
+			Error(w, http.StatusBadRequest, msg, s.Logger)
+			return
+		}
 	}
 
 	// To preserve any HTTP query arguments to the kapacitor path,
@@ -173,13 +177,22 @@ func (s *Service) ProxyFlux(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Queries of InfluxDB 2.x run within the organization of the source
+	if src.Type == chronograf.InfluxDBv2 {
+		params := u.Query()
+		if params.Get("org") == "" && params.Get("orgID") == "" {
+			params.Set("org", src.Org)
+			u.RawQuery = params.Encode()
+		}
+	}
+
 	director := func(req *http.Request) {
 		// Set the Host header of the original Flux URL
 		req.Host = u.Host
 		req.URL = u
 
 		// Use authorization method based on whether it is OSS or Enterprise
-		auth := influx.DefaultAuthorization(&src)
+		auth := influx.DefaultAuthorization(ctx, &src)
 		auth.Set(req)
 	}
 
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/flux"
+)
+
+// FluxTranslateRequest is an InfluxQL query to be translated into Flux.
+// DB and RP are used when the measurement of the query is not fully
+// qualified.
+type FluxTranslateRequest struct {
+	Query string `json:"query"`
+	DB    string `json:"db,omitempty"`
+	RP    string `json:"rp,omitempty"`
+}
+
+// FluxTranslate converts InfluxQL into the equivalent Flux
+func (s *Service) FluxTranslate(w http.ResponseWriter, r *http.Request) {
+	var req FluxTranslateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	res, err := flux.TranslateInfluxQL(req.Query, req.DB, req.RP)
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	if res.Warnings == nil {
+		res.Warnings = []flux.Warning{}
+	}
+
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// fluxTranslatedQuery reports the translation of a single influxql query
+// of a dashboard cell
+type fluxTranslatedQuery struct {
+	CellID     string         `json:"cellID"`
+	Index      int            `json:"index"`      // Index of the query within the cell
+	InfluxQL   string         `json:"influxql"`   // InfluxQL is the original query
+	Translated bool           `json:"translated"` // Translated is set if the query has been rewritten as Flux
+	Warnings   []flux.Warning `json:"warnings"`
+}
+
+type fluxTranslateDashboardResponse struct {
+	Dashboard *dashboardResponse    `json:"dashboard"`
+	Queries   []fluxTranslatedQuery `json:"queries"`
+}
+
+// TranslateDashboard rewrites every influxql query of a dashboard into
+// Flux. Only queries that translate without any warning are rewritten; the
+// others are left as InfluxQL and reported along with their warnings. The
+// db and rp query parameters qualify measurements that have no database.
+func (s *Service) TranslateDashboard(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardEditPermission) {
+		return
+	}
+
+	db, rp := r.URL.Query().Get("db"), r.URL.Query().Get("rp")
+
+	res := fluxTranslateDashboardResponse{
+		Queries: []fluxTranslatedQuery{},
+	}
+	rewritten := false
+	for _, cell := range dash.Cells {
+		for i, q := range cell.Queries {
+			// Only InfluxQL can be translated; Flux and PromQL are left as is
+			if q.Type != "" && q.Type != "influxql" {
+				continue
+			}
+
+			tq := fluxTranslatedQuery{
+				CellID:   cell.ID,
+				Index:    i,
+				InfluxQL: q.Command,
+				Warnings: []flux.Warning{},
+			}
+
+			translation, err := flux.TranslateInfluxQL(q.Command, db, rp)
+			if err != nil {
+				tq.Warnings = append(tq.Warnings, flux.Warning{
+					Construct: q.Command,
+					Message:   err.Error(),
+				})
+			} else {
+				tq.Warnings = append(tq.Warnings, translation.Warnings...)
+			}
+			if len(q.Shifts) > 0 || len(q.QueryConfig.Shifts) > 0 {
+				tq.Warnings = append(tq.Warnings, flux.Warning{
+					Construct: "shifts",
+					Message:   "time shifts cannot be translated",
+				})
+			}
+
+			if len(tq.Warnings) == 0 {
+				cell.Queries[i] = chronograf.DashboardQuery{
+					Command: translation.Flux,
+					Label:   q.Label,
+					Range:   q.Range,
+					Source:  q.Source,
+					Type:    "flux",
+				}
+				tq.Translated = true
+				rewritten = true
+			}
+			res.Queries = append(res.Queries, tq)
+		}
+	}
+
+	if rewritten {
+		if err := s.Store.Dashboards(ctx).Update(ctx, dash); err != nil {
+			msg := fmt.Sprintf("Error updating dashboard ID %d: %v", id, err)
+			Error(w, http.StatusInternalServerError, msg, s.Logger)
+			return
+		}
+	}
+
+	res.Dashboard = newDashboardResponse(dash)
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
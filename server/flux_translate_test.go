@@ -0,0 +1,134 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_FluxTranslate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{
+			name:       "translates a query",
+			body:       `{"query":"SELECT mean(\"usage_idle\") FROM \"cpu\" WHERE time > now() - 1h GROUP BY time(1m)","db":"telegraf","rp":"autogen"}`,
+			wantStatus: 200,
+			want: `{"flux":"from(bucket: \"telegraf/autogen\")\n  |\u003e range(start: -1h)\n  |\u003e filter(fn: (r) =\u003e r._measurement == \"cpu\" and r._field == \"usage_idle\")\n  |\u003e group(columns: [\"_measurement\", \"_field\"])\n  |\u003e aggregateWindow(every: 1m, fn: mean)\n","warnings":[]}
+`,
+		},
+		{
+			name:       "rejects queries other than SELECT",
+			body:       `{"query":"DROP DATABASE telegraf"}`,
+			wantStatus: 422,
+			want:       `{"code":422,"message":"only SELECT statements can be translated to Flux"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Logger: &mocks.TestLogger{},
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/chronograf/v1/flux/translate", bytes.NewBufferString(tt.body))
+
+			s.FluxTranslate(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Service.FluxTranslate() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("Service.FluxTranslate() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_TranslateDashboard(t *testing.T) {
+	var updated chronograf.Dashboard
+	s := &Service{
+		Store: &mocks.Store{
+			DashboardsStore: &mocks.DashboardsStore{
+				GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+					return chronograf.Dashboard{
+						ID:   id,
+						Name: "system",
+						Cells: []chronograf.DashboardCell{
+							{
+								ID: "cpu",
+								Queries: []chronograf.DashboardQuery{
+									{
+										Command: `SELECT max("usage_user") FROM "telegraf"."autogen"."cpu" WHERE time > :dashboardTime: GROUP BY time(:interval:)`,
+										Type:    "influxql",
+									},
+									{
+										Command: `SELECT "usage_user" FROM "telegraf"."autogen"."cpu" WHERE time > now() - 1h SLIMIT 1`,
+										Type:    "influxql",
+									},
+									{
+										Command: `rate(cpu_seconds_total[5m])`,
+										Type:    "promql",
+									},
+								},
+							},
+						},
+					}, nil
+				},
+				UpdateF: func(ctx context.Context, target chronograf.Dashboard) error {
+					updated = target
+					return nil
+				},
+			},
+		},
+		Logger: &mocks.TestLogger{},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/chronograf/v1/dashboards/1/flux", nil)
+	r = r.WithContext(httprouter.WithParams(
+		context.Background(),
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "1",
+			},
+		}))
+
+	s.TranslateDashboard(w, r)
+	if w.Code != 200 {
+		t.Fatalf("Service.TranslateDashboard() status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	var res fluxTranslateDashboardResponse
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("unable to decode response: %v", err)
+	}
+	if len(res.Queries) != 2 || !res.Queries[0].Translated || res.Queries[1].Translated {
+		t.Fatalf("Service.TranslateDashboard() queries = %#v, want only the first query translated", res.Queries)
+	}
+	if len(res.Queries[1].Warnings) != 1 {
+		t.Errorf("Service.TranslateDashboard() warnings = %#v, want the SLIMIT warning", res.Queries[1].Warnings)
+	}
+
+	queries := updated.Cells[0].Queries
+	if queries[0].Type != "flux" || queries[1].Type != "influxql" || queries[2].Type != "promql" {
+		t.Errorf("Service.TranslateDashboard() stored query types %q, %q and %q, want flux, influxql and promql", queries[0].Type, queries[1].Type, queries[2].Type)
+	}
+	wantFlux := `from(bucket: "telegraf/autogen")
+  |> range(start: dashboardTime)
+  |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user")
+  |> group(columns: ["_measurement", "_field"])
+  |> aggregateWindow(every: autoInterval, fn: (columns, tables=<-) => tables |> max())
+`
+	if queries[0].Command != wantFlux {
+		t.Errorf("Service.TranslateDashboard() stored\n%s\nwant\n%s", queries[0].Command, wantFlux)
+	}
+}
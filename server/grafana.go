@@ -0,0 +1,112 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/grafana"
+	idgen "github.com/influxdata/chronograf/id"
+)
+
+// maxGrafanaDashboardSize is the largest Grafana dashboard in bytes that can
+// be imported
+var maxGrafanaDashboardSize int64 = 32 * 1024 * 1024
+
+type grafanaImportResponse struct {
+	Dashboard *dashboardResponse `json:"dashboard"`
+	Report    grafana.Report     `json:"report"`
+}
+
+// ImportGrafanaDashboard creates a dashboard from the JSON of a Grafana
+// dashboard. Queries run against the source of the source parameter and
+// measurements without a database are read from the db parameter, or the
+// telegraf database of the source. Everything that could not be converted
+// is listed in the report of the response.
+func (s *Service) ImportGrafanaDashboard(w http.ResponseWriter, r *http.Request) {
+	// The route shares its wildcard with the routes of dashboards; see mux.go
+	if httprouter.GetParamFromContext(r.Context(), "id") != "import" {
+		Error(w, http.StatusNotFound, "Not found", s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	opts := grafana.Options{
+		Database: r.URL.Query().Get("db"),
+	}
+	if source := r.URL.Query().Get("source"); source != "" {
+		srcID, err := strconv.Atoi(source)
+		if err != nil {
+			invalidData(w, fmt.Errorf("invalid source %q", source), s.Logger)
+			return
+		}
+		src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+		if err != nil {
+			invalidData(w, fmt.Errorf("source %d not found", srcID), s.Logger)
+			return
+		}
+		opts.Source = fmt.Sprintf("/chronograf/v1/sources/%d", src.ID)
+		if opts.Database == "" {
+			opts.Database = src.Telegraf
+		}
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxGrafanaDashboardSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		msg := fmt.Sprintf("Dashboard is larger than %d bytes", tooLarge.Limit)
+		Error(w, http.StatusRequestEntityTooLarge, msg, s.Logger)
+		return
+	}
+	if err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	gd, err := grafana.Parse(body)
+	if err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	dashboard, report := grafana.Convert(gd, opts)
+	ids := &idgen.UUID{}
+	for i := range dashboard.Templates {
+		tid, err := ids.Generate()
+		if err != nil {
+			unknownErrorWithMessage(w, err, s.Logger)
+			return
+		}
+		dashboard.Templates[i].ID = chronograf.TemplateID(tid)
+	}
+
+	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	if err := ValidDashboardRequest(&dashboard, defaultOrg.ID); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	if user, ok := hasUserContext(ctx); ok {
+		dashboard.Owner, dashboard.OwnerID = user.Name, user.ID
+	}
+
+	if dashboard, err = s.Store.Dashboards(ctx).Add(ctx, dashboard); err != nil {
+		msg := fmt.Errorf("Error storing dashboard %v: %v", dashboard, err)
+		unknownErrorWithMessage(w, msg, s.Logger)
+		return
+	}
+
+	res := grafanaImportResponse{
+		Dashboard: newDashboardResponse(dashboard),
+		Report:    report,
+	}
+	location(w, res.Dashboard.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_ImportGrafanaDashboard(t *testing.T) {
+	body := `{
+	  "title": "Hosts",
+	  "panels": [
+	    {"id": 1, "type": "graph", "title": "CPU", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 9},
+	     "targets": [{"refId": "A", "rawQuery": true, "query": "SELECT mean(\"usage_idle\") FROM \"cpu\" WHERE \"host\" = '$host' AND $timeFilter GROUP BY time($__interval)"}]},
+	    {"id": 2, "type": "heatmap", "title": "Latency"}
+	  ],
+	  "templating": {"list": [
+	    {"name": "host", "type": "query", "query": "SHOW TAG VALUES WITH KEY = \"host\""}
+	  ]}
+	}`
+
+	tests := []struct {
+		name       string
+		id         string
+		query      string
+		body       string
+		limit      int64 // limit is the largest dashboard imported, if set
+		wantStatus int
+	}{
+		{
+			name:       "Imports a dashboard",
+			id:         "import",
+			query:      "?source=1",
+			body:       body,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Unknown source",
+			id:         "import",
+			query:      "?source=2",
+			body:       body,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Invalid JSON",
+			id:         "import",
+			body:       `{"title":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Dashboard too large",
+			id:         "import",
+			body:       body,
+			limit:      64,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "Dashboard route",
+			id:         "1",
+			body:       body,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.limit != 0 {
+				defer func(limit int64) { maxGrafanaDashboardSize = limit }(maxGrafanaDashboardSize)
+				maxGrafanaDashboardSize = tt.limit
+			}
+			var added *chronograf.Dashboard
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							if ID != 1 {
+								return chronograf.Source{}, chronograf.ErrSourceNotFound
+							}
+							return chronograf.Source{ID: 1, Telegraf: "telegraf"}, nil
+						},
+					},
+					OrganizationsStore: &mocks.OrganizationsStore{
+						DefaultOrganizationF: func(ctx context.Context) (*chronograf.Organization, error) {
+							return &chronograf.Organization{ID: "default"}, nil
+						},
+					},
+					DashboardsStore: &mocks.DashboardsStore{
+						AddF: func(ctx context.Context, d chronograf.Dashboard) (chronograf.Dashboard, error) {
+							d.ID = 1
+							added = &d
+							return d, nil
+						},
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/chronograf/v1/dashboards/"+tt.id+"/grafana"+tt.query, bytes.NewBufferString(tt.body))
+			r = r.WithContext(httprouter.WithParams(
+				r.Context(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: tt.id,
+					},
+				}))
+
+			s.ImportGrafanaDashboard(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Service.ImportGrafanaDashboard() status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				if added != nil {
+					t.Errorf("Service.ImportGrafanaDashboard() stored %v", added)
+				}
+				return
+			}
+
+			if added == nil || added.Name != "Hosts" || added.Organization != "default" || len(added.Cells) != 1 || len(added.Templates) != 1 {
+				t.Fatalf("Service.ImportGrafanaDashboard() stored %#v", added)
+			}
+			q := added.Cells[0].Queries[0]
+			want := `SELECT mean("usage_idle") FROM "telegraf".."cpu" WHERE "host" = ':host:' AND time > :dashboardTime: AND time < :upperDashboardTime: GROUP BY time(:interval:)`
+			if q.Command != want || q.Source != "/chronograf/v1/sources/1" {
+				t.Errorf("Service.ImportGrafanaDashboard() query = %#v, want %s", q, want)
+			}
+			if tpl := added.Templates[0]; tpl.ID == "" || tpl.Type != "tagValues" || tpl.Query.DB != "telegraf" {
+				t.Errorf("Service.ImportGrafanaDashboard() template = %#v", tpl)
+			}
+			if loc := w.Header().Get("Location"); loc != "/chronograf/v1/dashboards/1" {
+				t.Errorf("Service.ImportGrafanaDashboard() location = %s", loc)
+			}
+
+			var res struct {
+				Dashboard struct {
+					ID int `json:"id"`
+				} `json:"dashboard"`
+				Report struct {
+					Unconverted []map[string]string `json:"unconverted"`
+				} `json:"report"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("unable to decode response: %v", err)
+			}
+			if res.Dashboard.ID != 1 || len(res.Report.Unconverted) != 1 || res.Report.Unconverted[0]["panel"] != "Latency" {
+				t.Errorf("Service.ImportGrafanaDashboard() = %#v, want the dashboard and the heatmap as unconverted", res)
+			}
+		})
+	}
+}
@@ -53,7 +53,7 @@ func (s *Service) Influx(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
@@ -109,6 +109,11 @@ func (s *Service) Write(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if src.Type == chronograf.Prometheus {
+		Error(w, http.StatusBadRequest, "Writing points is not supported by Prometheus sources", s.Logger)
+		return
+	}
+
 	u, err := url.Parse(src.URL)
 	if err != nil {
 		msg := fmt.Sprintf("Error parsing source url: %v", err)
@@ -118,13 +123,21 @@ func (s *Service) Write(w http.ResponseWriter, r *http.Request) {
 	u.Path = "/write"
 	u.RawQuery = r.URL.RawQuery
 
+	// Writes of users without an identity on sources authenticating each
+	// user are rejected rather than proxied unauthenticated
+	auth := influx.DefaultAuthorization(ctx, &src)
+	if err := auth.Set(&http.Request{Header: http.Header{}}); err != nil {
+		msg := fmt.Sprintf("Unable to authorize write to source %d: %v", id, err)
+		Error(w, http.StatusForbidden, msg, s.Logger)
+		return
+	}
+
 	director := func(req *http.Request) {
 		// Set the Host header of the original source URL
 		req.Host = u.Host
 		req.URL = u
 		// Because we are acting as a proxy, influxdb needs to have the
 		// basic auth or bearer token information set as a header directly
-		auth := influx.DefaultAuthorization(&src)
 		auth.Set(req)
 	}
 
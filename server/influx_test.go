@@ -116,3 +116,47 @@ func TestService_Influx(t *testing.T) {
 
 	}
 }
+
+func TestService_Write_RequiresUserIdentity(t *testing.T) {
+	writes := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		writes++
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{
+						ID:       1,
+						URL:      ts.URL,
+						UserAuth: chronograf.UserAuthCredentials,
+					}, nil
+				},
+			},
+		},
+		Logger: &mocks.TestLogger{},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "http://any.url?db=telegraf", bytes.NewReader([]byte("cpu value=1")))
+	r = r.WithContext(httprouter.WithParams(
+		context.Background(),
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "1",
+			},
+		},
+	))
+	s.Write(w, r)
+
+	if got := w.Result().StatusCode; got != http.StatusForbidden {
+		t.Errorf("Write() status = %d, want %d", got, http.StatusForbidden)
+	}
+	if writes != 0 {
+		t.Errorf("Write() proxied %d unauthenticated writes", writes)
+	}
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+// maxIngestErrors is the most line errors reported by an ingest
+const maxIngestErrors = 100
+
+// maxIngestSize is the largest upload in bytes, both as sent and once
+// decompressed
+const maxIngestSize = 256 * 1024 * 1024
+
+// lineWriter is implemented by the clients of sources accepting line protocol
+type lineWriter interface {
+	WriteLines(ctx context.Context, db, rp, precision string, lines []string) (int, error)
+}
+
+type ingestResponse struct {
+	Accepted int                `json:"accepted"`          // Accepted is the number of lines written to the source
+	Rejected int                `json:"rejected"`          // Rejected is the number of invalid lines
+	Errors   []influx.LineError `json:"errors"`            // Errors of the first invalid lines
+	Message  string             `json:"message,omitempty"` // Message explains why valid lines were not written
+}
+
+// csvMapping parses the mapping of CSV columns from the query parameters.
+// Fields are listed as column or column:type.
+func csvMapping(r *http.Request) influx.CSVMapping {
+	params := r.URL.Query()
+	m := influx.CSVMapping{
+		Measurement:       params.Get("measurement"),
+		MeasurementColumn: params.Get("measurementColumn"),
+		Tags:              []string{},
+		Fields:            map[string]string{},
+		Time:              params.Get("time"),
+		TimeFormat:        params.Get("timeFormat"),
+	}
+	for _, tag := range strings.Split(params.Get("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
+	for _, field := range strings.Split(params.Get("fields"), ",") {
+		parts := strings.SplitN(strings.TrimSpace(field), ":", 2)
+		if parts[0] == "" {
+			continue
+		}
+		typ := ""
+		if len(parts) == 2 {
+			typ = parts[1]
+		}
+		m.Fields[parts[0]] = typ
+	}
+	return m
+}
+
+// ingestFormat is csv when requested by the format parameter or the content
+// type of the upload and lp for line protocol otherwise
+func ingestFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		return "csv"
+	}
+	return "lp"
+}
+
+// Ingest validates every line of an upload of line protocol or CSV and
+// writes them to a database of the source in gzipped batches. Nothing is
+// written if any line is invalid unless skipInvalid is set.
+func (s *Service) Ingest(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	params := r.URL.Query()
+	db, rp, precision := params.Get("db"), params.Get("rp"), params.Get("precision")
+	if db == "" {
+		invalidData(w, fmt.Errorf("db query parameter required"), s.Logger)
+		return
+	}
+	if err := influx.ValidPrecision(precision); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Store.Sources(ctx).Get(ctx, id)
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+
+	if src.Type == chronograf.Prometheus {
+		Error(w, http.StatusBadRequest, "Writing points is not supported by Prometheus sources", s.Logger)
+		return
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxIngestSize)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			invalidData(w, fmt.Errorf("invalid gzip upload: %v", err), s.Logger)
+			return
+		}
+		defer gz.Close()
+		body = http.MaxBytesReader(w, gz, maxIngestSize)
+	}
+
+	var lines []string
+	var errs []influx.LineError
+	switch format := ingestFormat(r); format {
+	case "lp":
+		lines, errs, err = influx.ParseLineProtocol(body)
+	case "csv":
+		lines, errs, err = influx.ParseCSV(body, csvMapping(r), precision)
+	default:
+		err = fmt.Errorf("invalid format %s; must be lp or csv", format)
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		msg := fmt.Sprintf("Upload is larger than %d bytes", tooLarge.Limit)
+		Error(w, http.StatusRequestEntityTooLarge, msg, s.Logger)
+		return
+	}
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	res := ingestResponse{
+		Rejected: len(errs),
+		Errors:   errs,
+	}
+	if len(res.Errors) > maxIngestErrors {
+		res.Errors = res.Errors[:maxIngestErrors]
+	}
+	if len(errs) > 0 && params.Get("skipInvalid") != "true" {
+		res.Message = "No lines were written because some are invalid; set skipInvalid to write the valid lines"
+		encodeJSON(w, http.StatusBadRequest, res, s.Logger)
+		return
+	}
+
+	ts, err := s.TimeSeries(ctx, src)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+	cl, ok := ts.(lineWriter)
+	if !ok {
+		msg := fmt.Sprintf("Writing points is not supported by %s sources", src.Type)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	res.Accepted, err = cl.WriteLines(ctx, db, rp, precision, lines)
+	if err != nil {
+		res.Message = fmt.Sprintf("Unable to write to source %d after %d lines: %v", id, res.Accepted, err)
+		encodeJSON(w, http.StatusBadRequest, res, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
@@ -0,0 +1,135 @@
+package server_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/server"
+)
+
+func TestService_Ingest(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		contentType  string
+		body         string
+		wantStatus   int
+		wantAccepted int
+		wantRejected int
+		wantWritten  string
+	}{
+		{
+			name:         "line protocol is written",
+			query:        "?db=telegraf&precision=s",
+			body:         "cpu,host=a value=1 1535760000\ncpu,host=b value=2 1535760000\n",
+			wantStatus:   http.StatusOK,
+			wantAccepted: 2,
+			wantWritten:  "cpu,host=a value=1 1535760000\ncpu,host=b value=2 1535760000\n",
+		},
+		{
+			name:         "nothing is written with invalid lines",
+			query:        "?db=telegraf",
+			body:         "cpu,host=a value=1\ncpu,host=b value=\n",
+			wantStatus:   http.StatusBadRequest,
+			wantRejected: 1,
+		},
+		{
+			name:         "valid lines are written when skipping invalid lines",
+			query:        "?db=telegraf&skipInvalid=true",
+			body:         "cpu,host=a value=1\ncpu,host=b value=\n",
+			wantStatus:   http.StatusOK,
+			wantAccepted: 1,
+			wantRejected: 1,
+			wantWritten:  "cpu,host=a value=1\n",
+		},
+		{
+			name:         "CSV columns are mapped",
+			query:        "?db=telegraf&precision=s&measurement=cpu&tags=host&fields=value:integer&time=time",
+			contentType:  "text/csv",
+			body:         "time,host,value\n1535760000,a,1\n",
+			wantStatus:   http.StatusOK,
+			wantAccepted: 1,
+			wantWritten:  "cpu,host=a value=1i 1535760000\n",
+		},
+		{
+			name:       "database is required",
+			body:       "cpu value=1\n",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written string
+			ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				gz, err := gzip.NewReader(r.Body)
+				if err != nil {
+					t.Fatal("Unexpected error reading write: err:", err)
+				}
+				b, _ := ioutil.ReadAll(gz)
+				written += string(b)
+				rw.WriteHeader(http.StatusNoContent)
+			}))
+			defer ts.Close()
+
+			svc := &server.Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{ID: ID, URL: ts.URL}, nil
+						},
+					},
+				},
+				TimeSeriesClient: &server.InfluxClient{},
+				Logger:           &mocks.TestLogger{},
+			}
+
+			req := httptest.NewRequest("POST", "/chronograf/v1/sources/1/ingest"+tt.query, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			req = req.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{
+				{Key: "id", Value: "1"},
+			}))
+			w := httptest.NewRecorder()
+			svc.Ingest(w, req)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("Ingest() status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if written != tt.wantWritten {
+				t.Errorf("Ingest() wrote %q, want %q", written, tt.wantWritten)
+			}
+			if tt.wantStatus == http.StatusUnprocessableEntity {
+				return
+			}
+
+			var res struct {
+				Accepted int `json:"accepted"`
+				Rejected int `json:"rejected"`
+				Errors   []struct {
+					Line int `json:"line"`
+				} `json:"errors"`
+			}
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatal("Unexpected error decoding response: err:", err)
+			}
+			if res.Accepted != tt.wantAccepted || res.Rejected != tt.wantRejected || len(res.Errors) != tt.wantRejected {
+				t.Errorf("Ingest() = %s", body)
+			}
+			if tt.wantRejected > 0 && res.Errors[0].Line != 2 {
+				t.Errorf("Ingest() reported line %d, want 2", res.Errors[0].Line)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/chronograf"
+	kapa "github.com/influxdata/chronograf/kapacitor"
+)
+
+type taskResponse struct {
+	ID     string            `json:"id"`               // ID of the task within kapacitor
+	Href   string            `json:"href"`             // Href is the kapacitor relative URI of the task
+	Status string            `json:"status"`           // Status is enabled or disabled
+	DBRPs  []chronograf.DBRP `json:"dbrps"`            // DBRPs are the databases and retention policies the task reads
+	Reason string            `json:"reason,omitempty"` // Reason the task is orphaned
+}
+
+func newTaskResponse(task *kapa.Task) taskResponse {
+	dbrps := task.Rule.DBRPs
+	if dbrps == nil {
+		dbrps = []chronograf.DBRP{}
+	}
+	return taskResponse{
+		ID:     task.ID,
+		Href:   task.Href,
+		Status: task.Rule.Status,
+		DBRPs:  dbrps,
+	}
+}
+
+type kapacitorTasksResponse struct {
+	ID    int            `json:"id,string"` // ID of the kapacitor
+	Name  string         `json:"name"`      // Name of the kapacitor
+	Tasks []taskResponse `json:"tasks"`     // Tasks generated by Chronograf on the kapacitor
+}
+
+type taskCleanupResponse struct {
+	DryRun     bool                     `json:"dryRun"`          // DryRun is true when the tasks were listed but not deleted
+	Kapacitors []kapacitorTasksResponse `json:"kapacitors"`      // Kapacitors whose tasks are deleted
+	Error      string                   `json:"error,omitempty"` // Error stopping the cleanup after the tasks listed were deleted
+}
+
+// taskCleanupParams parses whether a deletion cascades to the tasks Chronograf
+// generated on the kapacitors and whether those tasks are only listed
+func taskCleanupParams(r *http.Request) (deleteTasks, dryRun bool) {
+	params := r.URL.Query()
+	return params.Get("deleteTasks") == "true", params.Get("dryRun") == "true"
+}
+
+// cleanupTasks deletes the tasks Chronograf generated on the kapacitors that
+// read the databases and retention policies of the source. Other sources may
+// share the kapacitors, so tasks reading anything missing from the source are
+// kept. The tasks are only listed on a dry run. Should a deletion fail, the
+// response lists the tasks deleted so far.
+func (s *Service) cleanupTasks(ctx context.Context, src *chronograf.Source, srvs []chronograf.Server, dryRun bool) (taskCleanupResponse, error) {
+	res := taskCleanupResponse{
+		DryRun:     dryRun,
+		Kapacitors: []kapacitorTasksResponse{},
+	}
+
+	dbsvc := s.databases(src)
+	if err := dbsvc.Connect(ctx, src); err != nil {
+		return res, fmt.Errorf("Unable to connect to source %d: %v", src.ID, err)
+	}
+	dbrps, err := newSourceDBRPs(ctx, src.ID, dbsvc)
+	if err != nil {
+		return res, err
+	}
+
+	for _, srv := range srvs {
+		c := kapa.NewClient(srv.URL, srv.Username, srv.Password, srv.InsecureSkipVerify)
+		tasks, err := c.ChronografTasks(ctx)
+		if err != nil {
+			return res, fmt.Errorf("Unable to list tasks of kapacitor %d: %v", srv.ID, err)
+		}
+
+		kt := kapacitorTasksResponse{
+			ID:    srv.ID,
+			Name:  srv.Name,
+			Tasks: []taskResponse{},
+		}
+		for _, task := range tasks {
+			if len(task.Rule.DBRPs) == 0 {
+				continue
+			}
+			reason, err := dbrps.missing(ctx, task)
+			if err != nil {
+				res.Kapacitors = append(res.Kapacitors, kt)
+				return res, err
+			}
+			if reason != "" {
+				continue
+			}
+			if !dryRun {
+				s.Logger.Debug("Deleting kapacitor task ", task.ID)
+				if err := c.Delete(ctx, task.Href); err != nil {
+					res.Kapacitors = append(res.Kapacitors, kt)
+					return res, fmt.Errorf("Unable to delete task %s of kapacitor %d: %v", task.ID, srv.ID, err)
+				}
+			}
+			kt.Tasks = append(kt.Tasks, newTaskResponse(task))
+		}
+		res.Kapacitors = append(res.Kapacitors, kt)
+	}
+	return res, nil
+}
+
+// taskCleanupError responds with the tasks deleted before the cleanup failed
+func taskCleanupError(w http.ResponseWriter, res taskCleanupResponse, err error, logger chronograf.Logger) {
+	res.Error = err.Error()
+	logger.
+		WithField("component", "server").
+		WithField("http_status ", http.StatusInternalServerError).
+		Error("Error message ", res.Error)
+	encodeJSON(w, http.StatusInternalServerError, res, logger)
+}
+
+// sourceDBRPs tells whether the databases and retention policies read by
+// tasks exist on a source. The retention policies of each database are
+// only listed once.
+type sourceDBRPs struct {
+	srcID int
+	dbsvc chronograf.Databases
+	rps   map[string]map[string]bool
+}
+
+func newSourceDBRPs(ctx context.Context, srcID int, dbsvc chronograf.Databases) (*sourceDBRPs, error) {
+	dbs, err := dbsvc.AllDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rps := map[string]map[string]bool{}
+	for _, db := range dbs {
+		rps[db.Name] = nil
+	}
+	return &sourceDBRPs{
+		srcID: srcID,
+		dbsvc: dbsvc,
+		rps:   rps,
+	}, nil
+}
+
+// missing returns why the task reads a database or retention policy missing
+// from the source, or an empty reason if the source has all of them
+func (s *sourceDBRPs) missing(ctx context.Context, task *kapa.Task) (string, error) {
+	for _, dbrp := range task.Rule.DBRPs {
+		known, ok := s.rps[dbrp.DB]
+		if !ok {
+			return fmt.Sprintf("database %s does not exist on source %d", dbrp.DB, s.srcID), nil
+		}
+		if dbrp.RP == "" {
+			continue
+		}
+		if known == nil {
+			all, err := s.dbsvc.AllRP(ctx, dbrp.DB)
+			if err != nil {
+				return "", err
+			}
+			known = map[string]bool{}
+			for _, rp := range all {
+				known[rp.Name] = true
+			}
+			s.rps[dbrp.DB] = known
+		}
+		if !known[dbrp.RP] {
+			return fmt.Sprintf("retention policy %s.%s does not exist on source %d", dbrp.DB, dbrp.RP, s.srcID), nil
+		}
+	}
+	return "", nil
+}
+
+// sourceKapacitors returns the kapacitors of a source
+func (s *Service) sourceKapacitors(ctx context.Context, srcID int) ([]chronograf.Server, error) {
+	srvs, err := s.Store.Servers(ctx).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kapas := []chronograf.Server{}
+	for _, srv := range srvs {
+		if srv.SrcID == srcID && srv.Type == "" {
+			kapas = append(kapas, srv)
+		}
+	}
+	return kapas, nil
+}
+
+type orphanTasksResponse struct {
+	Tasks []taskResponse `json:"tasks"` // Tasks reading databases or retention policies missing from the source
+	Links selfLinks      `json:"links"`
+}
+
+// KapacitorOrphans reports the tasks Chronograf generated on a kapacitor
+// that read databases or retention policies missing from its source, such as
+// those left behind by a deleted source that used the same kapacitor.
+func (s *Service) KapacitorOrphans(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("kid", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srv, err := s.Store.Servers(ctx).Get(ctx, id)
+	if err != nil || srv.SrcID != srcID || srv.Type != "" {
+		notFound(w, id, s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	c := kapa.NewClient(srv.URL, srv.Username, srv.Password, srv.InsecureSkipVerify)
+	tasks, err := c.ChronografTasks(ctx)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err.Error(), s.Logger)
+		return
+	}
+
+	dbrps, err := newSourceDBRPs(ctx, srcID, dbsvc)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+		return
+	}
+
+	res := orphanTasksResponse{
+		Tasks: []taskResponse{},
+		Links: selfLinks{
+			Self: fmt.Sprintf("/chronograf/v1/sources/%d/kapacitors/%d/orphans", srcID, id),
+		},
+	}
+	for _, task := range tasks {
+		reason, err := dbrps.missing(ctx, task)
+		if err != nil {
+			Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+			return
+		}
+		if reason == "" {
+			continue
+		}
+		tr := newTaskResponse(task)
+		tr.Reason = reason
+		res.Tasks = append(res.Tasks, tr)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
@@ -0,0 +1,249 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/server"
+)
+
+// newKapacitorTasks serves the tasks of a kapacitor, keyed by the database
+// they read, and records their deletion. Deleting the failing task fails.
+func newKapacitorTasks(t *testing.T, dbs map[string]string, failing string) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	deleted := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/kapacitor/v1/tasks":
+			tasks := []map[string]interface{}{}
+			if r.URL.Query().Get("offset") == "0" {
+				for id, db := range dbs {
+					tasks = append(tasks, map[string]interface{}{
+						"id":     id,
+						"status": "enabled",
+						"type":   "stream",
+						"dbrps":  []map[string]string{{"db": db, "rp": "autogen"}},
+						"link":   map[string]string{"rel": "self", "href": "/kapacitor/v1/tasks/" + id},
+					})
+				}
+			}
+			json.NewEncoder(rw).Encode(map[string]interface{}{"tasks": tasks})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/kapacitor/v1/tasks/"):
+			id := strings.TrimPrefix(r.URL.Path, "/kapacitor/v1/tasks/")
+			if id == failing {
+				rw.WriteHeader(http.StatusInternalServerError)
+				rw.Write([]byte(`{"error":"unable to delete task"}`))
+				return
+			}
+			mu.Lock()
+			deleted = append(deleted, id)
+			mu.Unlock()
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request to kapacitor %s %s", r.Method, r.URL)
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), deleted...)
+	}
+}
+
+func TestService_RemoveKapacitorTasks(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		failing     string
+		wantStatus  int
+		wantDeleted []string
+		wantListed  []string
+		wantStored  bool
+	}{
+		{
+			name:        "tasks are kept by default",
+			wantStatus:  http.StatusNoContent,
+			wantDeleted: []string{},
+		},
+		{
+			name:        "dry run lists the tasks generated by Chronograf",
+			query:       "?dryRun=true",
+			wantStatus:  http.StatusOK,
+			wantDeleted: []string{},
+			wantListed:  []string{"chronograf-v1-a", "chronograf-v1-b"},
+			wantStored:  true,
+		},
+		{
+			name:        "tasks generated by Chronograf for the source are deleted",
+			query:       "?deleteTasks=true",
+			wantStatus:  http.StatusOK,
+			wantDeleted: []string{"chronograf-v1-a", "chronograf-v1-b"},
+			wantListed:  []string{"chronograf-v1-a", "chronograf-v1-b"},
+		},
+		{
+			name:        "a failed deletion reports the tasks already deleted",
+			query:       "?deleteTasks=true",
+			failing:     "chronograf-v1-b",
+			wantStatus:  http.StatusInternalServerError,
+			wantDeleted: []string{"chronograf-v1-a"},
+			wantListed:  []string{"chronograf-v1-a"},
+			wantStored:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kapaSrv, deleted := newKapacitorTasks(t, map[string]string{
+				"chronograf-v1-b":     "telegraf",
+				"cpu_alert":           "telegraf",
+				"chronograf-v1-a":     "telegraf",
+				"chronograf-v1-other": "other",
+			}, tt.failing)
+			defer kapaSrv.Close()
+
+			stored := true
+			svc := &server.Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{ID: ID}, nil
+						},
+					},
+					ServersStore: &mocks.ServersStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Server, error) {
+							return chronograf.Server{ID: ID, SrcID: 1, Name: "kapa", URL: kapaSrv.URL}, nil
+						},
+						DeleteF: func(ctx context.Context, srv chronograf.Server) error {
+							stored = false
+							return nil
+						},
+					},
+				},
+				DatabasesClient: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					AllDBF: func(context.Context) ([]chronograf.Database, error) {
+						return []chronograf.Database{{Name: "telegraf"}}, nil
+					},
+					AllRPF: func(context.Context, string) ([]chronograf.RetentionPolicy, error) {
+						return []chronograf.RetentionPolicy{{Name: "autogen"}}, nil
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+
+			req := httptest.NewRequest("DELETE", "/chronograf/v1/sources/1/kapacitors/2"+tt.query, nil)
+			req = req.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{
+				{Key: "id", Value: "1"},
+				{Key: "kid", Value: "2"},
+			}))
+			w := httptest.NewRecorder()
+			svc.RemoveKapacitor(w, req)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("RemoveKapacitor() status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if got := deleted(); strings.Join(got, ",") != strings.Join(tt.wantDeleted, ",") {
+				t.Errorf("RemoveKapacitor() deleted tasks %v, want %v", got, tt.wantDeleted)
+			}
+			if stored != tt.wantStored {
+				t.Errorf("RemoveKapacitor() kapacitor stored = %v, want %v", stored, tt.wantStored)
+			}
+			if tt.wantStatus == http.StatusNoContent {
+				return
+			}
+
+			var res struct {
+				DryRun     bool   `json:"dryRun"`
+				Error      string `json:"error"`
+				Kapacitors []struct {
+					ID    string `json:"id"`
+					Tasks []struct {
+						ID string `json:"id"`
+					} `json:"tasks"`
+				} `json:"kapacitors"`
+			}
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatal("Unexpected error decoding response: err:", err)
+			}
+			if res.DryRun != (tt.query == "?dryRun=true") || len(res.Kapacitors) != 1 || res.Kapacitors[0].ID != "2" {
+				t.Fatalf("RemoveKapacitor() unexpected response %s", body)
+			}
+			listed := []string{}
+			for _, task := range res.Kapacitors[0].Tasks {
+				listed = append(listed, task.ID)
+			}
+			if strings.Join(listed, ",") != strings.Join(tt.wantListed, ",") {
+				t.Errorf("RemoveKapacitor() listed tasks %v, want %v", listed, tt.wantListed)
+			}
+			if failed := res.Error != ""; failed != (tt.wantStatus == http.StatusInternalServerError) {
+				t.Errorf("RemoveKapacitor() error %q", res.Error)
+			}
+		})
+	}
+}
+
+func TestService_KapacitorOrphans(t *testing.T) {
+	kapaSrv, _ := newKapacitorTasks(t, map[string]string{
+		"chronograf-v1-a": "telegraf",
+		"cpu_alert":       "telegraf",
+	}, "")
+	defer kapaSrv.Close()
+
+	svc := &server.Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{ID: ID}, nil
+				},
+			},
+			ServersStore: &mocks.ServersStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Server, error) {
+					return chronograf.Server{ID: ID, SrcID: 1, URL: kapaSrv.URL}, nil
+				},
+			},
+		},
+		DatabasesClient: &mocks.Databases{
+			ConnectF: func(context.Context, *chronograf.Source) error {
+				return nil
+			},
+			AllDBF: func(context.Context) ([]chronograf.Database, error) {
+				return []chronograf.Database{{Name: "telegraf"}}, nil
+			},
+			AllRPF: func(context.Context, string) ([]chronograf.RetentionPolicy, error) {
+				return []chronograf.RetentionPolicy{{Name: "weekly"}}, nil
+			},
+		},
+		Logger: &mocks.TestLogger{},
+	}
+
+	req := httptest.NewRequest("GET", "/chronograf/v1/sources/1/kapacitors/2/orphans", nil)
+	req = req.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{
+		{Key: "id", Value: "1"},
+		{Key: "kid", Value: "2"},
+	}))
+	w := httptest.NewRecorder()
+	svc.KapacitorOrphans(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("KapacitorOrphans() status = %d: %s", resp.StatusCode, body)
+	}
+	want := `{"tasks":[{"id":"chronograf-v1-a","href":"/kapacitor/v1/tasks/chronograf-v1-a","status":"enabled","dbrps":[{"db":"telegraf","rp":"autogen"}],"reason":"retention policy telegraf.autogen does not exist on source 1"}],"links":{"self":"/chronograf/v1/sources/1/kapacitors/2/orphans"}}` + "\n"
+	if string(body) != want {
+		t.Errorf("KapacitorOrphans() =\n%s\nwant\n%s", body, want)
+	}
+}
@@ -191,7 +191,9 @@ func (s *Service) KapacitorsID(w http.ResponseWriter, r *http.Request) {
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
 
-// RemoveKapacitor deletes kapacitor from store.
+// RemoveKapacitor deletes kapacitor from store. With deleteTasks=true the
+// tasks Chronograf generated on the kapacitor for the source are deleted as
+// well, while dryRun=true only lists them.
 func (s *Service) RemoveKapacitor(w http.ResponseWriter, r *http.Request) {
 	id, err := paramID("kid", r)
 	if err != nil {
@@ -212,11 +214,34 @@ func (s *Service) RemoveKapacitor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var cleanup *taskCleanupResponse
+	if deleteTasks, dryRun := taskCleanupParams(r); deleteTasks || dryRun {
+		src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+		if err != nil {
+			notFound(w, srcID, s.Logger)
+			return
+		}
+		res, err := s.cleanupTasks(ctx, &src, []chronograf.Server{srv}, dryRun)
+		if err != nil {
+			taskCleanupError(w, res, err, s.Logger)
+			return
+		}
+		if dryRun {
+			encodeJSON(w, http.StatusOK, res, s.Logger)
+			return
+		}
+		cleanup = &res
+	}
+
 	if err = s.Store.Servers(ctx).Delete(ctx, srv); err != nil {
 		unknownErrorWithMessage(w, err, s.Logger)
 		return
 	}
 
+	if cleanup != nil {
+		encodeJSON(w, http.StatusOK, cleanup, s.Logger)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
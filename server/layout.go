@@ -1,11 +1,13 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/bouk/httprouter"
 	"github.com/influxdata/chronograf"
+	idgen "github.com/influxdata/chronograf/id"
 )
 
 type link struct {
@@ -117,3 +119,122 @@ func (s *Service) LayoutsID(w http.ResponseWriter, r *http.Request) {
 	res := newLayoutResponse(layout)
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
+
+// ValidLayoutRequest checks if the layout has valid application, measurement
+// and cells. Cells without an ID are given one.
+func ValidLayoutRequest(l *chronograf.Layout) error {
+	if l.Application == "" || l.Measurement == "" || len(l.Cells) == 0 {
+		return fmt.Errorf("app, measurement, and cells required")
+	}
+
+	ids := &idgen.UUID{}
+	for i, c := range l.Cells {
+		if c.W <= 0 || c.H <= 0 {
+			return fmt.Errorf("w, and h required")
+		}
+		if len(c.Queries) == 0 {
+			return fmt.Errorf("cell %q requires queries", c.Name)
+		}
+		for _, q := range c.Queries {
+			if q.Command == "" {
+				return fmt.Errorf("query required")
+			}
+		}
+		cell := &chronograf.DashboardCell{Axes: c.Axes, CellColors: c.CellColors}
+		if err := HasCorrectAxes(cell); err != nil {
+			return err
+		}
+		if err := HasCorrectColors(cell); err != nil {
+			return err
+		}
+		if c.I == "" {
+			id, err := ids.Generate()
+			if err != nil {
+				return err
+			}
+			l.Cells[i].I = id
+		}
+	}
+	return nil
+}
+
+// NewLayout adds a custom layout
+func (s *Service) NewLayout(w http.ResponseWriter, r *http.Request) {
+	var layout chronograf.Layout
+	if err := json.NewDecoder(r.Body).Decode(&layout); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	if err := ValidLayoutRequest(&layout); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	layout, err := s.Store.Layouts(ctx).Add(ctx, layout)
+	if err != nil {
+		msg := fmt.Errorf("Error storing layout %v: %v", layout, err)
+		unknownErrorWithMessage(w, msg, s.Logger)
+		return
+	}
+
+	res := newLayoutResponse(layout)
+	location(w, res.Link.Href)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateLayout replaces a custom layout. Canned layouts cannot be changed.
+func (s *Service) UpdateLayout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httprouter.GetParamFromContext(ctx, "id")
+
+	var req chronograf.Layout
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	req.ID = id
+
+	if err := ValidLayoutRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	err := s.Store.Layouts(ctx).Update(ctx, req)
+	if !s.layoutChanged(w, id, err) {
+		return
+	}
+
+	res := newLayoutResponse(req)
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// RemoveLayout deletes a custom layout. Canned layouts cannot be removed.
+func (s *Service) RemoveLayout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httprouter.GetParamFromContext(ctx, "id")
+
+	err := s.Store.Layouts(ctx).Delete(ctx, chronograf.Layout{ID: id})
+	if !s.layoutChanged(w, id, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// layoutChanged writes the error response of a failed change of a layout
+func (s *Service) layoutChanged(w http.ResponseWriter, id string, err error) bool {
+	switch err {
+	case nil:
+		return true
+	case chronograf.ErrLayoutNotFound:
+		Error(w, http.StatusNotFound, fmt.Sprintf("ID %s not found", id), s.Logger)
+	case chronograf.ErrLayoutReadOnly:
+		Error(w, http.StatusForbidden, err.Error(), s.Logger)
+	default:
+		msg := fmt.Sprintf("Error changing layout ID %s: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+	}
+	return false
+}
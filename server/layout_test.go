@@ -184,3 +184,71 @@ func Test_Layouts(t *testing.T) {
 		})
 	}
 }
+
+func TestValidLayoutRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  chronograf.Layout
+		wantErr bool
+	}{
+		{
+			name: "Valid layout",
+			layout: chronograf.Layout{
+				Application: "redis",
+				Measurement: "redis",
+				Cells: []chronograf.Cell{
+					{
+						W:       4,
+						H:       4,
+						Queries: []chronograf.Query{{Command: `SELECT mean("clients") FROM "redis"`}},
+						Axes:    map[string]chronograf.Axis{"y": {Scale: "linear"}},
+					},
+				},
+			},
+		},
+		{
+			name: "Requires cells",
+			layout: chronograf.Layout{
+				Application: "redis",
+				Measurement: "redis",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Requires queries",
+			layout: chronograf.Layout{
+				Application: "redis",
+				Measurement: "redis",
+				Cells:       []chronograf.Cell{{W: 4, H: 4, Queries: []chronograf.Query{{}}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid axes",
+			layout: chronograf.Layout{
+				Application: "redis",
+				Measurement: "redis",
+				Cells: []chronograf.Cell{
+					{
+						W:       4,
+						H:       4,
+						Queries: []chronograf.Query{{Command: `SELECT mean("clients") FROM "redis"`}},
+						Axes:    map[string]chronograf.Axis{"z": {}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := server.ValidLayoutRequest(&tt.layout)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidLayoutRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.layout.Cells[0].I == "" {
+				t.Errorf("ValidLayoutRequest() did not set the cell ID")
+			}
+		})
+	}
+}
@@ -122,6 +122,14 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 			next,
 		)
 	}
+	EnsureShare := func(next http.HandlerFunc) http.HandlerFunc {
+		return AuthorizedShare(
+			service.Store,
+			service.ShareSecret,
+			opts.Logger,
+			next,
+		)
+	}
 
 	rawStoreAccess := func(next http.HandlerFunc) http.HandlerFunc {
 		return RawStoreAccess(opts.Logger, next)
@@ -170,9 +178,15 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.DELETE("/chronograf/v1/sources/:id", EnsureEditor(service.RemoveSource))
 	router.GET("/chronograf/v1/sources/:id/health", EnsureViewer(service.SourceHealth))
 
+	// Per-user InfluxDB credentials of the current user
+	router.GET("/chronograf/v1/sources/:id/credentials", EnsureViewer(service.SourceCredentials))
+	router.PUT("/chronograf/v1/sources/:id/credentials", EnsureViewer(service.UpdateSourceCredentials))
+	router.DELETE("/chronograf/v1/sources/:id/credentials", EnsureViewer(service.RemoveSourceCredentials))
+
 	// Flux
 	router.GET("/chronograf/v1/flux", EnsureViewer(service.Flux))
 	router.POST("/chronograf/v1/flux/ast", EnsureViewer(service.FluxAST))
+	router.POST("/chronograf/v1/flux/translate", EnsureViewer(service.FluxTranslate))
 	router.GET("/chronograf/v1/flux/suggestions", EnsureViewer(service.FluxSuggestions))
 	router.GET("/chronograf/v1/flux/suggestions/:name", EnsureViewer(service.FluxSuggestion))
 
@@ -187,6 +201,12 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	// Write proxies line protocol write requests to InfluxDB
 	router.POST("/chronograf/v1/sources/:id/write", EnsureViewer(service.Write))
 
+	// Ingest validates uploads of line protocol or CSV before writing them to InfluxDB
+	router.POST("/chronograf/v1/sources/:id/ingest", EnsureEditor(service.Ingest))
+
+	// Protoboards and layouts ranked by the measurements of the source
+	router.GET("/chronograf/v1/sources/:id/protoboards/suggestions", EnsureViewer(service.ProtoboardSuggestions))
+
 	// Queries is used to analyze a specific queries and does not create any
 	// resources. It's a POST because Queries are POSTed to InfluxDB, but this
 	// only modifies InfluxDB resources with certain metaqueries, e.g. DROP DATABASE.
@@ -195,6 +215,21 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	// intended for Chronograf Users with the Viewer Role type.
 	router.POST("/chronograf/v1/sources/:id/queries", EnsureViewer(service.Queries))
 
+	// BuildQueries is the reverse of Queries; it generates InfluxQL from
+	// queryConfigs and does not send anything to the source.
+	router.POST("/chronograf/v1/sources/:id/queries/build", EnsureViewer(service.BuildQueries))
+
+	// Running queries can be inspected and killed by admins, e.g. when a
+	// dashboard sends a runaway query.
+	router.GET("/chronograf/v1/sources/:id/queries/running", EnsureAdmin(service.RunningQueries))
+	router.DELETE("/chronograf/v1/sources/:id/queries/running/:qid", EnsureAdmin(service.KillRunningQuery))
+
+	// Cluster overview of InfluxDB Enterprise sources
+	router.GET("/chronograf/v1/sources/:id/cluster", EnsureAdmin(service.ClusterOverview))
+
+	// Health of every source and server as checked in the background
+	router.GET("/chronograf/v1/status", EnsureViewer(service.Status))
+
 	// Annotations are user-defined events associated with this source
 	router.GET("/chronograf/v1/sources/:id/annotations", EnsureViewer(service.Annotations))
 	router.POST("/chronograf/v1/sources/:id/annotations", EnsureEditor(service.NewAnnotation))
@@ -251,6 +286,8 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.PATCH("/chronograf/v1/sources/:id/kapacitors/:kid/rules/:tid", EnsureEditor(service.KapacitorRulesStatus))
 	router.DELETE("/chronograf/v1/sources/:id/kapacitors/:kid/rules/:tid", EnsureEditor(service.KapacitorRulesDelete))
 
+	router.GET("/chronograf/v1/sources/:id/kapacitors/:kid/orphans", EnsureViewer(service.KapacitorOrphans))
+
 	// Kapacitor Proxy
 	router.GET("/chronograf/v1/sources/:id/kapacitors/:kid/proxy", EnsureViewer(service.ProxyGet))
 	router.POST("/chronograf/v1/sources/:id/kapacitors/:kid/proxy", EnsureEditor(service.ProxyPost))
@@ -259,11 +296,18 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 
 	// Layouts
 	router.GET("/chronograf/v1/layouts", EnsureViewer(service.Layouts))
+	router.POST("/chronograf/v1/layouts", EnsureEditor(service.NewLayout))
 	router.GET("/chronograf/v1/layouts/:id", EnsureViewer(service.LayoutsID))
+	router.PUT("/chronograf/v1/layouts/:id", EnsureEditor(service.UpdateLayout))
+	router.DELETE("/chronograf/v1/layouts/:id", EnsureEditor(service.RemoveLayout))
 
 	// Protoboards
 	router.GET("/chronograf/v1/protoboards", EnsureViewer(service.Protoboards))
+	router.POST("/chronograf/v1/protoboards", EnsureEditor(service.NewProtoboard))
 	router.GET("/chronograf/v1/protoboards/:id", EnsureViewer(service.ProtoboardsID))
+	router.PUT("/chronograf/v1/protoboards/:id", EnsureEditor(service.UpdateProtoboard))
+	router.DELETE("/chronograf/v1/protoboards/:id", EnsureEditor(service.RemoveProtoboard))
+	router.POST("/chronograf/v1/protoboards/:id/dashboards", EnsureEditor(service.NewProtoboardDashboard))
 
 	// Users associated with Chronograf
 	router.GET("/chronograf/v1/me", service.Me)
@@ -301,6 +345,28 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.GET("/chronograf/v1/dashboards/:id/cells/:cid", EnsureViewer(service.DashboardCellID))
 	router.DELETE("/chronograf/v1/dashboards/:id/cells/:cid", EnsureEditor(service.RemoveDashboardCell))
 	router.PUT("/chronograf/v1/dashboards/:id/cells/:cid", EnsureEditor(service.ReplaceDashboardCell))
+	router.POST("/chronograf/v1/dashboards/:id/cells/:cid/render", EnsureViewer(service.RenderDashboardCell))
+
+	// TranslateDashboard rewrites the influxql queries of every cell as Flux
+	router.POST("/chronograf/v1/dashboards/:id/flux", EnsureEditor(service.TranslateDashboard))
+	// NewDashboardProtoboard saves the dashboard as a protoboard of the organization
+	router.POST("/chronograf/v1/dashboards/:id/protoboard", EnsureEditor(service.NewDashboardProtoboard))
+	// ImportGrafanaDashboard creates a dashboard from a Grafana dashboard. The
+	// router does not allow /dashboards/import next to /dashboards/:id, so the
+	// route is /dashboards/import/grafana through the id of the dashboard routes.
+	router.POST("/chronograf/v1/dashboards/:id/grafana", EnsureEditor(service.ImportGrafanaDashboard))
+	// Dashboard Permissions restrict who may view and edit a dashboard and lock it
+	router.GET("/chronograf/v1/dashboards/:id/permissions", EnsureViewer(service.DashboardPermissions))
+	router.PUT("/chronograf/v1/dashboards/:id/permissions", EnsureEditor(service.ReplaceDashboardPermissions))
+	// Dashboard Shares grant read-only access to a dashboard with signed tokens
+	router.GET("/chronograf/v1/dashboards/:id/shares", EnsureEditor(service.DashboardShares))
+	router.POST("/chronograf/v1/dashboards/:id/shares", EnsureEditor(service.NewDashboardShare))
+	router.DELETE("/chronograf/v1/dashboards/:id/shares/:sid", EnsureEditor(service.RemoveDashboardShare))
+	// Shared dashboards are read without a Chronograf user; only the dashboard
+	// of the share and the queries of its cells can be read
+	router.GET("/chronograf/v1/shared/:token", EnsureShare(service.SharedDashboard))
+	router.POST("/chronograf/v1/shared/:token/cells/:cid/query", EnsureShare(service.SharedCellQuery))
+
 	// Dashboard Templates
 	router.GET("/chronograf/v1/dashboards/:id/templates", EnsureViewer(service.Templates))
 	router.POST("/chronograf/v1/dashboards/:id/templates", EnsureEditor(service.NewTemplate))
@@ -325,6 +391,26 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	// Measurements
 	router.GET("/chronograf/v1/sources/:id/dbs/:db/measurements", EnsureViewer(service.Measurements))
 
+	// Continuous Queries
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/cqs", EnsureViewer(service.ContinuousQueries))
+	router.POST("/chronograf/v1/sources/:id/dbs/:db/cqs", EnsureEditor(service.NewContinuousQuery))
+
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/cqs/:cq", EnsureViewer(service.ContinuousQueryID))
+	router.PUT("/chronograf/v1/sources/:id/dbs/:db/cqs/:cq", EnsureEditor(service.UpdateContinuousQuery))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/cqs/:cq", EnsureEditor(service.DropContinuousQuery))
+
+	// Shards
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/shards", EnsureViewer(service.Shards))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/shards/:sid", EnsureEditor(service.DropShard))
+
+	// Subscriptions
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/subscriptions", EnsureViewer(service.Subscriptions))
+	router.POST("/chronograf/v1/sources/:id/dbs/:db/subscriptions", EnsureEditor(service.NewSubscription))
+
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/subscriptions/:rp/:name", EnsureViewer(service.SubscriptionID))
+	router.PUT("/chronograf/v1/sources/:id/dbs/:db/subscriptions/:rp/:name", EnsureEditor(service.UpdateSubscription))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/subscriptions/:rp/:name", EnsureEditor(service.DropSubscription))
+
 	// Global application config for Chronograf
 	router.GET("/chronograf/v1/config", EnsureSuperAdmin(service.Config))
 	router.GET("/chronograf/v1/config/auth", EnsureSuperAdmin(service.AuthConfig))
@@ -348,6 +434,7 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	// V2 Dashboards
 	router.GET("/chronograf/v2/dashboards", EnsureViewer(service.DashboardsV2))
 	router.POST("/chronograf/v2/dashboards", EnsureEditor(service.NewDashboardV2))
+	router.POST("/chronograf/v2/dashboards/migrate", EnsureAdmin(service.MigrateDashboardsV2))
 
 	router.GET("/chronograf/v2/dashboards/:id", EnsureViewer(service.DashboardIDV2))
 	router.DELETE("/chronograf/v2/dashboards/:id", EnsureEditor(service.RemoveDashboardV2))
@@ -415,11 +502,17 @@ func AuthAPI(opts MuxOpts, router chronograf.Router) (http.Handler, AuthRoutes)
 
 	rootPath := path.Join(opts.Basepath, "/chronograf/v1")
 	logoutPath := path.Join(opts.Basepath, "/oauth/logout")
+	// Shared dashboards are authorized by their share token instead
+	sharedPath := path.Join(opts.Basepath, "/chronograf/v1/shared") + "/"
 
 	tokenMiddleware := AuthorizedToken(opts.Auth, opts.Logger, router)
 	// Wrap the API with token validation middleware.
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cleanPath := path.Clean(r.URL.Path) // compare ignoring path garbage, trailing slashes, etc.
+		if strings.HasPrefix(cleanPath, sharedPath) {
+			router.ServeHTTP(w, r)
+			return
+		}
 		if (strings.HasPrefix(cleanPath, rootPath) && len(cleanPath) > len(rootPath)) || cleanPath == logoutPath {
 			tokenMiddleware.ServeHTTP(w, r)
 			return
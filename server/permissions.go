@@ -22,7 +22,7 @@ func (s *Service) Permissions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
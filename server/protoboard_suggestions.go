@@ -0,0 +1,168 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// suggestionMeasurementsPage is the number of measurements listed per query
+// while collecting the measurements of a source
+const suggestionMeasurementsPage = 1000
+
+type protoboardSuggestionLinks struct {
+	Self string `json:"self"` // Self link to the suggested protoboard or layouts
+}
+
+// protoboardSuggestion is a protoboard or an application of canned layouts
+// along with how much of the measurements it needs exist in the source
+type protoboardSuggestion struct {
+	Kind         string                    `json:"kind"` // Kind is either protoboard or layout
+	ID           string                    `json:"id"`   // ID of the protoboard or the application of the layouts
+	Name         string                    `json:"name"`
+	Measurements []string                  `json:"measurements"` // Measurements needed by the protoboard or layouts
+	Missing      []string                  `json:"missing"`      // Missing measurements that do not exist in the source
+	Coverage     float64                   `json:"coverage"`     // Coverage is the fraction of the measurements existing in the source
+	Links        protoboardSuggestionLinks `json:"links"`
+}
+
+type protoboardSuggestionsResponse struct {
+	Database    string                 `json:"db"`
+	Suggestions []protoboardSuggestion `json:"suggestions"`
+}
+
+func newProtoboardSuggestion(kind, id, name string, measurements []string, existing map[string]bool) protoboardSuggestion {
+	missing := []string{}
+	for _, m := range measurements {
+		if !existing[m] {
+			missing = append(missing, m)
+		}
+	}
+	coverage := 0.0
+	if len(measurements) > 0 {
+		coverage = float64(len(measurements)-len(missing)) / float64(len(measurements))
+	}
+
+	self := fmt.Sprintf("/chronograf/v1/protoboards/%s", id)
+	if kind == "layout" {
+		self = fmt.Sprintf("/chronograf/v1/layouts?app=%s", url.QueryEscape(id))
+	}
+	return protoboardSuggestion{
+		Kind:         kind,
+		ID:           id,
+		Name:         name,
+		Measurements: measurements,
+		Missing:      missing,
+		Coverage:     coverage,
+		Links: protoboardSuggestionLinks{
+			Self: self,
+		},
+	}
+}
+
+// ProtoboardSuggestions ranks the protoboards and canned layouts by how many
+// of the measurements they need exist in the telegraf database of the source.
+// Protoboards and layouts without any of their measurements are omitted.
+func (s *Service) ProtoboardSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := src.Telegraf
+	if db == "" {
+		db = "telegraf"
+	}
+	existing := map[string]bool{}
+	for offset := 0; ; offset += suggestionMeasurementsPage {
+		measurements, err := dbsvc.GetMeasurements(ctx, db, suggestionMeasurementsPage, offset)
+		if err != nil {
+			msg := fmt.Sprintf("Unable to get measurements %d: %v", srcID, err)
+			Error(w, http.StatusBadRequest, msg, s.Logger)
+			return
+		}
+		for _, m := range measurements {
+			existing[m.Name] = true
+		}
+		if len(measurements) < suggestionMeasurementsPage {
+			break
+		}
+	}
+
+	protoboards, err := s.Store.Protoboards(ctx).All(ctx)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "Error loading protoboards", s.Logger)
+		return
+	}
+	layouts, err := s.Store.Layouts(ctx).All(ctx)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "Error loading layouts", s.Logger)
+		return
+	}
+
+	candidates := []protoboardSuggestion{}
+	seen := map[string]bool{}
+	for _, pb := range protoboards {
+		if seen[pb.ID] {
+			continue
+		}
+		seen[pb.ID] = true
+		candidates = append(candidates, newProtoboardSuggestion("protoboard", pb.ID, pb.Meta.Name, pb.Meta.Measurements, existing))
+	}
+
+	// Canned layouts need one measurement each and are grouped by application
+	apps := []string{}
+	appMeasurements := map[string][]string{}
+	for _, layout := range layouts {
+		ms, ok := appMeasurements[layout.Application]
+		if !ok {
+			apps = append(apps, layout.Application)
+		}
+		found := false
+		for _, m := range ms {
+			found = found || m == layout.Measurement
+		}
+		if !found {
+			appMeasurements[layout.Application] = append(ms, layout.Measurement)
+		}
+	}
+	for _, app := range apps {
+		candidates = append(candidates, newProtoboardSuggestion("layout", app, app, appMeasurements[app], existing))
+	}
+
+	res := protoboardSuggestionsResponse{
+		Database:    db,
+		Suggestions: []protoboardSuggestion{},
+	}
+	for _, c := range candidates {
+		if c.Coverage > 0 {
+			res.Suggestions = append(res.Suggestions, c)
+		}
+	}
+	sort.SliceStable(res.Suggestions, func(i, j int) bool {
+		a, b := res.Suggestions[i], res.Suggestions[j]
+		if a.Coverage != b.Coverage {
+			return a.Coverage > b.Coverage
+		}
+		return len(a.Measurements)-len(a.Missing) > len(b.Measurements)-len(b.Missing)
+	})
+
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
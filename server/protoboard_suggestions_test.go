@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_ProtoboardSuggestions(t *testing.T) {
+	h := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+					return chronograf.Source{
+						ID:       1,
+						Telegraf: "metrics",
+					}, nil
+				},
+			},
+			ProtoboardsStore: &mocks.ProtoboardsStore{
+				AllF: func(ctx context.Context) ([]chronograf.Protoboard, error) {
+					return []chronograf.Protoboard{
+						{
+							ID:   "system",
+							Meta: chronograf.ProtoboardMeta{Name: "System", Measurements: []string{"cpu", "mem", "system"}},
+						},
+						{
+							ID:   "docker",
+							Meta: chronograf.ProtoboardMeta{Name: "Docker", Measurements: []string{"docker"}},
+						},
+						{
+							ID:   "redis",
+							Meta: chronograf.ProtoboardMeta{Name: "Redis", Measurements: []string{"redis"}},
+						},
+					}, nil
+				},
+			},
+			LayoutsStore: &mocks.LayoutsStore{
+				AllF: func(ctx context.Context) ([]chronograf.Layout, error) {
+					return []chronograf.Layout{
+						{ID: "1", Application: "system", Measurement: "cpu"},
+						{ID: "2", Application: "system", Measurement: "cpu"},
+						{ID: "3", Application: "system", Measurement: "disk"},
+					}, nil
+				},
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+		DatabasesClient: &mocks.Databases{
+			ConnectF: func(context.Context, *chronograf.Source) error {
+				return nil
+			},
+			GetMeasurementsF: func(ctx context.Context, db string, limit, offset int) ([]chronograf.Measurement, error) {
+				if db != "metrics" {
+					t.Errorf("GetMeasurements() db = %s, want metrics", db)
+				}
+				return []chronograf.Measurement{{Name: "cpu"}, {Name: "docker"}, {Name: "mem"}}, nil
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "http://any.url", nil)
+	r = r.WithContext(httprouter.WithParams(
+		context.Background(),
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "1",
+			},
+		}))
+
+	h.ProtoboardSuggestions(w, r)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		t.Errorf("StatusCode:\nwant\n200\ngot\n%v", resp.StatusCode)
+	}
+	want := `{"db":"metrics","suggestions":[{"kind":"protoboard","id":"docker","name":"Docker","measurements":["docker"],"missing":[],"coverage":1,"links":{"self":"/chronograf/v1/protoboards/docker"}},{"kind":"protoboard","id":"system","name":"System","measurements":["cpu","mem","system"],"missing":["system"],"coverage":0.6666666666666666,"links":{"self":"/chronograf/v1/protoboards/system"}},{"kind":"layout","id":"system","name":"system","measurements":["cpu","disk"],"missing":["disk"],"coverage":0.5,"links":{"self":"/chronograf/v1/layouts?app=system"}}]}
+`
+	if string(body) != want {
+		t.Errorf("Body:\nwant\n%s\ngot\n%s", want, body)
+	}
+}
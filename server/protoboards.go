@@ -1,28 +1,48 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/bouk/httprouter"
 	"github.com/influxdata/chronograf"
+	idgen "github.com/influxdata/chronograf/id"
+	"github.com/influxdata/chronograf/influx"
 )
 
 type protoboardLinks struct {
 	Self string `json:"self"`
 }
 
+// Provenance of protoboards shipped with chronograf and of those defined by
+// the users of an organization
+const (
+	ProtoboardBuiltin      = "builtin"
+	ProtoboardOrganization = "organization"
+)
+
 type protoboardResponse struct {
 	chronograf.Protoboard
-	Links protoboardLinks `json:"links"`
+	Provenance string          `json:"provenance"` // Provenance is either builtin or organization
+	Links      protoboardLinks `json:"links"`
 }
 
 func newProtoboardResponse(protoboard chronograf.Protoboard) protoboardResponse {
 	httpAPIProtoboards := "/chronograf/v1/protoboards"
 	selfLink := fmt.Sprintf("%s/%s", httpAPIProtoboards, protoboard.ID)
 
+	provenance := ProtoboardBuiltin
+	if protoboard.Organization != "" {
+		provenance = ProtoboardOrganization
+	}
 	return protoboardResponse{
 		Protoboard: protoboard,
+		Provenance: provenance,
 		Links: protoboardLinks{
 			Self: selfLink,
 		},
@@ -74,3 +94,491 @@ func (s *Service) ProtoboardsID(w http.ResponseWriter, r *http.Request) {
 	res := newProtoboardResponse(protoboard)
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
+
+// ValidProtoboardRequest checks that a protoboard can be stored
+func ValidProtoboardRequest(p *chronograf.Protoboard) error {
+	if p.Meta.Name == "" {
+		return fmt.Errorf("protoboard name is required")
+	}
+	if p.Meta.Measurements == nil {
+		p.Meta.Measurements = []string{}
+	}
+	if p.Data.Cells == nil {
+		p.Data.Cells = []chronograf.ProtoboardCell{}
+	}
+	if p.Data.Templates == nil {
+		p.Data.Templates = []chronograf.Template{}
+	}
+	return nil
+}
+
+// NewProtoboard adds a protoboard to the organization of the caller
+func (s *Service) NewProtoboard(w http.ResponseWriter, r *http.Request) {
+	var protoboard chronograf.Protoboard
+	if err := json.NewDecoder(r.Body).Decode(&protoboard); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	if err := ValidProtoboardRequest(&protoboard); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	s.addProtoboard(w, r, protoboard)
+}
+
+func (s *Service) addProtoboard(w http.ResponseWriter, r *http.Request, protoboard chronograf.Protoboard) {
+	ctx := r.Context()
+	protoboard, err := s.Store.Protoboards(ctx).Add(ctx, protoboard)
+	if err != nil {
+		msg := fmt.Errorf("Error storing protoboard %s: %v", protoboard.Meta.Name, err)
+		unknownErrorWithMessage(w, msg, s.Logger)
+		return
+	}
+
+	res := newProtoboardResponse(protoboard)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateProtoboard replaces a protoboard of the organization of the caller.
+// Built-in protoboards cannot be changed.
+func (s *Service) UpdateProtoboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httprouter.GetParamFromContext(ctx, "id")
+
+	var req chronograf.Protoboard
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	req.ID = id
+
+	if err := ValidProtoboardRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ok := s.changeProtoboard(w, r, id, func(p chronograf.Protoboard) error {
+		req.Organization = p.Organization
+		return s.Store.Protoboards(ctx).Update(ctx, req)
+	})
+	if !ok {
+		return
+	}
+
+	res := newProtoboardResponse(req)
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// RemoveProtoboard deletes a protoboard of the organization of the caller.
+// Built-in protoboards cannot be removed.
+func (s *Service) RemoveProtoboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httprouter.GetParamFromContext(ctx, "id")
+
+	ok := s.changeProtoboard(w, r, id, func(p chronograf.Protoboard) error {
+		return s.Store.Protoboards(ctx).Delete(ctx, p)
+	})
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// changeProtoboard applies fn to a protoboard defined by users and writes
+// the error response if the protoboard does not exist or is built-in
+func (s *Service) changeProtoboard(w http.ResponseWriter, r *http.Request, id string, fn func(chronograf.Protoboard) error) bool {
+	ctx := r.Context()
+	protoboard, err := s.Store.Protoboards(ctx).Get(ctx, id)
+	if err != nil {
+		Error(w, http.StatusNotFound, fmt.Sprintf("ID %s not found", id), s.Logger)
+		return false
+	}
+
+	if protoboard.Organization == "" {
+		err = chronograf.ErrProtoboardReadOnly
+	} else {
+		err = fn(protoboard)
+	}
+	if err == chronograf.ErrProtoboardReadOnly {
+		Error(w, http.StatusForbidden, err.Error(), s.Logger)
+		return false
+	} else if err != nil {
+		msg := fmt.Sprintf("Error changing protoboard ID %s: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return false
+	}
+	return true
+}
+
+// dashboardProtoboardRequest optionally overrides the metadata of a
+// protoboard created from a dashboard
+type dashboardProtoboardRequest struct {
+	Meta chronograf.ProtoboardMeta `json:"meta"`
+}
+
+// NewDashboardProtoboard creates a protoboard of the organization of the
+// caller from a dashboard. The database, retention policy and source of the
+// queries of the dashboard become parameters of the protoboard.
+func (s *Service) NewDashboardProtoboard(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dashboard, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboard(w, ctx, dashboard, chronograf.DashboardViewPermission) {
+		return
+	}
+
+	var req dashboardProtoboardRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			invalidJSON(w, s.Logger)
+			return
+		}
+	}
+
+	protoboard := dashboardProtoboard(dashboard, req.Meta)
+	if err := ValidProtoboardRequest(&protoboard); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	s.addProtoboard(w, r, protoboard)
+}
+
+// dashboardProtoboard converts the cells and templates of a dashboard into a
+// protoboard. The database and retention policy of the queries become the
+// :db: and :rp: parameters, which are bound again on instantiation.
+func dashboardProtoboard(d chronograf.Dashboard, meta chronograf.ProtoboardMeta) chronograf.Protoboard {
+	if meta.Name == "" {
+		meta.Name = d.Name
+	}
+
+	measurements := []string{}
+	seen := map[string]bool{}
+	cells := make([]chronograf.ProtoboardCell, len(d.Cells))
+	for i, c := range d.Cells {
+		queries := make([]chronograf.DashboardQuery, len(c.Queries))
+		for j, q := range c.Queries {
+			qc := q.QueryConfig
+			if qc.Database != "" {
+				q.Command, q.QueryConfig = parameterizeQuery(q.Command, qc)
+			}
+			if m := qc.Measurement; m != "" && !seen[m] {
+				seen[m] = true
+				measurements = append(measurements, m)
+			}
+			q.Source = ""
+			queries[j] = q
+		}
+		cells[i] = chronograf.ProtoboardCell{
+			X:              c.X,
+			Y:              c.Y,
+			W:              c.W,
+			H:              c.H,
+			Name:           c.Name,
+			Queries:        queries,
+			Axes:           c.Axes,
+			Type:           c.Type,
+			CellColors:     c.CellColors,
+			Legend:         c.Legend,
+			TableOptions:   c.TableOptions,
+			FieldOptions:   c.FieldOptions,
+			TimeFormat:     c.TimeFormat,
+			DecimalPlaces:  c.DecimalPlaces,
+			Note:           c.Note,
+			NoteVisibility: c.NoteVisibility,
+		}
+	}
+	if len(meta.Measurements) == 0 {
+		sort.Strings(measurements)
+		meta.Measurements = measurements
+	}
+
+	// Templates get new IDs on instantiation and the choices of templates
+	// backed by queries depend on the source
+	templates := make([]chronograf.Template, len(d.Templates))
+	for i, t := range d.Templates {
+		t.ID = ""
+		if t.Query != nil {
+			query := *t.Query
+			query.DB = ""
+			t.Query = &query
+			t.Values = []chronograf.TemplateValue{}
+		}
+		templates[i] = t
+	}
+
+	return chronograf.Protoboard{
+		Meta: meta,
+		Data: chronograf.ProtoboardData{
+			Cells:     cells,
+			Templates: templates,
+		},
+	}
+}
+
+// protoboardDashboardRequest instantiates a protoboard into a dashboard of
+// a source
+type protoboardDashboardRequest struct {
+	Source   string            `json:"source"`             // Source is the ID of the source the dashboard queries
+	Name     string            `json:"name,omitempty"`     // Name of the dashboard; defaults to the name of the protoboard
+	Bindings map[string]string `json:"bindings,omitempty"` // Bindings select the values of template variables, e.g. ":host:"
+}
+
+// parameterizeQuery replaces the database and retention policy of a query
+// with the :db: and :rp: parameters. Queries described by their query config
+// are built again from it; the measurements of raw queries are rewritten in
+// any of the forms db.rp.m, "db"."rp"."m" and "db".."m".
+func parameterizeQuery(command string, qc chronograf.QueryConfig) (string, chronograf.QueryConfig) {
+	db, rp := qc.Database, qc.RetentionPolicy
+	qc.Database = ":db:"
+	if rp != "" {
+		qc.RetentionPolicy = ":rp:"
+	}
+	if qc.RawText == nil {
+		if built, err := influx.BuildQuery(qc); err == nil {
+			return built, qc
+		}
+	}
+
+	ident := func(name string) string {
+		return fmt.Sprintf(`(?:"%s"|%s)`, regexp.QuoteMeta(name), regexp.QuoteMeta(name))
+	}
+	source := regexp.MustCompile(`(^|[\s,(])` + ident(db) + `\.(` + ident(rp) + `)?\.`)
+	command = source.ReplaceAllStringFunc(command, func(match string) string {
+		parts := source.FindStringSubmatch(match)
+		if parts[2] == "" {
+			return parts[1] + `":db:"..`
+		}
+		return parts[1] + `":db:".":rp:".`
+	})
+	if qc.RawText != nil {
+		qc.RawText = &command
+	}
+	return command, qc
+}
+
+// NewProtoboardDashboard instantiates a protoboard into a new dashboard of
+// the organization of the caller
+func (s *Service) NewProtoboardDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httprouter.GetParamFromContext(ctx, "id")
+
+	protoboard, err := s.Store.Protoboards(ctx).Get(ctx, id)
+	if err != nil {
+		Error(w, http.StatusNotFound, fmt.Sprintf("ID %s not found", id), s.Logger)
+		return
+	}
+
+	var req protoboardDashboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	srcID, err := strconv.Atoi(req.Source)
+	if err != nil {
+		invalidData(w, fmt.Errorf("invalid source %q", req.Source), s.Logger)
+		return
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		invalidData(w, fmt.Errorf("source %d not found", srcID), s.Logger)
+		return
+	}
+
+	dashboard, err := instantiateProtoboard(protoboard, src, req.Bindings)
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	if req.Name != "" {
+		dashboard.Name = req.Name
+	}
+
+	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	if err := ValidDashboardRequest(&dashboard, defaultOrg.ID); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	if dashboard, err = s.Store.Dashboards(ctx).Add(ctx, dashboard); err != nil {
+		msg := fmt.Errorf("Error storing dashboard %v: %v", dashboard, err)
+		unknownErrorWithMessage(w, msg, s.Logger)
+		return
+	}
+
+	res := newDashboardResponse(dashboard)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// instantiateProtoboard converts the cells and templates of a protoboard into
+// a dashboard querying the telegraf database of the source. Bindings select
+// the values of the templates by template variable.
+func instantiateProtoboard(pb chronograf.Protoboard, src chronograf.Source, bindings map[string]string) (chronograf.Dashboard, error) {
+	db := src.Telegraf
+	if db == "" {
+		db = "telegraf"
+	}
+	rp := src.DefaultRP
+	if rp == "" {
+		rp = "autogen"
+	}
+	replacer := strings.NewReplacer(":db:", db, ":rp:", rp)
+	srcLink := fmt.Sprintf("/chronograf/v1/sources/%d", src.ID)
+
+	cells := make([]chronograf.DashboardCell, len(pb.Data.Cells))
+	for i, c := range pb.Data.Cells {
+		queries := make([]chronograf.DashboardQuery, len(c.Queries))
+		for j, q := range c.Queries {
+			q.Command = replacer.Replace(q.Command)
+			q.QueryConfig.Database = replacer.Replace(q.QueryConfig.Database)
+			q.QueryConfig.RetentionPolicy = replacer.Replace(q.QueryConfig.RetentionPolicy)
+			q.Source = srcLink
+			queries[j] = q
+		}
+		cells[i] = chronograf.DashboardCell{
+			X:              c.X,
+			Y:              c.Y,
+			W:              c.W,
+			H:              c.H,
+			Name:           c.Name,
+			Queries:        queries,
+			Axes:           c.Axes,
+			Type:           c.Type,
+			CellColors:     c.CellColors,
+			Legend:         c.Legend,
+			TableOptions:   c.TableOptions,
+			FieldOptions:   c.FieldOptions,
+			TimeFormat:     c.TimeFormat,
+			DecimalPlaces:  c.DecimalPlaces,
+			Note:           c.Note,
+			NoteVisibility: c.NoteVisibility,
+		}
+	}
+	placeCells(cells)
+
+	bound := map[string]bool{}
+	ids := idgen.UUID{}
+	templates := make([]chronograf.Template, len(pb.Data.Templates))
+	for i, t := range pb.Data.Templates {
+		tid, err := ids.Generate()
+		if err != nil {
+			return chronograf.Dashboard{}, err
+		}
+		t.ID = chronograf.TemplateID(tid)
+		if t.Query != nil {
+			query := *t.Query
+			query.DB = db
+			t.Query = &query
+		}
+		if value, ok := bindings[t.Var]; ok {
+			values, err := bindTemplate(t, value)
+			if err != nil {
+				return chronograf.Dashboard{}, err
+			}
+			t.Values = values
+			bound[t.Var] = true
+		}
+		templates[i] = t
+	}
+	for tempVar := range bindings {
+		if !bound[tempVar] {
+			return chronograf.Dashboard{}, fmt.Errorf("protoboard %s has no template %s", pb.ID, tempVar)
+		}
+	}
+
+	return chronograf.Dashboard{
+		Name:      pb.Meta.Name,
+		Cells:     cells,
+		Templates: templates,
+	}, nil
+}
+
+// bindTemplate selects the value of a template. Values that are not one of
+// the predefined choices replace them, except for map templates.
+func bindTemplate(t chronograf.Template, value string) ([]chronograf.TemplateValue, error) {
+	if len(t.Values) > 0 {
+		values := make([]chronograf.TemplateValue, len(t.Values))
+		found := false
+		for i, v := range t.Values {
+			v.Selected = v.Value == value || (t.Type == "map" && v.Key == value)
+			found = found || v.Selected
+			values[i] = v
+		}
+		if found {
+			return values, nil
+		}
+	}
+	if t.Type == "map" {
+		return nil, fmt.Errorf("%s is not a choice of template %s", value, t.Var)
+	}
+	return []chronograf.TemplateValue{
+		{
+			Value:    value,
+			Type:     templateValueType(t.Type),
+			Selected: true,
+		},
+	}, nil
+}
+
+// templateValueType is the type of the values of a type of template
+func templateValueType(templateType string) string {
+	switch templateType {
+	case "fieldKeys", "tagKeys", "tagValues", "measurements", "databases":
+		return strings.TrimSuffix(templateType, "s")
+	case "text":
+		return "constant"
+	}
+	return templateType
+}
+
+// placeCells lays out cells of protoboards without positions left to right
+// and top to bottom, as the UI does
+func placeCells(cells []chronograf.DashboardCell) {
+	for _, c := range cells {
+		if c.X != 0 || c.Y != 0 {
+			return
+		}
+	}
+	for i := range cells {
+		CorrectWidthHeight(&cells[i])
+		if i == 0 {
+			continue
+		}
+		cells[i].X, cells[i].Y = nextAvailablePosition(cells[:i], cells[i])
+	}
+}
+
+// nextAvailablePosition places a cell after the last cell of the bottom row
+// or on a new row if it does not fit
+func nextAvailablePosition(cells []chronograf.DashboardCell, cell chronograf.DashboardCell) (int32, int32) {
+	const maxColumns = 12
+	last := cells[0]
+	for _, c := range cells[1:] {
+		if c.Y > last.Y || (c.Y == last.Y && c.X > last.X) {
+			last = c
+		}
+	}
+	if maxColumns-(last.X+last.W) >= cell.W {
+		return last.X + last.W, last.Y
+	}
+	return 0, last.Y + last.H
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -43,7 +44,7 @@ func Test_Protoboards(t *testing.T) {
 			wants: wants{
 				statusCode:  http.StatusOK,
 				contentType: "application/json",
-				body:        `{"protoboards":[{"id":"1","meta":{"name":"protodashboard 1","icon":"http://example.com/icon.png","version":"1.2.3","measurements":["m1","m2"],"dashboardVersion":"1.7.0","description":"this is great","author":"Chronogiraffe","license":"Apache-2.0","url":"http://example.com"},"data":{"cells":[{"x":0,"y":0,"w":0,"h":0,"name":"","queries":null,"axes":null,"type":"","colors":null,"legend":{},"tableOptions":{"verticalTimeAxis":false,"sortBy":{"internalName":"","displayName":"","visible":false},"wrapping":"","fixFirstColumn":false},"fieldOptions":null,"timeFormat":"","decimalPlaces":{"isEnforced":false,"digits":0},"note":"","noteVisibility":""}],"templates":[{"tempVar":"","values":null,"id":"","type":"","label":""}]},"links":{"self":"/chronograf/v1/protoboards/1"}},{"id":"2","meta":{"name":"protodashboard 2","icon":"http://example.com/icon.png","version":"1.2.3","measurements":["m1","m2"],"dashboardVersion":"1.7.0","description":"this is great","author":"Chronogiraffe","license":"Apache-2.0","url":"http://example.com"},"data":{"cells":[{"x":8,"y":0,"w":3,"h":5,"name":"Untitled Cell","queries":[{"query":"SELECT mean(\"usage_steal\") AS \"mean_usage_steal\", mean(\"usage_system\") AS \"mean_usage_system\" FROM \"telegraf\".\"autogen\".\"cpu\" WHERE time \u003e :dashboardTime: AND \"host\"='denizs-MacBook-Pro.local' GROUP BY time(:interval:) FILL(null)","queryConfig":{"database":"telegraf","measurement":"cpu","retentionPolicy":"autogen","fields":[{"value":"mean","type":"func","alias":"mean_usage_steal","args":[{"value":"usage_steal","type":"field","alias":""}]},{"value":"mean","type":"func","alias":"mean_usage_system","args":[{"value":"usage_steal","type":"field","alias":""}]}],"tags":{"host":["denizs-MacBook-Pro.local"]},"groupBy":{"time":"auto","tags":[]},"areTagsAccepted":true,"fill":"null","rawText":null,"range":null,"shifts":null},"source":"","type":"influxql"}],"axes":{"x":{"bounds":["",""],"label":"","prefix":"","suffix":"","base":"10","scale":"linear"},"y":{"bounds":["",""],"label":"","prefix":"","suffix":"","base":"10","scale":"linear"},"y2":{"bounds":["",""],"label":"","prefix":"","suffix":"","base":"10","scale":"linear"}},"type":"line","colors":[],"legend":{},"tableOptions":{"verticalTimeAxis":false,"sortBy":{"internalName":"","displayName":"","visible":false},"wrapping":"","fixFirstColumn":false},"fieldOptions":[],"timeFormat":"","decimalPlaces":{"isEnforced":true,"digits":2},"note":"","noteVisibility":""}],"templates":null},"links":{"self":"/chronograf/v1/protoboards/2"}}]}`},
+				body:        `{"protoboards":[{"id":"1","meta":{"name":"protodashboard 1","icon":"http://example.com/icon.png","version":"1.2.3","measurements":["m1","m2"],"dashboardVersion":"1.7.0","description":"this is great","author":"Chronogiraffe","license":"Apache-2.0","url":"http://example.com"},"data":{"cells":[{"x":0,"y":0,"w":0,"h":0,"name":"","queries":null,"axes":null,"type":"","colors":null,"legend":{},"tableOptions":{"verticalTimeAxis":false,"sortBy":{"internalName":"","displayName":"","visible":false},"wrapping":"","fixFirstColumn":false},"fieldOptions":null,"timeFormat":"","decimalPlaces":{"isEnforced":false,"digits":0},"note":"","noteVisibility":""}],"templates":[{"tempVar":"","values":null,"id":"","type":"","label":""}]},"provenance":"builtin","links":{"self":"/chronograf/v1/protoboards/1"}},{"id":"2","meta":{"name":"protodashboard 2","icon":"http://example.com/icon.png","version":"1.2.3","measurements":["m1","m2"],"dashboardVersion":"1.7.0","description":"this is great","author":"Chronogiraffe","license":"Apache-2.0","url":"http://example.com"},"data":{"cells":[{"x":8,"y":0,"w":3,"h":5,"name":"Untitled Cell","queries":[{"query":"SELECT mean(\"usage_steal\") AS \"mean_usage_steal\", mean(\"usage_system\") AS \"mean_usage_system\" FROM \"telegraf\".\"autogen\".\"cpu\" WHERE time \u003e :dashboardTime: AND \"host\"='denizs-MacBook-Pro.local' GROUP BY time(:interval:) FILL(null)","queryConfig":{"database":"telegraf","measurement":"cpu","retentionPolicy":"autogen","fields":[{"value":"mean","type":"func","alias":"mean_usage_steal","args":[{"value":"usage_steal","type":"field","alias":""}]},{"value":"mean","type":"func","alias":"mean_usage_system","args":[{"value":"usage_steal","type":"field","alias":""}]}],"tags":{"host":["denizs-MacBook-Pro.local"]},"groupBy":{"time":"auto","tags":[]},"areTagsAccepted":true,"fill":"null","rawText":null,"range":null,"shifts":null},"source":"","type":"influxql"}],"axes":{"x":{"bounds":["",""],"label":"","prefix":"","suffix":"","base":"10","scale":"linear"},"y":{"bounds":["",""],"label":"","prefix":"","suffix":"","base":"10","scale":"linear"},"y2":{"bounds":["",""],"label":"","prefix":"","suffix":"","base":"10","scale":"linear"}},"type":"line","colors":[],"legend":{},"tableOptions":{"verticalTimeAxis":false,"sortBy":{"internalName":"","displayName":"","visible":false},"wrapping":"","fixFirstColumn":false},"fieldOptions":[],"timeFormat":"","decimalPlaces":{"isEnforced":true,"digits":2},"note":"","noteVisibility":""}],"templates":null},"provenance":"builtin","links":{"self":"/chronograf/v1/protoboards/2"}}]}`},
 			arg: []chronograf.Protoboard{
 				chronograf.Protoboard{
 					ID: "1",
@@ -239,7 +240,7 @@ func Test_ProtoboardsID(t *testing.T) {
 			wants: wants{
 				statusCode:  http.StatusOK,
 				contentType: "application/json",
-				body:        `{"id":"1","meta":{"name":"","version":"","dashboardVersion":""},"data":{"cells":null},"links":{"self":"/chronograf/v1/protoboards/1"}}`,
+				body:        `{"id":"1","meta":{"name":"","version":"","dashboardVersion":""},"data":{"cells":null},"provenance":"builtin","links":{"self":"/chronograf/v1/protoboards/1"}}`,
 			},
 			args: args{
 				id: "1",
@@ -325,3 +326,308 @@ func Test_ProtoboardsID(t *testing.T) {
 		})
 	}
 }
+
+func Test_instantiateProtoboard(t *testing.T) {
+	pb := chronograf.Protoboard{
+		ID: "system",
+		Meta: chronograf.ProtoboardMeta{
+			Name: "System",
+		},
+		Data: chronograf.ProtoboardData{
+			Cells: []chronograf.ProtoboardCell{
+				{
+					W:    8,
+					H:    4,
+					Name: "CPU",
+					Queries: []chronograf.DashboardQuery{
+						{
+							Command: `SELECT mean("usage_user") FROM ":db:".":rp:"."cpu" WHERE "host" = :host:`,
+							Type:    "influxql",
+						},
+					},
+				},
+				{
+					W:    4,
+					H:    4,
+					Name: "Load",
+				},
+				{
+					W:    6,
+					H:    4,
+					Name: "Memory",
+				},
+			},
+			Templates: []chronograf.Template{
+				{
+					TemplateVar: chronograf.TemplateVar{
+						Var:    ":host:",
+						Values: []chronograf.TemplateValue{},
+					},
+					Type: "tagValues",
+					Query: &chronograf.TemplateQuery{
+						Command:     "SHOW TAG VALUES ON :database: FROM :measurement: WITH KEY=:tagKey:",
+						DB:          "telegraf",
+						Measurement: "cpu",
+						TagKey:      "host",
+					},
+				},
+			},
+		},
+	}
+	src := chronograf.Source{
+		ID:       3,
+		Telegraf: "metrics",
+	}
+
+	got, err := instantiateProtoboard(pb, src, map[string]string{":host:": "gibson"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q := got.Cells[0].Queries[0]; q.Command != `SELECT mean("usage_user") FROM "metrics"."autogen"."cpu" WHERE "host" = :host:` || q.Source != "/chronograf/v1/sources/3" {
+		t.Errorf("instantiateProtoboard() query = %#v", q)
+	}
+	positions := [][2]int32{}
+	for _, c := range got.Cells {
+		positions = append(positions, [2]int32{c.X, c.Y})
+	}
+	if want := [][2]int32{{0, 0}, {8, 0}, {0, 4}}; !reflect.DeepEqual(positions, want) {
+		t.Errorf("instantiateProtoboard() positions = %v, want %v", positions, want)
+	}
+
+	tmpl := got.Templates[0]
+	if tmpl.ID == "" {
+		t.Errorf("instantiateProtoboard() template without ID")
+	}
+	if tmpl.Query.DB != "metrics" {
+		t.Errorf("instantiateProtoboard() template db = %s, want metrics", tmpl.Query.DB)
+	}
+	wantValues := []chronograf.TemplateValue{{Value: "gibson", Type: "tagValue", Selected: true}}
+	if !reflect.DeepEqual(tmpl.Values, wantValues) {
+		t.Errorf("instantiateProtoboard() template values = %v, want %v", tmpl.Values, wantValues)
+	}
+	if pb.Data.Templates[0].Query.DB != "telegraf" {
+		t.Errorf("instantiateProtoboard() modified the protoboard")
+	}
+
+	if _, err := instantiateProtoboard(pb, src, map[string]string{":cluster:": "a"}); err == nil {
+		t.Errorf("instantiateProtoboard() expected error for unknown template")
+	}
+}
+
+func Test_dashboardProtoboard(t *testing.T) {
+	d := chronograf.Dashboard{
+		ID:   1,
+		Name: "Hosts",
+		Cells: []chronograf.DashboardCell{
+			{
+				ID:   "cell",
+				W:    4,
+				H:    4,
+				Name: "CPU",
+				Queries: []chronograf.DashboardQuery{
+					{
+						Command: `SELECT mean("usage_user") FROM "metrics"."weekly"."cpu" WHERE "host" = :host:`,
+						QueryConfig: chronograf.QueryConfig{
+							Database:        "metrics",
+							RetentionPolicy: "weekly",
+							Measurement:     "cpu",
+						},
+						Source: "/chronograf/v1/sources/1",
+						Type:   "influxql",
+					},
+					{
+						Command: `SELECT mean("used") FROM "metrics"."weekly"."mem"`,
+						QueryConfig: chronograf.QueryConfig{
+							Database:        "metrics",
+							RetentionPolicy: "weekly",
+							Measurement:     "mem",
+						},
+						Source: "/chronograf/v1/sources/1",
+						Type:   "influxql",
+					},
+				},
+			},
+		},
+		Templates: []chronograf.Template{
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var:    ":host:",
+					Values: []chronograf.TemplateValue{{Value: "db01", Type: "tagValue", Selected: true}},
+				},
+				ID:   "tmpl",
+				Type: "tagValues",
+				Query: &chronograf.TemplateQuery{
+					Command: `SHOW TAG VALUES ON :database: FROM :measurement: WITH KEY=:tagKey:`,
+					DB:      "metrics",
+				},
+			},
+		},
+		Organization: "1337",
+	}
+
+	want := chronograf.Protoboard{
+		Meta: chronograf.ProtoboardMeta{
+			Name:         "Hosts",
+			Measurements: []string{"cpu", "mem"},
+		},
+		Data: chronograf.ProtoboardData{
+			Cells: []chronograf.ProtoboardCell{
+				{
+					W:    4,
+					H:    4,
+					Name: "CPU",
+					Queries: []chronograf.DashboardQuery{
+						{
+							Command: `SELECT mean("usage_user") FROM ":db:".":rp:"."cpu" WHERE "host" = :host:`,
+							QueryConfig: chronograf.QueryConfig{
+								Database:        ":db:",
+								RetentionPolicy: ":rp:",
+								Measurement:     "cpu",
+							},
+							Type: "influxql",
+						},
+						{
+							Command: `SELECT mean("used") FROM ":db:".":rp:"."mem"`,
+							QueryConfig: chronograf.QueryConfig{
+								Database:        ":db:",
+								RetentionPolicy: ":rp:",
+								Measurement:     "mem",
+							},
+							Type: "influxql",
+						},
+					},
+				},
+			},
+			Templates: []chronograf.Template{
+				{
+					TemplateVar: chronograf.TemplateVar{
+						Var:    ":host:",
+						Values: []chronograf.TemplateValue{},
+					},
+					Type: "tagValues",
+					Query: &chronograf.TemplateQuery{
+						Command: `SHOW TAG VALUES ON :database: FROM :measurement: WITH KEY=:tagKey:`,
+					},
+				},
+			},
+		},
+	}
+
+	got := dashboardProtoboard(d, chronograf.ProtoboardMeta{})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dashboardProtoboard() =\n%+v\nwant\n%+v", got, want)
+	}
+	if d.Templates[0].Query.DB != "metrics" {
+		t.Errorf("dashboardProtoboard() changed the templates of the dashboard")
+	}
+
+	src := chronograf.Source{ID: 2, Telegraf: "telegraf", DefaultRP: "autogen"}
+	dashboard, err := instantiateProtoboard(got, src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := dashboard.Cells[0].Queries[0]
+	if q.Command != `SELECT mean("usage_user") FROM "telegraf"."autogen"."cpu" WHERE "host" = :host:` || q.QueryConfig.Database != "telegraf" {
+		t.Errorf("instantiateProtoboard() of a dashboard protoboard = %+v", q)
+	}
+}
+
+func Test_parameterizeQuery(t *testing.T) {
+	raw := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		command string
+		qc      chronograf.QueryConfig
+		want    string
+		wantQC  chronograf.QueryConfig
+	}{
+		{
+			name:    "query built from its config",
+			command: `SELECT mean("usage_user") AS "mean_usage_user" FROM metrics.weekly.cpu WHERE time > :dashboardTime: GROUP BY time(:interval:) FILL(null)`,
+			qc: chronograf.QueryConfig{
+				Database:        "metrics",
+				RetentionPolicy: "weekly",
+				Measurement:     "cpu",
+				Fields: []chronograf.Field{
+					{
+						Value: "mean",
+						Type:  "func",
+						Args:  []chronograf.Field{{Value: "usage_user", Type: "field"}},
+					},
+				},
+				GroupBy: chronograf.GroupBy{Time: "auto", Tags: []string{}},
+				Tags:    map[string][]string{},
+			},
+			want: `SELECT mean("usage_user") FROM ":db:".":rp:"."cpu" WHERE time > :dashboardTime: GROUP BY time(:interval:) FILL(null)`,
+			wantQC: chronograf.QueryConfig{
+				Database:        ":db:",
+				RetentionPolicy: ":rp:",
+				Measurement:     "cpu",
+				Fields: []chronograf.Field{
+					{
+						Value: "mean",
+						Type:  "func",
+						Args:  []chronograf.Field{{Value: "usage_user", Type: "field"}},
+					},
+				},
+				GroupBy: chronograf.GroupBy{Time: "auto", Tags: []string{}},
+				Tags:    map[string][]string{},
+			},
+		},
+		{
+			name:    "unquoted raw query",
+			command: `SELECT mean(usage_user) FROM metrics.weekly.cpu, metrics.weekly.mem WHERE host = :host:`,
+			qc: chronograf.QueryConfig{
+				Database:        "metrics",
+				RetentionPolicy: "weekly",
+				RawText:         raw(`SELECT mean(usage_user) FROM metrics.weekly.cpu, metrics.weekly.mem WHERE host = :host:`),
+			},
+			want: `SELECT mean(usage_user) FROM ":db:".":rp:".cpu, ":db:".":rp:".mem WHERE host = :host:`,
+			wantQC: chronograf.QueryConfig{
+				Database:        ":db:",
+				RetentionPolicy: ":rp:",
+				RawText:         raw(`SELECT mean(usage_user) FROM ":db:".":rp:".cpu, ":db:".":rp:".mem WHERE host = :host:`),
+			},
+		},
+		{
+			name:    "default retention policy",
+			command: `SELECT mean("used") FROM "metrics".."mem"`,
+			qc: chronograf.QueryConfig{
+				Database:    "metrics",
+				Measurement: "mem",
+			},
+			want: `SELECT mean("used") FROM ":db:".."mem"`,
+			wantQC: chronograf.QueryConfig{
+				Database:    ":db:",
+				Measurement: "mem",
+			},
+		},
+		{
+			name:    "measurements named like the database are kept",
+			command: `SELECT "value" FROM "metrics"."weekly"."metrics" WHERE "metrics" = 'metrics.weekly.'`,
+			qc: chronograf.QueryConfig{
+				Database:        "metrics",
+				RetentionPolicy: "weekly",
+				Measurement:     "metrics",
+			},
+			want: `SELECT "value" FROM ":db:".":rp:"."metrics" WHERE "metrics" = 'metrics.weekly.'`,
+			wantQC: chronograf.QueryConfig{
+				Database:        ":db:",
+				RetentionPolicy: ":rp:",
+				Measurement:     "metrics",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotQC := parameterizeQuery(tt.command, tt.qc)
+			if got != tt.want {
+				t.Errorf("parameterizeQuery() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotQC, tt.wantQC) {
+				t.Errorf("parameterizeQuery() query config = %+v, want %+v", gotQC, tt.wantQC)
+			}
+		})
+	}
+}
@@ -113,7 +113,7 @@ func (s *Service) DefaultRP(ctx context.Context, qc *chronograf.QueryConfig, src
 		return nil
 	}
 
-	db := s.Databases
+	db := s.databases(src)
 	if err := db.Connect(ctx, src); err != nil {
 		return fmt.Errorf("Unable to connect to source: %v", err)
 	}
@@ -132,3 +132,91 @@ func (s *Service) DefaultRP(ctx context.Context, qc *chronograf.QueryConfig, src
 
 	return nil
 }
+
+// BuildQueryRequest is a queryConfig that will be converted to InfluxQL
+type BuildQueryRequest struct {
+	ID          string                 `json:"id"`
+	QueryConfig chronograf.QueryConfig `json:"queryConfig"`
+}
+
+// BuildQueriesRequest converts all queryConfigs to InfluxQL
+type BuildQueriesRequest struct {
+	Queries []BuildQueryRequest `json:"queries"`
+}
+
+// ShiftedQuery is the InfluxQL of a queryConfig moved back in time by Shift
+type ShiftedQuery struct {
+	Shift chronograf.TimeShift `json:"shift"`
+	Query string               `json:"query"`
+}
+
+// BuildQueryResponse is the InfluxQL built from a BuildQueryRequest along
+// with one query for each of the time shifts of the queryConfig
+type BuildQueryResponse struct {
+	ID      string         `json:"id"`
+	Query   string         `json:"query"`
+	Shifted []ShiftedQuery `json:"shifted"`
+}
+
+// BuildQueriesResponse is the response for a BuildQueriesRequest
+type BuildQueriesResponse struct {
+	Queries []BuildQueryResponse `json:"queries"`
+}
+
+// BuildQueries generates InfluxQL from front-end QueryConfigs. It is the
+// reverse of Queries.
+func (s *Service) BuildQueries(w http.ResponseWriter, r *http.Request) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err = s.Store.Sources(ctx).Get(ctx, srcID); err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	var req BuildQueriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	res := BuildQueriesResponse{
+		Queries: make([]BuildQueryResponse, len(req.Queries)),
+	}
+	for i, q := range req.Queries {
+		if err := ValidateQueryConfig(&q.QueryConfig); err != nil {
+			invalidData(w, err, s.Logger)
+			return
+		}
+
+		influxQL, err := influx.BuildQuery(q.QueryConfig)
+		if err != nil {
+			invalidData(w, fmt.Errorf("query %s: %v", q.ID, err), s.Logger)
+			return
+		}
+
+		qr := BuildQueryResponse{
+			ID:      q.ID,
+			Query:   influxQL,
+			Shifted: []ShiftedQuery{},
+		}
+		for _, shift := range q.QueryConfig.Shifts {
+			shifted, err := influx.BuildShiftedQuery(q.QueryConfig, shift)
+			if err != nil {
+				invalidData(w, fmt.Errorf("query %s: %v", q.ID, err), s.Logger)
+				return
+			}
+			qr.Shifted = append(qr.Shifted, ShiftedQuery{
+				Shift: shift,
+				Query: shifted,
+			})
+		}
+		res.Queries[i] = qr
+	}
+
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
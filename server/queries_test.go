@@ -109,3 +109,95 @@ func TestService_Queries(t *testing.T) {
 		})
 	}
 }
+
+func TestService_BuildQueries(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{
+			name: "query config with a time shift",
+			body: `{
+				"queries": [
+				  {
+					"id": "82b60d37-251e-4afe-ac93-ca20a3642b11",
+					"queryConfig": {
+					  "database": "telegraf",
+					  "retentionPolicy": "autogen",
+					  "measurement": "cpu",
+					  "fields": [
+						{
+						  "value": "mean",
+						  "type": "func",
+						  "alias": "mean_usage_idle",
+						  "args": [{"value": "usage_idle", "type": "field"}]
+						}
+					  ],
+					  "tags": {"host": ["server01"]},
+					  "areTagsAccepted": true,
+					  "groupBy": {"time": "auto", "tags": ["cpu"]},
+					  "fill": "null",
+					  "shifts": [{"label": "1h", "unit": "h", "quantity": "1"}]
+					}
+				  }
+				]
+			}`,
+			wantStatus: http.StatusOK,
+			want: `{"queries":[{"id":"82b60d37-251e-4afe-ac93-ca20a3642b11","query":"SELECT mean(\"usage_idle\") AS \"mean_usage_idle\" FROM \"telegraf\".\"autogen\".\"cpu\" WHERE time \u003e :dashboardTime: AND \"host\"='server01' GROUP BY time(:interval:), \"cpu\" FILL(null)","shifted":[{"shift":{"label":"1h","unit":"h","quantity":"1"},"query":"SELECT mean(\"usage_idle\") AS \"mean_usage_idle_shifted__1__h\" FROM \"telegraf\".\"autogen\".\"cpu\" WHERE time \u003e :dashboardTime: - 1h AND time \u003c now() - 1h AND \"host\"='server01' GROUP BY time(:interval:), \"cpu\" FILL(null)"}]}]}
+`,
+		},
+		{
+			name: "query config without a measurement",
+			body: `{
+				"queries": [
+				  {
+					"id": "1",
+					"queryConfig": {
+					  "database": "telegraf",
+					  "fields": [{"value": "usage_idle", "type": "field"}]
+					}
+				  }
+				]
+			}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			want:       `{"code":422,"message":"query 1: query config requires a measurement"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID: ID,
+							}, nil
+						},
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/queries/build", bytes.NewReader([]byte(tt.body)))
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "1",
+					},
+				}))
+
+			s.BuildQueries(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Service.BuildQueries() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("Service.BuildQueries() = \n%s\n, want\n%s\n", got, tt.want)
+			}
+		})
+	}
+}
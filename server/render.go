@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+// RenderCellRequest describes how the queries of a dashboard cell are to be
+// rendered. Resolution is the number of points wanted over the time range;
+// it defaults to the number used by the UI. Selections, keyed by template
+// variable, override the selected values of the dashboard templates. Source
+// is the ID or link of the source template queries run against; it defaults
+// to the source of the first query of the cell.
+type RenderCellRequest struct {
+	Range      chronograf.DurationRange `json:"range"`
+	Resolution int64                    `json:"resolution,omitempty"`
+	Selections map[string]string        `json:"selections,omitempty"`
+	Source     string                   `json:"source,omitempty"`
+}
+
+// RenderedQuery is a cell query with all its template variables replaced
+type RenderedQuery struct {
+	Query    string         `json:"query"`    // Query is the original query of the cell
+	Rendered string         `json:"rendered"` // Rendered is the query ready to be sent to the source
+	Source   string         `json:"source"`
+	Type     string         `json:"type"`
+	Shifted  []ShiftedQuery `json:"shifted,omitempty"`
+}
+
+// RenderCellResponse holds the rendered queries of a cell along with the
+// templates of the dashboard as resolved to render them
+type RenderCellResponse struct {
+	Queries   []RenderedQuery          `json:"queries"`
+	Templates []templateResponse       `json:"templates"`
+	TimeVars  []chronograf.TemplateVar `json:"timeVars"`
+}
+
+// RenderDashboardCell resolves the templates of a dashboard, querying the
+// source for templates backed by a meta query, and expands the influxql
+// queries of one of its cells for a time range and resolution
+func (s *Service) RenderDashboardCell(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	dash, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardViewPermission) {
+		return
+	}
+
+	cid := httprouter.GetParamFromContext(ctx, "cid")
+	var cell *chronograf.DashboardCell
+	for i := range dash.Cells {
+		if dash.Cells[i].ID == cid {
+			cell = &dash.Cells[i]
+			break
+		}
+	}
+	if cell == nil {
+		notFound(w, cid, s.Logger)
+		return
+	}
+
+	var req RenderCellRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	res, ok := s.renderCell(w, ctx, dash, *cell, req)
+	if !ok {
+		return
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// renderCell resolves the templates of the dashboard and expands the
+// queries of the cell. It responds with the error and returns false when
+// the cell cannot be rendered.
+func (s *Service) renderCell(w http.ResponseWriter, ctx context.Context, dash chronograf.Dashboard, cell chronograf.DashboardCell, req RenderCellRequest) (*RenderCellResponse, bool) {
+	vars, err := influx.TimeTemplates(req.Range, req.Resolution, time.Now())
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return nil, false
+	}
+
+	var ts chronograf.TimeSeries
+	if needsSource(dash.Templates) {
+		link := req.Source
+		if link == "" && len(cell.Queries) > 0 {
+			link = cell.Queries[0].Source
+		}
+		srcID, err := strconv.Atoi(path.Base(link))
+		if link == "" || err != nil {
+			invalidData(w, fmt.Errorf("a source is required to query the dashboard templates"), s.Logger)
+			return nil, false
+		}
+		src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+		if err != nil {
+			notFound(w, srcID, s.Logger)
+			return nil, false
+		}
+		if ts, err = s.TimeSeries(ctx, src); err == nil {
+			err = ts.Connect(ctx, &src)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+			Error(w, http.StatusBadRequest, msg, s.Logger)
+			return nil, false
+		}
+	}
+
+	templates, err := influx.HydrateTemplates(ctx, ts, dash.Templates, req.Selections, vars)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+		return nil, false
+	}
+
+	res := RenderCellResponse{
+		Queries:  []RenderedQuery{},
+		TimeVars: vars,
+	}
+	for _, q := range cell.Queries {
+		rq := RenderedQuery{
+			Query:    q.Command,
+			Rendered: q.Command,
+			Source:   q.Source,
+			Type:     q.Type,
+		}
+		if q.Type != "flux" {
+			if rq.Rendered, err = influx.RenderQuery(q.Command, templates, vars); err != nil {
+				invalidData(w, err, s.Logger)
+				return nil, false
+			}
+			for _, shift := range q.QueryConfig.Shifts {
+				shifted, err := influx.BuildShiftedQuery(q.QueryConfig, shift)
+				if err != nil {
+					invalidData(w, err, s.Logger)
+					return nil, false
+				}
+				if shifted, err = influx.RenderQuery(shifted, templates, vars); err != nil {
+					invalidData(w, err, s.Logger)
+					return nil, false
+				}
+				rq.Shifted = append(rq.Shifted, ShiftedQuery{
+					Shift: shift,
+					Query: shifted,
+				})
+			}
+		}
+		res.Queries = append(res.Queries, rq)
+	}
+	res.Templates = newTemplateResponses(dash.ID, templates)
+	return &res, true
+}
+
+// needsSource reports whether any template is populated by a query
+func needsSource(templates []chronograf.Template) bool {
+	for _, t := range templates {
+		if t.Query != nil && t.Query.Command != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_RenderDashboardCell(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:   1,
+		Name: "system",
+		Cells: []chronograf.DashboardCell{
+			{
+				ID: "cpu",
+				Queries: []chronograf.DashboardQuery{
+					{
+						Command: `SELECT mean(:field:) FROM "telegraf"."autogen"."cpu" WHERE time > :dashboardTime: AND "host" = :host: GROUP BY time(:interval:)`,
+						Source:  "/chronograf/v1/sources/1",
+						Type:    "influxql",
+					},
+				},
+			},
+		},
+		Templates: []chronograf.Template{
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var: ":field:",
+					Values: []chronograf.TemplateValue{
+						{Value: `"usage_idle"`, Type: "csv", Selected: true},
+						{Value: `"usage_user"`, Type: "csv"},
+					},
+				},
+				ID:   "field",
+				Type: "csv",
+			},
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var:    ":host:",
+					Values: []chronograf.TemplateValue{},
+				},
+				ID:   "host",
+				Type: "tagValues",
+				Query: &chronograf.TemplateQuery{
+					Command:     `SHOW TAG VALUES ON :database: FROM :measurement: WITH KEY=:tagKey:`,
+					DB:          "telegraf",
+					Measurement: "cpu",
+					TagKey:      "host",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cid        string
+		body       string
+		wantStatus int
+		want       []string
+	}{
+		{
+			name:       "Renders the queries of a cell",
+			cid:        "cpu",
+			body:       `{"range":{"lower":"2018-09-01T00:00:00Z","upper":"2018-09-01T01:00:00Z"},"resolution":60,"selections":{":field:":"\"usage_user\""}}`,
+			wantStatus: 200,
+			want: []string{
+				`SELECT mean("usage_user") FROM "telegraf"."autogen"."cpu" WHERE time > '2018-09-01T00:00:00Z' AND "host" = 'server01' GROUP BY time(60000ms)`,
+			},
+		},
+		{
+			name:       "Unknown cell",
+			cid:        "mem",
+			body:       `{"range":{"lower":"now() - 1h"}}`,
+			wantStatus: 404,
+		},
+		{
+			name:       "Missing time range",
+			cid:        "cpu",
+			body:       `{}`,
+			wantStatus: 422,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []chronograf.Query
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							return dashboard, nil
+						},
+					},
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID: ID,
+							}, nil
+						},
+					},
+				},
+				TimeSeriesClient: &mocks.TimeSeries{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+						queries = append(queries, q)
+						return mocks.NewResponse(`[{"series":[{"name":"cpu","columns":["key","value"],"values":[["host","server01"],["host","server02"]]}]}]`, nil), nil
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/chronograf/v1/dashboards/1/cells/"+tt.cid+"/render", bytes.NewBufferString(tt.body))
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "1",
+					},
+					{
+						Key:   "cid",
+						Value: tt.cid,
+					},
+				}))
+
+			s.RenderDashboardCell(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Service.RenderDashboardCell() status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == 404 && !strings.Contains(w.Body.String(), "ID "+tt.cid+" not found") {
+				t.Errorf("Service.RenderDashboardCell() = %s, want the cell %s not found", w.Body.String(), tt.cid)
+			}
+			if tt.wantStatus != 200 {
+				return
+			}
+
+			var res RenderCellResponse
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("unable to decode response: %v", err)
+			}
+			if len(res.Queries) != len(tt.want) {
+				t.Fatalf("Service.RenderDashboardCell() returned %d queries, want %d", len(res.Queries), len(tt.want))
+			}
+			for i, q := range res.Queries {
+				if q.Rendered != tt.want[i] {
+					t.Errorf("Service.RenderDashboardCell() query %d =\n%s\nwant\n%s", i, q.Rendered, tt.want[i])
+				}
+			}
+			if len(queries) != 1 || queries[0].Command != `SHOW TAG VALUES ON "telegraf" FROM "cpu" WITH KEY="host"` {
+				t.Errorf("Service.RenderDashboardCell() template queries = %v", queries)
+			}
+			if len(res.Templates) != 2 || len(res.Templates[1].Values) != 2 || !res.Templates[1].Values[0].Selected {
+				t.Errorf("Service.RenderDashboardCell() templates = %#v, want the first host selected", res.Templates)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+)
+
+type runningQueryLinks struct {
+	Self string `json:"self"` // Self link used to kill the running query
+}
+
+type runningQueryResponse struct {
+	chronograf.RunningQuery
+	Links runningQueryLinks `json:"links"`
+}
+
+func newRunningQueryResponse(srcID int, q chronograf.RunningQuery) runningQueryResponse {
+	self := fmt.Sprintf("/chronograf/v1/sources/%d/queries/running/%d", srcID, q.ID)
+	if q.Host != "" {
+		self = fmt.Sprintf("%s?host=%s", self, url.QueryEscape(q.Host))
+	}
+	return runningQueryResponse{
+		RunningQuery: q,
+		Links: runningQueryLinks{
+			Self: self,
+		},
+	}
+}
+
+// runningQueriesError is the failure to list the queries of a data node
+type runningQueriesError struct {
+	Host  string `json:"host"`
+	Error string `json:"error"`
+}
+
+type runningQueriesResponse struct {
+	Queries []runningQueryResponse `json:"queries"`
+	Errors  []runningQueriesError  `json:"errors,omitempty"` // Errors are the data nodes whose queries could not be listed
+}
+
+// RunningQueries lists the queries currently executing within a source
+func (s *Service) RunningQueries(w http.ResponseWriter, r *http.Request) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	qm, err := s.queryManager(r, src)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+		return
+	}
+
+	// The queries of the data nodes that responded are listed along with
+	// the failures of the others
+	queries, err := qm.RunningQueries(ctx)
+	var nodeErrs enterprise.DataNodeErrors
+	if errors.As(err, &nodeErrs) && queries != nil {
+		err = nil
+	}
+	if err != nil {
+		if err == chronograf.ErrUpstreamTimeout {
+			Error(w, http.StatusRequestTimeout, "Timeout waiting for Influx response", s.Logger)
+			return
+		}
+		msg := fmt.Sprintf("Unable to get running queries %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	res := runningQueriesResponse{
+		Queries: make([]runningQueryResponse, len(queries)),
+	}
+	for i, q := range queries {
+		res.Queries[i] = newRunningQueryResponse(srcID, q)
+	}
+	for _, ne := range nodeErrs {
+		res.Errors = append(res.Errors, runningQueriesError{
+			Host:  ne.Host,
+			Error: ne.Err.Error(),
+		})
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// KillRunningQuery terminates a query executing within a source. Enterprise
+// sources use the host query parameter to identify the data node running it.
+func (s *Service) KillRunningQuery(w http.ResponseWriter, r *http.Request) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	qid, err := paramInt64("qid", r)
+	if err != nil || qid < 0 {
+		Error(w, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid query id: %v", err), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	qm, err := s.queryManager(r, src)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+		return
+	}
+
+	// Query IDs are only unique per data node of an Enterprise cluster
+	host := r.URL.Query().Get("host")
+	if _, ok := qm.(*enterprise.Client); ok && host == "" {
+		invalidData(w, enterprise.ErrQueryHostRequired, s.Logger)
+		return
+	}
+	if err := qm.KillQuery(ctx, uint64(qid), host); err != nil {
+		msg := fmt.Sprintf("Unable to kill query %d: %v", qid, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// queryManager connects to the source and returns its query manager
+func (s *Service) queryManager(r *http.Request, src chronograf.Source) (chronograf.QueryManager, error) {
+	ts, err := s.TimeSeries(r.Context(), src)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to connect to source %d: %v", src.ID, err)
+	}
+
+	if err = ts.Connect(r.Context(), &src); err != nil {
+		return nil, fmt.Errorf("Unable to connect to source %d: %v", src.ID, err)
+	}
+
+	qm, ok := ts.(chronograf.QueryManager)
+	if !ok {
+		return nil, fmt.Errorf("Source %d does not support query management", src.ID)
+	}
+	return qm, nil
+}
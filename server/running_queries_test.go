@@ -0,0 +1,266 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+type queryManagerTimeSeries struct {
+	mocks.TimeSeries
+	RunningQueriesF func(context.Context) ([]chronograf.RunningQuery, error)
+	KillQueryF      func(context.Context, uint64, string) error
+}
+
+func (q *queryManagerTimeSeries) New(context.Context, chronograf.Source, chronograf.Logger) (chronograf.TimeSeries, error) {
+	return q, nil
+}
+
+func (q *queryManagerTimeSeries) RunningQueries(ctx context.Context) ([]chronograf.RunningQuery, error) {
+	return q.RunningQueriesF(ctx)
+}
+
+func (q *queryManagerTimeSeries) KillQuery(ctx context.Context, id uint64, host string) error {
+	return q.KillQueryF(ctx, id, host)
+}
+
+func TestService_RunningQueries(t *testing.T) {
+	type wants struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name             string
+		timeSeriesClient TimeSeriesClient
+		wants            wants
+	}{
+		{
+			name: "Lists running queries with kill links",
+			timeSeriesClient: &queryManagerTimeSeries{
+				TimeSeries: mocks.TimeSeries{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+				},
+				RunningQueriesF: func(context.Context) ([]chronograf.RunningQuery, error) {
+					return []chronograf.RunningQuery{
+						{
+							ID:       38,
+							Query:    "SELECT * FROM cpu",
+							Database: "telegraf",
+							Duration: "2m3s",
+							Status:   "running",
+							Host:     "data-1:8086",
+						},
+					}, nil
+				},
+			},
+			wants: wants{
+				statusCode: 200,
+				body: `{"queries":[{"id":38,"query":"SELECT * FROM cpu","database":"telegraf","duration":"2m3s","status":"running","host":"data-1:8086","links":{"self":"/chronograf/v1/sources/1/queries/running/38?host=data-1%3A8086"}}]}
+`,
+			},
+		},
+		{
+			name: "Lists the running queries of the data nodes that responded",
+			timeSeriesClient: &queryManagerTimeSeries{
+				TimeSeries: mocks.TimeSeries{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+				},
+				RunningQueriesF: func(context.Context) ([]chronograf.RunningQuery, error) {
+					return []chronograf.RunningQuery{
+						{
+							ID:       38,
+							Query:    "SELECT * FROM cpu",
+							Database: "telegraf",
+							Duration: "2m3s",
+							Status:   "running",
+							Host:     "data-1:8086",
+						},
+					}, enterprise.DataNodeErrors{
+						{Host: "data-2:8086", Err: fmt.Errorf("connection refused")},
+					}
+				},
+			},
+			wants: wants{
+				statusCode: 200,
+				body: `{"queries":[{"id":38,"query":"SELECT * FROM cpu","database":"telegraf","duration":"2m3s","status":"running","host":"data-1:8086","links":{"self":"/chronograf/v1/sources/1/queries/running/38?host=data-1%3A8086"}}],"errors":[{"host":"data-2:8086","error":"connection refused"}]}
+`,
+			},
+		},
+		{
+			name: "Source without query management",
+			timeSeriesClient: &mocks.TimeSeries{
+				ConnectF: func(context.Context, *chronograf.Source) error {
+					return nil
+				},
+			},
+			wants: wants{
+				statusCode: 400,
+				body:       `{"code":400,"message":"Source 1 does not support query management"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID: 1,
+							}, nil
+						},
+					},
+				},
+				TimeSeriesClient: tt.timeSeriesClient,
+				Logger:           log.New(log.DebugLevel),
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "1",
+					},
+				}))
+
+			s.RunningQueries(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+
+			if tt.wants.statusCode != resp.StatusCode {
+				t.Errorf("%q. StatusCode:\nwant\n%v\ngot\n%v", tt.name, tt.wants.statusCode, resp.StatusCode)
+			}
+			if tt.wants.body != string(body) {
+				t.Errorf("%q. Body:\nwant\n*%s*\ngot\n*%s*", tt.name, tt.wants.body, string(body))
+			}
+		})
+	}
+}
+
+func TestService_KillRunningQuery(t *testing.T) {
+	var gotID uint64
+	var gotHost string
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{
+						ID: 1,
+					}, nil
+				},
+			},
+		},
+		TimeSeriesClient: &queryManagerTimeSeries{
+			TimeSeries: mocks.TimeSeries{
+				ConnectF: func(context.Context, *chronograf.Source) error {
+					return nil
+				},
+			},
+			KillQueryF: func(ctx context.Context, id uint64, host string) error {
+				gotID, gotHost = id, host
+				return nil
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "http://any.url?host=data-1%3A8086", nil)
+	r = r.WithContext(httprouter.WithParams(
+		context.Background(),
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "1",
+			},
+			{
+				Key:   "qid",
+				Value: "38",
+			},
+		}))
+
+	s.KillRunningQuery(w, r)
+
+	if w.Code != 204 {
+		t.Errorf("KillRunningQuery status = %d, want 204", w.Code)
+	}
+	if gotID != 38 || gotHost != "data-1:8086" {
+		t.Errorf("KillRunningQuery killed query %d on %q, want 38 on data-1:8086", gotID, gotHost)
+	}
+}
+
+type enterpriseTimeSeriesClient struct {
+	client *enterprise.Client
+}
+
+func (e *enterpriseTimeSeriesClient) New(context.Context, chronograf.Source, chronograf.Logger) (chronograf.TimeSeries, error) {
+	return e.client, nil
+}
+
+func TestService_KillRunningQuery_EnterpriseRequiresHost(t *testing.T) {
+	killed := false
+	node := &queryManagerTimeSeries{
+		KillQueryF: func(context.Context, uint64, string) error {
+			killed = true
+			return nil
+		},
+	}
+	client, err := enterprise.NewClientWithTimeSeries(log.New(log.DebugLevel), "http://meta:8091", nil, false, false, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{
+						ID:   1,
+						Type: chronograf.InfluxEnterprise,
+					}, nil
+				},
+			},
+		},
+		TimeSeriesClient: &enterpriseTimeSeriesClient{client: client},
+		Logger:           log.New(log.DebugLevel),
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "http://any.url", nil)
+	r = r.WithContext(httprouter.WithParams(
+		context.Background(),
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "1",
+			},
+			{
+				Key:   "qid",
+				Value: "38",
+			},
+		}))
+
+	s.KillRunningQuery(w, r)
+
+	if w.Code != 422 {
+		t.Errorf("KillRunningQuery status = %d, want 422", w.Code)
+	}
+	if killed {
+		t.Errorf("KillRunningQuery killed a query without knowing its data node")
+	}
+}
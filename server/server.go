@@ -19,7 +19,6 @@ import (
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/bolt"
 	idgen "github.com/influxdata/chronograf/id"
-	"github.com/influxdata/chronograf/influx"
 	clog "github.com/influxdata/chronograf/log"
 	"github.com/influxdata/chronograf/oauth2"
 	client "github.com/influxdata/usage-client/v1"
@@ -56,15 +55,16 @@ type Server struct {
 
 	NewSources string `long:"new-sources" description:"Config for adding a new InfluxDB source and Kapacitor server, in JSON as an array of objects, and surrounded by single quotes. E.g. --new-sources='[{\"influxdb\":{\"name\":\"Influx 1\",\"username\":\"user1\",\"password\":\"pass1\",\"url\":\"http://localhost:8086\",\"metaUrl\":\"http://metaurl.com\",\"type\":\"influx-enterprise\",\"insecureSkipVerify\":false,\"default\":true,\"telegraf\":\"telegraf\",\"sharedSecret\":\"cubeapples\"},\"kapacitor\":{\"name\":\"Kapa 1\",\"url\":\"http://localhost:9092\",\"active\":true}}]'" env:"NEW_SOURCES" hidden:"true"`
 
-	Develop         bool          `short:"d" long:"develop" description:"Run server in develop mode."`
-	BoltPath        string        `short:"b" long:"bolt-path" description:"Full path to boltDB file (e.g. './chronograf-v1.db')" env:"BOLT_PATH" default:"chronograf-v1.db"`
-	CannedPath      string        `short:"c" long:"canned-path" description:"Path to directory of pre-canned application layouts (/usr/share/chronograf/canned)" env:"CANNED_PATH" default:"canned"`
-	ProtoboardsPath string        `long:"protoboards-path" description:"Path to directory of protoboards (/usr/share/chronograf/protoboards)" env:"PROTOBOARDS_PATH" default:"protoboards"`
-	ResourcesPath   string        `long:"resources-path" description:"Path to directory of pre-canned dashboards, sources, kapacitors, and organizations (/usr/share/chronograf/resources)" env:"RESOURCES_PATH" default:"canned"`
-	TokenSecret     string        `short:"t" long:"token-secret" description:"Secret to sign tokens" env:"TOKEN_SECRET"`
-	JwksURL         string        `long:"jwks-url" description:"URL that returns OpenID Key Discovery JWKS document." env:"JWKS_URL"`
-	UseIDToken      bool          `long:"use-id-token" description:"Enable id_token processing." env:"USE_ID_TOKEN"`
-	AuthDuration    time.Duration `long:"auth-duration" default:"720h" description:"Total duration of cookie life for authentication (in hours). 0 means authentication expires on browser close." env:"AUTH_DURATION"`
+	Develop           bool          `short:"d" long:"develop" description:"Run server in develop mode."`
+	BoltPath          string        `short:"b" long:"bolt-path" description:"Full path to boltDB file (e.g. './chronograf-v1.db')" env:"BOLT_PATH" default:"chronograf-v1.db"`
+	CannedPath        string        `short:"c" long:"canned-path" description:"Path to directory of pre-canned application layouts (/usr/share/chronograf/canned)" env:"CANNED_PATH" default:"canned"`
+	ProtoboardsPath   string        `long:"protoboards-path" description:"Path to directory of protoboards (/usr/share/chronograf/protoboards)" env:"PROTOBOARDS_PATH" default:"protoboards"`
+	ResourcesPath     string        `long:"resources-path" description:"Path to directory of pre-canned dashboards, sources, kapacitors, and organizations (/usr/share/chronograf/resources)" env:"RESOURCES_PATH" default:"canned"`
+	TokenSecret       string        `short:"t" long:"token-secret" description:"Secret to sign tokens" env:"TOKEN_SECRET"`
+	CredentialsSecret string        `long:"credentials-secret" description:"Secret to encrypt the InfluxDB credentials users store for sources. Defaults to the token secret" env:"CREDENTIALS_SECRET"`
+	JwksURL           string        `long:"jwks-url" description:"URL that returns OpenID Key Discovery JWKS document." env:"JWKS_URL"`
+	UseIDToken        bool          `long:"use-id-token" description:"Enable id_token processing." env:"USE_ID_TOKEN"`
+	AuthDuration      time.Duration `long:"auth-duration" default:"720h" description:"Total duration of cookie life for authentication (in hours). 0 means authentication expires on browser close." env:"AUTH_DURATION"`
 
 	GithubClientID     string   `short:"i" long:"github-client-id" description:"Github Client ID for OAuth 2 support" env:"GH_CLIENT_ID"`
 	GithubClientSecret string   `short:"s" long:"github-client-secret" description:"Github Client Secret for OAuth 2 support" env:"GH_CLIENT_SECRET"`
@@ -99,6 +99,11 @@ type Server struct {
 	CustomLinks            map[string]string `long:"custom-link" description:"Custom link to be added to the client User menu. Multiple links can be added by using multiple of the same flag with different 'name:url' values, or as an environment variable with comma-separated 'name:url' values. E.g. via flags: '--custom-link=InfluxData:https://www.influxdata.com --custom-link=Chronograf:https://github.com/influxdata/chronograf'. E.g. via environment variable: 'export CUSTOM_LINKS=InfluxData:https://www.influxdata.com,Chronograf:https://github.com/influxdata/chronograf'" env:"CUSTOM_LINKS" env-delim:","`
 	TelegrafSystemInterval time.Duration     `long:"telegraf-system-interval" default:"1m" description:"Duration used in the GROUP BY time interval for the hosts list" env:"TELEGRAF_SYSTEM_INTERVAL"`
 
+	HealthSourceInterval time.Duration `long:"health-source-interval" default:"1m" description:"Interval between background health checks of InfluxDB sources. 0 disables them" env:"HEALTH_SOURCE_INTERVAL"`
+	HealthServerInterval time.Duration `long:"health-server-interval" default:"1m" description:"Interval between background health checks of Kapacitor and Flux servers. 0 disables them" env:"HEALTH_SERVER_INTERVAL"`
+	HealthHistory        int           `long:"health-history" default:"60" description:"Number of health checks kept for every source and server" env:"HEALTH_HISTORY"`
+	HealthAnnotations    bool          `long:"health-annotations" description:"Record outages of sources and servers as annotations on the source, except on sources authenticating each user" env:"HEALTH_ANNOTATIONS"`
+
 	ReportingDisabled bool   `short:"r" long:"reporting-disabled" description:"Disable reporting of usage stats (os,arch,version,cluster_id,uptime) once every 24hr" env:"REPORTING_DISABLED"`
 	LogLevel          string `short:"l" long:"log-level" value-name:"choice" choice:"debug" choice:"info" choice:"error" default:"info" description:"Set the logging level" env:"LOG_LEVEL"`
 	Basepath          string `short:"p" long:"basepath" description:"A URL path prefix under which all chronograf routes will be mounted. (Note: PREFIX_ROUTES has been deprecated. Now, if basepath is set, all routes will be prefixed with it.)" env:"BASE_PATH"`
@@ -244,6 +249,14 @@ func (s *Server) useAuth() bool {
 	return s.UseGithub() || s.UseGoogle() || s.UseHeroku() || s.UseGenericOAuth2() || s.UseAuth0()
 }
 
+// credentialsSecret is the secret encrypting the InfluxDB credentials of users
+func (s *Server) credentialsSecret() string {
+	if s.CredentialsSecret != "" {
+		return s.CredentialsSecret
+	}
+	return s.TokenSecret
+}
+
 func (s *Server) useTLS() bool {
 	return s.Cert != ""
 }
@@ -339,13 +352,23 @@ func (s *Server) Serve(ctx context.Context) error {
 			Error(err)
 		return err
 	}
-	service := openService(ctx, s.BuildInfo, s.BoltPath, s.newBuilders(logger), s.ProtoboardsPath, logger, s.useAuth())
+	service := openService(ctx, s.BuildInfo, s.BoltPath, s.newBuilders(logger), s.ProtoboardsPath, s.credentialsSecret(), logger, s.useAuth())
 	service.SuperAdminProviderGroups = superAdminProviderGroups{
 		auth0: s.Auth0SuperAdminOrg,
 	}
 	service.Env = chronograf.Environment{
 		TelegrafSystemInterval: s.TelegrafSystemInterval,
 	}
+	service.ShareSecret = s.TokenSecret
+	service.HealthMonitor = &HealthMonitor{
+		Store:            service.Store,
+		TimeSeriesClient: service.TimeSeriesClient,
+		Logger:           logger,
+		SourceInterval:   s.HealthSourceInterval,
+		ServerInterval:   s.HealthServerInterval,
+		HistorySize:      s.HealthHistory,
+		Annotate:         s.HealthAnnotations,
+	}
 	if err := service.HandleNewSources(ctx, s.NewSources); err != nil {
 		logger.
 			WithField("component", "server").
@@ -421,6 +444,7 @@ func (s *Server) Serve(ctx context.Context) error {
 	if !s.ReportingDisabled {
 		go reportUsageStats(s.BuildInfo, logger)
 	}
+	go service.HealthMonitor.Run(ctx)
 	scheme := "http"
 	if s.useTLS() {
 		scheme = "https"
@@ -443,9 +467,10 @@ func (s *Server) Serve(ctx context.Context) error {
 	return nil
 }
 
-func openService(ctx context.Context, buildInfo chronograf.BuildInfo, boltPath string, builder builders, protoboardsPath string, logger chronograf.Logger, useAuth bool) Service {
+func openService(ctx context.Context, buildInfo chronograf.BuildInfo, boltPath string, builder builders, protoboardsPath string, credentialsSecret string, logger chronograf.Logger, useAuth bool) Service {
 	db := bolt.NewClient()
 	db.Path = boltPath
+	db.SourceCredentialsStore.Secret = credentialsSecret
 
 	if err := db.Open(ctx, logger, buildInfo, bolt.WithBackup()); err != nil {
 		logger.
@@ -493,7 +518,7 @@ func openService(ctx context.Context, buildInfo chronograf.BuildInfo, boltPath s
 		os.Exit(1)
 	}
 
-	protoboards, err := builder.Protoboards.Build()
+	protoboards, err := builder.Protoboards.Build(db.ProtoboardsStore)
 	if err != nil {
 		logger.
 			WithField("component", "LayoutsStore").
@@ -501,8 +526,10 @@ func openService(ctx context.Context, buildInfo chronograf.BuildInfo, boltPath s
 		os.Exit(1)
 	}
 
+	// Clients of every request share the health of the nodes of sources
+	nodes := &SourceNodes{}
 	return Service{
-		TimeSeriesClient: &InfluxClient{},
+		TimeSeriesClient: &InfluxClient{Nodes: nodes},
 		Store: &Store{
 			LayoutsStore:            layouts,
 			DashboardsStore:         dashboards,
@@ -514,11 +541,13 @@ func openService(ctx context.Context, buildInfo chronograf.BuildInfo, boltPath s
 			ConfigStore:             db.ConfigStore,
 			MappingsStore:           db.MappingsStore,
 			OrganizationConfigStore: db.OrganizationConfigStore,
+			SourceCredentialsStore:  db.SourceCredentialsStore,
+			DashboardSharesStore:    db.DashboardSharesStore,
 			CellService:             db,
 		},
-		Logger:    logger,
-		UseAuth:   useAuth,
-		Databases: &influx.Client{Logger: logger},
+		Logger:          logger,
+		UseAuth:         useAuth,
+		DatabasesClient: &InfluxDatabasesClient{Nodes: nodes},
 	}
 }
 
@@ -7,6 +7,8 @@ import (
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/enterprise"
 	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/prometheus"
+	"github.com/influxdata/chronograf/relay"
 )
 
 // Service handles REST calls to the persistence
@@ -17,7 +19,9 @@ type Service struct {
 	UseAuth                  bool
 	SuperAdminProviderGroups superAdminProviderGroups
 	Env                      chronograf.Environment
-	Databases                chronograf.Databases
+	DatabasesClient          DatabasesClient
+	HealthMonitor            *HealthMonitor
+	ShareSecret              string // ShareSecret signs the tokens of dashboard shares
 }
 
 type superAdminProviderGroups struct {
@@ -26,7 +30,7 @@ type superAdminProviderGroups struct {
 
 // TimeSeriesClient returns the correct client for a time series database.
 type TimeSeriesClient interface {
-	New(chronograf.Source, chronograf.Logger) (chronograf.TimeSeries, error)
+	New(context.Context, chronograf.Source, chronograf.Logger) (chronograf.TimeSeries, error)
 }
 
 // ErrorMessage is the error response format for all service errors
@@ -35,26 +39,115 @@ type ErrorMessage struct {
 	Message string `json:"message"`
 }
 
+// DatabasesClient returns a new client administering the databases of a
+// source; each request connects its own client with the identity of its user.
+type DatabasesClient interface {
+	New(chronograf.Source, chronograf.Logger) chronograf.Databases
+}
+
 // TimeSeries returns a new client connected to a time series database
-func (s *Service) TimeSeries(src chronograf.Source) (chronograf.TimeSeries, error) {
-	return s.TimeSeriesClient.New(src, s.Logger)
+func (s *Service) TimeSeries(ctx context.Context, src chronograf.Source) (chronograf.TimeSeries, error) {
+	return s.TimeSeriesClient.New(ctx, src, s.Logger)
 }
 
-// InfluxClient returns a new client to connect to OSS or Enterprise
-type InfluxClient struct{}
+// databases returns a new service administering the databases of the source
+func (s *Service) databases(src *chronograf.Source) chronograf.Databases {
+	return s.DatabasesClient.New(*src, s.Logger)
+}
 
-// New creates a client to connect to OSS or enterprise
-func (c *InfluxClient) New(src chronograf.Source, logger chronograf.Logger) (chronograf.TimeSeries, error) {
-	client := &influx.Client{
+// InfluxDatabasesClient returns new clients administering the databases of
+// InfluxDB sources
+type InfluxDatabasesClient struct {
+	// Nodes shares the health of the backends of Relay sources between the
+	// clients of every request; clients have their own if nil
+	Nodes *SourceNodes
+}
+
+// New creates a client administering the databases of the source. Databases
+// of InfluxDB Relay sources are administered on every backend.
+func (c *InfluxDatabasesClient) New(src chronograf.Source, logger chronograf.Logger) chronograf.Databases {
+	if src.Type == chronograf.InfluxRelay && len(src.Backends) != 0 {
+		return &relay.Client{
+			Logger: logger,
+			Health: c.Nodes.relay(src),
+		}
+	}
+	return &influx.Client{
 		Logger: logger,
 	}
-	if err := client.Connect(context.TODO(), &src); err != nil {
-		return nil, err
+}
+
+// InfluxClient returns a new client to connect to OSS or Enterprise
+type InfluxClient struct {
+	// Nodes shares the state of the data nodes of Enterprise sources and the
+	// health of the backends of Relay sources between the clients of every
+	// request; clients have their own if nil
+	Nodes *SourceNodes
+}
+
+// New creates a client to connect to OSS, enterprise, relay or Prometheus
+func (c *InfluxClient) New(ctx context.Context, src chronograf.Source, logger chronograf.Logger) (chronograf.TimeSeries, error) {
+	if src.Type == chronograf.InfluxRelay && len(src.Backends) != 0 {
+		client := &relay.Client{
+			Logger: logger,
+			Health: c.Nodes.relay(src),
+		}
+		if err := client.Connect(ctx, &src); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	if src.Type == chronograf.Prometheus {
+		client := &prometheus.Client{
+			Logger: logger,
+		}
+		if err := client.Connect(ctx, &src); err != nil {
+			return nil, err
+		}
+		return client, nil
 	}
 	if src.Type == chronograf.InfluxEnterprise && src.MetaURL != "" {
 		tls := strings.Contains(src.MetaURL, "https")
 		insecure := src.InsecureSkipVerify
-		return enterprise.NewClientWithTimeSeries(logger, src.MetaURL, influx.DefaultAuthorization(&src), tls, insecure, client)
+		client, err := enterprise.NewClientWithURL(src.MetaURL, influx.DefaultAuthorization(ctx, &src), tls, insecure, logger)
+		if err != nil {
+			return nil, err
+		}
+		client.Nodes = c.Nodes.cluster(src)
+		// Data nodes are discovered from the meta nodes so that reads fail
+		// over to the other data nodes
+		if err := client.Connect(ctx, &src); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	client := &influx.Client{
+		Logger: logger,
+	}
+	if err := client.Connect(ctx, &src); err != nil {
+		return nil, err
 	}
 	return client, nil
 }
+
+// CheckNodes pings the data nodes of the Enterprise sources and the backends
+// of the Relay sources and forgets those of sources no longer listed
+func (c *InfluxClient) CheckNodes(ctx context.Context, srcs []chronograf.Source) {
+	c.Nodes.CheckNodes(ctx, srcs)
+}
+
+// newTSDBStatus creates a client reporting the status of the source
+func newTSDBStatus(ctx context.Context, src *chronograf.Source, logger chronograf.Logger) (chronograf.TSDBStatus, error) {
+	var cli chronograf.TSDBStatus = &influx.Client{
+		Logger: logger,
+	}
+	if src.Type == chronograf.Prometheus {
+		cli = &prometheus.Client{
+			Logger: logger,
+		}
+	}
+	if err := cli.Connect(ctx, src); err != nil {
+		return nil, err
+	}
+	return cli, nil
+}
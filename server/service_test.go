@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestInfluxClient_New_EnterpriseFailsOver(t *testing.T) {
+	queries := map[string]int{}
+	newDataNode := func(name string, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			queries[name]++
+			rw.WriteHeader(status)
+			rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		}))
+	}
+	down, up := newDataNode("down", http.StatusServiceUnavailable), newDataNode("up", http.StatusOK)
+	defer down.Close()
+	defer up.Close()
+
+	discoveries := 0
+	meta := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		discoveries++
+		if r.URL.Path != "/show-cluster" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(rw).Encode(enterprise.Cluster{
+			DataNodes: []enterprise.DataNode{
+				{HTTPAddr: down.URL},
+				{HTTPAddr: up.URL},
+			},
+		})
+	}))
+	defer meta.Close()
+
+	c := &InfluxClient{Nodes: &SourceNodes{}}
+	src := chronograf.Source{
+		ID:      1,
+		Type:    chronograf.InfluxEnterprise,
+		URL:     down.URL,
+		MetaURL: meta.URL,
+	}
+	// Every request creates its own client
+	for i := 0; i < 2; i++ {
+		ts, err := c.New(context.Background(), src, &mocks.TestLogger{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ts.Query(context.Background(), chronograf.Query{Command: "SELECT * FROM cpu", DB: "telegraf"}); err != nil {
+			t.Fatalf("InfluxClient.New() query did not fail over: %v", err)
+		}
+	}
+	if queries["down"] != 1 || queries["up"] != 2 {
+		t.Errorf("InfluxClient.New() queries = %v, want the failing data node tried once and avoided by the next request", queries)
+	}
+	if discoveries != 1 {
+		t.Errorf("InfluxClient.New() discovered the data nodes %d times, want once", discoveries)
+	}
+
+	c.CheckNodes(context.Background(), nil)
+	if len(c.Nodes.clusters) != 0 {
+		t.Errorf("InfluxClient.CheckNodes() kept the data nodes of a source no longer listed")
+	}
+}
+
+func TestInfluxClient_New_EnterpriseWithoutMetaNodes(t *testing.T) {
+	queries := 0
+	ds := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		queries++
+		rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	defer ds.Close()
+	meta := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer meta.Close()
+
+	c := &InfluxClient{Nodes: &SourceNodes{}}
+	ts, err := c.New(context.Background(), chronograf.Source{
+		ID:      1,
+		Type:    chronograf.InfluxEnterprise,
+		URL:     ds.URL,
+		MetaURL: meta.URL,
+	}, &mocks.TestLogger{})
+	if err != nil {
+		t.Fatalf("InfluxClient.New() error = %v, want the URL of the source queried", err)
+	}
+	if _, err := ts.Query(context.Background(), chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+		t.Fatalf("InfluxClient.New() query error = %v", err)
+	}
+	if queries != 1 {
+		t.Errorf("InfluxClient.New() sent %d queries to the URL of the source, want 1", queries)
+	}
+}
+
+func TestInfluxClient_New_RelayRemembersFailedBackends(t *testing.T) {
+	queries := map[string]int{}
+	newBackend := func(name string, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			queries[name]++
+			rw.WriteHeader(status)
+			rw.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		}))
+	}
+	down, up := newBackend("down", http.StatusServiceUnavailable), newBackend("up", http.StatusOK)
+	defer down.Close()
+	defer up.Close()
+
+	c := &InfluxClient{Nodes: &SourceNodes{}}
+	src := chronograf.Source{
+		ID:       1,
+		Type:     chronograf.InfluxRelay,
+		URL:      "http://relay:9096",
+		Backends: []string{down.URL, up.URL},
+	}
+	// Every request creates its own client
+	for i := 0; i < 2; i++ {
+		ts, err := c.New(context.Background(), src, &mocks.TestLogger{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ts.Query(context.Background(), chronograf.Query{Command: "SELECT * FROM cpu", DB: "telegraf"}); err != nil {
+			t.Fatalf("InfluxClient.New() query did not fail over: %v", err)
+		}
+	}
+	if queries["down"] != 1 || queries["up"] != 2 {
+		t.Errorf("InfluxClient.New() queries = %v, want the failing backend tried once and avoided by the next request", queries)
+	}
+
+	c.CheckNodes(context.Background(), []chronograf.Source{src})
+	if len(c.Nodes.relays) != 1 {
+		t.Errorf("InfluxClient.CheckNodes() forgot the backends of a listed source")
+	}
+	c.CheckNodes(context.Background(), nil)
+	if len(c.Nodes.relays) != 0 {
+		t.Errorf("InfluxClient.CheckNodes() kept the backends of a source no longer listed")
+	}
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+)
+
+type shardLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type shardResponse struct {
+	chronograf.Shard
+	Links shardLinks `json:"links"` // Links are URI locations related to the shard
+}
+
+func newShardResponse(srcID int, db string, shard chronograf.Shard) shardResponse {
+	base := "/chronograf/v1/sources"
+	return shardResponse{
+		Shard: shard,
+		Links: shardLinks{
+			Self: fmt.Sprintf("%s/%d/dbs/%s/shards/%d", base, srcID, db, shard.ID),
+		},
+	}
+}
+
+type shardsResponse struct {
+	Shards []shardResponse `json:"shards"`
+}
+
+// Shards lists the shards of a database along with their time ranges and
+// sizes. The shards of a single retention policy are listed with the rp
+// query parameter.
+func (s *Service) Shards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	shards, err := dbsvc.AllShards(ctx, db)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to get shards %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	rp := r.URL.Query().Get("rp")
+	res := shardsResponse{
+		Shards: []shardResponse{},
+	}
+	for _, shard := range shards {
+		if rp != "" && shard.RetentionPolicy != rp {
+			continue
+		}
+		res.Shards = append(res.Shards, newShardResponse(srcID, db, shard))
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// DropShard removes a shard of a database along with its data
+func (s *Service) DropShard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	param := httprouter.GetParamFromContext(ctx, "sid")
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, fmt.Sprintf("Error converting shard ID %s", param), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	// Shard ids are unique to the source, so the shard is only dropped if
+	// it belongs to the database of the request
+	db := httprouter.GetParamFromContext(ctx, "db")
+	shards, err := dbsvc.AllShards(ctx, db)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to get shards %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	found := false
+	for _, shard := range shards {
+		if shard.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		notFound(w, id, s.Logger)
+		return
+	}
+
+	if err := dbsvc.DropShard(ctx, id); err != nil {
+		databasesError(w, err, s.Logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
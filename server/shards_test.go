@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_Shards(t *testing.T) {
+	h := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+					return chronograf.Source{
+						ID: 0,
+					}, nil
+				},
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+		DatabasesClient: &mocks.Databases{
+			ConnectF: func(context.Context, *chronograf.Source) error {
+				return nil
+			},
+			AllShardsF: func(ctx context.Context, db string) ([]chronograf.Shard, error) {
+				return []chronograf.Shard{
+					{
+						ID:              2,
+						Database:        db,
+						RetentionPolicy: "autogen",
+						ShardGroup:      2,
+						StartTime:       "2018-08-27T00:00:00Z",
+						EndTime:         "2018-09-03T00:00:00Z",
+						ExpiryTime:      "2018-09-03T00:00:00Z",
+						Owners:          []string{},
+						Size:            4096,
+					},
+					{
+						ID:              3,
+						Database:        db,
+						RetentionPolicy: "monthly",
+						ShardGroup:      3,
+						Owners:          []string{},
+					},
+				}, nil
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "http://any.url?rp=autogen", nil)
+	r = r.WithContext(httprouter.WithParams(
+		context.Background(),
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "0",
+			},
+			{
+				Key:   "db",
+				Value: "pineapples",
+			},
+		}))
+
+	h.Shards(w, r)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		t.Errorf("StatusCode:\nwant\n200\ngot\n%v", resp.StatusCode)
+	}
+	want := `{"shards":[{"id":"2","db":"pineapples","rp":"autogen","shardGroup":"2","startTime":"2018-08-27T00:00:00Z","endTime":"2018-09-03T00:00:00Z","expiryTime":"2018-09-03T00:00:00Z","owners":[],"size":4096,"links":{"self":"/chronograf/v1/sources/0/dbs/pineapples/shards/2"}}]}
+`
+	if string(body) != want {
+		t.Errorf("Body:\nwant\n%s\ngot\n%s", want, body)
+	}
+}
+
+func TestService_DropShard(t *testing.T) {
+	tests := []struct {
+		name        string
+		shardID     string
+		wantStatus  int
+		wantDropped bool
+	}{
+		{
+			name:        "Drops a shard of the database",
+			shardID:     "2",
+			wantStatus:  204,
+			wantDropped: true,
+		},
+		{
+			name:       "Shards of other databases are not found",
+			shardID:    "7",
+			wantStatus: 404,
+		},
+		{
+			name:       "Invalid shard ids are rejected",
+			shardID:    "two",
+			wantStatus: 422,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dropped := false
+			h := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID: 0,
+							}, nil
+						},
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+				DatabasesClient: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					AllShardsF: func(ctx context.Context, db string) ([]chronograf.Shard, error) {
+						return []chronograf.Shard{{ID: 2, Database: db}}, nil
+					},
+					DropShardF: func(ctx context.Context, id uint64) error {
+						dropped = id == 2
+						return nil
+					},
+				},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", "http://any.url", nil)
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "0",
+					},
+					{
+						Key:   "db",
+						Value: "pineapples",
+					},
+					{
+						Key:   "sid",
+						Value: tt.shardID,
+					},
+				}))
+
+			h.DropShard(w, r)
+
+			if resp := w.Result(); resp.StatusCode != tt.wantStatus {
+				t.Errorf("%q. StatusCode:\nwant\n%v\ngot\n%v", tt.name, tt.wantStatus, resp.StatusCode)
+			}
+			if dropped != tt.wantDropped {
+				t.Errorf("%q. Dropped:\nwant\n%v\ngot\n%v", tt.name, tt.wantDropped, dropped)
+			}
+		})
+	}
+}
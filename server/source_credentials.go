@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+// userCredentials resolves the InfluxDB identity of the Chronograf user of a
+// request for sources that authenticate each user
+type userCredentials struct {
+	ctx   context.Context
+	store chronograf.SourceCredentialsStore
+	user  *chronograf.User
+}
+
+var _ influx.CredentialsResolver = &userCredentials{}
+
+// Username is the name of the Chronograf user
+func (u *userCredentials) Username() string {
+	return u.user.Name
+}
+
+// Credentials are the InfluxDB credentials the user stored for the source
+func (u *userCredentials) Credentials(src *chronograf.Source) (string, string, error) {
+	if u.store == nil {
+		return "", "", chronograf.ErrSourceCredentialsNotFound
+	}
+	creds, err := u.store.Get(u.ctx, src.ID, u.user.ID)
+	if err != nil {
+		return "", "", err
+	}
+	return creds.Username, creds.Password, nil
+}
+
+type sourceCredentialsLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type sourceCredentialsResponse struct {
+	Username string                 `json:"username"`
+	Links    sourceCredentialsLinks `json:"links"`
+}
+
+func newSourceCredentialsResponse(creds chronograf.SourceCredentials) sourceCredentialsResponse {
+	return sourceCredentialsResponse{
+		Username: creds.Username,
+		Links: sourceCredentialsLinks{
+			Self: fmt.Sprintf("/chronograf/v1/sources/%d/credentials", creds.SourceID),
+		},
+	}
+}
+
+// sourceCredentialsUser returns the source of the request and the user
+// owning the credentials. Credentials are always those of the current user.
+func (s *Service) sourceCredentialsUser(w http.ResponseWriter, r *http.Request) (chronograf.Source, *chronograf.User, bool) {
+	ctx := r.Context()
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return chronograf.Source{}, nil, false
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return chronograf.Source{}, nil, false
+	}
+
+	u, ok := hasUserContext(ctx)
+	if !ok {
+		Error(w, http.StatusUnprocessableEntity, "Credentials can only be stored by authenticated users", s.Logger)
+		return chronograf.Source{}, nil, false
+	}
+	return src, u, true
+}
+
+// SourceCredentials returns the InfluxDB username the current user stored
+// for a source. The password is never returned.
+func (s *Service) SourceCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	src, u, ok := s.sourceCredentialsUser(w, r)
+	if !ok {
+		return
+	}
+
+	creds, err := s.Store.SourceCredentials(ctx).Get(ctx, src.ID, u.ID)
+	if err == chronograf.ErrSourceCredentialsNotFound {
+		Error(w, http.StatusNotFound, err.Error(), s.Logger)
+		return
+	} else if err != nil {
+		Error(w, http.StatusInternalServerError, err.Error(), s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newSourceCredentialsResponse(creds), s.Logger)
+}
+
+type putSourceCredentialsRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// UpdateSourceCredentials stores the InfluxDB credentials of the current
+// user for a source using per-user credentials
+func (s *Service) UpdateSourceCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	src, u, ok := s.sourceCredentialsUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req putSourceCredentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if src.UserAuth != chronograf.UserAuthCredentials {
+		invalidData(w, fmt.Errorf("source %d does not use per-user credentials", src.ID), s.Logger)
+		return
+	}
+	if req.Username == "" {
+		invalidData(w, fmt.Errorf("username is required"), s.Logger)
+		return
+	}
+
+	creds := chronograf.SourceCredentials{
+		SourceID: src.ID,
+		UserID:   u.ID,
+		Username: req.Username,
+		Password: req.Password,
+	}
+	if err := s.Store.SourceCredentials(ctx).Put(ctx, creds); err != nil {
+		Error(w, http.StatusInternalServerError, fmt.Sprintf("Unable to store credentials: %v", err), s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newSourceCredentialsResponse(creds), s.Logger)
+}
+
+// RemoveSourceCredentials deletes the InfluxDB credentials of the current
+// user for a source
+func (s *Service) RemoveSourceCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	src, u, ok := s.sourceCredentialsUser(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.Store.SourceCredentials(ctx).Delete(ctx, src.ID, u.ID)
+	if err == chronograf.ErrSourceCredentialsNotFound {
+		Error(w, http.StatusNotFound, err.Error(), s.Logger)
+		return
+	} else if err != nil {
+		Error(w, http.StatusInternalServerError, err.Error(), s.Logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
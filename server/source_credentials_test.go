@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_UpdateSourceCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		userAuth   string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantStored chronograf.SourceCredentials
+	}{
+		{
+			name:       "Stores the credentials of the current user",
+			userAuth:   chronograf.UserAuthCredentials,
+			body:       `{"username":"mcfly","password":"hoverboard"}`,
+			wantStatus: 200,
+			wantBody: `{"username":"mcfly","links":{"self":"/chronograf/v1/sources/1/credentials"}}
+`,
+			wantStored: chronograf.SourceCredentials{
+				SourceID: 1,
+				UserID:   1955,
+				Username: "mcfly",
+				Password: "hoverboard",
+			},
+		},
+		{
+			name:       "Sources with shared credentials are rejected",
+			body:       `{"username":"mcfly","password":"hoverboard"}`,
+			wantStatus: 422,
+			wantBody:   `{"code":422,"message":"source 1 does not use per-user credentials"}`,
+		},
+		{
+			name:       "Requires a username",
+			userAuth:   chronograf.UserAuthCredentials,
+			body:       `{"password":"hoverboard"}`,
+			wantStatus: 422,
+			wantBody:   `{"code":422,"message":"username is required"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored chronograf.SourceCredentials
+			h := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID:       srcID,
+								UserAuth: tt.userAuth,
+							}, nil
+						},
+					},
+					SourceCredentialsStore: &mocks.SourceCredentialsStore{
+						PutF: func(ctx context.Context, creds chronograf.SourceCredentials) error {
+							stored = creds
+							return nil
+						},
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "http://any.url", ioutil.NopCloser(bytes.NewReader([]byte(tt.body))))
+			ctx := context.WithValue(context.Background(), UserContextKey, &chronograf.User{ID: 1955, Name: "marty"})
+			r = r.WithContext(httprouter.WithParams(
+				ctx,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "1",
+					},
+				}))
+
+			h.UpdateSourceCredentials(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("%q. StatusCode:\nwant\n%v\ngot\n%v", tt.name, tt.wantStatus, resp.StatusCode)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("%q. Body:\nwant\n%s\ngot\n%s", tt.name, tt.wantBody, body)
+			}
+			if stored != tt.wantStored {
+				t.Errorf("%q. Stored:\nwant\n%v\ngot\n%v", tt.name, tt.wantStored, stored)
+			}
+		})
+	}
+}
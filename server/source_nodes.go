@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/relay"
+)
+
+// SourceNodes keeps the state of the data nodes of InfluxDB Enterprise
+// sources and the health of the backends of InfluxDB Relay sources across
+// the clients created for every request, so that nodes ejected by one
+// request are avoided by the next ones
+type SourceNodes struct {
+	mu       sync.Mutex
+	clusters map[int]*sourceCluster
+	relays   map[int]*sourceRelay
+}
+
+// sourceRelay is the health of the backends of a Relay source along with
+// the backends it was tracked for
+type sourceRelay struct {
+	backends string
+	health   *enterprise.Health
+}
+
+// sourceCluster is the state of the data nodes of an Enterprise source
+// along with the meta URL they were discovered from
+type sourceCluster struct {
+	metaURL string
+	state   *enterprise.ClusterState
+}
+
+// nodeChecker is implemented by TimeSeriesClients keeping the health of the
+// nodes of sources across requests
+type nodeChecker interface {
+	// CheckNodes pings the nodes of the sources and forgets the nodes of
+	// sources no longer listed
+	CheckNodes(context.Context, []chronograf.Source)
+}
+
+// cluster returns the state of the data nodes of the source. Sources not yet
+// stored and sources without SourceNodes get a state of their own.
+func (n *SourceNodes) cluster(src chronograf.Source) *enterprise.ClusterState {
+	if n == nil || src.ID == 0 {
+		return enterprise.NewClusterState()
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.clusters == nil {
+		n.clusters = map[int]*sourceCluster{}
+	}
+	c, ok := n.clusters[src.ID]
+	if !ok || c.metaURL != src.MetaURL {
+		c = &sourceCluster{
+			metaURL: src.MetaURL,
+			state:   enterprise.NewClusterState(),
+		}
+		n.clusters[src.ID] = c
+	}
+	return c.state
+}
+
+// relay returns the health of the backends of the Relay source. Sources not
+// yet stored and sources without SourceNodes get a health of their own.
+func (n *SourceNodes) relay(src chronograf.Source) *enterprise.Health {
+	if n == nil || src.ID == 0 {
+		return enterprise.NewHealth()
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.relays == nil {
+		n.relays = map[int]*sourceRelay{}
+	}
+	backends := strings.Join(src.Backends, ",")
+	r, ok := n.relays[src.ID]
+	if !ok || r.backends != backends {
+		r = &sourceRelay{
+			backends: backends,
+			health:   enterprise.NewHealth(),
+		}
+		n.relays[src.ID] = r
+	}
+	return r.health
+}
+
+// CheckNodes pings the data nodes of the Enterprise sources and the
+// backends of the Relay sources queried so far and forgets those of sources
+// no longer listed
+func (n *SourceNodes) CheckNodes(ctx context.Context, srcs []chronograf.Source) {
+	if n == nil {
+		return
+	}
+
+	listed := map[int]bool{}
+	for _, src := range srcs {
+		listed[src.ID] = true
+	}
+	n.mu.Lock()
+	states := map[int]*enterprise.ClusterState{}
+	for id, c := range n.clusters {
+		if !listed[id] {
+			delete(n.clusters, id)
+			continue
+		}
+		states[id] = c.state
+	}
+	healths := map[int]*enterprise.Health{}
+	for id, r := range n.relays {
+		if !listed[id] {
+			delete(n.relays, id)
+			continue
+		}
+		healths[id] = r.health
+	}
+	n.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := range srcs {
+		if state, ok := states[srcs[i].ID]; ok {
+			wg.Add(1)
+			go func(src *chronograf.Source) {
+				defer wg.Done()
+				state.CheckHealth(ctx, src)
+			}(&srcs[i])
+		}
+		if health, ok := healths[srcs[i].ID]; ok {
+			wg.Add(1)
+			go func(src *chronograf.Source) {
+				defer wg.Done()
+				cl := &relay.Client{
+					Health: health,
+				}
+				if err := cl.Connect(ctx, src); err == nil {
+					cl.CheckHealth(ctx)
+				}
+			}(&srcs[i])
+		}
+	}
+	wg.Wait()
+}
@@ -25,12 +25,14 @@ type sourceLinks struct {
 	Proxy       string `json:"proxy"`           // URL for proxy endpoint
 	Queries     string `json:"queries"`         // URL for the queries analysis endpoint
 	Write       string `json:"write"`           // URL for the write line-protocol endpoint
+	Ingest      string `json:"ingest"`          // URL for the validated line-protocol and CSV ingest endpoint
 	Permissions string `json:"permissions"`     // URL for all allowed permissions for this source
 	Users       string `json:"users"`           // URL for all users associated with this source
 	Roles       string `json:"roles,omitempty"` // URL for all users associated with this source
 	Databases   string `json:"databases"`       // URL for the databases contained within this source
 	Annotations string `json:"annotations"`     // URL for the annotations of this source
 	Health      string `json:"health"`          // URL for source health
+	Credentials string `json:"credentials,omitempty"` // URL for the InfluxDB credentials of the current user
 	Flux		string `json:"flux,omitempty"`  // URL for flux if it exists
 }
 
@@ -46,9 +48,17 @@ type authenticationResponse struct {
 }
 
 func sourceAuthenticationMethod(ctx context.Context, src chronograf.Source) authenticationResponse {
+	if src.Type == chronograf.InfluxDBv2 {
+		return authenticationResponse{ID: src.ID, AuthenticationMethod: "token"}
+	}
+	// Sources authenticating each user report how users are authenticated
+	if src.UserAuth != "" {
+		return authenticationResponse{ID: src.ID, AuthenticationMethod: src.UserAuth}
+	}
+
 	ldapEnabled := false
 	if src.MetaURL != "" {
-		authorizer := influx.DefaultAuthorization(&src)
+		authorizer := influx.DefaultAuthorization(ctx, &src)
 		metaURL, err := url.Parse(src.MetaURL)
 
 		if err == nil {
@@ -102,9 +112,10 @@ func newSourceResponse(ctx context.Context, src chronograf.Source) sourceRespons
 
 	authMethod := sourceAuthenticationMethod(ctx, src)
 
-	// Omit the password and shared secret on response
+	// Omit the password, shared secret and token on response
 	src.Password = ""
 	src.SharedSecret = ""
+	src.Token = ""
 
 	httpAPISrcs := "/chronograf/v1/sources"
 	res := sourceResponse{
@@ -117,6 +128,7 @@ func newSourceResponse(ctx context.Context, src chronograf.Source) sourceRespons
 			Proxy:       fmt.Sprintf("%s/%d/proxy", httpAPISrcs, src.ID),
 			Queries:     fmt.Sprintf("%s/%d/queries", httpAPISrcs, src.ID),
 			Write:       fmt.Sprintf("%s/%d/write", httpAPISrcs, src.ID),
+			Ingest:      fmt.Sprintf("%s/%d/ingest", httpAPISrcs, src.ID),
 			Permissions: fmt.Sprintf("%s/%d/permissions", httpAPISrcs, src.ID),
 			Users:       fmt.Sprintf("%s/%d/users", httpAPISrcs, src.ID),
 			Databases:   fmt.Sprintf("%s/%d/dbs", httpAPISrcs, src.ID),
@@ -124,11 +136,17 @@ func newSourceResponse(ctx context.Context, src chronograf.Source) sourceRespons
 			Health:      fmt.Sprintf("%s/%d/health", httpAPISrcs, src.ID),
 		},
 	}
+	if src.UserAuth == chronograf.UserAuthCredentials {
+		res.Links.Credentials = fmt.Sprintf("%s/%d/credentials", httpAPISrcs, src.ID)
+	}
 
 	// we are ignoring the error because the error state means that we'll
 	// turn off the flux querying in the frontend anyway.  Is this English?
 	// good 'nuf
-	isFluxEnabled, _ := hasFlux(ctx, src)
+	isFluxEnabled := src.Type == chronograf.InfluxDBv2
+	if !isFluxEnabled {
+		isFluxEnabled, _ = hasFlux(ctx, src)
+	}
 	if isFluxEnabled {
 		res.Links.Flux = fmt.Sprintf("%s/%d/proxy/flux", httpAPISrcs, src.ID)
 	}
@@ -193,11 +211,8 @@ func (s *Service) NewSource(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Service) tsdbVersion(ctx context.Context, src *chronograf.Source) (string, error) {
-	cli := &influx.Client{
-		Logger: s.Logger,
-	}
-
-	if err := cli.Connect(ctx, src); err != nil {
+	cli, err := newTSDBStatus(ctx, src, s.Logger)
+	if err != nil {
 		return "", err
 	}
 
@@ -208,11 +223,8 @@ func (s *Service) tsdbVersion(ctx context.Context, src *chronograf.Source) (stri
 }
 
 func (s *Service) tsdbType(ctx context.Context, src *chronograf.Source) (string, error) {
-	cli := &influx.Client{
-		Logger: s.Logger,
-	}
-
-	if err := cli.Connect(ctx, src); err != nil {
+	cli, err := newTSDBStatus(ctx, src, s.Logger)
+	if err != nil {
 		return "", err
 	}
 	return cli.Type(ctx)
@@ -278,7 +290,9 @@ func (s *Service) SourcesID(w http.ResponseWriter, r *http.Request) {
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
 
-// RemoveSource deletes the source from the store
+// RemoveSource deletes the source from the store. With deleteTasks=true the
+// tasks Chronograf generated for the source on its kapacitors are deleted as
+// well, while dryRun=true only lists them.
 func (s *Service) RemoveSource(w http.ResponseWriter, r *http.Request) {
 	id, err := paramID("id", r)
 	if err != nil {
@@ -286,8 +300,32 @@ func (s *Service) RemoveSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	src := chronograf.Source{ID: id}
 	ctx := r.Context()
+	var cleanup *taskCleanupResponse
+	if deleteTasks, dryRun := taskCleanupParams(r); deleteTasks || dryRun {
+		src, err := s.Store.Sources(ctx).Get(ctx, id)
+		if err != nil {
+			notFound(w, id, s.Logger)
+			return
+		}
+		kapas, err := s.sourceKapacitors(ctx, id)
+		if err != nil {
+			unknownErrorWithMessage(w, err, s.Logger)
+			return
+		}
+		res, err := s.cleanupTasks(ctx, &src, kapas, dryRun)
+		if err != nil {
+			taskCleanupError(w, res, err, s.Logger)
+			return
+		}
+		if dryRun {
+			encodeJSON(w, http.StatusOK, res, s.Logger)
+			return
+		}
+		cleanup = &res
+	}
+
+	src := chronograf.Source{ID: id}
 	if err = s.Store.Sources(ctx).Delete(ctx, src); err != nil {
 		if err == chronograf.ErrSourceNotFound {
 			notFound(w, id, s.Logger)
@@ -303,6 +341,18 @@ func (s *Service) RemoveSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Remove the InfluxDB credentials users stored for this source
+	if store := s.Store.SourceCredentials(ctx); store != nil {
+		if err = store.DeleteSource(ctx, id); err != nil {
+			unknownErrorWithMessage(w, err, s.Logger)
+			return
+		}
+	}
+
+	if cleanup != nil {
+		encodeJSON(w, http.StatusOK, cleanup, s.Logger)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -321,11 +371,8 @@ func (s *Service) SourceHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cli := &influx.Client{
-		Logger: s.Logger,
-	}
-
-	if err := cli.Connect(ctx, &src); err != nil {
+	cli, err := newTSDBStatus(ctx, &src, s.Logger)
+	if err != nil {
 		Error(w, http.StatusBadRequest, "Error contacting source", s.Logger)
 		return
 	}
@@ -339,7 +386,8 @@ func (s *Service) SourceHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 // removeSrcsKapa will remove all kapacitors and kapacitor rules from the stores.
-// However, it will not remove the kapacitor tickscript from kapacitor itself.
+// However, it will not remove the kapacitor tickscript from kapacitor itself;
+// RemoveSource does so beforehand when asked to delete the tasks.
 func (s *Service) removeSrcsKapa(ctx context.Context, srcID int) error {
 	kapas, err := s.Store.Servers(ctx).All(ctx)
 	if err != nil {
@@ -368,6 +416,13 @@ func (s *Service) removeSrcsKapa(ctx context.Context, srcID int) error {
 	return nil
 }
 
+// patchSourceRequest is a source whose user authentication is only changed
+// when present, as an empty one switches to the shared credentials
+type patchSourceRequest struct {
+	chronograf.Source
+	UserAuth *string `json:"userAuth,omitempty"`
+}
+
 // UpdateSource handles incremental updates of a data source
 func (s *Service) UpdateSource(w http.ResponseWriter, r *http.Request) {
 	id, err := paramID("id", r)
@@ -383,7 +438,7 @@ func (s *Service) UpdateSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req chronograf.Source
+	var req patchSourceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		invalidJSON(w, s.Logger)
 		return
@@ -400,6 +455,12 @@ func (s *Service) UpdateSource(w http.ResponseWriter, r *http.Request) {
 	if req.Username != "" {
 		src.Username = req.Username
 	}
+	if req.Org != "" {
+		src.Org = req.Org
+	}
+	if req.Token != "" {
+		src.Token = req.Token
+	}
 	if req.URL != "" {
 		src.URL = req.URL
 	}
@@ -408,6 +469,9 @@ func (s *Service) UpdateSource(w http.ResponseWriter, r *http.Request) {
 	if req.MetaURL != src.MetaURL {
 		src.MetaURL = req.MetaURL
 	}
+	if req.Backends != nil {
+		src.Backends = req.Backends
+	}
 	if req.Type != "" {
 		src.Type = req.Type
 	}
@@ -415,6 +479,9 @@ func (s *Service) UpdateSource(w http.ResponseWriter, r *http.Request) {
 		src.Telegraf = req.Telegraf
 	}
 	src.DefaultRP = req.DefaultRP
+	if req.UserAuth != nil {
+		src.UserAuth = *req.UserAuth
+	}
 
 	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
 	if err != nil {
@@ -458,11 +525,40 @@ func ValidSourceRequest(s *chronograf.Source, defaultOrgID string) error {
 	if s.URL == "" {
 		return fmt.Errorf("url required")
 	}
-	// Type must be influx or influx-enterprise
-	if s.Type != "" {
-		if s.Type != chronograf.InfluxDB && s.Type != chronograf.InfluxEnterprise && s.Type != chronograf.InfluxRelay {
-			return fmt.Errorf("invalid source type %s", s.Type)
+	// Type must be one of the supported time series databases
+	switch s.Type {
+	case "", chronograf.InfluxDB, chronograf.InfluxEnterprise, chronograf.Prometheus:
+	case chronograf.InfluxRelay:
+		if len(s.Backends) == 0 {
+			return fmt.Errorf("backend InfluxDB URLs required for InfluxDB Relay sources")
+		}
+		for _, backend := range s.Backends {
+			u, err := url.ParseRequestURI(backend)
+			if err != nil || u.Scheme == "" {
+				return fmt.Errorf("invalid backend URI %s", backend)
+			}
 		}
+	case chronograf.InfluxDBv2:
+		if s.Org == "" || s.Token == "" {
+			return fmt.Errorf("organization and token required for InfluxDB 2.x sources")
+		}
+	default:
+		return fmt.Errorf("invalid source type %s", s.Type)
+	}
+
+	// Each user is authenticated either with their own stored credentials
+	// or with a JWT of their name signed by the shared secret
+	switch s.UserAuth {
+	case "", chronograf.UserAuthCredentials:
+	case chronograf.UserAuthJWT:
+		if s.SharedSecret == "" {
+			return fmt.Errorf("shared secret required for per-user JWT authentication")
+		}
+	default:
+		return fmt.Errorf("invalid user authentication %s", s.UserAuth)
+	}
+	if s.UserAuth != "" && (s.Type == chronograf.InfluxDBv2 || s.Type == chronograf.Prometheus) {
+		return fmt.Errorf("per-user authentication is not supported by %s sources", s.Type)
 	}
 
 	if s.Organization == "" {
@@ -733,7 +829,7 @@ func (s *Service) sourcesSeries(ctx context.Context, w http.ResponseWriter, r *h
 		return 0, nil, err
 	}
 
-	ts, err := s.TimeSeries(src)
+	ts, err := s.TimeSeries(ctx, src)
 	if err != nil {
 		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
 		Error(w, http.StatusBadRequest, msg, s.Logger)
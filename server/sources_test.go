@@ -116,6 +116,74 @@ func Test_ValidSourceRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "prometheus source",
+			args: args{
+				source: &chronograf.Source{
+					ID:           1,
+					Name:         "Prometheus",
+					Type:         chronograf.Prometheus,
+					URL:          "http://localhost:9090",
+					Organization: "0",
+				},
+			},
+			wants: wants{
+				source: &chronograf.Source{
+					ID:           1,
+					Name:         "Prometheus",
+					Type:         chronograf.Prometheus,
+					URL:          "http://localhost:9090",
+					Organization: "0",
+				},
+			},
+		},
+		{
+			name: "InfluxDB 2.x source without a token",
+			args: args{
+				source: &chronograf.Source{
+					ID:           1,
+					Name:         "InfluxDB 2",
+					Type:         chronograf.InfluxDBv2,
+					Org:          "my-org",
+					URL:          "http://localhost:9999",
+					Organization: "0",
+				},
+			},
+			wants: wants{
+				err: fmt.Errorf("organization and token required for InfluxDB 2.x sources"),
+			},
+		},
+		{
+			name: "relay without backends",
+			args: args{
+				source: &chronograf.Source{
+					ID:           1,
+					Name:         "Relay",
+					Type:         chronograf.InfluxRelay,
+					URL:          "http://localhost:9096",
+					Organization: "0",
+				},
+			},
+			wants: wants{
+				err: fmt.Errorf("backend InfluxDB URLs required for InfluxDB Relay sources"),
+			},
+		},
+		{
+			name: "relay with an invalid backend",
+			args: args{
+				source: &chronograf.Source{
+					ID:           1,
+					Name:         "Relay",
+					Type:         chronograf.InfluxRelay,
+					URL:          "http://localhost:9096",
+					Backends:     []string{"http://influx-a:8086", "influx-b"},
+					Organization: "0",
+				},
+			},
+			wants: wants{
+				err: fmt.Errorf("invalid backend URI influx-b"),
+			},
+		},
 		{
 			name: "bad url",
 			args: args{
@@ -180,6 +248,7 @@ func Test_newSourceResponse(t *testing.T) {
 					Proxy:       "/chronograf/v1/sources/1/proxy",
 					Queries:     "/chronograf/v1/sources/1/queries",
 					Write:       "/chronograf/v1/sources/1/write",
+					Ingest:      "/chronograf/v1/sources/1/ingest",
 					Kapacitors:  "/chronograf/v1/sources/1/kapacitors",
 					Users:       "/chronograf/v1/sources/1/users",
 					Permissions: "/chronograf/v1/sources/1/permissions",
@@ -207,6 +276,7 @@ func Test_newSourceResponse(t *testing.T) {
 					Services:    "/chronograf/v1/sources/1/services",
 					Queries:     "/chronograf/v1/sources/1/queries",
 					Write:       "/chronograf/v1/sources/1/write",
+					Ingest:      "/chronograf/v1/sources/1/ingest",
 					Kapacitors:  "/chronograf/v1/sources/1/kapacitors",
 					Users:       "/chronograf/v1/sources/1/users",
 					Permissions: "/chronograf/v1/sources/1/permissions",
@@ -444,7 +514,7 @@ func TestService_SourcesID(t *testing.T) {
 			ID:              "1",
 			wantStatusCode:  200,
 			wantContentType: "application/json",
-			wantBody: `{"id":"1","name":"","url":"","default":false,"telegraf":"telegraf","organization":"","defaultRP":"","version":"Unknown","authentication":"unknown","links":{"self":"/chronograf/v1/sources/1","kapacitors":"/chronograf/v1/sources/1/kapacitors","services":"/chronograf/v1/sources/1/services","proxy":"/chronograf/v1/sources/1/proxy","queries":"/chronograf/v1/sources/1/queries","write":"/chronograf/v1/sources/1/write","permissions":"/chronograf/v1/sources/1/permissions","users":"/chronograf/v1/sources/1/users","databases":"/chronograf/v1/sources/1/dbs","annotations":"/chronograf/v1/sources/1/annotations","health":"/chronograf/v1/sources/1/health"}}
+			wantBody: `{"id":"1","name":"","url":"","default":false,"telegraf":"telegraf","organization":"","defaultRP":"","version":"Unknown","authentication":"unknown","links":{"self":"/chronograf/v1/sources/1","kapacitors":"/chronograf/v1/sources/1/kapacitors","services":"/chronograf/v1/sources/1/services","proxy":"/chronograf/v1/sources/1/proxy","queries":"/chronograf/v1/sources/1/queries","write":"/chronograf/v1/sources/1/write","ingest":"/chronograf/v1/sources/1/ingest","permissions":"/chronograf/v1/sources/1/permissions","users":"/chronograf/v1/sources/1/users","databases":"/chronograf/v1/sources/1/dbs","annotations":"/chronograf/v1/sources/1/annotations","health":"/chronograf/v1/sources/1/health"}}
 `,
 		},
 	}
@@ -534,7 +604,7 @@ func TestService_UpdateSource(t *testing.T) {
 			wantStatusCode:  200,
 			wantContentType: "application/json",
 			wantBody: func(url string) string {
-				return fmt.Sprintf(`{"id":"1","name":"marty","type":"influx","username":"bob","url":"%s","metaUrl":"http://murl","default":false,"telegraf":"murlin","organization":"1337","defaultRP":"pineapple","authentication":"basic","links":{"self":"/chronograf/v1/sources/1","kapacitors":"/chronograf/v1/sources/1/kapacitors","services":"/chronograf/v1/sources/1/services","proxy":"/chronograf/v1/sources/1/proxy","queries":"/chronograf/v1/sources/1/queries","write":"/chronograf/v1/sources/1/write","permissions":"/chronograf/v1/sources/1/permissions","users":"/chronograf/v1/sources/1/users","databases":"/chronograf/v1/sources/1/dbs","annotations":"/chronograf/v1/sources/1/annotations","health":"/chronograf/v1/sources/1/health"}}
+				return fmt.Sprintf(`{"id":"1","name":"marty","type":"influx","username":"bob","url":"%s","metaUrl":"http://murl","default":false,"telegraf":"murlin","organization":"1337","defaultRP":"pineapple","authentication":"basic","links":{"self":"/chronograf/v1/sources/1","kapacitors":"/chronograf/v1/sources/1/kapacitors","services":"/chronograf/v1/sources/1/services","proxy":"/chronograf/v1/sources/1/proxy","queries":"/chronograf/v1/sources/1/queries","write":"/chronograf/v1/sources/1/write","ingest":"/chronograf/v1/sources/1/ingest","permissions":"/chronograf/v1/sources/1/permissions","users":"/chronograf/v1/sources/1/users","databases":"/chronograf/v1/sources/1/dbs","annotations":"/chronograf/v1/sources/1/annotations","health":"/chronograf/v1/sources/1/health"}}
 `, url)
 			},
 		},
@@ -585,6 +655,79 @@ func TestService_UpdateSource(t *testing.T) {
 	}
 }
 
+func TestService_UpdateSourceUserAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "missing user authentication is kept",
+			body: `{"name":"marty"}`,
+			want: chronograf.UserAuthJWT,
+		},
+		{
+			name: "empty user authentication switches to the shared credentials",
+			body: `{"name":"marty","userAuth":""}`,
+			want: "",
+		},
+		{
+			name: "user authentication is changed",
+			body: `{"name":"marty","userAuth":"credentials"}`,
+			want: chronograf.UserAuthCredentials,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated chronograf.Source
+			h := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID:           1,
+								URL:          ts.URL,
+								Username:     "bob",
+								SharedSecret: "secret",
+								UserAuth:     chronograf.UserAuthJWT,
+							}, nil
+						},
+						UpdateF: func(ctx context.Context, upd chronograf.Source) error {
+							updated = upd
+							return nil
+						},
+					},
+					OrganizationsStore: &mocks.OrganizationsStore{
+						DefaultOrganizationF: func(context.Context) (*chronograf.Organization, error) {
+							return &chronograf.Organization{ID: "1337"}, nil
+						},
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PATCH", "http://any.url", bytes.NewBufferString(tt.body))
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{
+				{Key: "id", Value: "1"},
+			}))
+			h.UpdateSource(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("UpdateSource() status = %d: %s", w.Code, w.Body.String())
+			}
+			if updated.UserAuth != tt.want {
+				t.Errorf("UpdateSource() stored user authentication %q, want %q", updated.UserAuth, tt.want)
+			}
+		})
+	}
+}
+
 func TestService_NewSourceUser(t *testing.T) {
 	type fields struct {
 		SourcesStore chronograf.SourcesStore
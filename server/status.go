@@ -0,0 +1,450 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	kapa "github.com/influxdata/chronograf/kapacitor"
+)
+
+const (
+	// DefaultHealthHistory is the number of checks kept for every resource
+	DefaultHealthHistory = 60
+
+	// healthCheckTimeout bounds how long a single check may take
+	healthCheckTimeout = 10 * time.Second
+
+	// sourceKind identifies sources in the status of the HealthMonitor.
+	// Servers are identified by their type (kapacitor or flux).
+	sourceKind = "source"
+)
+
+// HealthCheck is the outcome of checking a source or server once
+type HealthCheck struct {
+	Time    time.Time `json:"time"`
+	Up      bool      `json:"up"`
+	Latency int64     `json:"latency"` // Latency of the check in milliseconds
+	Version string    `json:"version,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// ResourceHealth is the rolling history of checks of a source or server
+type ResourceHealth struct {
+	ID           int           `json:"id"`
+	Kind         string        `json:"kind"`            // Kind is source, kapacitor or flux
+	Name         string        `json:"name"`            // Name is the user-defined name of the resource
+	SrcID        int           `json:"srcId,omitempty"` // SrcID is the source of a server
+	Organization string        `json:"organization"`
+	Up           bool          `json:"up"`      // Up is the outcome of the latest check
+	Since        time.Time     `json:"since"`   // Since is when the resource was first seen in its current state
+	History      []HealthCheck `json:"history"` // History is ordered from oldest to newest
+}
+
+// HealthMonitor periodically checks that every source and server can be
+// reached and keeps a rolling history of the checks in memory
+type HealthMonitor struct {
+	Store            DataStore
+	TimeSeriesClient TimeSeriesClient
+	Logger           chronograf.Logger
+
+	SourceInterval time.Duration // SourceInterval between checks of sources; zero disables them
+	ServerInterval time.Duration // ServerInterval between checks of kapacitor and flux servers; zero disables them
+	HistorySize    int           // HistorySize is the number of checks kept per resource
+	Annotate       bool          // Annotate records outages as annotations on the source
+
+	// CheckSource and CheckServer default to pinging the resource
+	CheckSource func(context.Context, chronograf.Source) HealthCheck
+	CheckServer func(context.Context, chronograf.Server) HealthCheck
+	Now         func() time.Time
+
+	mu      sync.RWMutex
+	sources map[int]*ResourceHealth
+	servers map[int]*ResourceHealth
+}
+
+// Run checks sources and servers at their intervals until the context is
+// canceled
+func (m *HealthMonitor) Run(ctx context.Context) {
+	var sourceTick, serverTick <-chan time.Time
+	if m.SourceInterval > 0 {
+		t := time.NewTicker(m.SourceInterval)
+		defer t.Stop()
+		sourceTick = t.C
+		m.CheckSources(ctx)
+	}
+	if m.ServerInterval > 0 {
+		t := time.NewTicker(m.ServerInterval)
+		defer t.Stop()
+		serverTick = t.C
+		m.CheckServers(ctx)
+	}
+	if sourceTick == nil && serverTick == nil {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sourceTick:
+			m.CheckSources(ctx)
+		case <-serverTick:
+			m.CheckServers(ctx)
+		}
+	}
+}
+
+// CheckSources checks every source of every organization once
+func (m *HealthMonitor) CheckSources(ctx context.Context) {
+	srcs, err := m.Store.Sources(serverContext(ctx)).All(serverContext(ctx))
+	if err != nil {
+		m.Logger.
+			WithField("component", "health").
+			Error("Unable to list sources: ", err)
+		return
+	}
+
+	checks := make([]HealthCheck, len(srcs))
+	var wg sync.WaitGroup
+	for i := range srcs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			checks[i] = m.checkSource(ctx, srcs[i])
+		}(i)
+	}
+	// The health of the data nodes of clusters is checked along with the
+	// sources so that failed nodes are re-admitted without waiting for a query
+	if nc, ok := m.TimeSeriesClient.(nodeChecker); ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			nc.CheckNodes(ctx, srcs)
+		}()
+	}
+	wg.Wait()
+
+	seen := map[int]bool{}
+	for i, src := range srcs {
+		seen[src.ID] = true
+		res := ResourceHealth{
+			ID:           src.ID,
+			Kind:         sourceKind,
+			Name:         src.Name,
+			Organization: src.Organization,
+		}
+		if since, ok := m.record(&m.sources, res, checks[i]); ok {
+			m.annotate(ctx, src.ID, fmt.Sprintf("Source %s was unreachable", src.Name), since, checks[i].Time)
+		}
+	}
+	m.prune(&m.sources, seen)
+}
+
+// CheckServers checks every kapacitor and flux server of every
+// organization once
+func (m *HealthMonitor) CheckServers(ctx context.Context) {
+	srvs, err := m.Store.Servers(serverContext(ctx)).All(serverContext(ctx))
+	if err != nil {
+		m.Logger.
+			WithField("component", "health").
+			Error("Unable to list servers: ", err)
+		return
+	}
+
+	checks := make([]HealthCheck, len(srvs))
+	var wg sync.WaitGroup
+	for i := range srvs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			checks[i] = m.checkServer(ctx, srvs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	seen := map[int]bool{}
+	for i, srv := range srvs {
+		seen[srv.ID] = true
+		kind := srv.Type
+		if kind == "" {
+			kind = "kapacitor"
+		}
+		res := ResourceHealth{
+			ID:           srv.ID,
+			Kind:         kind,
+			Name:         srv.Name,
+			SrcID:        srv.SrcID,
+			Organization: srv.Organization,
+		}
+		if since, ok := m.record(&m.servers, res, checks[i]); ok {
+			m.annotate(ctx, srv.SrcID, fmt.Sprintf("%s server %s was unreachable", kind, srv.Name), since, checks[i].Time)
+		}
+	}
+	m.prune(&m.servers, seen)
+}
+
+// Status returns a copy of the health of every checked source and server
+// ordered by kind and ID
+func (m *HealthMonitor) Status() []ResourceHealth {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := []ResourceHealth{}
+	for _, resources := range []map[int]*ResourceHealth{m.sources, m.servers} {
+		for _, r := range resources {
+			res := *r
+			res.History = append([]HealthCheck{}, r.History...)
+			status = append(status, res)
+		}
+	}
+	sort.Slice(status, func(i, j int) bool {
+		if status[i].Kind != status[j].Kind {
+			return status[i].Kind < status[j].Kind
+		}
+		return status[i].ID < status[j].ID
+	})
+	return status
+}
+
+// record appends the check to the history of the resource. When the check
+// ends an outage it returns the time the outage started.
+func (m *HealthMonitor) record(resources *map[int]*ResourceHealth, res ResourceHealth, check HealthCheck) (time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if *resources == nil {
+		*resources = map[int]*ResourceHealth{}
+	}
+
+	prev, ok := (*resources)[res.ID]
+	if !ok || prev.Kind != res.Kind {
+		res.Up = check.Up
+		res.Since = check.Time
+		res.History = []HealthCheck{check}
+		(*resources)[res.ID] = &res
+		return time.Time{}, false
+	}
+
+	size := m.HistorySize
+	if size <= 0 {
+		size = DefaultHealthHistory
+	}
+	history := append(prev.History, check)
+	if len(history) > size {
+		history = append([]HealthCheck{}, history[len(history)-size:]...)
+	}
+
+	res.History = history
+	res.Up = check.Up
+	res.Since = prev.Since
+	if prev.Up != check.Up {
+		res.Since = check.Time
+	}
+	(*resources)[res.ID] = &res
+
+	return prev.Since, !prev.Up && check.Up
+}
+
+// prune forgets resources that no longer exist
+func (m *HealthMonitor) prune(resources *map[int]*ResourceHealth, seen map[int]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id := range *resources {
+		if !seen[id] {
+			delete(*resources, id)
+		}
+	}
+}
+
+// annotate records an outage as an annotation on the source. Sources
+// authenticating each user are not annotated.
+func (m *HealthMonitor) annotate(ctx context.Context, srcID int, text string, start, end time.Time) {
+	if !m.Annotate {
+		return
+	}
+
+	log := m.Logger.
+		WithField("component", "health").
+		WithField("source", srcID)
+
+	src, err := m.Store.Sources(serverContext(ctx)).Get(serverContext(ctx), srcID)
+	if err != nil {
+		log.Error("Unable to find source to annotate: ", err)
+		return
+	}
+	// The monitor has no user whose credentials could write the annotation
+	if src.UserAuth != "" {
+		log.Info("Not annotating outage of source authenticating each user")
+		return
+	}
+
+	ts, err := m.TimeSeriesClient.New(ctx, src, m.Logger)
+	if err == nil {
+		err = ts.Connect(ctx, &src)
+	}
+	if err == nil {
+		_, err = influx.NewAnnotationStore(ts).Add(ctx, &chronograf.Annotation{
+			StartTime: start,
+			EndTime:   end,
+			Text:      text,
+			Tags: chronograf.AnnotationTags{
+				"source": "health",
+			},
+		})
+	}
+	if err != nil {
+		log.Error("Unable to annotate outage: ", err)
+	}
+}
+
+func (m *HealthMonitor) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+func (m *HealthMonitor) checkSource(ctx context.Context, src chronograf.Source) HealthCheck {
+	if m.CheckSource != nil {
+		return m.CheckSource(ctx, src)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := m.now()
+	var version string
+	cli, err := newTSDBStatus(ctx, &src, m.Logger)
+	if err == nil {
+		err = cli.Ping(ctx)
+	}
+	if err == nil {
+		// Not every version of every database reports its version
+		version, _ = cli.Version(ctx)
+	}
+	return newHealthCheck(start, m.now(), version, err)
+}
+
+func (m *HealthMonitor) checkServer(ctx context.Context, srv chronograf.Server) HealthCheck {
+	if m.CheckServer != nil {
+		return m.CheckServer(ctx, srv)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := m.now()
+	var version string
+	var err error
+	switch srv.Type {
+	case "flux":
+		err = pingFlux(ctx, srv.URL, srv.InsecureSkipVerify)
+	default:
+		version, err = kapa.Ping(srv.URL, srv.Username, srv.Password, srv.InsecureSkipVerify, healthCheckTimeout)
+	}
+	return newHealthCheck(start, m.now(), version, err)
+}
+
+func newHealthCheck(start, end time.Time, version string, err error) HealthCheck {
+	check := HealthCheck{
+		Time:    end,
+		Up:      err == nil,
+		Latency: int64(end.Sub(start) / time.Millisecond),
+		Version: version,
+	}
+	if err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// healthSummary counts the resources that are up and down
+type healthSummary struct {
+	Total int `json:"total"`
+	Up    int `json:"up"`
+	Down  int `json:"down"`
+}
+
+func (h *healthSummary) add(res ResourceHealth) {
+	h.Total++
+	if res.Up {
+		h.Up++
+	} else {
+		h.Down++
+	}
+}
+
+type organizationStatus struct {
+	Organization string           `json:"organization"`
+	Sources      healthSummary    `json:"sources"`
+	Servers      healthSummary    `json:"servers"`
+	Resources    []ResourceHealth `json:"resources"`
+}
+
+type statusLinks struct {
+	Self string `json:"self"`
+}
+
+type statusResponse struct {
+	Organizations []organizationStatus `json:"organizations"`
+	Links         statusLinks          `json:"links"`
+}
+
+// Status returns the health of the sources and servers of the current
+// organization. Super admins get the status of every organization.
+func (s *Service) Status(w http.ResponseWriter, r *http.Request) {
+	if s.HealthMonitor == nil {
+		Error(w, http.StatusNotFound, "Health monitoring is disabled", s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	orgID, scoped := hasOrganizationContext(ctx)
+	if !s.UseAuth || hasSuperAdminContext(ctx) {
+		scoped = false
+	}
+
+	orgs := map[string]*organizationStatus{}
+	for _, res := range s.HealthMonitor.Status() {
+		if scoped && res.Organization != orgID {
+			continue
+		}
+		org, ok := orgs[res.Organization]
+		if !ok {
+			org = &organizationStatus{
+				Organization: res.Organization,
+				Resources:    []ResourceHealth{},
+			}
+			orgs[res.Organization] = org
+		}
+		if res.Kind == sourceKind {
+			org.Sources.add(res)
+		} else {
+			org.Servers.add(res)
+		}
+		org.Resources = append(org.Resources, res)
+	}
+
+	status := statusResponse{
+		Organizations: []organizationStatus{},
+		Links: statusLinks{
+			Self: "/chronograf/v1/status",
+		},
+	}
+	for _, org := range orgs {
+		status.Organizations = append(status.Organizations, *org)
+	}
+	sort.Slice(status.Organizations, func(i, j int) bool {
+		return status.Organizations[i].Organization < status.Organizations[j].Organization
+	})
+
+	encodeJSON(w, http.StatusOK, status, s.Logger)
+}
@@ -0,0 +1,286 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/organizations"
+)
+
+func TestHealthMonitor_CheckSources(t *testing.T) {
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	up := []bool{true, false, false, true}
+	checks := 0
+
+	var points []chronograf.Point
+	src := chronograf.Source{
+		ID:           1,
+		Name:         "influx",
+		Organization: "default",
+	}
+	m := &HealthMonitor{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				AllF: func(context.Context) ([]chronograf.Source, error) {
+					return []chronograf.Source{src}, nil
+				},
+				GetF: func(context.Context, int) (chronograf.Source, error) {
+					return src, nil
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error {
+				return nil
+			},
+			WriteF: func(ctx context.Context, pts []chronograf.Point) error {
+				points = append(points, pts...)
+				return nil
+			},
+		},
+		Logger:      &mocks.TestLogger{},
+		HistorySize: 3,
+		Annotate:    true,
+		CheckSource: func(context.Context, chronograf.Source) HealthCheck {
+			check := HealthCheck{
+				Time: now,
+				Up:   up[checks],
+			}
+			if !check.Up {
+				check.Error = "connection refused"
+			}
+			checks++
+			return check
+		},
+	}
+
+	for i := range up {
+		now = start.Add(time.Duration(i) * time.Minute)
+		m.CheckSources(context.Background())
+	}
+
+	status := m.Status()
+	if len(status) != 1 {
+		t.Fatalf("HealthMonitor.Status() = %v, want one source", status)
+	}
+	if got := status[0]; !got.Up || !got.Since.Equal(start.Add(3*time.Minute)) || len(got.History) != 3 {
+		t.Errorf("HealthMonitor.Status() = %#v, want the source up since the last check with three checks", got)
+	}
+	if got := status[0].History[0]; got.Up || got.Error != "connection refused" {
+		t.Errorf("HealthMonitor.Status() oldest check = %#v, want the first failure", got)
+	}
+
+	if len(points) != 1 {
+		t.Fatalf("HealthMonitor annotated %d outages, want 1", len(points))
+	}
+	if text := points[0].Fields["text"]; text != "Source influx was unreachable" {
+		t.Errorf("HealthMonitor annotation text = %v", text)
+	}
+	if got := points[0].Fields["start_time"]; got != start.Add(time.Minute).UnixNano() {
+		t.Errorf("HealthMonitor annotation start_time = %v, want the first failure", got)
+	}
+}
+
+func TestHealthMonitor_SkipsAnnotationsOfPerUserSources(t *testing.T) {
+	up := []bool{false, true}
+	checks := 0
+	src := chronograf.Source{
+		ID:           1,
+		Name:         "influx",
+		Organization: "default",
+		UserAuth:     chronograf.UserAuthCredentials,
+	}
+	logger := &mocks.TestLogger{}
+	m := &HealthMonitor{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				AllF: func(context.Context) ([]chronograf.Source, error) {
+					return []chronograf.Source{src}, nil
+				},
+				GetF: func(context.Context, int) (chronograf.Source, error) {
+					return src, nil
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error {
+				return nil
+			},
+			WriteF: func(ctx context.Context, pts []chronograf.Point) error {
+				t.Errorf("HealthMonitor annotated an outage of a source authenticating each user")
+				return nil
+			},
+		},
+		Logger:   logger,
+		Annotate: true,
+		CheckSource: func(context.Context, chronograf.Source) HealthCheck {
+			check := HealthCheck{
+				Time: time.Now(),
+				Up:   up[checks],
+			}
+			checks++
+			return check
+		},
+	}
+
+	for range up {
+		m.CheckSources(context.Background())
+	}
+	if !logger.HasMessage("info", "Not annotating outage of source authenticating each user") {
+		t.Errorf("HealthMonitor did not log the skipped annotation: %v", logger.Messages)
+	}
+}
+
+func TestHealthMonitor_PrunesDeletedServers(t *testing.T) {
+	servers := []chronograf.Server{
+		{ID: 1, Name: "kapa", Type: "kapacitor"},
+		{ID: 2, Name: "flux", Type: "flux"},
+	}
+	m := &HealthMonitor{
+		Store: &mocks.Store{
+			ServersStore: &mocks.ServersStore{
+				AllF: func(context.Context) ([]chronograf.Server, error) {
+					return servers, nil
+				},
+			},
+		},
+		Logger: &mocks.TestLogger{},
+		CheckServer: func(_ context.Context, srv chronograf.Server) HealthCheck {
+			return HealthCheck{
+				Up: srv.Type == "flux",
+			}
+		},
+	}
+
+	m.CheckServers(context.Background())
+	servers = servers[1:]
+	m.CheckServers(context.Background())
+
+	status := m.Status()
+	if len(status) != 1 || status[0].Kind != "flux" || !status[0].Up || len(status[0].History) != 2 {
+		t.Errorf("HealthMonitor.Status() = %#v, want only the flux server", status)
+	}
+}
+
+func TestService_Status(t *testing.T) {
+	monitor := &HealthMonitor{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				AllF: func(context.Context) ([]chronograf.Source, error) {
+					return []chronograf.Source{
+						{ID: 1, Organization: "default"},
+						{ID: 2, Organization: "default"},
+						{ID: 3, Organization: "other"},
+					}, nil
+				},
+			},
+			ServersStore: &mocks.ServersStore{
+				AllF: func(context.Context) ([]chronograf.Server, error) {
+					return []chronograf.Server{
+						{ID: 1, SrcID: 1, Type: "kapacitor", Organization: "default"},
+					}, nil
+				},
+			},
+		},
+		Logger: &mocks.TestLogger{},
+		CheckSource: func(_ context.Context, src chronograf.Source) HealthCheck {
+			if src.ID == 2 {
+				return HealthCheck{Error: "timeout"}
+			}
+			return HealthCheck{Up: true}
+		},
+		CheckServer: func(context.Context, chronograf.Server) HealthCheck {
+			return HealthCheck{Up: true}
+		},
+	}
+	monitor.CheckSources(context.Background())
+	monitor.CheckServers(context.Background())
+
+	tests := []struct {
+		name    string
+		useAuth bool
+		user    *chronograf.User
+		want    map[string][2]healthSummary
+	}{
+		{
+			name: "All organizations without auth",
+			want: map[string][2]healthSummary{
+				"default": {{Total: 2, Up: 1, Down: 1}, {Total: 1, Up: 1}},
+				"other":   {{Total: 1, Up: 1}, {}},
+			},
+		},
+		{
+			name:    "Only the current organization",
+			useAuth: true,
+			user:    &chronograf.User{Name: "viewer"},
+			want: map[string][2]healthSummary{
+				"default": {{Total: 2, Up: 1, Down: 1}, {Total: 1, Up: 1}},
+			},
+		},
+		{
+			name:    "All organizations for super admins",
+			useAuth: true,
+			user:    &chronograf.User{Name: "admin", SuperAdmin: true},
+			want: map[string][2]healthSummary{
+				"default": {{Total: 2, Up: 1, Down: 1}, {Total: 1, Up: 1}},
+				"other":   {{Total: 1, Up: 1}, {}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Logger:        &mocks.TestLogger{},
+				UseAuth:       tt.useAuth,
+				HealthMonitor: monitor,
+			}
+
+			ctx := context.WithValue(context.Background(), organizations.ContextKey, "default")
+			if tt.user != nil {
+				ctx = context.WithValue(ctx, UserContextKey, tt.user)
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/chronograf/v1/status", nil).WithContext(ctx)
+
+			s.Status(w, r)
+			if w.Code != 200 {
+				t.Fatalf("Service.Status() status = %d, want 200: %s", w.Code, w.Body.String())
+			}
+
+			var res statusResponse
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("unable to decode response: %v", err)
+			}
+			if len(res.Organizations) != len(tt.want) {
+				t.Fatalf("Service.Status() = %#v, want %d organizations", res.Organizations, len(tt.want))
+			}
+			for _, org := range res.Organizations {
+				want, ok := tt.want[org.Organization]
+				if !ok {
+					t.Errorf("Service.Status() unexpected organization %s", org.Organization)
+					continue
+				}
+				if org.Sources != want[0] || org.Servers != want[1] {
+					t.Errorf("Service.Status() %s = sources %v servers %v, want %v", org.Organization, org.Sources, org.Servers, want)
+				}
+			}
+		})
+	}
+
+	t.Run("Disabled monitor", func(t *testing.T) {
+		s := &Service{
+			Logger: &mocks.TestLogger{},
+		}
+		w := httptest.NewRecorder()
+		s.Status(w, httptest.NewRequest("GET", "/chronograf/v1/status", nil))
+		if w.Code != 404 {
+			t.Errorf("Service.Status() status = %d, want 404", w.Code)
+		}
+	})
+}
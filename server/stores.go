@@ -91,6 +91,8 @@ type DataStore interface {
 	Users(ctx context.Context) chronograf.UsersStore
 	Organizations(ctx context.Context) chronograf.OrganizationsStore
 	Mappings(ctx context.Context) chronograf.MappingsStore
+	SourceCredentials(ctx context.Context) chronograf.SourceCredentialsStore
+	DashboardShares(ctx context.Context) chronograf.DashboardSharesStore
 	Dashboards(ctx context.Context) chronograf.DashboardsStore
 	Config(ctx context.Context) chronograf.ConfigStore
 	OrganizationConfig(ctx context.Context) chronograf.OrganizationConfigStore
@@ -113,6 +115,8 @@ type Store struct {
 	OrganizationsStore      chronograf.OrganizationsStore
 	ConfigStore             chronograf.ConfigStore
 	OrganizationConfigStore chronograf.OrganizationConfigStore
+	SourceCredentialsStore  chronograf.SourceCredentialsStore
+	DashboardSharesStore    chronograf.DashboardSharesStore
 	CellService             platform.CellService
 	DashboardService        platform.DashboardService
 }
@@ -148,8 +152,13 @@ func (s *Store) Layouts(ctx context.Context) chronograf.LayoutsStore {
 	return s.LayoutsStore
 }
 
-// Protoboards returns all protoboards in the underlying protoboards store.
+// Protoboards returns an organizations.ProtoboardsStore of the built-in
+// protoboards and those of the organization specified on context, and all
+// protoboards in the underlying protoboards store otherwise.
 func (s *Store) Protoboards(ctx context.Context) chronograf.ProtoboardsStore {
+	if org, ok := hasOrganizationContext(ctx); ok {
+		return organizations.NewProtoboardsStore(s.ProtoboardsStore, org)
+	}
 	return s.ProtoboardsStore
 }
 
@@ -230,6 +239,20 @@ func (s *Store) Mappings(ctx context.Context) chronograf.MappingsStore {
 	return &noop.MappingsStore{}
 }
 
+// SourceCredentials returns the underlying SourceCredentialsStore. Credentials
+// belong to users rather than organizations, so callers only access the
+// credentials of the user of the request.
+func (s *Store) SourceCredentials(ctx context.Context) chronograf.SourceCredentialsStore {
+	return s.SourceCredentialsStore
+}
+
+// DashboardShares returns the underlying DashboardSharesStore. Shares are
+// looked up by the ID in their token before the organization of the request
+// is known, so they are not scoped to an organization.
+func (s *Store) DashboardShares(ctx context.Context) chronograf.DashboardSharesStore {
+	return s.DashboardSharesStore
+}
+
 // Cells returns the underlying CellService.
 func (s *Store) Cells(ctx context.Context) platform.CellService {
 	return s.CellService
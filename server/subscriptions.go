@@ -0,0 +1,274 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+)
+
+type subscriptionLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type subscriptionResponse struct {
+	chronograf.Subscription
+	Links subscriptionLinks `json:"links"` // Links are URI locations related to the subscription
+}
+
+func newSubscriptionResponse(srcID int, db string, sub chronograf.Subscription) subscriptionResponse {
+	base := "/chronograf/v1/sources"
+	sub.Database = db
+	return subscriptionResponse{
+		Subscription: sub,
+		Links: subscriptionLinks{
+			Self: fmt.Sprintf("%s/%d/dbs/%s/subscriptions/%s/%s", base, srcID, db, sub.RetentionPolicy, sub.Name),
+		},
+	}
+}
+
+type subscriptionsResponse struct {
+	Subscriptions []subscriptionResponse `json:"subscriptions"`
+}
+
+// Subscriptions lists the subscriptions of every retention policy of a database
+func (s *Service) Subscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	subs, err := dbsvc.AllSubscriptions(ctx, db)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to get subscriptions %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	res := subscriptionsResponse{
+		Subscriptions: make([]subscriptionResponse, len(subs)),
+	}
+	for i, sub := range subs {
+		res.Subscriptions[i] = newSubscriptionResponse(srcID, db, sub)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// SubscriptionID returns a single subscription of a retention policy
+func (s *Service) SubscriptionID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	rp := httprouter.GetParamFromContext(ctx, "rp")
+	name := httprouter.GetParamFromContext(ctx, "name")
+	subs, err := dbsvc.AllSubscriptions(ctx, db)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to get subscriptions %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	for _, sub := range subs {
+		if sub.RetentionPolicy == rp && sub.Name == name {
+			encodeJSON(w, http.StatusOK, newSubscriptionResponse(srcID, db, sub), s.Logger)
+			return
+		}
+	}
+	notFound(w, name, s.Logger)
+}
+
+// NewSubscription creates a new subscription on a retention policy of a database
+func (s *Service) NewSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	postedSub := &chronograf.Subscription{}
+	if err := json.NewDecoder(r.Body).Decode(postedSub); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidSubscriptionRequest(postedSub); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	sub, err := dbsvc.CreateSubscription(ctx, db, postedSub)
+	if err != nil {
+		databasesError(w, err, s.Logger)
+		return
+	}
+
+	res := newSubscriptionResponse(srcID, db, *sub)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateSubscription replaces an existing subscription of a retention policy
+func (s *Service) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	rp := httprouter.GetParamFromContext(ctx, "rp")
+	name := httprouter.GetParamFromContext(ctx, "name")
+
+	postedSub := &chronograf.Subscription{}
+	if err := json.NewDecoder(r.Body).Decode(postedSub); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if postedSub.Name == "" {
+		postedSub.Name = name
+	}
+	if postedSub.RetentionPolicy == "" {
+		postedSub.RetentionPolicy = rp
+	}
+	if err := ValidSubscriptionRequest(postedSub); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	sub, err := dbsvc.UpdateSubscription(ctx, db, rp, name, postedSub)
+	if err != nil {
+		databasesError(w, err, s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newSubscriptionResponse(srcID, db, *sub), s.Logger)
+}
+
+// DropSubscription removes a subscription from a retention policy
+func (s *Service) DropSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	dbsvc := s.databases(&src)
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	rp := httprouter.GetParamFromContext(ctx, "rp")
+	name := httprouter.GetParamFromContext(ctx, "name")
+	if err := dbsvc.DropSubscription(ctx, db, rp, name); err != nil {
+		databasesError(w, err, s.Logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidSubscriptionRequest checks if a subscription is valid on POST or PUT
+func ValidSubscriptionRequest(sub *chronograf.Subscription) error {
+	if len(sub.Name) == 0 {
+		return fmt.Errorf("name is required")
+	}
+	if len(sub.RetentionPolicy) == 0 {
+		return fmt.Errorf("rp is required")
+	}
+	switch strings.ToUpper(sub.Mode) {
+	case "ALL", "ANY":
+	default:
+		return fmt.Errorf("mode must be ALL or ANY")
+	}
+	if len(sub.Destinations) == 0 {
+		return fmt.Errorf("at least one destination is required")
+	}
+	for _, dest := range sub.Destinations {
+		u, err := url.Parse(dest)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid destination %s", dest)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_NewSubscription(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "Creates a subscription",
+			body:       `{"name":"kapacitor-1","rp":"autogen","mode":"ANY","destinations":["http://kapa:9092"]}`,
+			wantStatus: 201,
+			wantBody: `{"name":"kapacitor-1","db":"pineapples","rp":"autogen","mode":"ANY","destinations":["http://kapa:9092"],"links":{"self":"/chronograf/v1/sources/0/dbs/pineapples/subscriptions/autogen/kapacitor-1"}}
+`,
+		},
+		{
+			name:       "Requires a valid mode",
+			body:       `{"name":"kapacitor-1","rp":"autogen","mode":"SOME","destinations":["http://kapa:9092"]}`,
+			wantStatus: 422,
+			wantBody:   `{"code":422,"message":"mode must be ALL or ANY"}`,
+		},
+		{
+			name:       "Requires valid destinations",
+			body:       `{"name":"kapacitor-1","rp":"autogen","mode":"ALL","destinations":["kapa"]}`,
+			wantStatus: 422,
+			wantBody:   `{"code":422,"message":"invalid destination kapa"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, srcID int) (chronograf.Source, error) {
+							return chronograf.Source{
+								ID: 0,
+							}, nil
+						},
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+				DatabasesClient: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					CreateSubscriptionF: func(ctx context.Context, db string, sub *chronograf.Subscription) (*chronograf.Subscription, error) {
+						return sub, nil
+					},
+				},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://any.url", ioutil.NopCloser(bytes.NewReader([]byte(tt.body))))
+			r = r.WithContext(httprouter.WithParams(
+				context.Background(),
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "0",
+					},
+					{
+						Key:   "db",
+						Value: "pineapples",
+					},
+				}))
+
+			h.NewSubscription(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("%q. StatusCode:\nwant\n%v\ngot\n%v", tt.name, tt.wantStatus, resp.StatusCode)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("%q. Body:\nwant\n%s\ngot\n%s", tt.name, tt.wantBody, body)
+			}
+		})
+	}
+}
@@ -82,6 +82,9 @@ func (s *Service) Templates(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, d, chronograf.DashboardViewPermission) {
+		return
+	}
 
 	res := templatesResponses{
 		Templates: newTemplateResponses(chronograf.DashboardID(id), d.Templates),
@@ -103,6 +106,9 @@ func (s *Service) NewTemplate(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardEditPermission) {
+		return
+	}
 
 	var template chronograf.Template
 	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
@@ -149,6 +155,9 @@ func (s *Service) TemplateID(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardViewPermission) {
+		return
+	}
 
 	tid := httprouter.GetParamFromContext(ctx, "tid")
 	for _, t := range dash.Templates {
@@ -176,6 +185,9 @@ func (s *Service) RemoveTemplate(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardEditPermission) {
+		return
+	}
 
 	tid := httprouter.GetParamFromContext(ctx, "tid")
 	pos := -1
@@ -214,6 +226,9 @@ func (s *Service) ReplaceTemplate(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !s.authorizeDashboard(w, ctx, dash, chronograf.DashboardEditPermission) {
+		return
+	}
 
 	tid := httprouter.GetParamFromContext(ctx, "tid")
 	pos := -1
@@ -24,6 +24,9 @@ type CellService interface {
 	// CreateCell creates a new cell and sets b.ID with the new identifier.
 	CreateCell(ctx context.Context, b *Cell) error
 
+	// PutCell stores a cell with the identifier set on b.
+	PutCell(ctx context.Context, b *Cell) error
+
 	// UpdateCell updates a single cell with changeset.
 	// Returns the new cell state after update.
 	UpdateCell(ctx context.Context, id ID, upd CellUpdate) (*Cell, error)
@@ -102,25 +105,73 @@ func UnmarshalVisualizationJSON(b []byte) (Visualization, error) {
 
 	var vis Visualization
 	switch t.Type {
-	case "chronograf-v1":
+	case V1VisualizationType:
 		var qv V1Visualization
 		if err := json.Unmarshal(v.B, &qv); err != nil {
 			return nil, err
 		}
 		vis = qv
-	case "empty":
+	case EmptyVisualizationType:
 		var ev EmptyVisualization
 		if err := json.Unmarshal(v.B, &ev); err != nil {
 			return nil, err
 		}
 		vis = ev
+	case LineGraphVisualizationType:
+		var lv LineGraphVisualization
+		if err := json.Unmarshal(v.B, &lv); err != nil {
+			return nil, err
+		}
+		vis = lv
+	case SingleStatVisualizationType:
+		var sv SingleStatVisualization
+		if err := json.Unmarshal(v.B, &sv); err != nil {
+			return nil, err
+		}
+		vis = sv
+	case GaugeVisualizationType:
+		var gv GaugeVisualization
+		if err := json.Unmarshal(v.B, &gv); err != nil {
+			return nil, err
+		}
+		vis = gv
+	case TableVisualizationType:
+		var tv TableVisualization
+		if err := json.Unmarshal(v.B, &tv); err != nil {
+			return nil, err
+		}
+		vis = tv
+	case MarkdownVisualizationType:
+		var mv MarkdownVisualization
+		if err := json.Unmarshal(v.B, &mv); err != nil {
+			return nil, err
+		}
+		vis = mv
+	case LogViewerVisualizationType:
+		var lv LogViewerVisualization
+		if err := json.Unmarshal(v.B, &lv); err != nil {
+			return nil, err
+		}
+		vis = lv
 	default:
 		return nil, fmt.Errorf("unknown type %v", t.Type)
 	}
 
+	if err := ValidVisualization(vis); err != nil {
+		return nil, err
+	}
 	return vis, nil
 }
 
+// ValidVisualization returns an error if the settings of a visualization are
+// invalid for its type
+func ValidVisualization(v Visualization) error {
+	if vv, ok := v.(interface{ Valid() error }); ok {
+		return vv.Valid()
+	}
+	return nil
+}
+
 func MarshalVisualizationJSON(v Visualization) ([]byte, error) {
 	var s interface{}
 	switch vis := v.(type) {
@@ -129,15 +180,63 @@ func MarshalVisualizationJSON(v Visualization) ([]byte, error) {
 			Type string `json:"type"`
 			V1Visualization
 		}{
-			Type:            "chronograf-v1",
+			Type:            V1VisualizationType,
 			V1Visualization: vis,
 		}
+	case LineGraphVisualization:
+		s = struct {
+			Type string `json:"type"`
+			LineGraphVisualization
+		}{
+			Type:                   LineGraphVisualizationType,
+			LineGraphVisualization: vis,
+		}
+	case SingleStatVisualization:
+		s = struct {
+			Type string `json:"type"`
+			SingleStatVisualization
+		}{
+			Type:                    SingleStatVisualizationType,
+			SingleStatVisualization: vis,
+		}
+	case GaugeVisualization:
+		s = struct {
+			Type string `json:"type"`
+			GaugeVisualization
+		}{
+			Type:               GaugeVisualizationType,
+			GaugeVisualization: vis,
+		}
+	case TableVisualization:
+		s = struct {
+			Type string `json:"type"`
+			TableVisualization
+		}{
+			Type:               TableVisualizationType,
+			TableVisualization: vis,
+		}
+	case MarkdownVisualization:
+		s = struct {
+			Type string `json:"type"`
+			MarkdownVisualization
+		}{
+			Type:                  MarkdownVisualizationType,
+			MarkdownVisualization: vis,
+		}
+	case LogViewerVisualization:
+		s = struct {
+			Type string `json:"type"`
+			LogViewerVisualization
+		}{
+			Type:                   LogViewerVisualizationType,
+			LogViewerVisualization: vis,
+		}
 	default:
 		s = struct {
 			Type string `json:"type"`
 			EmptyVisualization
 		}{
-			Type:               "empty",
+			Type:               EmptyVisualizationType,
 			EmptyVisualization: EmptyVisualization{},
 		}
 	}
@@ -229,6 +328,50 @@ type DashboardQuery struct {
 	Shifts      []TimeShift `json:"-"`                     // Shifts represents shifts to apply to an influxql query's time range.  Clients expect the shift to be in the generated QueryConfig
 }
 
+// TemplateValue is a value use to replace a template in an InfluxQL query
+type TemplateValue struct {
+	Value    string `json:"value"`         // Value is the specific value used to replace a template in an InfluxQL query
+	Type     string `json:"type"`          // Type can be tagKey, tagValue, fieldKey, csv, map, measurement, database, constant, influxql
+	Selected bool   `json:"selected"`      // Selected states that this variable has been picked to use for replacement
+	Key      string `json:"key,omitempty"` // Key is the key for the Value if the Template Type is 'map'
+}
+
+// TemplateVar is a named variable within an InfluxQL query to be replaced with Values
+type TemplateVar struct {
+	Var    string          `json:"tempVar"` // Var is the string to replace within InfluxQL
+	Values []TemplateValue `json:"values"`  // Values are the replacement values within InfluxQL
+}
+
+// Template represents a series of choices to replace TemplateVars within InfluxQL
+type Template struct {
+	TemplateVar
+	ID    string         `json:"id"`              // ID is the unique ID associated with this template
+	Type  string         `json:"type"`            // Type can be fieldKeys, tagKeys, tagValues, csv, constant, measurements, databases, map, influxql, text
+	Label string         `json:"label"`           // Label is a user-facing description of the Template
+	Query *TemplateQuery `json:"query,omitempty"` // Query is used to generate the choices for a template
+}
+
+// Valid returns an error if the template has no variable or an unknown type
+func (t Template) Valid() error {
+	if t.Var == "" {
+		return fmt.Errorf("template requires a tempVar")
+	}
+	if !oneOf(t.Type, "fieldKeys", "tagKeys", "tagValues", "csv", "constant", "measurements", "databases", "map", "influxql", "text") {
+		return fmt.Errorf("invalid type %q of template %s", t.Type, t.Var)
+	}
+	return nil
+}
+
+// TemplateQuery is used to retrieve choices for template replacement
+type TemplateQuery struct {
+	Command     string `json:"influxql"`     // Command is the query itself
+	DB          string `json:"db,omitempty"` // DB is optional and if empty will not be used.
+	RP          string `json:"rp,omitempty"` // RP is a retention policy and optional; if empty will not be used.
+	Measurement string `json:"measurement"`  // Measurement is the optionally selected measurement for the query
+	TagKey      string `json:"tagKey"`       // TagKey is the optionally selected tag key for the query
+	FieldKey    string `json:"fieldKey"`     // FieldKey is the optionally selected field key for the query
+}
+
 // Range represents an upper and lower bound for data
 type Range struct {
 	Upper int64 `json:"upper"` // Upper is the upper bound
@@ -97,3 +97,115 @@ func jsonEqual(s1, s2 string) (eq bool, err error) {
 
 	return cmp.Equal(o1, o2), nil
 }
+
+func TestUnmarshalVisualizationJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    platform.Visualization
+		wantErr bool
+	}{
+		{
+			name: "line graph",
+			json: `{"visualization":{"type":"line-graph","geom":"step","axes":{"y":{"scale":"log"}},"colors":[{"type":"scale","hex":"#31C0F6"}]}}`,
+			want: platform.LineGraphVisualization{
+				Geom:   "step",
+				Axes:   map[string]platform.Axis{"y": {Scale: "log"}},
+				Colors: []platform.CellColor{{Type: "scale", Hex: "#31C0F6"}},
+			},
+		},
+		{
+			name:    "line graph with unknown axis",
+			json:    `{"visualization":{"type":"line-graph","axes":{"z":{}}}}`,
+			wantErr: true,
+		},
+		{
+			name: "single stat",
+			json: `{"visualization":{"type":"single-stat","suffix":"%","colors":[{"type":"text","hex":"#7A65F2"}]}}`,
+			want: platform.SingleStatVisualization{
+				Suffix: "%",
+				Colors: []platform.CellColor{{Type: "text", Hex: "#7A65F2"}},
+			},
+		},
+		{
+			name: "gauge",
+			json: `{"visualization":{"type":"gauge","colors":[{"type":"min","hex":"#00C9FF","value":"0"},{"type":"max","hex":"#9394FF","value":"100"}]}}`,
+			want: platform.GaugeVisualization{
+				Colors: []platform.CellColor{{Type: "min", Hex: "#00C9FF", Value: "0"}, {Type: "max", Hex: "#9394FF", Value: "100"}},
+			},
+		},
+		{
+			name:    "gauge without max",
+			json:    `{"visualization":{"type":"gauge","colors":[{"type":"min","hex":"#00C9FF","value":"0"}]}}`,
+			wantErr: true,
+		},
+		{
+			name: "table",
+			json: `{"visualization":{"type":"table","tableOptions":{"wrapping":"wrap"},"timeFormat":"HH:mm"}}`,
+			want: platform.TableVisualization{
+				TableOptions: platform.TableOptions{Wrapping: "wrap"},
+				TimeFormat:   "HH:mm",
+			},
+		},
+		{
+			name:    "table with unknown wrapping",
+			json:    `{"visualization":{"type":"table","tableOptions":{"wrapping":"fold"}}}`,
+			wantErr: true,
+		},
+		{
+			name: "markdown",
+			json: `{"visualization":{"type":"markdown","note":"# Hosts"}}`,
+			want: platform.MarkdownVisualization{Note: "# Hosts"},
+		},
+		{
+			name: "log viewer",
+			json: `{"visualization":{"type":"log-viewer","columns":[{"name":"severity","position":1,"settings":[{"type":"visibility","value":"visible"}]}]}}`,
+			want: platform.LogViewerVisualization{
+				Columns: []platform.LogViewerColumn{
+					{Name: "severity", Position: 1, Settings: []platform.LogColumnSetting{{Type: "visibility", Value: "visible"}}},
+				},
+			},
+		},
+		{
+			name:    "log viewer with duplicate columns",
+			json:    `{"visualization":{"type":"log-viewer","columns":[{"name":"severity"},{"name":"severity"}]}}`,
+			wantErr: true,
+		},
+		{
+			name: "no visualization",
+			json: `{}`,
+			want: platform.EmptyVisualization{},
+		},
+		{
+			name:    "unknown type",
+			json:    `{"visualization":{"type":"pie"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := platform.UnmarshalVisualizationJSON([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalVisualizationJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("UnmarshalVisualizationJSON() = %s", cmp.Diff(got, tt.want))
+			}
+
+			b, err := platform.MarshalVisualizationJSON(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := platform.UnmarshalVisualizationJSON([]byte(`{"visualization":` + string(b) + `}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(again, tt.want) {
+				t.Errorf("MarshalVisualizationJSON() did not round trip: %s", cmp.Diff(again, tt.want))
+			}
+		})
+	}
+}
@@ -20,6 +20,9 @@ type DashboardService interface {
 	// CreateDashboard creates a new dashboard and sets b.ID with the new identifier.
 	CreateDashboard(ctx context.Context, b *Dashboard) error
 
+	// PutDashboard stores a dashboard with the identifier set on b.
+	PutDashboard(ctx context.Context, b *Dashboard) error
+
 	// UpdateDashboard updates a single dashboard with changeset.
 	// Returns the new dashboard state after update.
 	UpdateDashboard(ctx context.Context, id ID, upd DashboardUpdate) (*Dashboard, error)
@@ -30,9 +33,30 @@ type DashboardService interface {
 
 // Dashboard represents all visual and query data for a dashboard
 type Dashboard struct {
-	ID    ID              `json:"id"`
-	Name  string          `json:"name"`
-	Cells []DashboardCell `json:"cells"`
+	ID          ID              `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Cells       []DashboardCell `json:"cells"`
+	Templates   []Template      `json:"templates"`
+	TimeRange   TimeRange       `json:"timeRange"`
+}
+
+// Valid returns an error if a template of the dashboard is invalid.
+func (d Dashboard) Valid() error {
+	for _, t := range d.Templates {
+		if err := t.Valid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TimeRange is the default time range of the queries of a dashboard, e.g.
+// a lower bound of now() - 1h and no upper bound.
+type TimeRange struct {
+	Lower string `json:"lower"`
+	Upper string `json:"upper,omitempty"`
 }
 
 // DashboardCell holds positional information about a cell on dashboard and a reference to a cell.
@@ -52,15 +76,28 @@ type DashboardFilter struct {
 
 // DashboardUpdate is the patch structure for a dashboard.
 type DashboardUpdate struct {
-	Name  *string         `json:"name"`
-	Cells []DashboardCell `json:"cells"`
+	Name        *string         `json:"name"`
+	Description *string         `json:"description"`
+	Cells       []DashboardCell `json:"cells"`
+	Templates   []Template      `json:"templates"`
+	TimeRange   *TimeRange      `json:"timeRange"`
 }
 
 // Valid returns an error if the dashboard update is invalid.
 func (u DashboardUpdate) Valid() error {
-	if u.Name == nil && u.Cells == nil {
+	if u.Name == nil && u.Description == nil && u.Cells == nil && u.Templates == nil && u.TimeRange == nil {
 		return fmt.Errorf("must update at least one attribute")
 	}
 
+	for _, t := range u.Templates {
+		if err := t.Valid(); err != nil {
+			return err
+		}
+	}
+
+	if u.TimeRange != nil && u.TimeRange.Lower == "" {
+		return fmt.Errorf("time range requires a lower bound")
+	}
+
 	return nil
 }
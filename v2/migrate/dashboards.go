@@ -0,0 +1,152 @@
+// Package migrate converts chronograf v1 dashboards into v2 dashboards and cells.
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/chronograf"
+	platform "github.com/influxdata/chronograf/v2"
+)
+
+// Result is the outcome of the migration of a single v1 dashboard
+type Result struct {
+	V1ID  chronograf.DashboardID `json:"v1ID"`
+	ID    platform.ID            `json:"id"`              // ID of the v2 dashboard
+	Name  string                 `json:"name"`            // Name of the dashboard
+	Cells int                    `json:"cells"`           // Cells is the number of cells of the dashboard
+	Error string                 `json:"error,omitempty"` // Error is the reason the dashboard could not be migrated
+}
+
+// Report summarizes a migration. Dashboards that were migrated by an earlier
+// run are skipped.
+type Report struct {
+	Migrated []Result `json:"migrated"`
+	Skipped  []Result `json:"skipped"`
+	Failed   []Result `json:"failed"`
+}
+
+// DashboardID is the ID of the v2 dashboard converted from a v1 dashboard.
+// IDs are derived from the v1 dashboard so re-runs find earlier migrations.
+func DashboardID(id chronograf.DashboardID) platform.ID {
+	return platform.ID(fmt.Sprintf("v1-%d", id))
+}
+
+// CellID is the ID of the v2 cell converted from the i-th cell of a v1 dashboard
+func CellID(id chronograf.DashboardID, i int, c chronograf.DashboardCell) platform.ID {
+	if c.ID == "" {
+		return platform.ID(fmt.Sprintf("v1-%d-%d", id, i))
+	}
+	return platform.ID(fmt.Sprintf("v1-%d-%s", id, c.ID))
+}
+
+// Dashboards migrates all dashboards of v1 into v2 dashboards and cells.
+// Dashboards that already exist in v2 are skipped and dashboards that fail
+// to convert are reported without stopping the migration.
+func Dashboards(ctx context.Context, v1 chronograf.DashboardsStore, dashboards platform.DashboardService, cells platform.CellService) (Report, error) {
+	report := Report{
+		Migrated: []Result{},
+		Skipped:  []Result{},
+		Failed:   []Result{},
+	}
+
+	ds, err := v1.All(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for _, d := range ds {
+		res := Result{
+			V1ID:  d.ID,
+			ID:    DashboardID(d.ID),
+			Name:  d.Name,
+			Cells: len(d.Cells),
+		}
+
+		_, err := dashboards.FindDashboardByID(ctx, res.ID)
+		if err == nil {
+			report.Skipped = append(report.Skipped, res)
+			continue
+		} else if err != platform.ErrDashboardNotFound {
+			res.Error = err.Error()
+			report.Failed = append(report.Failed, res)
+			continue
+		}
+
+		if err := migrate(ctx, d, dashboards, cells); err != nil {
+			res.Error = err.Error()
+			report.Failed = append(report.Failed, res)
+			continue
+		}
+		report.Migrated = append(report.Migrated, res)
+	}
+
+	return report, nil
+}
+
+// migrate puts the cells before the dashboard so that a dashboard only
+// exists once all of its cells do
+func migrate(ctx context.Context, d chronograf.Dashboard, dashboards platform.DashboardService, cells platform.CellService) error {
+	dashboard, cs, err := Dashboard(d)
+	if err != nil {
+		return err
+	}
+	for _, c := range cs {
+		if err := cells.PutCell(ctx, c); err != nil {
+			return err
+		}
+	}
+	return dashboards.PutDashboard(ctx, dashboard)
+}
+
+// Dashboard converts a v1 dashboard into a v2 dashboard with the positions of
+// its cells and a v2 cell embedding each v1 cell as a V1Visualization.
+func Dashboard(d chronograf.Dashboard) (*platform.Dashboard, []*platform.Cell, error) {
+	dashboard := &platform.Dashboard{
+		ID:    DashboardID(d.ID),
+		Name:  d.Name,
+		Cells: make([]platform.DashboardCell, 0, len(d.Cells)),
+	}
+	if err := convert(d.Templates, &dashboard.Templates); err != nil {
+		return nil, nil, fmt.Errorf("invalid templates of dashboard %d: %v", d.ID, err)
+	}
+
+	cells := make([]*platform.Cell, 0, len(d.Cells))
+	for i, c := range d.Cells {
+		var vis platform.V1Visualization
+		if err := convert(c, &vis); err != nil {
+			return nil, nil, fmt.Errorf("invalid cell %s of dashboard %d: %v", c.ID, d.ID, err)
+		}
+		vis.Type = c.Type
+
+		cell := &platform.Cell{
+			CellContents: platform.CellContents{
+				ID:   CellID(d.ID, i, c),
+				Name: c.Name,
+			},
+			Visualization: vis,
+		}
+		cells = append(cells, cell)
+
+		dashboard.Cells = append(dashboard.Cells, platform.DashboardCell{
+			X:   c.X,
+			Y:   c.Y,
+			W:   c.W,
+			H:   c.H,
+			Ref: fmt.Sprintf("/chronograf/v2/cells/%s", cell.ID),
+		})
+	}
+
+	return dashboard, cells, nil
+}
+
+// convert copies the fields of a v1 value into the v2 value of the same
+// JSON representation
+func convert(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
@@ -0,0 +1,119 @@
+package migrate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	platform "github.com/influxdata/chronograf/v2"
+	"github.com/influxdata/chronograf/v2/migrate"
+)
+
+func TestDashboards(t *testing.T) {
+	v1 := &mocks.DashboardsStore{
+		AllF: func(ctx context.Context) ([]chronograf.Dashboard, error) {
+			return []chronograf.Dashboard{
+				{
+					ID:   1,
+					Name: "Hosts",
+					Cells: []chronograf.DashboardCell{
+						{
+							ID:   "cpu",
+							X:    0,
+							Y:    2,
+							W:    4,
+							H:    3,
+							Name: "CPU",
+							Type: "line-stacked",
+							Queries: []chronograf.DashboardQuery{
+								{Command: `SELECT mean("usage_user") FROM "cpu"`},
+							},
+							Legend: chronograf.Legend{Type: "static", Orientation: "bottom"},
+						},
+					},
+					Templates: []chronograf.Template{
+						{
+							TemplateVar: chronograf.TemplateVar{Var: ":host:"},
+							ID:          "host",
+							Type:        "tagValues",
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	dashboards := map[platform.ID]*platform.Dashboard{}
+	cells := map[platform.ID]*platform.Cell{}
+	dashboardSvc := &mocks.DashboardService{
+		FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+			if d, ok := dashboards[id]; ok {
+				return d, nil
+			}
+			return nil, platform.ErrDashboardNotFound
+		},
+		PutDashboardF: func(ctx context.Context, d *platform.Dashboard) error {
+			dashboards[d.ID] = d
+			return nil
+		},
+	}
+	cellSvc := &mocks.CellService{
+		PutCellF: func(ctx context.Context, c *platform.Cell) error {
+			cells[c.ID] = c
+			return nil
+		},
+	}
+
+	ctx := context.Background()
+	report, err := migrate.Dashboards(ctx, v1, dashboardSvc, cellSvc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := migrate.Report{
+		Migrated: []migrate.Result{{V1ID: 1, ID: "v1-1", Name: "Hosts", Cells: 1}},
+		Skipped:  []migrate.Result{},
+		Failed:   []migrate.Result{},
+	}
+	if diff := cmp.Diff(report, want); diff != "" {
+		t.Errorf("Dashboards() = %s", diff)
+	}
+
+	wantDashboard := &platform.Dashboard{
+		ID:    "v1-1",
+		Name:  "Hosts",
+		Cells: []platform.DashboardCell{{X: 0, Y: 2, W: 4, H: 3, Ref: "/chronograf/v2/cells/v1-1-cpu"}},
+		Templates: []platform.Template{
+			{
+				TemplateVar: platform.TemplateVar{Var: ":host:"},
+				ID:          "host",
+				Type:        "tagValues",
+			},
+		},
+	}
+	if diff := cmp.Diff(dashboards["v1-1"], wantDashboard); diff != "" {
+		t.Errorf("migrated dashboard = %s", diff)
+	}
+
+	cell, ok := cells["v1-1-cpu"]
+	if !ok {
+		t.Fatalf("cell v1-1-cpu was not migrated")
+	}
+	vis, ok := cell.Visualization.(platform.V1Visualization)
+	if !ok {
+		t.Fatalf("migrated cell visualization = %T, want V1Visualization", cell.Visualization)
+	}
+	if cell.Name != "CPU" || vis.Type != "line-stacked" || vis.Legend.Orientation != "bottom" || len(vis.Queries) != 1 {
+		t.Errorf("migrated cell = %+v", cell)
+	}
+
+	// Re-runs skip the dashboards already migrated
+	report, err = migrate.Dashboards(ctx, v1, dashboardSvc, cellSvc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Migrated) != 0 || len(report.Skipped) != 1 {
+		t.Errorf("Dashboards() re-run = %+v, want one skipped dashboard", report)
+	}
+}
@@ -0,0 +1,213 @@
+package platform
+
+import "fmt"
+
+// Types of visualizations of cells
+const (
+	V1VisualizationType         = "chronograf-v1"
+	EmptyVisualizationType      = "empty"
+	LineGraphVisualizationType  = "line-graph"
+	SingleStatVisualizationType = "single-stat"
+	GaugeVisualizationType      = "gauge"
+	TableVisualizationType      = "table"
+	MarkdownVisualizationType   = "markdown"
+	LogViewerVisualizationType  = "log-viewer"
+)
+
+// LineGraphVisualization plots the results of queries over time
+type LineGraphVisualization struct {
+	Queries       []DashboardQuery `json:"queries"`
+	Axes          map[string]Axis  `json:"axes"`
+	Geom          string           `json:"geom"` // Geom is the shape of the series: line, step, stacked or bar
+	Colors        []CellColor      `json:"colors"`
+	Legend        Legend           `json:"legend"`
+	DecimalPlaces DecimalPlaces    `json:"decimalPlaces"`
+}
+
+func (LineGraphVisualization) Visualization() {}
+
+// Valid returns an error if the axes, geom, colors or legend are unknown
+func (v LineGraphVisualization) Valid() error {
+	if err := validAxes(v.Axes); err != nil {
+		return err
+	}
+	if !oneOf(v.Geom, "", "line", "step", "stacked", "bar") {
+		return fmt.Errorf("invalid geom %q", v.Geom)
+	}
+	if err := validColors(v.Colors, "scale"); err != nil {
+		return err
+	}
+	if err := validLegend(v.Legend); err != nil {
+		return err
+	}
+	return validDecimalPlaces(v.DecimalPlaces)
+}
+
+// SingleStatVisualization displays the last value of the results of queries
+type SingleStatVisualization struct {
+	Queries       []DashboardQuery `json:"queries"`
+	Prefix        string           `json:"prefix"`
+	Suffix        string           `json:"suffix"`
+	Colors        []CellColor      `json:"colors"`
+	DecimalPlaces DecimalPlaces    `json:"decimalPlaces"`
+}
+
+func (SingleStatVisualization) Visualization() {}
+
+// Valid returns an error if the colors are not text or background thresholds
+func (v SingleStatVisualization) Valid() error {
+	if err := validColors(v.Colors, "text", "background"); err != nil {
+		return err
+	}
+	return validDecimalPlaces(v.DecimalPlaces)
+}
+
+// GaugeVisualization displays the last value of the results of queries
+// between a minimum and a maximum
+type GaugeVisualization struct {
+	Queries       []DashboardQuery `json:"queries"`
+	Prefix        string           `json:"prefix"`
+	Suffix        string           `json:"suffix"`
+	Colors        []CellColor      `json:"colors"`
+	DecimalPlaces DecimalPlaces    `json:"decimalPlaces"`
+}
+
+func (GaugeVisualization) Visualization() {}
+
+// Valid returns an error unless the colors hold exactly one min and one max
+func (v GaugeVisualization) Valid() error {
+	if err := validColors(v.Colors, "min", "max", "threshold"); err != nil {
+		return err
+	}
+	counts := map[string]int{}
+	for _, c := range v.Colors {
+		counts[c.Type]++
+	}
+	if counts["min"] != 1 || counts["max"] != 1 {
+		return fmt.Errorf("gauge requires one min and one max color")
+	}
+	return validDecimalPlaces(v.DecimalPlaces)
+}
+
+// TableVisualization displays the results of queries as a table
+type TableVisualization struct {
+	Queries       []DashboardQuery `json:"queries"`
+	Colors        []CellColor      `json:"colors"`
+	TableOptions  TableOptions     `json:"tableOptions"`
+	FieldOptions  []RenamableField `json:"fieldOptions"`
+	TimeFormat    string           `json:"timeFormat"`
+	DecimalPlaces DecimalPlaces    `json:"decimalPlaces"`
+}
+
+func (TableVisualization) Visualization() {}
+
+// Valid returns an error if the colors or the wrapping of the table are unknown
+func (v TableVisualization) Valid() error {
+	if err := validColors(v.Colors, "text", "background"); err != nil {
+		return err
+	}
+	if !oneOf(v.TableOptions.Wrapping, "", "truncate", "wrap", "single-line") {
+		return fmt.Errorf("invalid table wrapping %q", v.TableOptions.Wrapping)
+	}
+	return validDecimalPlaces(v.DecimalPlaces)
+}
+
+// MarkdownVisualization displays a note written in markdown
+type MarkdownVisualization struct {
+	Note string `json:"note"`
+}
+
+func (MarkdownVisualization) Visualization() {}
+
+// LogViewerVisualization displays the logs of a source as columns
+type LogViewerVisualization struct {
+	Columns []LogViewerColumn `json:"columns"`
+}
+
+func (LogViewerVisualization) Visualization() {}
+
+// LogViewerColumn is a column of the log viewer
+type LogViewerColumn struct {
+	Name     string             `json:"name"`
+	Position int32              `json:"position"`
+	Settings []LogColumnSetting `json:"settings"`
+}
+
+// LogColumnSetting is a setting of a column of the log viewer, e.g. its
+// visibility or display name
+type LogColumnSetting struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Name  string `json:"name,omitempty"`
+}
+
+// Valid returns an error if columns are unnamed or named more than once
+func (v LogViewerVisualization) Valid() error {
+	seen := map[string]bool{}
+	for _, c := range v.Columns {
+		if c.Name == "" {
+			return fmt.Errorf("log viewer column requires a name")
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("duplicate log viewer column %q", c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
+
+func validAxes(axes map[string]Axis) error {
+	for label, axis := range axes {
+		if !oneOf(label, "x", "y", "y2") {
+			return fmt.Errorf("invalid axis %q", label)
+		}
+		if !oneOf(axis.Scale, "", "linear", "log") {
+			return fmt.Errorf("invalid scale %q of axis %s", axis.Scale, label)
+		}
+		if !oneOf(axis.Base, "", "10", "2", "raw") {
+			return fmt.Errorf("invalid base %q of axis %s", axis.Base, label)
+		}
+	}
+	return nil
+}
+
+func validColors(colors []CellColor, types ...string) error {
+	for _, c := range colors {
+		if !oneOf(c.Type, types...) {
+			return fmt.Errorf("invalid color type %q", c.Type)
+		}
+		if len(c.Hex) != 7 || c.Hex[0] != '#' {
+			return fmt.Errorf("invalid color %q", c.Hex)
+		}
+	}
+	return nil
+}
+
+func validLegend(l Legend) error {
+	if l.Type == "" && l.Orientation == "" {
+		return nil
+	}
+	if l.Type != "static" {
+		return fmt.Errorf("invalid legend type %q", l.Type)
+	}
+	if !oneOf(l.Orientation, "top", "bottom", "left", "right") {
+		return fmt.Errorf("invalid legend orientation %q", l.Orientation)
+	}
+	return nil
+}
+
+func validDecimalPlaces(d DecimalPlaces) error {
+	if d.Digits < 0 {
+		return fmt.Errorf("decimal places digits must not be negative")
+	}
+	return nil
+}
+
+func oneOf(s string, choices ...string) bool {
+	for _, c := range choices {
+		if s == c {
+			return true
+		}
+	}
+	return false
+}